@@ -68,6 +68,12 @@ func Bind(r *http.Request, dest interface{}) error {
 				return err
 			}
 			fieldValue.SetInt(v)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v, err := strconv.ParseUint(formValue, 10, 64)
+			if err != nil {
+				return err
+			}
+			fieldValue.SetUint(v)
 		case reflect.Float32, reflect.Float64:
 			v, err := strconv.ParseFloat(formValue, 64)
 			if err != nil {
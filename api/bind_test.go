@@ -19,6 +19,7 @@ func (c *CSV) Bind(value string) error {
 type TestStructNonPointer struct {
 	Name  string `form:"name"`
 	Age   int    `form:"age"`
+	Slot  uint64 `form:"slot"`
 	Email string `form:"email"`
 	Tags  CSV    `form:"tags"`
 }
@@ -26,6 +27,7 @@ type TestStructNonPointer struct {
 type TestStructPointer struct {
 	Name  string `form:"name"`
 	Age   int    `form:"age"`
+	Slot  uint64 `form:"slot"`
 	Email string `form:"email"`
 	Tags  *CSV   `form:"tags"`
 }
@@ -34,6 +36,7 @@ func runTestBindForm(t *testing.T, dest interface{}, validate func(*testing.T, i
 	form := url.Values{
 		"name":  []string{"John Doe"},
 		"age":   []string{"30"},
+		"slot":  []string{"12345"},
 		"email": []string{"john.doe@example.com"},
 		"tags":  []string{"tag1,tag2,tag3"},
 	}
@@ -54,6 +57,7 @@ func TestBindFormNonPointer(t *testing.T) {
 		assert.True(t, ok)
 		assert.Equal(t, "John Doe", s.Name)
 		assert.Equal(t, 30, s.Age)
+		assert.Equal(t, uint64(12345), s.Slot)
 		assert.Equal(t, "john.doe@example.com", s.Email)
 		assert.Equal(t, CSV{"tag1", "tag2", "tag3"}, s.Tags)
 	}
@@ -67,6 +71,7 @@ func TestBindFormPointer(t *testing.T) {
 		assert.True(t, ok)
 		assert.Equal(t, "John Doe", s.Name)
 		assert.Equal(t, 30, s.Age)
+		assert.Equal(t, uint64(12345), s.Slot)
 		assert.Equal(t, "john.doe@example.com", s.Email)
 		assert.Equal(t, &CSV{"tag1", "tag2", "tag3"}, s.Tags)
 	}
@@ -0,0 +1,154 @@
+// Package client is a Go client of the SSV API, as described by its OpenAPI document at /v1/openapi.json.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ssvlabs/ssv/api/openapi"
+)
+
+const (
+	pathNodeIdentity           = "/v1/node/identity"
+	pathNodePeers              = "/v1/node/peers"
+	pathNodeTopics             = "/v1/node/topics"
+	pathNodeHealth             = "/v1/node/health"
+	pathValidators             = "/v1/validators"
+	pathDecideds               = "/v1/exporter/decideds"
+	pathParticipants           = "/v1/exporter/participants"
+	pathDuties                 = "/v1/duties"
+	pathParticipationAnalytics = "/v1/exporter/analytics"
+	pathOpenAPI                = "/v1/openapi.json"
+)
+
+// Error is the error response of the API.
+type Error struct {
+	StatusCode int    `json:"-"`
+	Status     string `json:"status"`
+	Message    string `json:"error,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("ssv api: %d %s", e.StatusCode, e.Status)
+	}
+	return fmt.Sprintf("ssv api: %d %s: %s", e.StatusCode, e.Status, e.Message)
+}
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests, which is http.DefaultClient by default.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// New returns a client of the API served at baseURL, such as http://localhost:16000.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) NodeIdentity(ctx context.Context) (*Identity, error) {
+	var resp Identity
+	return &resp, c.get(ctx, pathNodeIdentity, nil, &resp)
+}
+
+func (c *Client) NodePeers(ctx context.Context) ([]Peer, error) {
+	var resp []Peer
+	return resp, c.get(ctx, pathNodePeers, nil, &resp)
+}
+
+func (c *Client) NodeTopics(ctx context.Context) (*Topics, error) {
+	var resp Topics
+	return &resp, c.get(ctx, pathNodeTopics, nil, &resp)
+}
+
+func (c *Client) NodeHealth(ctx context.Context) (*Health, error) {
+	var resp Health
+	return &resp, c.get(ctx, pathNodeHealth, nil, &resp)
+}
+
+func (c *Client) Validators(ctx context.Context, req ValidatorsRequest) (*ValidatorsResponse, error) {
+	var resp ValidatorsResponse
+	return &resp, c.get(ctx, pathValidators, req.query(), &resp)
+}
+
+func (c *Client) Decideds(ctx context.Context, req ExporterRequest) (*DecidedsResponse, error) {
+	var resp DecidedsResponse
+	return &resp, c.get(ctx, pathDecideds, req.query(), &resp)
+}
+
+func (c *Client) Participants(ctx context.Context, req ExporterRequest) (*ParticipantsResponse, error) {
+	var resp ParticipantsResponse
+	return &resp, c.get(ctx, pathParticipants, req.query(), &resp)
+}
+
+// Duties returns the duty traces, which are only served by nodes with duty tracing enabled.
+func (c *Client) Duties(ctx context.Context, req DutiesRequest) (*DutiesResponse, error) {
+	var resp DutiesResponse
+	return &resp, c.get(ctx, pathDuties, req.query(), &resp)
+}
+
+// ParticipationAnalytics returns the participation report, which is only served by exporters with analytics enabled.
+func (c *Client) ParticipationAnalytics(ctx context.Context, req ParticipationRequest) (*ParticipationReport, error) {
+	var resp ParticipationReport
+	return &resp, c.get(ctx, pathParticipationAnalytics, req.query(), &resp)
+}
+
+// OpenAPI returns the OpenAPI document of the routes served by the node.
+func (c *Client) OpenAPI(ctx context.Context) (*openapi.Document, error) {
+	var resp openapi.Document
+	return &resp, c.get(ctx, pathOpenAPI, nil, &resp)
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, dest any) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("could not create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &Error{StatusCode: resp.StatusCode}
+		if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Status == "" {
+			apiErr.Status = http.StatusText(resp.StatusCode)
+		}
+		return apiErr
+	}
+	if err := json.Unmarshal(body, dest); err != nil {
+		return fmt.Errorf("could not decode response: %w", err)
+	}
+	return nil
+}
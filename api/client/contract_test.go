@@ -0,0 +1,471 @@
+package client
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/go-chi/chi/v5"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/api/handlers"
+	"github.com/ssvlabs/ssv/api/openapi"
+	"github.com/ssvlabs/ssv/api/server"
+	"github.com/ssvlabs/ssv/exporter/analytics"
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/dutytracer"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+// clientOperations describes how the client calls each operation of the document,
+// with requests which set every parameter.
+var clientOperations = map[string]struct {
+	path     string
+	query    url.Values
+	response any
+}{
+	"getNodeIdentity": {pathNodeIdentity, nil, Identity{}},
+	"getNodePeers":    {pathNodePeers, nil, []Peer{}},
+	"getNodeTopics":   {pathNodeTopics, nil, Topics{}},
+	"getNodeHealth":   {pathNodeHealth, nil, Health{}},
+	"listValidators": {pathValidators, ValidatorsRequest{
+		Owners:      api.HexSlice{{0x1}},
+		Operators:   []uint64{1},
+		Clusters:    [][]uint64{{1, 2}},
+		Subclusters: [][]uint64{{1}},
+		PubKeys:     api.HexSlice{{0x1}},
+		Indices:     []uint64{1},
+	}.query(), ValidatorsResponse{}},
+	"getDecideds":     {pathDecideds, fullExporterRequest.query(), DecidedsResponse{}},
+	"getParticipants": {pathParticipants, fullExporterRequest.query(), ParticipantsResponse{}},
+	"listDuties":      {pathDuties, fullDutiesRequest.query(), DutiesResponse{}},
+	"getOpenAPI":      {pathOpenAPI, nil, openapi.Document{}},
+	"getParticipationAnalytics": {pathParticipationAnalytics, ParticipationRequest{
+		PubKeys:      api.HexSlice{{0x1}},
+		CommitteeIDs: api.HexSlice{{0x1}},
+		Epochs:       1,
+		Validators:   true,
+	}.query(), ParticipationReport{}},
+}
+
+var (
+	fullExporterRequest = ExporterRequest{
+		PubKeys:      api.HexSlice{{0x1}},
+		Indices:      []uint64{1},
+		CommitteeIDs: api.HexSlice{{0x1}},
+		Roles:        []convert.RunnerRole{convert.RoleProposer},
+		From:         1,
+		To:           2,
+		Page:         1,
+		PerPage:      1,
+	}
+	fullDutiesRequest = DutiesRequest{
+		PubKeys:      api.HexSlice{{0x1}},
+		CommitteeIDs: api.HexSlice{{0x1}},
+		Roles:        []spectypes.RunnerRole{spectypes.RoleProposer},
+		From:         1,
+		To:           2,
+		Page:         1,
+		PerPage:      1,
+	}
+)
+
+func TestContract_Document(t *testing.T) {
+	srv, _ := testServer(t)
+	doc, err := srv.OpenAPI()
+	require.NoError(t, err)
+	require.Equal(t, openapi.Version, doc.OpenAPI)
+
+	// Every route is documented.
+	var routes []string
+	err = chi.Walk(srv.Handler().(chi.Routes), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routes = append(routes, strings.ToLower(method)+" "+route)
+		return nil
+	})
+	require.NoError(t, err)
+	var documented []string
+	for path, item := range doc.Paths {
+		for method := range item {
+			documented = append(documented, method+" "+path)
+		}
+	}
+	require.ElementsMatch(t, routes, documented)
+
+	// Every operation is called by the client as documented.
+	clientGenerator := server.NewOpenAPIGenerator()
+	seen := make(map[string]bool)
+	for path, item := range doc.Paths {
+		for method, operation := range item {
+			t.Run(operation.OperationID, func(t *testing.T) {
+				seen[operation.OperationID] = true
+				call, ok := clientOperations[operation.OperationID]
+				require.True(t, ok, "operation isn't called by the client")
+				require.Equal(t, "get", method)
+				require.Equal(t, path, call.path)
+
+				var params []string
+				for _, param := range operation.Parameters {
+					params = append(params, param.Name)
+				}
+				var query []string
+				for name := range call.query {
+					query = append(query, name)
+				}
+				require.ElementsMatch(t, params, query)
+
+				clientSchema, err := clientGenerator.Schema(reflect.TypeOf(call.response))
+				require.NoError(t, err)
+				serverSchema := operation.Responses["200"].Content["application/json"].Schema
+				require.NoError(t, compareSchemas(
+					clientSchema, clientGenerator.Components(),
+					serverSchema, doc.Components.Schemas,
+					"response", make(map[[2]string]bool),
+				))
+			})
+		}
+	}
+	require.Len(t, seen, len(clientOperations), "client calls undocumented operations")
+}
+
+func TestContract_Responses(t *testing.T) {
+	srv, committeeID := testServer(t)
+	doc, err := srv.OpenAPI()
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+	c := New(ts.URL)
+	ctx := context.Background()
+
+	share1PubKey, share2PubKey := spectypes.ValidatorPK{0x1}, spectypes.ValidatorPK{0x2}
+
+	// Raw responses are valid by the document.
+	for _, target := range []string{
+		pathValidators + fmt.Sprintf("?pubkeys=%x,%x", share1PubKey[:], share2PubKey[:]),
+		pathDecideds + fmt.Sprintf("?roles=COMMITTEE&from=10&to=19&committee_ids=%x", committeeID[:]),
+		pathParticipants + fmt.Sprintf("?roles=PROPOSER&from=10&to=19&pubkeys=%x", share1PubKey[:]),
+		pathDuties + "?from=10&to=19",
+		pathParticipationAnalytics,
+		pathOpenAPI,
+		pathValidators + "?pubkeys=zz",
+	} {
+		t.Run(target, func(t *testing.T) {
+			resp, err := http.Get(ts.URL + target)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			u, err := url.Parse(target)
+			require.NoError(t, err)
+			operation := doc.Paths[u.Path]["get"]
+			require.NotNil(t, operation)
+			status := "200"
+			if resp.StatusCode != http.StatusOK {
+				status = "default"
+			}
+			var value any
+			require.NoError(t, json.Unmarshal(body, &value))
+			require.NoError(t, validate(value, operation.Responses[status].Content["application/json"].Schema, doc.Components.Schemas, "response"))
+		})
+	}
+
+	// The client decodes the responses.
+	validators, err := c.Validators(ctx, ValidatorsRequest{PubKeys: api.HexSlice{share1PubKey[:]}})
+	require.NoError(t, err)
+	require.Len(t, validators.Data, 1)
+	require.Equal(t, api.Hex(share1PubKey[:]), validators.Data[0].PubKey)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 3, 4}, validators.Data[0].Committee)
+
+	decideds, err := c.Decideds(ctx, ExporterRequest{
+		CommitteeIDs: api.HexSlice{committeeID[:]},
+		Roles:        []convert.RunnerRole{convert.RoleCommittee},
+		From:         10,
+		To:           19,
+	})
+	require.NoError(t, err)
+	require.Len(t, decideds.Data, 10)
+	require.Equal(t, phase0.Slot(10), decideds.Data[0].Slot)
+	require.Equal(t, api.Hex(committeeID[:]), decideds.Data[0].DutyExecutorID)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 3}, decideds.Data[0].Signers)
+	require.Equal(t, 10, decideds.Pagination.Total)
+
+	participants, err := c.Participants(ctx, ExporterRequest{
+		PubKeys: api.HexSlice{share2PubKey[:]},
+		Roles:   []convert.RunnerRole{convert.RoleProposer},
+		From:    10,
+		To:      14,
+		PerPage: 2,
+	})
+	require.NoError(t, err)
+	require.Len(t, participants.Data, 2)
+	require.Equal(t, 5, participants.Pagination.Total)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 3, 4}, participants.Data[0].Signers)
+
+	duties, err := c.Duties(ctx, DutiesRequest{Roles: []spectypes.RunnerRole{spectypes.RoleProposer}, From: 10, To: 10})
+	require.NoError(t, err)
+	require.Len(t, duties.Data, 2)
+	require.Equal(t, "PROPOSER", duties.Data[0].Role)
+
+	report, err := c.ParticipationAnalytics(ctx, ParticipationRequest{Epochs: 1})
+	require.NoError(t, err)
+	require.Equal(t, phase0.Epoch(1), report.FromEpoch)
+	require.Len(t, report.Operators, 1)
+
+	served, err := c.OpenAPI(ctx)
+	require.NoError(t, err)
+	expected, err := json.Marshal(doc)
+	require.NoError(t, err)
+	actual, err := json.Marshal(served)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(actual))
+
+	// Errors are returned as *Error.
+	_, err = c.Decideds(ctx, ExporterRequest{From: 2, To: 1})
+	var apiErr *Error
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	require.NotEmpty(t, apiErr.Message)
+}
+
+type testReporter struct{}
+
+func (testReporter) Report(analytics.ReportOptions) (*analytics.Report, error) {
+	return &analytics.Report{
+		FromEpoch: 1,
+		ToEpoch:   1,
+		Operators: []*analytics.OperatorStats{{
+			OperatorID:         1,
+			ParticipationStats: analytics.ParticipationStats{Duties: 2, Missed: 1, ParticipationRate: 0.5},
+		}},
+		Committees: []*analytics.CommitteeStats{},
+	}, nil
+}
+
+// testServer returns a server of two validators with proposer participants and duties in slots 10 to 19,
+// and the committee decideds of the first validator's committee.
+func testServer(t *testing.T) (*server.Server, spectypes.CommitteeID) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+
+	shares, _, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	share1, share2 := testShare(0x1, 1, 2, 3, 4), testShare(0x2, 5, 6, 7, 8)
+	require.NoError(t, shares.Save(nil, share1, share2))
+
+	stores := storage.NewStoresFromRoles(db, convert.RoleCommittee, convert.RoleProposer)
+	domain := networkconfig.TestNetwork.DomainType()
+	ks := testingutils.Testing4SharesSet()
+	tracer := dutytracer.New(64)
+	committeeID := share1.CommitteeID()
+	for slot := phase0.Slot(10); slot < 20; slot++ {
+		for _, share := range []*types.SSVShare{share1, share2} {
+			msgID := convert.NewMsgID(domain, share.ValidatorPubKey[:], convert.RoleProposer)
+			require.NoError(t, stores.Get(convert.RoleProposer).SaveParticipants(msgID, slot, []spectypes.OperatorID{1, 2, 3, 4}))
+			tracer.TraceDuty(types.DutyTraceEvent{Type: types.DutyStarted, Role: spectypes.RoleProposer, DutyExecutorID: share.ValidatorPubKey[:], Slot: slot})
+		}
+
+		msgID := convert.NewMsgID(domain, committeeID[:], convert.RoleCommittee)
+		require.NoError(t, stores.Get(convert.RoleCommittee).SaveInstance(&qbftstorage.StoredInstance{
+			State: &specqbft.State{
+				ID:     msgID[:],
+				Height: specqbft.Height(slot),
+			},
+			DecidedMessage: testingutils.TestingCommitMultiSignerMessageWithHeightAndIdentifier(
+				[]*rsa.PrivateKey{ks.OperatorKeys[1], ks.OperatorKeys[2], ks.OperatorKeys[3]},
+				[]spectypes.OperatorID{1, 2, 3},
+				specqbft.Height(slot),
+				msgID[:],
+			),
+		}))
+	}
+
+	srv := server.New(
+		logger,
+		"",
+		&handlers.Node{},
+		&handlers.Validators{Shares: shares},
+		&handlers.Exporter{DomainType: networkconfig.TestNetwork, QBFTStores: stores, Shares: shares},
+		&handlers.Duties{Tracer: tracer, Shares: shares},
+		&handlers.Analytics{Reporter: testReporter{}},
+	)
+	return srv, committeeID
+}
+
+func testShare(pubKey byte, operatorIDs ...spectypes.OperatorID) *types.SSVShare {
+	share := &types.SSVShare{}
+	share.ValidatorPubKey = spectypes.ValidatorPK{pubKey}
+	for _, id := range operatorIDs {
+		share.Committee = append(share.Committee, &spectypes.ShareMember{Signer: id})
+	}
+	return share
+}
+
+// compareSchemas compares the structure of two schemas, resolving the references of each in its own components.
+// Descriptions, patterns and enums only document values and aren't compared.
+func compareSchemas(a *openapi.Schema, aComponents map[string]*openapi.Schema, b *openapi.Schema, bComponents map[string]*openapi.Schema, path string, seen map[[2]string]bool) error {
+	if a.Ref != "" || b.Ref != "" {
+		if seen[[2]string{a.Ref, b.Ref}] {
+			return nil
+		}
+		seen[[2]string{a.Ref, b.Ref}] = true
+		return compareSchemas(resolve(a, aComponents), aComponents, resolve(b, bComponents), bComponents, path, seen)
+	}
+
+	if a.Type != b.Type || a.Format != b.Format || a.Nullable != b.Nullable {
+		return fmt.Errorf("%s: %s/%s (nullable: %v) != %s/%s (nullable: %v)", path, a.Type, a.Format, a.Nullable, b.Type, b.Format, b.Nullable)
+	}
+	if !slices.Equal(a.Required, b.Required) {
+		return fmt.Errorf("%s: required %v != %v", path, a.Required, b.Required)
+	}
+	if len(a.Properties) != len(b.Properties) {
+		return fmt.Errorf("%s: properties %v != %v", path, sortedKeys(a.Properties), sortedKeys(b.Properties))
+	}
+	for name, property := range a.Properties {
+		other, ok := b.Properties[name]
+		if !ok {
+			return fmt.Errorf("%s: property %s is missing", path, name)
+		}
+		if err := compareSchemas(property, aComponents, other, bComponents, path+"."+name, seen); err != nil {
+			return err
+		}
+	}
+	if len(a.AllOf) != len(b.AllOf) {
+		return fmt.Errorf("%s: allOf length %d != %d", path, len(a.AllOf), len(b.AllOf))
+	}
+	for i := range a.AllOf {
+		if err := compareSchemas(a.AllOf[i], aComponents, b.AllOf[i], bComponents, path, seen); err != nil {
+			return err
+		}
+	}
+	for _, pair := range [][2]*openapi.Schema{{a.Items, b.Items}, {a.AdditionalProperties, b.AdditionalProperties}} {
+		if (pair[0] == nil) != (pair[1] == nil) {
+			return fmt.Errorf("%s: items or additional properties differ", path)
+		}
+		if pair[0] != nil {
+			if err := compareSchemas(pair[0], aComponents, pair[1], bComponents, path+"[]", seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validate validates a decoded JSON value by a schema.
+func validate(value any, schema *openapi.Schema, components map[string]*openapi.Schema, path string) error {
+	schema = resolve(schema, components)
+	if value == nil {
+		if !schema.Nullable {
+			return fmt.Errorf("%s: null isn't nullable", path)
+		}
+		return nil
+	}
+	for _, s := range schema.AllOf {
+		if err := validate(value, s, components, path); err != nil {
+			return err
+		}
+	}
+
+	switch schema.Type {
+	case "":
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: %v isn't an object", path, value)
+		}
+		for _, name := range schema.Required {
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("%s: %s is required", path, name)
+			}
+		}
+		for name, v := range obj {
+			property, ok := schema.Properties[name]
+			if !ok {
+				property = schema.AdditionalProperties
+			}
+			if property == nil {
+				return fmt.Errorf("%s: %s isn't a property", path, name)
+			}
+			if err := validate(v, property, components, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: %v isn't an array", path, value)
+		}
+		for i, v := range arr {
+			if err := validate(v, schema.Items, components, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: %v isn't a string", path, value)
+		}
+		if schema.Pattern != "" && !regexp.MustCompile(schema.Pattern).MatchString(s) {
+			return fmt.Errorf("%s: %q doesn't match %s", path, s, schema.Pattern)
+		}
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, s) {
+			return fmt.Errorf("%s: %q isn't one of %v", path, s, schema.Enum)
+		}
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("%s: %v isn't a number", path, value)
+		}
+		if schema.Type == "integer" && n != float64(int64(n)) {
+			return fmt.Errorf("%s: %v isn't an integer", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: %v isn't a boolean", path, value)
+		}
+	default:
+		return fmt.Errorf("%s: unknown type %s", path, schema.Type)
+	}
+	return nil
+}
+
+func resolve(schema *openapi.Schema, components map[string]*openapi.Schema) *openapi.Schema {
+	if schema.Ref == "" {
+		return schema
+	}
+	return components[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+}
+
+func sortedKeys(m map[string]*openapi.Schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,310 @@
+package client
+
+import (
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/peer"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/operator/dutytracer"
+)
+
+type Identity struct {
+	PeerID    peer.ID  `json:"peer_id"`
+	Addresses []string `json:"addresses"`
+	Subnets   string   `json:"subnets"`
+	Version   string   `json:"version"`
+}
+
+type Connection struct {
+	Address   string `json:"address"`
+	Direction string `json:"direction"`
+}
+
+type Peer struct {
+	ID            peer.ID      `json:"id"`
+	Addresses     []string     `json:"addresses"`
+	Connections   []Connection `json:"connections"`
+	Connectedness string       `json:"connectedness"`
+	Subnets       string       `json:"subnets"`
+	Version       string       `json:"version"`
+}
+
+type TopicPeers struct {
+	Topic string    `json:"topic"`
+	Peers []peer.ID `json:"peers"`
+}
+
+type Topics struct {
+	AllPeers     []peer.ID    `json:"all_peers"`
+	PeersByTopic []TopicPeers `json:"peers_by_topic"`
+}
+
+// HealthStatus is either "good" or "bad: <reason>".
+type HealthStatus string
+
+func (s HealthStatus) Good() bool {
+	return s == "good"
+}
+
+type Health struct {
+	P2P            HealthStatus            `json:"p2p"`
+	BeaconNode     HealthStatus            `json:"beacon_node"`
+	BeaconNodes    map[string]HealthStatus `json:"beacon_nodes,omitempty"`
+	ExecutionNode  HealthStatus            `json:"execution_node"`
+	ExecutionNodes map[string]HealthStatus `json:"execution_nodes,omitempty"`
+	EventSyncer    HealthStatus            `json:"event_syncer"`
+	Advanced       HealthAdvanced          `json:"advanced"`
+}
+
+type HealthAdvanced struct {
+	Peers           int      `json:"peers"`
+	InboundConns    int      `json:"inbound_conns"`
+	OutboundConns   int      `json:"outbound_conns"`
+	ListenAddresses []string `json:"p2p_listen_addresses"`
+}
+
+// ValidatorsRequest selects the validators matching all of its non-empty fields.
+type ValidatorsRequest struct {
+	Owners      api.HexSlice
+	Operators   []uint64
+	Clusters    [][]uint64
+	Subclusters [][]uint64
+	PubKeys     api.HexSlice
+	Indices     []uint64
+}
+
+func (r ValidatorsRequest) query() url.Values {
+	q := url.Values{}
+	setHexes(q, "owners", r.Owners)
+	setUints(q, "operators", r.Operators)
+	setClusters(q, "clusters", r.Clusters)
+	setClusters(q, "subclusters", r.Subclusters)
+	setHexes(q, "pubkeys", r.PubKeys)
+	setUints(q, "indices", r.Indices)
+	return q
+}
+
+type Validator struct {
+	PubKey          api.Hex                `json:"public_key"`
+	Index           phase0.ValidatorIndex  `json:"index"`
+	Status          string                 `json:"status"`
+	ActivationEpoch phase0.Epoch           `json:"activation_epoch"`
+	Owner           api.Hex                `json:"owner"`
+	Committee       []spectypes.OperatorID `json:"committee"`
+	Quorum          uint64                 `json:"quorum"`
+	PartialQuorum   uint64                 `json:"partial_quorum"`
+	Graffiti        string                 `json:"graffiti"`
+	Liquidated      bool                   `json:"liquidated"`
+}
+
+type ValidatorsResponse struct {
+	Data []*Validator `json:"data"`
+}
+
+type Pagination struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+	Total   int `json:"total"`
+}
+
+// ExporterRequest selects the decided instances or participants of the given validators
+// and committees with the given roles in the slot range.
+type ExporterRequest struct {
+	PubKeys      api.HexSlice
+	Indices      []uint64
+	CommitteeIDs api.HexSlice
+	Roles        []convert.RunnerRole
+	From         phase0.Slot
+	To           phase0.Slot
+	Page         int
+	PerPage      int
+}
+
+func (r ExporterRequest) query() url.Values {
+	q := url.Values{}
+	setHexes(q, "pubkeys", r.PubKeys)
+	setUints(q, "indices", r.Indices)
+	setHexes(q, "committee_ids", r.CommitteeIDs)
+	roles := make([]string, 0, len(r.Roles))
+	for _, role := range r.Roles {
+		roles = append(roles, role.String())
+	}
+	setStrings(q, "roles", roles)
+	setUint(q, "from", uint64(r.From))
+	setUint(q, "to", uint64(r.To))
+	setUint(q, "page", uint64(r.Page))
+	setUint(q, "per_page", uint64(r.PerPage))
+	return q
+}
+
+type Decided struct {
+	Role string      `json:"role"`
+	Slot phase0.Slot `json:"slot"`
+	// DutyExecutorID is the committee ID for committee duties, and the validator public key otherwise.
+	DutyExecutorID api.Hex                `json:"duty_executor_id"`
+	Round          specqbft.Round         `json:"round"`
+	Root           api.Hex                `json:"root"`
+	Signers        []spectypes.OperatorID `json:"signers"`
+}
+
+type DecidedsResponse struct {
+	Data       []*Decided `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type Participant struct {
+	Role      string                 `json:"role"`
+	Slot      phase0.Slot            `json:"slot"`
+	PublicKey api.Hex                `json:"public_key"`
+	Signers   []spectypes.OperatorID `json:"signers"`
+}
+
+type ParticipantsResponse struct {
+	Data       []*Participant `json:"data"`
+	Pagination Pagination     `json:"pagination"`
+}
+
+// DutiesRequest selects the duty traces of the given validators and committees
+// with the given roles in the slot range.
+type DutiesRequest struct {
+	PubKeys      api.HexSlice
+	CommitteeIDs api.HexSlice
+	Roles        []spectypes.RunnerRole
+	From         phase0.Slot
+	To           phase0.Slot
+	Page         int
+	PerPage      int
+}
+
+func (r DutiesRequest) query() url.Values {
+	q := url.Values{}
+	setHexes(q, "pubkeys", r.PubKeys)
+	setHexes(q, "committee_ids", r.CommitteeIDs)
+	roles := make([]string, 0, len(r.Roles))
+	for _, role := range r.Roles {
+		roles = append(roles, dutytracer.RoleName(role))
+	}
+	setStrings(q, "roles", roles)
+	setUint(q, "from", uint64(r.From))
+	setUint(q, "to", uint64(r.To))
+	setUint(q, "page", uint64(r.Page))
+	setUint(q, "per_page", uint64(r.PerPage))
+	return q
+}
+
+type DutiesResponse struct {
+	Data       []*dutytracer.DutyTrace `json:"data"`
+	Pagination Pagination              `json:"pagination"`
+}
+
+// ParticipationRequest selects the validators and committees of the participation report
+// and the number of epochs it covers.
+type ParticipationRequest struct {
+	PubKeys      api.HexSlice
+	CommitteeIDs api.HexSlice
+	Epochs       uint64
+	// Validators includes the participation in the duties of each validator.
+	Validators bool
+}
+
+func (r ParticipationRequest) query() url.Values {
+	q := url.Values{}
+	setHexes(q, "pubkeys", r.PubKeys)
+	setHexes(q, "committee_ids", r.CommitteeIDs)
+	setUint(q, "epochs", r.Epochs)
+	if r.Validators {
+		q.Set("validators", "true")
+	}
+	return q
+}
+
+// ParticipationReport is the participation of operators over a window of epochs.
+type ParticipationReport struct {
+	FromEpoch  phase0.Epoch              `json:"from_epoch"`
+	ToEpoch    phase0.Epoch              `json:"to_epoch"`
+	Operators  []*OperatorParticipation  `json:"operators"`
+	Committees []*CommitteeParticipation `json:"committees"`
+	Validators []*ValidatorParticipation `json:"validators,omitempty"`
+}
+
+// ParticipationStats counts the duties an operator participated in and missed.
+type ParticipationStats struct {
+	Duties            uint64            `json:"duties"`
+	Missed            uint64            `json:"missed"`
+	MissedByRole      map[string]uint64 `json:"missed_by_role,omitempty"`
+	ParticipationRate float64           `json:"participation_rate"`
+}
+
+type OperatorParticipation struct {
+	OperatorID spectypes.OperatorID `json:"operator_id"`
+	ParticipationStats
+	// AverageLatency is the average time from the start of the slot until the exporter recorded
+	// the operator's post-consensus signature, which is once a quorum was seen or later,
+	// so it isn't the operator's decision latency.
+	AverageLatency *float64 `json:"average_latency_seconds,omitempty"`
+}
+
+type CommitteeParticipation struct {
+	CommitteeID string                   `json:"committee_id"`
+	Validators  int                      `json:"validators"`
+	Operators   []*OperatorParticipation `json:"operators"`
+}
+
+type ValidatorParticipation struct {
+	PublicKey   string                   `json:"public_key"`
+	CommitteeID string                   `json:"committee_id"`
+	Operators   []*OperatorParticipation `json:"operators"`
+}
+
+// The API binds lists from comma-separated values, and omitted values are empty.
+
+func setStrings(q url.Values, key string, values []string) {
+	if len(values) > 0 {
+		q.Set(key, strings.Join(values, ","))
+	}
+}
+
+func setHexes(q url.Values, key string, values api.HexSlice) {
+	s := make([]string, 0, len(values))
+	for _, v := range values {
+		s = append(s, hex.EncodeToString(v))
+	}
+	setStrings(q, key, s)
+}
+
+func setUints(q url.Values, key string, values []uint64) {
+	setStrings(q, key, uintStrings(values))
+}
+
+func setUint(q url.Values, key string, value uint64) {
+	if value != 0 {
+		q.Set(key, strconv.FormatUint(value, 10))
+	}
+}
+
+// setClusters sets a space-separated list of comma-separated lists.
+func setClusters(q url.Values, key string, clusters [][]uint64) {
+	s := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		s = append(s, strings.Join(uintStrings(cluster), ","))
+	}
+	if len(s) > 0 {
+		q.Set(key, strings.Join(s, " "))
+	}
+}
+
+func uintStrings(values []uint64) []string {
+	s := make([]string, 0, len(values))
+	for _, v := range values {
+		s = append(s, strconv.FormatUint(v, 10))
+	}
+	return s
+}
@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/operator/backup"
+)
+
+const adminActionTimeout = 30 * time.Second
+
+type ValidatorsAdmin interface {
+	PauseValidator(pubKey spectypes.ValidatorPK) error
+	ResumeValidator(pubKey spectypes.ValidatorPK) error
+	PausedValidators() []spectypes.ValidatorPK
+	RefreshValidatorsMetadata(pubKeys []spectypes.ValidatorPK) int
+}
+
+type TrustedPeersDialer interface {
+	DialTrustedPeers(ctx context.Context) (int, error)
+}
+
+type EventsResyncer interface {
+	Resync(ctx context.Context, fromBlock uint64) error
+}
+
+type DatabaseSnapshotter interface {
+	Snapshot(w io.Writer) (*backup.Metadata, error)
+}
+
+type DecidedPruner interface {
+	Trigger() bool
+}
+
+// Admin serves the runtime operations of the admin API.
+// Every action is audit-logged with its parameters and outcome.
+type Admin struct {
+	Logger     *zap.Logger
+	Validators ValidatorsAdmin
+	// Peers is nil if the network can't dial trusted peers.
+	Peers    TrustedPeersDialer
+	Events   EventsResyncer
+	Database DatabaseSnapshotter
+	// Decided is nil unless the decided history is pruned.
+	Decided DecidedPruner
+}
+
+type logLevelsJSON struct {
+	Global  string            `json:"global"`
+	Loggers map[string]string `json:"loggers"`
+}
+
+// LogLevels returns the global log level and the log level overrides by logger name.
+func (h *Admin) LogLevels(w http.ResponseWriter, r *http.Request) error {
+	return api.Render(w, r, currentLogLevels())
+}
+
+type setLogLevelRequest struct {
+	Name  string `json:"name" form:"name"`
+	Level string `json:"level" form:"level"`
+}
+
+// SetLogLevel sets the log level of the loggers with the given name and their children,
+// or the global log level if no name is given. An empty level removes the override of the name.
+func (h *Admin) SetLogLevel(w http.ResponseWriter, r *http.Request) error {
+	var request setLogLevelRequest
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	switch {
+	case request.Level != "":
+		level, err := zapcore.ParseLevel(request.Level)
+		if err != nil {
+			return api.InvalidRequestError(err)
+		}
+		logging.SetLevel(request.Name, level)
+	case request.Name != "":
+		logging.ResetLevel(request.Name)
+	default:
+		return api.InvalidRequestError(errors.New("level is required to set the global log level"))
+	}
+
+	h.audit(r, "set log level", nil, zap.String("name", request.Name), zap.String("level", request.Level))
+	return api.Render(w, r, currentLogLevels())
+}
+
+type refreshMetadataRequest struct {
+	PubKeys api.HexSlice `json:"pubkeys" form:"pubkeys"`
+}
+
+type refreshMetadataResponse struct {
+	Validators int `json:"validators"`
+}
+
+// RefreshValidatorsMetadata schedules the given validators, or all validators if none are given,
+// for a metadata update from the beacon node.
+func (h *Admin) RefreshValidatorsMetadata(w http.ResponseWriter, r *http.Request) error {
+	var request refreshMetadataRequest
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	pubKeys, err := validatorPubKeys(request.PubKeys)
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	validators := h.Validators.RefreshValidatorsMetadata(pubKeys)
+	h.audit(r, "refresh validators metadata", nil, zap.Int("requested", len(pubKeys)), zap.Int("validators", validators))
+	return api.Render(w, r, refreshMetadataResponse{Validators: validators})
+}
+
+type validatorRequest struct {
+	PubKey api.Hex `json:"pubkey" form:"pubkey"`
+}
+
+// PauseValidator stops a validator until it's resumed or the node restarts.
+func (h *Admin) PauseValidator(w http.ResponseWriter, r *http.Request) error {
+	return h.validatorAction(w, r, "pause validator", h.Validators.PauseValidator)
+}
+
+// ResumeValidator starts a paused validator.
+func (h *Admin) ResumeValidator(w http.ResponseWriter, r *http.Request) error {
+	return h.validatorAction(w, r, "resume validator", h.Validators.ResumeValidator)
+}
+
+type pausedValidatorsResponse struct {
+	Data []api.Hex `json:"data"`
+}
+
+// PausedValidators returns the public keys of the paused validators.
+func (h *Admin) PausedValidators(w http.ResponseWriter, r *http.Request) error {
+	var response pausedValidatorsResponse
+	response.Data = []api.Hex{}
+	for _, pubKey := range h.Validators.PausedValidators() {
+		response.Data = append(response.Data, pubKey[:])
+	}
+	return api.Render(w, r, response)
+}
+
+func (h *Admin) validatorAction(w http.ResponseWriter, r *http.Request, action string, fn func(spectypes.ValidatorPK) error) error {
+	var request validatorRequest
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	pubKeys, err := validatorPubKeys(api.HexSlice{request.PubKey})
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	err = fn(pubKeys[0])
+	h.audit(r, action, err, fields.PubKey(request.PubKey))
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+	return api.Render(w, r, struct{}{})
+}
+
+type dialTrustedPeersResponse struct {
+	Dialed int `json:"dialed"`
+}
+
+// DialTrustedPeers connects to the trusted peers which aren't connected.
+func (h *Admin) DialTrustedPeers(w http.ResponseWriter, r *http.Request) error {
+	if h.Peers == nil {
+		return api.InvalidRequestError(errors.New("dialing trusted peers is not supported"))
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), adminActionTimeout)
+	defer cancel()
+
+	dialed, err := h.Peers.DialTrustedPeers(ctx)
+	h.audit(r, "dial trusted peers", err, zap.Int("dialed", dialed))
+	if err != nil {
+		return err
+	}
+	return api.Render(w, r, dialTrustedPeersResponse{Dialed: dialed})
+}
+
+type resyncEventsRequest struct {
+	FromBlock uint64 `json:"from_block" form:"from_block"`
+}
+
+// ResyncEvents rolls back the registry changes of the blocks from the given one,
+// then restarts the ongoing sync of registry events from it.
+// Blocks older than the reorg journal can't be resynced.
+func (h *Admin) ResyncEvents(w http.ResponseWriter, r *http.Request) error {
+	var request resyncEventsRequest
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if request.FromBlock == 0 {
+		return api.InvalidRequestError(errors.New("from_block is required"))
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), adminActionTimeout)
+	defer cancel()
+
+	err := h.Events.Resync(ctx, request.FromBlock)
+	h.audit(r, "resync events", err, fields.FromBlock(request.FromBlock))
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+	return api.Render(w, r, struct{}{})
+}
+
+// BackupDatabase streams a consistent snapshot of the node database, to be restored with `ssvnode db restore`.
+func (h *Admin) BackupDatabase(w http.ResponseWriter, r *http.Request) error {
+	// Snapshots of large databases take longer than the write timeout of the server.
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	sw := &startedWriter{w: w}
+	metadata, err := h.Database.Snapshot(sw)
+	if err != nil {
+		h.audit(r, "backup database", err)
+		if !sw.started {
+			return err
+		}
+		// The snapshot is partially sent, so abort the response for the client to notice.
+		panic(http.ErrAbortHandler)
+	}
+	h.audit(r, "backup database", nil,
+		zap.String("network", metadata.Config.NetworkName),
+		zap.Uint64("last_processed_block", metadata.LastProcessedBlock),
+	)
+	return nil
+}
+
+type pruneDecidedResponse struct {
+	Scheduled bool `json:"scheduled"`
+}
+
+// PruneDecided schedules a run of the decided history pruner, whose progress is exposed as metrics.
+// Nothing is scheduled if a run is already pending.
+func (h *Admin) PruneDecided(w http.ResponseWriter, r *http.Request) error {
+	if h.Decided == nil {
+		return api.InvalidRequestError(errors.New("decided history pruning is not enabled"))
+	}
+
+	scheduled := h.Decided.Trigger()
+	h.audit(r, "prune decided history", nil, zap.Bool("scheduled", scheduled))
+	return api.Render(w, r, pruneDecidedResponse{Scheduled: scheduled})
+}
+
+// startedWriter records whether anything was written.
+type startedWriter struct {
+	w       io.Writer
+	started bool
+}
+
+func (w *startedWriter) Write(p []byte) (int, error) {
+	w.started = true
+	return w.w.Write(p)
+}
+
+// audit logs the outcome of an admin action along with who requested it.
+func (h *Admin) audit(r *http.Request, action string, err error, fieldz ...zap.Field) {
+	fieldz = append(fieldz,
+		zap.String("action", action),
+		zap.String("remote_addr", r.RemoteAddr),
+	)
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		fieldz = append(fieldz, zap.String("client", r.TLS.PeerCertificates[0].Subject.String()))
+	}
+	if err != nil {
+		h.Logger.Warn("admin action failed", append(fieldz, zap.Error(err))...)
+		return
+	}
+	h.Logger.Info("admin action succeeded", fieldz...)
+}
+
+func currentLogLevels() logLevelsJSON {
+	levels := logging.Levels()
+	response := logLevelsJSON{
+		Global:  levels[""].String(),
+		Loggers: make(map[string]string, len(levels)-1),
+	}
+	for name, level := range levels {
+		if name != "" {
+			response.Loggers[name] = level.String()
+		}
+	}
+	return response
+}
+
+func validatorPubKeys(hexes api.HexSlice) ([]spectypes.ValidatorPK, error) {
+	pubKeys := make([]spectypes.ValidatorPK, 0, len(hexes))
+	for _, pubKey := range hexes {
+		if len(pubKey) != len(spectypes.ValidatorPK{}) {
+			return nil, fmt.Errorf("invalid validator public key: %x", []byte(pubKey))
+		}
+		pubKeys = append(pubKeys, spectypes.ValidatorPK(pubKey))
+	}
+	return pubKeys, nil
+}
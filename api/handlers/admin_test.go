@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/operator/backup"
+)
+
+type mockValidatorsAdmin struct {
+	paused    map[spectypes.ValidatorPK]struct{}
+	refreshed []spectypes.ValidatorPK
+}
+
+func (m *mockValidatorsAdmin) PauseValidator(pubKey spectypes.ValidatorPK) error {
+	m.paused[pubKey] = struct{}{}
+	return nil
+}
+
+func (m *mockValidatorsAdmin) ResumeValidator(pubKey spectypes.ValidatorPK) error {
+	if _, ok := m.paused[pubKey]; !ok {
+		return errors.New("validator is not paused")
+	}
+	delete(m.paused, pubKey)
+	return nil
+}
+
+func (m *mockValidatorsAdmin) PausedValidators() []spectypes.ValidatorPK {
+	var pubKeys []spectypes.ValidatorPK
+	for pubKey := range m.paused {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	return pubKeys
+}
+
+func (m *mockValidatorsAdmin) RefreshValidatorsMetadata(pubKeys []spectypes.ValidatorPK) int {
+	m.refreshed = pubKeys
+	return len(pubKeys)
+}
+
+type mockEventsResyncer struct {
+	fromBlock uint64
+}
+
+func (m *mockEventsResyncer) Resync(ctx context.Context, fromBlock uint64) error {
+	m.fromBlock = fromBlock
+	return nil
+}
+
+type mockDatabaseSnapshotter struct {
+	err error
+}
+
+func (m *mockDatabaseSnapshotter) Snapshot(w io.Writer) (*backup.Metadata, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	_, err := w.Write([]byte("snapshot"))
+	return &backup.Metadata{}, err
+}
+
+type mockDecidedPruner struct {
+	pending bool
+}
+
+func (m *mockDecidedPruner) Trigger() bool {
+	if m.pending {
+		return false
+	}
+	m.pending = true
+	return true
+}
+
+func TestAdmin(t *testing.T) {
+	validators := &mockValidatorsAdmin{paused: map[spectypes.ValidatorPK]struct{}{}}
+	events := &mockEventsResyncer{}
+	database := &mockDatabaseSnapshotter{}
+	h := &Admin{
+		Logger:     logging.TestLogger(t),
+		Validators: validators,
+		Events:     events,
+		Database:   database,
+	}
+
+	post := func(t *testing.T, handler api.HandlerFunc, body string, response any) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		api.Handler(handler)(rec, req)
+		if rec.Code == http.StatusOK && response != nil {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), response))
+		}
+		return rec.Code
+	}
+
+	t.Run("log levels", func(t *testing.T) {
+		defer logging.ResetLevel("P2PNetwork")
+
+		var levels logLevelsJSON
+		require.Equal(t, http.StatusOK, post(t, h.SetLogLevel, `{"name":"P2PNetwork","level":"debug"}`, &levels))
+		require.Equal(t, map[string]string{"P2PNetwork": "debug"}, levels.Loggers)
+		require.Equal(t, zapcore.DebugLevel, logging.Levels()["P2PNetwork"])
+
+		require.Equal(t, http.StatusBadRequest, post(t, h.SetLogLevel, `{"name":"P2PNetwork","level":"loud"}`, nil))
+		require.Equal(t, http.StatusBadRequest, post(t, h.SetLogLevel, `{}`, nil))
+
+		var resetLevels logLevelsJSON
+		require.Equal(t, http.StatusOK, post(t, h.SetLogLevel, `{"name":"P2PNetwork"}`, &resetLevels))
+		require.Empty(t, resetLevels.Loggers)
+	})
+
+	t.Run("pause and resume", func(t *testing.T) {
+		pubKey := spectypes.ValidatorPK{0x1}
+		body := `{"pubkey":"` + hex.EncodeToString(pubKey[:]) + `"}`
+
+		require.Equal(t, http.StatusBadRequest, post(t, h.ResumeValidator, body, nil))
+		require.Equal(t, http.StatusOK, post(t, h.PauseValidator, body, nil))
+		require.Contains(t, validators.paused, pubKey)
+		require.Equal(t, http.StatusOK, post(t, h.ResumeValidator, body, nil))
+		require.Empty(t, validators.paused)
+
+		require.Equal(t, http.StatusBadRequest, post(t, h.PauseValidator, `{"pubkey":"01"}`, nil))
+	})
+
+	t.Run("refresh metadata", func(t *testing.T) {
+		var res refreshMetadataResponse
+		require.Equal(t, http.StatusOK, post(t, h.RefreshValidatorsMetadata, `{}`, &res))
+		require.Empty(t, validators.refreshed)
+
+		pubKey := spectypes.ValidatorPK{0x2}
+		require.Equal(t, http.StatusOK, post(t, h.RefreshValidatorsMetadata, `{"pubkeys":["`+hex.EncodeToString(pubKey[:])+`"]}`, &res))
+		require.Equal(t, 1, res.Validators)
+		require.Equal(t, []spectypes.ValidatorPK{pubKey}, validators.refreshed)
+	})
+
+	t.Run("resync events", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, post(t, h.ResyncEvents, `{}`, nil))
+		require.Equal(t, http.StatusOK, post(t, h.ResyncEvents, `{"from_block":123}`, nil))
+		require.Equal(t, uint64(123), events.fromBlock)
+	})
+	t.Run("backup database", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		api.Handler(h.BackupDatabase)(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "application/octet-stream", rec.Header().Get("Content-Type"))
+		require.Equal(t, "snapshot", rec.Body.String())
+
+		database.err = errors.New("no config lock")
+		rec = httptest.NewRecorder()
+		api.Handler(h.BackupDatabase)(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+	t.Run("prune decided", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, post(t, h.PruneDecided, `{}`, nil))
+
+		h.Decided = &mockDecidedPruner{}
+		var res pruneDecidedResponse
+		require.Equal(t, http.StatusOK, post(t, h.PruneDecided, `{}`, &res))
+		require.True(t, res.Scheduled)
+		require.Equal(t, http.StatusOK, post(t, h.PruneDecided, `{}`, &res))
+		require.False(t, res.Scheduled)
+	})
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/exporter/analytics"
+)
+
+type ParticipationReporter interface {
+	Report(opts analytics.ReportOptions) (*analytics.Report, error)
+}
+
+type Analytics struct {
+	Reporter ParticipationReporter
+}
+
+type analyticsRequest struct {
+	PubKeys      api.HexSlice `json:"pubkeys" form:"pubkeys"`
+	CommitteeIDs api.HexSlice `json:"committee_ids" form:"committee_ids"`
+	Epochs       uint64       `json:"epochs" form:"epochs"`
+	Validators   bool         `json:"validators" form:"validators"`
+}
+
+// Participation returns the participation of operators in the duties of the matching validators
+// over the last completed epochs, including the stats of each validator if requested.
+// Reports of the configured epochs are served from the report computed once per epoch.
+func (h *Analytics) Participation(w http.ResponseWriter, r *http.Request) error {
+	var request analyticsRequest
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	opts := analytics.ReportOptions{
+		Epochs:     request.Epochs,
+		Validators: request.Validators,
+	}
+	if len(request.PubKeys) > 0 {
+		opts.Filters = append(opts.Filters, byPubKeys(request.PubKeys))
+	}
+	if len(request.CommitteeIDs) > 0 {
+		opts.Filters = append(opts.Filters, byCommitteeIDs(request.CommitteeIDs))
+	}
+
+	report, err := h.Reporter.Report(opts)
+	if errors.Is(err, analytics.ErrEpochsOutOfRange) {
+		return api.InvalidRequestError(err)
+	}
+	if err != nil {
+		return err
+	}
+	return api.Render(w, r, report)
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/api/openapi"
+	"github.com/ssvlabs/ssv/operator/dutytracer"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+type DutyTraces interface {
+	Traces(filter dutytracer.Filter) []*dutytracer.DutyTrace
+}
+
+type Duties struct {
+	Tracer DutyTraces
+	Shares registrystorage.Shares
+}
+
+type dutiesRequest struct {
+	PubKeys      api.HexSlice `json:"pubkeys" form:"pubkeys"`
+	CommitteeIDs api.HexSlice `json:"committee_ids" form:"committee_ids"`
+	Roles        dutyRoles    `json:"roles" form:"roles"`
+	From         uint64       `json:"from" form:"from"`
+	To           uint64       `json:"to" form:"to"`
+	Page         int          `json:"page" form:"page"`
+	PerPage      int          `json:"per_page" form:"per_page"`
+}
+
+type dutiesResponse struct {
+	Data       []*dutytracer.DutyTrace `json:"data"`
+	Pagination pagination              `json:"pagination"`
+}
+
+// List returns the execution traces of the recent duties of the matching validators and committees.
+// Committee duties of validators are matched by their committee IDs.
+func (h *Duties) List(w http.ResponseWriter, r *http.Request) error {
+	var request dutiesRequest
+	var response dutiesResponse
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	filter, err := h.filter(&request)
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	response.Data, response.Pagination = paginate(h.Tracer.Traces(filter), request.Page, request.PerPage)
+	return api.Render(w, r, response)
+}
+
+func (h *Duties) filter(request *dutiesRequest) (dutytracer.Filter, error) {
+	if request.To != 0 && request.From > request.To {
+		return dutytracer.Filter{}, fmt.Errorf("from slot %d is after to slot %d", request.From, request.To)
+	}
+	if err := validatePagination(request.Page, request.PerPage); err != nil {
+		return dutytracer.Filter{}, err
+	}
+
+	filter := dutytracer.Filter{
+		FromSlot: phase0.Slot(request.From),
+		ToSlot:   phase0.Slot(request.To),
+		Roles:    request.Roles,
+	}
+
+	for _, pubKey := range request.PubKeys {
+		filter.DutyExecutorIDs = append(filter.DutyExecutorIDs, pubKey)
+	}
+	for _, committeeID := range request.CommitteeIDs {
+		filter.DutyExecutorIDs = append(filter.DutyExecutorIDs, committeeID)
+	}
+	if len(request.PubKeys) > 0 {
+		seen := make(map[spectypes.CommitteeID]struct{})
+		for _, share := range h.Shares.List(nil, byPubKeys(request.PubKeys)) {
+			committeeID := share.CommitteeID()
+			if _, ok := seen[committeeID]; ok {
+				continue
+			}
+			seen[committeeID] = struct{}{}
+			filter.DutyExecutorIDs = append(filter.DutyExecutorIDs, committeeID[:])
+		}
+	}
+	return filter, nil
+}
+
+// dutyRoles is a comma-separated list of runner role names.
+// An empty list matches all roles.
+type dutyRoles []spectypes.RunnerRole
+
+func (dr dutyRoles) OpenAPISchema() *openapi.Schema {
+	var names []string
+	for role := spectypes.RoleCommittee; role <= spectypes.RoleVoluntaryExit; role++ {
+		names = append(names, dutytracer.RoleName(role))
+	}
+	return &openapi.Schema{Type: "array", Items: &openapi.Schema{Type: "string", Enum: names}}
+}
+
+func (dr *dutyRoles) Bind(value string) error {
+	if value == "" {
+		return nil
+	}
+	return dr.parse(strings.Split(value, ","))
+}
+
+func (dr *dutyRoles) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	return dr.parse(names)
+}
+
+func (dr *dutyRoles) parse(names []string) error {
+	for _, name := range names {
+		role, ok := dutyRoleByName(name)
+		if !ok {
+			return fmt.Errorf("unknown role: %s", name)
+		}
+		*dr = append(*dr, role)
+	}
+	return nil
+}
+
+func dutyRoleByName(name string) (spectypes.RunnerRole, bool) {
+	for role := spectypes.RoleCommittee; role <= spectypes.RoleVoluntaryExit; role++ {
+		if strings.EqualFold(name, dutytracer.RoleName(role)) {
+			return role, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/operator/dutytracer"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestDuties(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+
+	shares, _, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	share1, share2 := mockShare(1, 2, 3, 4), mockShare(5, 6, 7, 8)
+	share1.ValidatorPubKey = spectypes.ValidatorPK{0x1}
+	share2.ValidatorPubKey = spectypes.ValidatorPK{0x2}
+	require.NoError(t, shares.Save(nil, share1, share2))
+
+	tracer := dutytracer.New(64)
+	for slot := phase0.Slot(10); slot < 20; slot++ {
+		for _, share := range []*types.SSVShare{share1, share2} {
+			committeeID := share.CommitteeID()
+			tracer.TraceDuty(types.DutyTraceEvent{Type: types.DutyStarted, Role: spectypes.RoleCommittee, DutyExecutorID: committeeID[:], Slot: slot})
+			tracer.TraceDuty(types.DutyTraceEvent{Type: types.DutyStarted, Role: spectypes.RoleProposer, DutyExecutorID: share.ValidatorPubKey[:], Slot: slot})
+		}
+	}
+
+	h := &Duties{
+		Tracer: tracer,
+		Shares: shares,
+	}
+
+	var res struct {
+		Data       []*dutytracer.DutyTrace `json:"data"`
+		Pagination pagination              `json:"pagination"`
+	}
+	get := func(t *testing.T, query string) int {
+		req := httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+		rec := httptest.NewRecorder()
+		api.Handler(h.List)(rec, req)
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		}
+		return rec.Code
+	}
+	pk1 := hex.EncodeToString(share1.ValidatorPubKey[:])
+	committeeID1 := share1.CommitteeID()
+
+	require.Equal(t, http.StatusOK, get(t, "pubkeys="+pk1+"&from=12&to=13"))
+	require.Equal(t, pagination{Page: 1, PerPage: defaultPerPage, Total: 4}, res.Pagination)
+	require.Equal(t, "COMMITTEE", res.Data[0].Role)
+	require.Equal(t, hex.EncodeToString(committeeID1[:]), res.Data[0].DutyExecutorID)
+	require.Equal(t, "PROPOSER", res.Data[1].Role)
+	require.Equal(t, pk1, res.Data[1].DutyExecutorID)
+
+	require.Equal(t, http.StatusOK, get(t, "roles=proposer&per_page=5&page=4"))
+	require.Equal(t, 20, res.Pagination.Total)
+	require.Len(t, res.Data, 5)
+	require.Equal(t, phase0.Slot(17), res.Data[0].Slot)
+
+	// 0 selects the default page size.
+	require.Equal(t, http.StatusOK, get(t, "roles=proposer&per_page=0"))
+	require.Equal(t, defaultPerPage, res.Pagination.PerPage)
+
+	require.Equal(t, http.StatusBadRequest, get(t, "roles=UNKNOWN"))
+	require.Equal(t, http.StatusBadRequest, get(t, "from=3&to=2"))
+	require.Equal(t, http.StatusBadRequest, get(t, "per_page=-1"))
+}
@@ -0,0 +1,463 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/api/openapi"
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/networkconfig"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+const (
+	// maxSlotRange is the maximum number of slots which can be queried at once.
+	maxSlotRange = 7200
+	// defaultPerPage is the number of results per page if not specified.
+	defaultPerPage = 100
+	// maxPerPage is the maximum number of results per page.
+	maxPerPage = 1000
+	// maxQueries is the maximum number of range queries of a request,
+	// one per matching validator or committee and role.
+	maxQueries = 1000
+)
+
+type Exporter struct {
+	DomainType networkconfig.DomainTypeProvider
+	QBFTStores *storage.QBFTStores
+	Shares     registrystorage.Shares
+}
+
+type exporterRequest struct {
+	PubKeys      api.HexSlice    `json:"pubkeys" form:"pubkeys"`
+	Indices      api.Uint64Slice `json:"indices" form:"indices"`
+	CommitteeIDs api.HexSlice    `json:"committee_ids" form:"committee_ids"`
+	Roles        requestRoles    `json:"roles" form:"roles"`
+	From         uint64          `json:"from" form:"from"`
+	To           uint64          `json:"to" form:"to"`
+	Page         int             `json:"page" form:"page"`
+	PerPage      int             `json:"per_page" form:"per_page"`
+}
+
+type participantsResponse struct {
+	Data       []*participantJSON `json:"data"`
+	Pagination pagination         `json:"pagination"`
+}
+
+type decidedsResponse struct {
+	Data       []*decidedJSON `json:"data"`
+	Pagination pagination     `json:"pagination"`
+}
+
+type pagination struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+	Total   int `json:"total"`
+}
+
+// Participants returns the operators which participated in the quorum of each duty
+// of the matching validators in the slot range.
+func (h *Exporter) Participants(w http.ResponseWriter, r *http.Request) error {
+	var request exporterRequest
+	var response participantsResponse
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if err := request.validate(); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	for _, role := range request.Roles {
+		if role == convert.RoleCommittee {
+			return api.InvalidRequestError(fmt.Errorf("participants are not stored for role %s", role))
+		}
+	}
+
+	queries, err := h.queries(&request, false)
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+	from, to := phase0.Slot(request.From), phase0.Slot(request.To)
+	response.Data, response.Pagination, err = fetchPage(queries, request.Page, request.PerPage,
+		func(query exporterQuery, offset, limit int) ([]*participantJSON, error) {
+			msgID := convert.NewMsgID(h.DomainType.DomainType(), query.dutyExecutorID, query.role)
+			entries, err := query.store.GetParticipantsPage(msgID, from, to, offset, limit)
+			if err != nil {
+				return nil, fmt.Errorf("could not get participants: %w", err)
+			}
+			participants := make([]*participantJSON, 0, len(entries))
+			for _, entry := range entries {
+				participants = append(participants, &participantJSON{
+					Role:      query.role.String(),
+					Slot:      entry.Slot,
+					PublicKey: api.Hex(query.dutyExecutorID),
+					Signers:   entry.Signers,
+				})
+			}
+			return participants, nil
+		},
+		func(query exporterQuery) (int, error) {
+			msgID := convert.NewMsgID(h.DomainType.DomainType(), query.dutyExecutorID, query.role)
+			return query.store.CountParticipantsInRange(msgID, from, to)
+		},
+		func(a, b *participantJSON) bool {
+			if a.Slot != b.Slot {
+				return a.Slot < b.Slot
+			}
+			if a.Role != b.Role {
+				return a.Role < b.Role
+			}
+			return bytes.Compare(a.PublicKey, b.PublicKey) < 0
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return api.Render(w, r, response)
+}
+
+// Decideds returns the decided instances of the matching validators and committees in the slot range.
+// Committee duties are looked up by the committee IDs of the matching validators.
+func (h *Exporter) Decideds(w http.ResponseWriter, r *http.Request) error {
+	var request exporterRequest
+	var response decidedsResponse
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if err := request.validate(); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	queries, err := h.queries(&request, true)
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+	from, to := specqbft.Height(request.From), specqbft.Height(request.To)
+	response.Data, response.Pagination, err = fetchPage(queries, request.Page, request.PerPage,
+		func(query exporterQuery, offset, limit int) ([]*decidedJSON, error) {
+			msgID := convert.NewMsgID(h.DomainType.DomainType(), query.dutyExecutorID, query.role)
+			instances, err := query.store.GetInstancesPage(msgID[:], from, to, offset, limit)
+			if err != nil {
+				return nil, fmt.Errorf("could not get decided instances: %w", err)
+			}
+			decideds := make([]*decidedJSON, 0, len(instances))
+			for _, instance := range instances {
+				decided, err := decidedFromInstance(query.role, query.dutyExecutorID, instance.DecidedMessage)
+				if err != nil {
+					return nil, err
+				}
+				decideds = append(decideds, decided)
+			}
+			return decideds, nil
+		},
+		func(query exporterQuery) (int, error) {
+			msgID := convert.NewMsgID(h.DomainType.DomainType(), query.dutyExecutorID, query.role)
+			return query.store.CountInstancesInRange(msgID[:], from, to)
+		},
+		func(a, b *decidedJSON) bool {
+			if a.Slot != b.Slot {
+				return a.Slot < b.Slot
+			}
+			if a.Role != b.Role {
+				return a.Role < b.Role
+			}
+			return bytes.Compare(a.DutyExecutorID, b.DutyExecutorID) < 0
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return api.Render(w, r, response)
+}
+
+// exporterQuery is a range query of the history of a validator or committee with a role.
+type exporterQuery struct {
+	role           convert.RunnerRole
+	store          qbftstorage.QBFTStore
+	dutyExecutorID []byte
+}
+
+// queries returns the range queries of the request, failing if there are more than maxQueries.
+// Committee duties are decided per committee, other duties per validator, and committee duties are only queried if requested.
+//
+// The given public keys and committee IDs are queried as they are, so that the history of validators and committees
+// which are no longer in the registry is found. The given indices are resolved to validators through the registry,
+// as are the validators of the given committees and the committees of the given validators.
+func (h *Exporter) queries(request *exporterRequest, committeeDuties bool) ([]exporterQuery, error) {
+	var validators, committees [][]byte
+	seenValidators := make(map[string]struct{})
+	addValidator := func(pubKey []byte) {
+		if _, ok := seenValidators[string(pubKey)]; !ok {
+			seenValidators[string(pubKey)] = struct{}{}
+			validators = append(validators, pubKey)
+		}
+	}
+	seenCommittees := make(map[string]struct{})
+	addCommittee := func(committeeID []byte) {
+		if _, ok := seenCommittees[string(committeeID)]; !ok {
+			seenCommittees[string(committeeID)] = struct{}{}
+			committees = append(committees, committeeID)
+		}
+	}
+
+	for _, pubKey := range request.PubKeys {
+		addValidator(pubKey)
+	}
+	for _, committeeID := range request.CommitteeIDs {
+		addCommittee(committeeID)
+	}
+	var shares []*types.SSVShare
+	if len(request.PubKeys) > 0 {
+		shares = append(shares, h.Shares.List(nil, byPubKeys(request.PubKeys))...)
+	}
+	if len(request.Indices) > 0 {
+		shares = append(shares, h.Shares.List(nil, byIndices(request.Indices))...)
+	}
+	if len(request.CommitteeIDs) > 0 {
+		shares = append(shares, h.Shares.List(nil, byCommitteeIDs(request.CommitteeIDs))...)
+	}
+	for _, share := range shares {
+		addValidator(share.ValidatorPubKey[:])
+		committeeID := share.CommitteeID()
+		addCommittee(committeeID[:])
+	}
+
+	var queries []exporterQuery
+	err := h.QBFTStores.Each(func(role convert.RunnerRole, store qbftstorage.QBFTStore) error {
+		if !request.Roles.contains(role) {
+			return nil
+		}
+		if role == convert.RoleCommittee {
+			if !committeeDuties {
+				return nil
+			}
+			for _, committeeID := range committees {
+				queries = append(queries, exporterQuery{role: role, store: store, dutyExecutorID: committeeID})
+			}
+		} else {
+			for _, pubKey := range validators {
+				queries = append(queries, exporterQuery{role: role, store: store, dutyExecutorID: pubKey})
+			}
+		}
+		if len(queries) > maxQueries {
+			return fmt.Errorf("too many validators and roles match the request, at most %d can be queried at once", maxQueries)
+		}
+		return nil
+	})
+	return queries, err
+}
+
+func (r *exporterRequest) validate() error {
+	if len(r.PubKeys) == 0 && len(r.Indices) == 0 && len(r.CommitteeIDs) == 0 {
+		return fmt.Errorf("at least one of pubkeys, indices or committee_ids is required")
+	}
+	if r.From > r.To {
+		return fmt.Errorf("from slot %d is after to slot %d", r.From, r.To)
+	}
+	if r.To-r.From >= maxSlotRange {
+		return fmt.Errorf("slot range must be shorter than %d slots", maxSlotRange)
+	}
+	return validatePagination(r.Page, r.PerPage)
+}
+
+// validatePagination validates the page and the number of results per page, where 0 selects the default.
+func validatePagination(page, perPage int) error {
+	if page < 0 {
+		return fmt.Errorf("page must be positive, or 0 for the first page")
+	}
+	if perPage < 0 || perPage > maxPerPage {
+		return fmt.Errorf("per_page must be between 1 and %d, or 0 for the default of %d", maxPerPage, defaultPerPage)
+	}
+	return nil
+}
+
+func byCommitteeIDs(committeeIDs []api.Hex) registrystorage.SharesFilter {
+	return func(share *types.SSVShare) bool {
+		shareCommitteeID := share.CommitteeID()
+		for _, committeeID := range committeeIDs {
+			if bytes.Equal(committeeID, shareCommitteeID[:]) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// fetchPage returns the given page of the results of the queries in the order of less, pages start from 1.
+// Each query is expected to fetch its results in that order, and to count them without fetching them.
+// Only the results up to the end of the page are fetched, and a single query only fetches the page.
+func fetchPage[T any](
+	queries []exporterQuery,
+	page, perPage int,
+	fetch func(query exporterQuery, offset, limit int) ([]T, error),
+	count func(query exporterQuery) (int, error),
+	less func(a, b T) bool,
+) ([]T, pagination, error) {
+	if page == 0 {
+		page = 1
+	}
+	if perPage == 0 {
+		perPage = defaultPerPage
+	}
+	p := pagination{Page: page, PerPage: perPage}
+	start := (page - 1) * perPage
+
+	results := []T{}
+	for _, query := range queries {
+		n, err := count(query)
+		if err != nil {
+			return nil, p, err
+		}
+		p.Total += n
+
+		// The results of the page are among the first start+perPage results of each query.
+		offset, limit := 0, start+perPage
+		if len(queries) == 1 {
+			offset, limit = start, perPage
+		}
+		if offset >= n {
+			continue
+		}
+		queryResults, err := fetch(query, offset, limit)
+		if err != nil {
+			return nil, p, err
+		}
+		results = append(results, queryResults...)
+	}
+	if len(queries) == 1 {
+		return results, p, nil
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return less(results[i], results[j])
+	})
+	if start >= len(results) {
+		return []T{}, p, nil
+	}
+	return results[start:min(start+perPage, len(results))], p, nil
+}
+
+// paginate returns the given page of the results, pages start from 1.
+func paginate[T any](results []T, page, perPage int) ([]T, pagination) {
+	if page == 0 {
+		page = 1
+	}
+	if perPage == 0 {
+		perPage = defaultPerPage
+	}
+	p := pagination{Page: page, PerPage: perPage, Total: len(results)}
+
+	start := (page - 1) * perPage
+	if start >= len(results) {
+		return []T{}, p
+	}
+	end := min(start+perPage, len(results))
+	return results[start:end], p
+}
+
+// requestRoles is a comma-separated list of role names.
+// An empty list matches all roles.
+type requestRoles []convert.RunnerRole
+
+func (rr requestRoles) OpenAPISchema() *openapi.Schema {
+	var names []string
+	for role := convert.RoleAttester; role <= convert.RoleCommittee; role++ {
+		names = append(names, role.String())
+	}
+	return &openapi.Schema{Type: "array", Items: &openapi.Schema{Type: "string", Enum: names}}
+}
+
+func (rr *requestRoles) Bind(value string) error {
+	if value == "" {
+		return nil
+	}
+	return rr.parse(strings.Split(value, ","))
+}
+
+func (rr *requestRoles) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	return rr.parse(names)
+}
+
+func (rr *requestRoles) parse(names []string) error {
+	for _, name := range names {
+		role, ok := roleByName(name)
+		if !ok {
+			return fmt.Errorf("unknown role: %s", name)
+		}
+		*rr = append(*rr, role)
+	}
+	return nil
+}
+
+func (rr requestRoles) contains(role convert.RunnerRole) bool {
+	if len(rr) == 0 {
+		return true
+	}
+	for _, r := range rr {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func roleByName(name string) (convert.RunnerRole, bool) {
+	for role := convert.RoleAttester; role <= convert.RoleCommittee; role++ {
+		if strings.EqualFold(name, role.String()) {
+			return role, true
+		}
+	}
+	return 0, false
+}
+
+type participantJSON struct {
+	Role      string                 `json:"role"`
+	Slot      phase0.Slot            `json:"slot"`
+	PublicKey api.Hex                `json:"public_key"`
+	Signers   []spectypes.OperatorID `json:"signers"`
+}
+
+type decidedJSON struct {
+	Role string      `json:"role"`
+	Slot phase0.Slot `json:"slot"`
+	// DutyExecutorID is the committee ID for committee duties, and the validator public key otherwise.
+	DutyExecutorID api.Hex                `json:"duty_executor_id"`
+	Round          specqbft.Round         `json:"round"`
+	Root           api.Hex                `json:"root"`
+	Signers        []spectypes.OperatorID `json:"signers"`
+}
+
+func decidedFromInstance(role convert.RunnerRole, dutyExecutorID []byte, decidedMsg *spectypes.SignedSSVMessage) (*decidedJSON, error) {
+	if decidedMsg == nil || decidedMsg.SSVMessage == nil {
+		return nil, fmt.Errorf("stored instance has no decided message")
+	}
+	msg, err := specqbft.DecodeMessage(decidedMsg.SSVMessage.Data)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode decided message: %w", err)
+	}
+	return &decidedJSON{
+		Role:           role.String(),
+		Slot:           phase0.Slot(msg.Height),
+		DutyExecutorID: api.Hex(dutyExecutorID),
+		Round:          msg.Round,
+		Root:           api.Hex(msg.Root[:]),
+		Signers:        decidedMsg.OperatorIDs,
+	}, nil
+}
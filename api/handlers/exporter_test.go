@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	beaconprotocol "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestExporter(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+
+	shares, _, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	share1, share2 := mockShare(1, 2, 3, 4), mockShare(5, 6, 7, 8)
+	share1.ValidatorPubKey = spectypes.ValidatorPK{0x1}
+	share2.ValidatorPubKey = spectypes.ValidatorPK{0x2}
+	share2.BeaconMetadata = &beaconprotocol.ValidatorMetadata{Index: 2}
+	require.NoError(t, shares.Save(nil, share1, share2))
+
+	stores := storage.NewStoresFromRoles(db, convert.RoleCommittee, convert.RoleProposer)
+	domain := networkconfig.TestNetwork.DomainType()
+	ks := testingutils.Testing4SharesSet()
+
+	// Participants of proposals by both validators, and committee decideds of the first committee.
+	for slot := phase0.Slot(10); slot < 20; slot++ {
+		for _, share := range []*types.SSVShare{share1, share2} {
+			msgID := convert.NewMsgID(domain, share.ValidatorPubKey[:], convert.RoleProposer)
+			require.NoError(t, stores.Get(convert.RoleProposer).SaveParticipants(msgID, slot, []spectypes.OperatorID{1, 2, 3, 4}))
+		}
+
+		committeeID := share1.CommitteeID()
+		msgID := convert.NewMsgID(domain, committeeID[:], convert.RoleCommittee)
+		require.NoError(t, stores.Get(convert.RoleCommittee).SaveInstance(&qbftstorage.StoredInstance{
+			State: &specqbft.State{
+				ID:     msgID[:],
+				Height: specqbft.Height(slot),
+			},
+			DecidedMessage: testingutils.TestingCommitMultiSignerMessageWithHeightAndIdentifier(
+				[]*rsa.PrivateKey{ks.OperatorKeys[1], ks.OperatorKeys[2], ks.OperatorKeys[3]},
+				[]spectypes.OperatorID{1, 2, 3},
+				specqbft.Height(slot),
+				msgID[:],
+			),
+		}))
+	}
+
+	h := &Exporter{
+		DomainType: networkconfig.TestNetwork,
+		QBFTStores: stores,
+		Shares:     shares,
+	}
+
+	type response[T any] struct {
+		Data       []T        `json:"data"`
+		Pagination pagination `json:"pagination"`
+	}
+	get := func(t *testing.T, handler api.HandlerFunc, query string, dest any) int {
+		req := httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+		rec := httptest.NewRecorder()
+		api.Handler(handler)(rec, req)
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), dest))
+		}
+		return rec.Code
+	}
+	pk1 := hex.EncodeToString(share1.ValidatorPubKey[:])
+	pk2 := hex.EncodeToString(share2.ValidatorPubKey[:])
+
+	t.Run("participants", func(t *testing.T) {
+		var res response[participantJSON]
+		require.Equal(t, http.StatusOK, get(t, h.Participants, "pubkeys="+pk1+","+pk2+"&roles=PROPOSER&from=12&to=14&per_page=4", &res))
+		require.Equal(t, pagination{Page: 1, PerPage: 4, Total: 6}, res.Pagination)
+		require.Len(t, res.Data, 4)
+		require.Equal(t, participantJSON{Role: "PROPOSER", Slot: 12, PublicKey: share1.ValidatorPubKey[:], Signers: []spectypes.OperatorID{1, 2, 3, 4}}, res.Data[0])
+		require.Equal(t, phase0.Slot(12), res.Data[1].Slot)
+		require.Equal(t, api.Hex(share2.ValidatorPubKey[:]), res.Data[1].PublicKey)
+
+		require.Equal(t, http.StatusOK, get(t, h.Participants, "pubkeys="+pk1+","+pk2+"&from=12&to=14&per_page=4&page=2", &res))
+		require.Len(t, res.Data, 2)
+		require.Equal(t, phase0.Slot(14), res.Data[1].Slot)
+	})
+
+	t.Run("participants by committee", func(t *testing.T) {
+		committeeID := share2.CommitteeID()
+		var res response[participantJSON]
+		require.Equal(t, http.StatusOK, get(t, h.Participants, "committee_ids="+hex.EncodeToString(committeeID[:])+"&from=0&to=100", &res))
+		require.Equal(t, 10, res.Pagination.Total)
+		for _, p := range res.Data {
+			require.Equal(t, api.Hex(share2.ValidatorPubKey[:]), p.PublicKey)
+		}
+	})
+
+	t.Run("decideds", func(t *testing.T) {
+		committeeID := share1.CommitteeID()
+		var res response[decidedJSON]
+		require.Equal(t, http.StatusOK, get(t, h.Decideds, "pubkeys="+pk1+"&roles=COMMITTEE&from=15&to=30", &res))
+		require.Equal(t, 5, res.Pagination.Total)
+		require.Equal(t, "COMMITTEE", res.Data[0].Role)
+		require.Equal(t, phase0.Slot(15), res.Data[0].Slot)
+		require.Equal(t, api.Hex(committeeID[:]), res.Data[0].DutyExecutorID)
+		require.Equal(t, []spectypes.OperatorID{1, 2, 3}, res.Data[0].Signers)
+
+		require.Equal(t, http.StatusOK, get(t, h.Decideds, "pubkeys="+pk2+"&from=0&to=100", &res))
+		require.Empty(t, res.Data)
+	})
+
+	t.Run("pages across validators", func(t *testing.T) {
+		var all, page response[participantJSON]
+		require.Equal(t, http.StatusOK, get(t, h.Participants, "pubkeys="+pk1+","+pk2+"&from=10&to=19", &all))
+		require.Equal(t, 20, all.Pagination.Total)
+		for p := 1; p <= 4; p++ {
+			require.Equal(t, http.StatusOK, get(t, h.Participants, fmt.Sprintf("pubkeys=%s,%s&from=10&to=19&per_page=6&page=%d", pk1, pk2, p), &page))
+			require.Equal(t, pagination{Page: p, PerPage: 6, Total: 20}, page.Pagination)
+			require.Equal(t, all.Data[(p-1)*6:min(p*6, 20)], page.Data)
+		}
+	})
+
+	t.Run("validators outside of the registry", func(t *testing.T) {
+		removed := spectypes.ValidatorPK{0x9}
+		msgID := convert.NewMsgID(domain, removed[:], convert.RoleProposer)
+		for slot := phase0.Slot(10); slot < 13; slot++ {
+			require.NoError(t, stores.Get(convert.RoleProposer).SaveParticipants(msgID, slot, []spectypes.OperatorID{1, 2, 3}))
+		}
+
+		var res response[participantJSON]
+		require.Equal(t, http.StatusOK, get(t, h.Participants, "pubkeys="+hex.EncodeToString(removed[:])+"&from=0&to=100&per_page=2&page=2", &res))
+		require.Equal(t, pagination{Page: 2, PerPage: 2, Total: 3}, res.Pagination)
+		require.Len(t, res.Data, 1)
+		require.Equal(t, phase0.Slot(12), res.Data[0].Slot)
+		require.Equal(t, api.Hex(removed[:]), res.Data[0].PublicKey)
+	})
+
+	t.Run("indices", func(t *testing.T) {
+		var res response[participantJSON]
+		require.Equal(t, http.StatusOK, get(t, h.Participants, "indices=2&from=0&to=100", &res))
+		require.Equal(t, 10, res.Pagination.Total)
+		for _, p := range res.Data {
+			require.Equal(t, api.Hex(share2.ValidatorPubKey[:]), p.PublicKey)
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, query := range []string{
+			"from=1&to=2",
+			"pubkeys=" + pk1 + "&from=3&to=2",
+			"pubkeys=" + pk1 + "&from=0&to=7200",
+			"pubkeys=" + pk1 + "&roles=UNKNOWN",
+			"pubkeys=" + pk1 + "&per_page=1001",
+		} {
+			require.Equal(t, http.StatusBadRequest, get(t, h.Decideds, query, nil), query)
+		}
+		require.Equal(t, http.StatusBadRequest, get(t, h.Participants, "pubkeys="+pk1+"&roles=COMMITTEE", nil))
+	})
+
+	t.Run("query limit", func(t *testing.T) {
+		var large []*types.SSVShare
+		for i := 0; i < maxQueries; i++ {
+			share := mockShare(9, 10, 11, 12)
+			share.ValidatorPubKey = spectypes.ValidatorPK{0x3, byte(i >> 8), byte(i)}
+			large = append(large, share)
+		}
+		require.NoError(t, shares.Save(nil, large...))
+		largeCommitteeID, committeeID1 := large[0].CommitteeID(), share1.CommitteeID()
+		largeCommittee, committee1 := hex.EncodeToString(largeCommitteeID[:]), hex.EncodeToString(committeeID1[:])
+
+		// Proposals are queried per validator, and committee duties per committee.
+		var res response[decidedJSON]
+		require.Equal(t, http.StatusOK, get(t, h.Decideds, "committee_ids="+largeCommittee+"&roles=PROPOSER", &res))
+		require.Equal(t, http.StatusBadRequest, get(t, h.Decideds, "committee_ids="+largeCommittee, nil))
+		require.Equal(t, http.StatusBadRequest, get(t, h.Decideds, "committee_ids="+largeCommittee+","+committee1+"&roles=PROPOSER", nil))
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/api/openapi"
 	networkpeers "github.com/ssvlabs/ssv/network/peers"
 	"github.com/ssvlabs/ssv/nodeprobe"
 )
@@ -66,12 +67,18 @@ func (h healthStatus) MarshalJSON() ([]byte, error) {
 	return json.Marshal(fmt.Sprintf("bad: %s", h.err.Error()))
 }
 
+func (h healthStatus) OpenAPISchema() *openapi.Schema {
+	return &openapi.Schema{Type: "string", Description: `Either "good" or "bad: <reason>".`, Pattern: "^(good|bad: .*)$"}
+}
+
 type healthCheckJSON struct {
-	P2P           healthStatus `json:"p2p"`
-	BeaconNode    healthStatus `json:"beacon_node"`
-	ExecutionNode healthStatus `json:"execution_node"`
-	EventSyncer   healthStatus `json:"event_syncer"`
-	Advanced      struct {
+	P2P            healthStatus            `json:"p2p"`
+	BeaconNode     healthStatus            `json:"beacon_node"`
+	BeaconNodes    map[string]healthStatus `json:"beacon_nodes,omitempty"`
+	ExecutionNode  healthStatus            `json:"execution_node"`
+	ExecutionNodes map[string]healthStatus `json:"execution_nodes,omitempty"`
+	EventSyncer    healthStatus            `json:"event_syncer"`
+	Advanced       struct {
 		Peers           int      `json:"peers"`
 		InboundConns    int      `json:"inbound_conns"`
 		OutboundConns   int      `json:"outbound_conns"`
@@ -160,7 +167,19 @@ func (h *Node) Health(w http.ResponseWriter, r *http.Request) error {
 
 	// Check the health of Ethereum nodes and EventSyncer.
 	resp.BeaconNode = healthStatus{h.NodeProber.CheckBeaconNodeHealth(ctx)}
+	if endpoints := h.NodeProber.CheckBeaconNodeEndpointsHealth(ctx); len(endpoints) > 1 {
+		resp.BeaconNodes = make(map[string]healthStatus, len(endpoints))
+		for address, err := range endpoints {
+			resp.BeaconNodes[address] = healthStatus{err}
+		}
+	}
 	resp.ExecutionNode = healthStatus{h.NodeProber.CheckExecutionNodeHealth(ctx)}
+	if endpoints := h.NodeProber.CheckExecutionNodeEndpointsHealth(ctx); len(endpoints) > 1 {
+		resp.ExecutionNodes = make(map[string]healthStatus, len(endpoints))
+		for addr, err := range endpoints {
+			resp.ExecutionNodes[addr] = healthStatus{err}
+		}
+	}
 	resp.EventSyncer = healthStatus{h.NodeProber.CheckEventSyncerHealth(ctx)}
 
 	return api.Render(w, r, resp)
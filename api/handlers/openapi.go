@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"github.com/ssvlabs/ssv/api/openapi"
+	"github.com/ssvlabs/ssv/exporter/analytics"
+)
+
+// Endpoints describe the parameters and responses of the handlers for the OpenAPI document.
+// Their method and path are set where the handlers are routed.
+var (
+	NodeIdentityEndpoint = openapi.Endpoint{
+		OperationID: "getNodeIdentity",
+		Summary:     "Returns the identity of the node in the P2P network.",
+		Tags:        []string{"Node"},
+		Response:    identityJSON{},
+	}
+	NodePeersEndpoint = openapi.Endpoint{
+		OperationID: "getNodePeers",
+		Summary:     "Returns the peers the node is connected to.",
+		Tags:        []string{"Node"},
+		Response:    []peerJSON{},
+	}
+	NodeTopicsEndpoint = openapi.Endpoint{
+		OperationID: "getNodeTopics",
+		Summary:     "Returns the peers of each topic the node is subscribed to.",
+		Tags:        []string{"Node"},
+		Response:    AllPeersAndTopicsJSON{},
+	}
+	NodeHealthEndpoint = openapi.Endpoint{
+		OperationID: "getNodeHealth",
+		Summary:     "Returns the health of the node and of the Ethereum nodes it's connected to.",
+		Tags:        []string{"Node"},
+		Response:    healthCheckJSON{},
+	}
+	ValidatorsListEndpoint = openapi.Endpoint{
+		OperationID: "listValidators",
+		Summary:     "Returns the validators matching all of the given filters.",
+		Tags:        []string{"Validators"},
+		Params:      validatorsRequest{},
+		Response:    validatorsResponse{},
+	}
+	ExporterDecidedsEndpoint = openapi.Endpoint{
+		OperationID: "getDecideds",
+		Summary:     "Returns the decided instances of the matching validators and committees in the slot range.",
+		Tags:        []string{"Exporter"},
+		Params:      exporterRequest{},
+		Response:    decidedsResponse{},
+	}
+	ExporterParticipantsEndpoint = openapi.Endpoint{
+		OperationID: "getParticipants",
+		Summary:     "Returns the operators which participated in the quorum of each duty of the matching validators in the slot range.",
+		Tags:        []string{"Exporter"},
+		Params:      exporterRequest{},
+		Response:    participantsResponse{},
+	}
+	DutiesListEndpoint = openapi.Endpoint{
+		OperationID: "listDuties",
+		Summary:     "Returns the execution traces of the recent duties of the matching validators and committees.",
+		Tags:        []string{"Duties"},
+		Params:      dutiesRequest{},
+		Response:    dutiesResponse{},
+	}
+	AnalyticsParticipationEndpoint = openapi.Endpoint{
+		OperationID: "getParticipationAnalytics",
+		Summary:     "Returns the participation of operators in the duties of the matching validators over the last completed epochs.",
+		Tags:        []string{"Exporter"},
+		Params:      analyticsRequest{},
+		Response:    &analytics.Report{},
+	}
+)
@@ -10,6 +10,7 @@ import (
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 
 	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/api/openapi"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
 	registrystorage "github.com/ssvlabs/ssv/registry/storage"
 )
@@ -18,18 +19,22 @@ type Validators struct {
 	Shares registrystorage.Shares
 }
 
+type validatorsRequest struct {
+	Owners      api.HexSlice    `json:"owners" form:"owners"`
+	Operators   api.Uint64Slice `json:"operators" form:"operators"`
+	Clusters    requestClusters `json:"clusters" form:"clusters"`
+	Subclusters requestClusters `json:"subclusters" form:"subclusters"`
+	PubKeys     api.HexSlice    `json:"pubkeys" form:"pubkeys"`
+	Indices     api.Uint64Slice `json:"indices" form:"indices"`
+}
+
+type validatorsResponse struct {
+	Data []*validatorJSON `json:"data"`
+}
+
 func (h *Validators) List(w http.ResponseWriter, r *http.Request) error {
-	var request struct {
-		Owners      api.HexSlice    `json:"owners" form:"owners"`
-		Operators   api.Uint64Slice `json:"operators" form:"operators"`
-		Clusters    requestClusters `json:"clusters" form:"clusters"`
-		Subclusters requestClusters `json:"subclusters" form:"subclusters"`
-		PubKeys     api.HexSlice    `json:"pubkeys" form:"pubkeys"`
-		Indices     api.Uint64Slice `json:"indices" form:"indices"`
-	}
-	var response struct {
-		Data []*validatorJSON `json:"data"`
-	}
+	var request validatorsRequest
+	var response validatorsResponse
 
 	if err := api.Bind(r, &request); err != nil {
 		return err
@@ -139,6 +144,14 @@ func byIndices(indices []uint64) registrystorage.SharesFilter {
 // requestClusters is a space-separated list of comma-separated lists of operator IDs.
 type requestClusters [][]uint64
 
+func (c requestClusters) OpenAPISchema() *openapi.Schema {
+	return &openapi.Schema{
+		Type:        "string",
+		Description: "Space-separated list of comma-separated lists of operator IDs.",
+		Pattern:     "^([0-9]+(,[0-9]+)*( [0-9]+(,[0-9]+)*)*)?$",
+	}
+}
+
 func (c *requestClusters) Bind(value string) error {
 	if value == "" {
 		return nil
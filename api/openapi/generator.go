@@ -0,0 +1,324 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	schemaProviderType = reflect.TypeOf((*SchemaProvider)(nil)).Elem()
+	jsonMarshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType  = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType           = reflect.TypeOf(time.Time{})
+)
+
+// Generator generates the schemas of Go types by their JSON encoding,
+// collecting the schemas of named structs as components.
+//
+// Types with custom JSON marshalling must either implement SchemaProvider, be registered with SetSchema,
+// or implement encoding.TextMarshaler (in which case they're strings), otherwise generation fails.
+type Generator struct {
+	overrides map[reflect.Type]*Schema
+	names     map[reflect.Type]string
+	schemas   map[string]*Schema
+}
+
+func NewGenerator() *Generator {
+	return &Generator{
+		overrides: make(map[reflect.Type]*Schema),
+		names:     make(map[reflect.Type]string),
+		schemas:   make(map[string]*Schema),
+	}
+}
+
+// SetSchema sets the schema of the type of the given value.
+func (g *Generator) SetSchema(value any, schema *Schema) {
+	g.overrides[reflect.TypeOf(value)] = schema
+}
+
+// Components returns the schemas of the named structs generated so far.
+func (g *Generator) Components() map[string]*Schema {
+	return g.schemas
+}
+
+// Document generates a document of the given endpoints, where errorResponse is rendered on failure.
+func (g *Generator) Document(info Info, endpoints []Endpoint, errorResponse any) (*Document, error) {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]PathItem),
+	}
+
+	var errorSchema *Schema
+	if errorResponse != nil {
+		var err error
+		if errorSchema, err = g.Schema(reflect.TypeOf(errorResponse)); err != nil {
+			return nil, fmt.Errorf("error response: %w", err)
+		}
+	}
+
+	for _, endpoint := range endpoints {
+		operation, err := g.operation(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", endpoint.Method, endpoint.Path, err)
+		}
+		if errorSchema != nil {
+			operation.Responses["default"] = jsonResponse("Error", errorSchema)
+		}
+		if doc.Paths[endpoint.Path] == nil {
+			doc.Paths[endpoint.Path] = make(PathItem)
+		}
+		doc.Paths[endpoint.Path][strings.ToLower(endpoint.Method)] = operation
+	}
+
+	doc.Components.Schemas = g.schemas
+	return doc, nil
+}
+
+func (g *Generator) operation(endpoint Endpoint) (*Operation, error) {
+	operation := &Operation{
+		OperationID: endpoint.OperationID,
+		Summary:     endpoint.Summary,
+		Tags:        endpoint.Tags,
+		Responses:   make(map[string]*Response),
+	}
+
+	if endpoint.Params != nil {
+		paramsType := reflect.TypeOf(endpoint.Params)
+		if endpoint.Method == http.MethodGet {
+			params, err := g.QueryParameters(paramsType)
+			if err != nil {
+				return nil, fmt.Errorf("parameters: %w", err)
+			}
+			operation.Parameters = params
+		} else {
+			schema, err := g.Schema(paramsType)
+			if err != nil {
+				return nil, fmt.Errorf("request body: %w", err)
+			}
+			operation.RequestBody = &RequestBody{
+				Content: map[string]MediaType{"application/json": {Schema: schema}},
+			}
+		}
+	}
+
+	schema, err := g.Schema(reflect.TypeOf(endpoint.Response))
+	if err != nil {
+		return nil, fmt.Errorf("response: %w", err)
+	}
+	operation.Responses["200"] = jsonResponse("OK", schema)
+	return operation, nil
+}
+
+// QueryParameters returns the query parameters a struct is bound from by api.Bind,
+// where lists are comma-separated.
+func (g *Generator) QueryParameters(t reflect.Type) ([]*Parameter, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s is not a struct", t)
+	}
+
+	var params []*Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Tag.Get("form")
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		schema, err := g.Schema(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		param := &Parameter{Name: name, In: "query", Schema: schema}
+		if schema.Type == "array" {
+			explode := false
+			param.Style = "form"
+			param.Explode = &explode
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// Schema returns the schema of the JSON encoding of the given type,
+// referencing the schemas of named structs.
+func (g *Generator) Schema(t reflect.Type) (*Schema, error) {
+	if schema, ok := g.overrides[t]; ok {
+		return clone(schema), nil
+	}
+	if t.Kind() == reflect.Pointer {
+		return g.Schema(t.Elem())
+	}
+	if reflect.PointerTo(t).Implements(schemaProviderType) {
+		return reflect.New(t).Interface().(SchemaProvider).OpenAPISchema(), nil
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	}
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return &Schema{Type: "string"}, nil
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		return nil, fmt.Errorf("no schema for %s, which has custom JSON marshalling", t)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "uint64"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return &Schema{Type: "string", Format: "byte"}, nil
+		}
+		items, err := g.Schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key of %s", t)
+		}
+		values, err := g.Schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return g.structSchema(t)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+func (g *Generator) structSchema(t reflect.Type) (*Schema, error) {
+	if t.Name() == "" {
+		return g.objectSchema(t)
+	}
+
+	if name, ok := g.names[t]; ok {
+		return &Schema{Ref: "#/components/schemas/" + name}, nil
+	}
+	name := componentName(t)
+	if _, ok := g.schemas[name]; ok {
+		name = strings.ToUpper(pkgName(t)[:1]) + pkgName(t)[1:] + name
+	}
+	// Registered before generating the properties in case the type is recursive.
+	g.names[t] = name
+	g.schemas[name] = &Schema{}
+
+	schema, err := g.objectSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	g.schemas[name] = schema
+	return &Schema{Ref: "#/components/schemas/" + name}, nil
+}
+
+func (g *Generator) objectSchema(t reflect.Type) (*Schema, error) {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	if err := g.addFields(schema, t); err != nil {
+		return nil, err
+	}
+	sort.Strings(schema.Required)
+	return schema, nil
+}
+
+func (g *Generator) addFields(schema *Schema, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		// Embedded structs without a name have their fields promoted.
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				if err := g.addFields(schema, fieldType); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema, err := g.Schema(field.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t, field.Name, err)
+		}
+		omitEmpty := strings.Contains(opts, "omitempty")
+		if !omitEmpty {
+			schema.Required = append(schema.Required, name)
+			switch field.Type.Kind() {
+			case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+				fieldSchema = nullable(fieldSchema)
+			default:
+			}
+		}
+		schema.Properties[name] = fieldSchema
+	}
+	return nil
+}
+
+// nullable marks the schema as nullable, wrapping references as their siblings are ignored.
+func nullable(schema *Schema) *Schema {
+	if schema.Ref != "" {
+		return &Schema{Nullable: true, AllOf: []*Schema{schema}}
+	}
+	schema.Nullable = true
+	return schema
+}
+
+func jsonResponse(description string, schema *Schema) *Response {
+	return &Response{
+		Description: description,
+		Content:     map[string]MediaType{"application/json": {Schema: schema}},
+	}
+}
+
+// componentName returns the exported name of the type, without its JSON suffix.
+func componentName(t reflect.Type) string {
+	name := strings.TrimSuffix(t.Name(), "JSON")
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func pkgName(t reflect.Type) string {
+	path := t.PkgPath()
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+func clone(schema *Schema) *Schema {
+	c := *schema
+	return &c
+}
@@ -0,0 +1,89 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type embedded struct {
+	ID uint64 `json:"id"`
+}
+
+type itemJSON struct {
+	embedded
+	Name     string            `json:"name"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Next     *itemJSON         `json:"next"`
+	Time     time.Time         `json:"time"`
+	Data     []byte            `json:"data,omitempty"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+type itemsRequest struct {
+	IDs   customList `form:"ids"`
+	Limit int
+}
+
+type customList []uint64
+
+func (customList) OpenAPISchema() *Schema {
+	return &Schema{Type: "array", Items: &Schema{Type: "integer"}}
+}
+
+func TestGenerator_Schema(t *testing.T) {
+	g := NewGenerator()
+	schema, err := g.Schema(reflect.TypeOf(&itemJSON{}))
+	require.NoError(t, err)
+	require.Equal(t, "#/components/schemas/Item", schema.Ref)
+
+	item := g.Components()["Item"]
+	require.Equal(t, []string{"id", "name", "next", "tags", "time"}, item.Required)
+	require.Equal(t, &Schema{Type: "integer", Format: "uint64"}, item.Properties["id"])
+	require.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "string"}, Nullable: true}, item.Properties["tags"])
+	require.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}, item.Properties["labels"])
+	require.Equal(t, &Schema{Nullable: true, AllOf: []*Schema{{Ref: "#/components/schemas/Item"}}}, item.Properties["next"])
+	require.Equal(t, &Schema{Type: "string", Format: "date-time"}, item.Properties["time"])
+	require.Equal(t, &Schema{Type: "string", Format: "byte"}, item.Properties["data"])
+	require.NotContains(t, item.Properties, "Ignored")
+	require.NotContains(t, item.Properties, "internal")
+
+	g.SetSchema(time.Duration(0), &Schema{Type: "string"})
+	schema, err = g.Schema(reflect.TypeOf(time.Duration(0)))
+	require.NoError(t, err)
+	require.Equal(t, &Schema{Type: "string"}, schema)
+
+	_, err = g.Schema(reflect.TypeOf(map[int]string{}))
+	require.Error(t, err)
+}
+
+func TestGenerator_Document(t *testing.T) {
+	g := NewGenerator()
+	doc, err := g.Document(Info{Title: "Test", Version: "v1"}, []Endpoint{
+		{Method: http.MethodGet, Path: "/items", OperationID: "listItems", Params: itemsRequest{}, Response: []itemJSON{}},
+		{Method: http.MethodPost, Path: "/items", OperationID: "createItem", Params: itemJSON{}, Response: itemJSON{}},
+	}, struct {
+		Error string `json:"error"`
+	}{})
+	require.NoError(t, err)
+	require.Equal(t, Version, doc.OpenAPI)
+
+	list := doc.Paths["/items"]["get"]
+	require.Len(t, list.Parameters, 2)
+	require.Equal(t, "ids", list.Parameters[0].Name)
+	require.Equal(t, "form", list.Parameters[0].Style)
+	require.False(t, *list.Parameters[0].Explode)
+	require.Equal(t, "limit", list.Parameters[1].Name)
+	require.Nil(t, list.Parameters[1].Explode)
+	require.Contains(t, list.Responses, "default")
+
+	create := doc.Paths["/items"]["post"]
+	require.Empty(t, create.Parameters)
+	require.Equal(t, "#/components/schemas/Item", create.RequestBody.Content["application/json"].Schema.Ref)
+	require.Contains(t, doc.Components.Schemas, "Item")
+}
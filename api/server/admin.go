@@ -0,0 +1,135 @@
+package server
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/api/handlers"
+)
+
+// AdminOptions configures the admin API, which requires either a bearer token,
+// client certificates (mTLS), or both.
+type AdminOptions struct {
+	Port     int    `yaml:"Port" env:"ADMIN_API_PORT" env-description:"Port to listen on for the admin API."`
+	Token    string `yaml:"Token" env:"ADMIN_API_TOKEN" env-description:"Bearer token required by the admin API."`
+	TLSCert  string `yaml:"TLSCert" env:"ADMIN_API_TLS_CERT" env-description:"Path to the TLS certificate of the admin API."`
+	TLSKey   string `yaml:"TLSKey" env:"ADMIN_API_TLS_KEY" env-description:"Path to the TLS key of the admin API."`
+	ClientCA string `yaml:"ClientCA" env:"ADMIN_API_CLIENT_CA" env-description:"Path to the CA certificate which admin API client certificates must be signed by."`
+}
+
+// AdminServer serves the admin API, which performs runtime operations on the node.
+type AdminServer struct {
+	logger *zap.Logger
+	addr   string
+	opts   AdminOptions
+	admin  *handlers.Admin
+}
+
+// NewAdmin returns an admin API server, failing if it isn't configured with any authentication.
+func NewAdmin(logger *zap.Logger, addr string, opts AdminOptions, admin *handlers.Admin) (*AdminServer, error) {
+	if opts.Token == "" && opts.ClientCA == "" {
+		return nil, errors.New("admin API requires a token or a client CA")
+	}
+	if (opts.TLSCert == "") != (opts.TLSKey == "") {
+		return nil, errors.New("admin API requires both TLS certificate and key")
+	}
+	if opts.ClientCA != "" && opts.TLSCert == "" {
+		return nil, errors.New("admin API requires a TLS certificate and key to verify client certificates")
+	}
+	if opts.TLSCert == "" {
+		logger.Warn("admin API is served without TLS, its token is sent in plain text")
+	}
+	return &AdminServer{
+		logger: logger,
+		addr:   addr,
+		opts:   opts,
+		admin:  admin,
+	}, nil
+}
+
+// Handler returns the authenticated router of the admin API.
+func (s *AdminServer) Handler() http.Handler {
+	router := chi.NewRouter()
+	router.Use(middleware.Recoverer)
+	router.Use(middlewareLogger(s.logger))
+	router.Use(s.middlewareAuth)
+
+	router.Get("/v1/admin/log-levels", api.Handler(s.admin.LogLevels))
+	router.Post("/v1/admin/log-levels", api.Handler(s.admin.SetLogLevel))
+	router.Post("/v1/admin/validators/metadata", api.Handler(s.admin.RefreshValidatorsMetadata))
+	router.Get("/v1/admin/validators/paused", api.Handler(s.admin.PausedValidators))
+	router.Post("/v1/admin/validators/pause", api.Handler(s.admin.PauseValidator))
+	router.Post("/v1/admin/validators/resume", api.Handler(s.admin.ResumeValidator))
+	router.Post("/v1/admin/peers/trusted/dial", api.Handler(s.admin.DialTrustedPeers))
+	router.Post("/v1/admin/events/resync", api.Handler(s.admin.ResyncEvents))
+	router.Get("/v1/admin/db/backup", api.Handler(s.admin.BackupDatabase))
+	router.Post("/v1/admin/decided/prune", api.Handler(s.admin.PruneDecided))
+	return router
+}
+
+func (s *AdminServer) Run() error {
+	s.logger.Info("Serving admin API",
+		zap.String("addr", s.addr),
+		zap.Bool("token", s.opts.Token != ""),
+		zap.Bool("tls", s.opts.TLSCert != ""),
+		zap.Bool("client_certs", s.opts.ClientCA != ""),
+	)
+
+	server := &http.Server{
+		Addr:         s.addr,
+		Handler:      s.Handler(),
+		ReadTimeout:  12 * time.Second,
+		WriteTimeout: time.Minute,
+	}
+	if s.opts.TLSCert == "" {
+		return server.ListenAndServe()
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if s.opts.ClientCA != "" {
+		pem, err := os.ReadFile(s.opts.ClientCA)
+		if err != nil {
+			return fmt.Errorf("could not read client CA: %w", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return errors.New("could not parse client CA")
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	server.TLSConfig = tlsConfig
+	return server.ListenAndServeTLS(s.opts.TLSCert, s.opts.TLSKey)
+}
+
+// middlewareAuth rejects requests without the configured bearer token.
+// Client certificates are verified by the TLS handshake.
+func (s *AdminServer) middlewareAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.opts.Token != "" {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.opts.Token)) != 1 {
+				s.logger.Warn("unauthorized admin API request",
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.String("remote_addr", r.RemoteAddr),
+				)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api/handlers"
+	"github.com/ssvlabs/ssv/logging"
+)
+
+func TestAdminServer_Auth(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	_, err := NewAdmin(logger, ":0", AdminOptions{}, &handlers.Admin{Logger: logger})
+	require.Error(t, err)
+	_, err = NewAdmin(logger, ":0", AdminOptions{ClientCA: "ca.pem"}, &handlers.Admin{Logger: logger})
+	require.Error(t, err)
+
+	s, err := NewAdmin(logger, ":0", AdminOptions{Token: "secret"}, &handlers.Admin{Logger: logger})
+	require.NoError(t, err)
+	router := s.Handler()
+
+	for authorization, code := range map[string]int{
+		"":              http.StatusUnauthorized,
+		"secret":        http.StatusUnauthorized,
+		"Bearer wrong":  http.StatusUnauthorized,
+		"Bearer secret": http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/admin/log-levels", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, code, rec.Code, authorization)
+	}
+}
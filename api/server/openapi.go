@@ -0,0 +1,53 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/api/openapi"
+	"github.com/ssvlabs/ssv/utils/commons"
+)
+
+const openAPIPath = "/v1/openapi.json"
+
+var openAPIEndpoint = openapi.Endpoint{
+	OperationID: "getOpenAPI",
+	Summary:     "Returns the OpenAPI document of the API.",
+	Tags:        []string{"Meta"},
+	Response:    &openapi.Document{},
+}
+
+// NewOpenAPIGenerator returns a generator which knows the schemas of the external types used by the API.
+func NewOpenAPIGenerator() *openapi.Generator {
+	g := openapi.NewGenerator()
+	// Beacon types are encoded as decimal strings.
+	quotedUint64 := &openapi.Schema{Type: "string", Format: "uint64", Pattern: "^[0-9]+$"}
+	g.SetSchema(phase0.Slot(0), quotedUint64)
+	g.SetSchema(phase0.Epoch(0), quotedUint64)
+	g.SetSchema(phase0.ValidatorIndex(0), quotedUint64)
+	return g
+}
+
+// OpenAPI returns the OpenAPI document of the routes served by the server.
+func (s *Server) OpenAPI() (*openapi.Document, error) {
+	var endpoints []openapi.Endpoint
+	for _, route := range s.routes() {
+		endpoints = append(endpoints, route.endpoint)
+	}
+	info := openapi.Info{
+		Title:       "SSV API",
+		Description: "API of the SSV node. Lists in query parameters are comma-separated.",
+		Version:     commons.GetNodeVersion(),
+	}
+	return NewOpenAPIGenerator().Document(info, endpoints, api.ErrorResponse{})
+}
+
+func (s *Server) openAPI(w http.ResponseWriter, r *http.Request) error {
+	doc, err := s.OpenAPI()
+	if err != nil {
+		return err
+	}
+	return api.Render(w, r, doc)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/ssvlabs/ssv/api"
 	"github.com/ssvlabs/ssv/api/handlers"
+	"github.com/ssvlabs/ssv/api/openapi"
 )
 
 type Server struct {
@@ -19,6 +20,9 @@ type Server struct {
 
 	node       *handlers.Node
 	validators *handlers.Validators
+	exporter   *handlers.Exporter
+	duties     *handlers.Duties
+	analytics  *handlers.Analytics
 }
 
 func New(
@@ -26,33 +30,73 @@ func New(
 	addr string,
 	node *handlers.Node,
 	validators *handlers.Validators,
+	exporter *handlers.Exporter,
+	duties *handlers.Duties,
+	analytics *handlers.Analytics,
 ) *Server {
 	return &Server{
 		logger:     logger,
 		addr:       addr,
 		node:       node,
 		validators: validators,
+		exporter:   exporter,
+		duties:     duties,
+		analytics:  analytics,
 	}
 }
 
-func (s *Server) Run() error {
+// route is a handler along with its description in the OpenAPI document.
+type route struct {
+	handler  api.HandlerFunc
+	endpoint openapi.Endpoint
+}
+
+func newRoute(method, path string, handler api.HandlerFunc, endpoint openapi.Endpoint) route {
+	endpoint.Method = method
+	endpoint.Path = path
+	return route{handler: handler, endpoint: endpoint}
+}
+
+func (s *Server) routes() []route {
+	routes := []route{
+		newRoute(http.MethodGet, "/v1/node/identity", s.node.Identity, handlers.NodeIdentityEndpoint),
+		newRoute(http.MethodGet, "/v1/node/peers", s.node.Peers, handlers.NodePeersEndpoint),
+		newRoute(http.MethodGet, "/v1/node/topics", s.node.Topics, handlers.NodeTopicsEndpoint),
+		newRoute(http.MethodGet, "/v1/node/health", s.node.Health, handlers.NodeHealthEndpoint),
+		newRoute(http.MethodGet, "/v1/validators", s.validators.List, handlers.ValidatorsListEndpoint),
+		newRoute(http.MethodGet, "/v1/exporter/decideds", s.exporter.Decideds, handlers.ExporterDecidedsEndpoint),
+		newRoute(http.MethodGet, "/v1/exporter/participants", s.exporter.Participants, handlers.ExporterParticipantsEndpoint),
+	}
+	if s.duties != nil {
+		routes = append(routes, newRoute(http.MethodGet, "/v1/duties", s.duties.List, handlers.DutiesListEndpoint))
+	}
+	if s.analytics != nil {
+		routes = append(routes, newRoute(http.MethodGet, "/v1/exporter/analytics", s.analytics.Participation, handlers.AnalyticsParticipationEndpoint))
+	}
+	routes = append(routes, newRoute(http.MethodGet, openAPIPath, s.openAPI, openAPIEndpoint))
+	return routes
+}
+
+// Handler returns the router of the API.
+func (s *Server) Handler() http.Handler {
 	router := chi.NewRouter()
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.Throttle(runtime.NumCPU() * 4))
 	router.Use(middleware.Compress(5, "application/json"))
 	router.Use(middlewareLogger(s.logger))
 
-	router.Get("/v1/node/identity", api.Handler(s.node.Identity))
-	router.Get("/v1/node/peers", api.Handler(s.node.Peers))
-	router.Get("/v1/node/topics", api.Handler(s.node.Topics))
-	router.Get("/v1/node/health", api.Handler(s.node.Health))
-	router.Get("/v1/validators", api.Handler(s.validators.List))
+	for _, route := range s.routes() {
+		router.Method(route.endpoint.Method, route.endpoint.Path, api.Handler(route.handler))
+	}
+	return router
+}
 
+func (s *Server) Run() error {
 	s.logger.Info("Serving SSV API", zap.String("addr", s.addr))
 
 	server := &http.Server{
 		Addr:         s.addr,
-		Handler:      router,
+		Handler:      s.Handler(),
 		ReadTimeout:  12 * time.Second,
 		WriteTimeout: 12 * time.Second,
 	}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"strconv"
 	"strings"
+
+	"github.com/ssvlabs/ssv/api/openapi"
 )
 
 type Hex []byte
@@ -69,3 +71,15 @@ func (us *Uint64Slice) Bind(value string) error {
 	}
 	return nil
 }
+
+func (h Hex) OpenAPISchema() *openapi.Schema {
+	return &openapi.Schema{Type: "string", Format: "hex", Pattern: "^[0-9a-fA-F]*$"}
+}
+
+func (hs HexSlice) OpenAPISchema() *openapi.Schema {
+	return &openapi.Schema{Type: "array", Items: Hex{}.OpenAPISchema()}
+}
+
+func (us Uint64Slice) OpenAPISchema() *openapi.Schema {
+	return &openapi.Schema{Type: "array", Items: &openapi.Schema{Type: "integer", Format: "uint64"}}
+}
@@ -13,7 +13,7 @@ import (
 
 // AttesterDuties returns attester duties for a given epoch.
 func (gc *GoClient) AttesterDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.AttesterDuty, error) {
-	resp, err := gc.client.AttesterDuties(ctx, &api.AttesterDutiesOpts{
+	resp, err := gc.multiClient.AttesterDuties(ctx, &api.AttesterDutiesOpts{
 		Epoch:   epoch,
 		Indices: validatorIndices,
 	})
@@ -29,23 +29,63 @@ func (gc *GoClient) AttesterDuties(ctx context.Context, epoch phase0.Epoch, vali
 
 func (gc *GoClient) GetAttestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, spec.DataVersion, error) {
 	attDataReqStart := time.Now()
-	resp, err := gc.client.AttestationData(gc.ctx, &api.AttestationDataOpts{
+	opts := &api.AttestationDataOpts{
 		Slot:           slot,
 		CommitteeIndex: committeeIndex,
-	})
-	if err != nil {
-		return nil, DataVersionNil, fmt.Errorf("failed to get attestation data: %w", err)
 	}
-	if resp == nil {
-		return nil, DataVersionNil, fmt.Errorf("attestation data response is nil")
+
+	var data *phase0.AttestationData
+	if len(gc.nodes) > 1 {
+		// Request all healthy nodes and take the first valid response.
+		var err error
+		data, err = firstSuccessful(gc.ctx, gc.healthyNodes(),
+			func(ctx context.Context, client Client) (*phase0.AttestationData, error) {
+				resp, err := client.AttestationData(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				if resp == nil {
+					return nil, fmt.Errorf("attestation data response is nil")
+				}
+				return resp.Data, nil
+			},
+			func(data *phase0.AttestationData) error {
+				return validateAttestationData(data, slot)
+			},
+		)
+		if err != nil {
+			return nil, DataVersionNil, fmt.Errorf("failed to get attestation data: %w", err)
+		}
+	} else {
+		resp, err := gc.multiClient.AttestationData(gc.ctx, opts)
+		if err != nil {
+			return nil, DataVersionNil, fmt.Errorf("failed to get attestation data: %w", err)
+		}
+		if resp == nil {
+			return nil, DataVersionNil, fmt.Errorf("attestation data response is nil")
+		}
+		data = resp.Data
 	}
 
 	metricsAttesterDataRequest.Observe(time.Since(attDataReqStart).Seconds())
 
-	return resp.Data, spec.DataVersionPhase0, nil
+	return data, spec.DataVersionPhase0, nil
+}
+
+func validateAttestationData(data *phase0.AttestationData, slot phase0.Slot) error {
+	if data == nil {
+		return fmt.Errorf("attestation data is nil")
+	}
+	if data.Source == nil || data.Target == nil {
+		return fmt.Errorf("attestation data checkpoints are missing")
+	}
+	if data.Slot != slot {
+		return fmt.Errorf("attestation data slot %d doesn't match requested slot %d", data.Slot, slot)
+	}
+	return nil
 }
 
 // SubmitAttestations implements Beacon interface
 func (gc *GoClient) SubmitAttestations(attestations []*phase0.Attestation) error {
-	return gc.client.SubmitAttestations(gc.ctx, attestations)
+	return gc.multiClient.SubmitAttestations(gc.ctx, attestations)
 }
@@ -0,0 +1,126 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/api"
+)
+
+// errNodeNotChecked is reported for beacon nodes whose health hasn't been checked yet.
+var errNodeNotChecked = fmt.Errorf("health not checked yet")
+
+// beaconNode is a single consensus client with its last known health.
+type beaconNode struct {
+	address    string // redacted, safe for logs and metrics
+	rawAddress string // not redacted, used for endpoints go-eth2-client doesn't support
+	client     Client
+
+	healthMu sync.RWMutex
+	lastErr  error
+	checked  bool
+}
+
+// checkHealth checks whether the node responds to requests, isn't syncing and isn't optimistic,
+// and reports the result in the node's status metric.
+func (n *beaconNode) checkHealth(ctx context.Context) error {
+	// TODO: get rid of global variable, pass metrics to goClient
+	metric := metricsBeaconNodeStatus.WithLabelValues(n.address)
+
+	nodeSyncingResp, err := n.client.NodeSyncing(ctx, &api.NodeSyncingOpts{})
+	if err != nil {
+		metric.Set(float64(statusUnknown))
+		return fmt.Errorf("failed to obtain node syncing status: %w", err)
+	}
+	if nodeSyncingResp == nil {
+		metric.Set(float64(statusUnknown))
+		return fmt.Errorf("node syncing response is nil")
+	}
+	if nodeSyncingResp.Data == nil {
+		metric.Set(float64(statusUnknown))
+		return fmt.Errorf("node syncing data is nil")
+	}
+	syncState := nodeSyncingResp.Data
+
+	// TODO: also check if syncState.ElOffline when github.com/attestantio/go-eth2-client supports it
+	metric.Set(float64(statusSyncing))
+	if syncState.IsSyncing {
+		return fmt.Errorf("syncing")
+	}
+	if syncState.IsOptimistic {
+		return fmt.Errorf("optimistic")
+	}
+
+	metric.Set(float64(statusOK))
+	return nil
+}
+
+func (n *beaconNode) setHealth(err error) {
+	n.healthMu.Lock()
+	defer n.healthMu.Unlock()
+
+	n.lastErr = err
+	n.checked = true
+}
+
+// health returns the result of the last health check.
+func (n *beaconNode) health() error {
+	n.healthMu.RLock()
+	defer n.healthMu.RUnlock()
+
+	if !n.checked {
+		return errNodeNotChecked
+	}
+	return n.lastErr
+}
+
+// healthyNodes returns the nodes which passed the last health check.
+// If none of them did, all nodes are returned, so that requests are still attempted.
+func (gc *GoClient) healthyNodes() []*beaconNode {
+	healthy := make([]*beaconNode, 0, len(gc.nodes))
+	for _, node := range gc.nodes {
+		if node.health() == nil {
+			healthy = append(healthy, node)
+		}
+	}
+	if len(healthy) == 0 {
+		return gc.nodes
+	}
+	return healthy
+}
+
+// ParseBeaconNodeAddresses splits a comma-separated list of beacon node addresses.
+func ParseBeaconNodeAddresses(addresses string) ([]string, error) {
+	var parsed []string
+	for _, address := range strings.Split(addresses, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		parsed = append(parsed, address)
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("no beacon node address provided")
+	}
+	return parsed, nil
+}
+
+// redactAddress strips credentials from the address, so it can be logged.
+func redactAddress(address string) string {
+	u, err := url.Parse(address)
+	if err != nil || u.User == nil {
+		return address
+	}
+	return u.Redacted()
+}
+
+func redactAddresses(addresses []string) []string {
+	redacted := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		redacted = append(redacted, redactAddress(address))
+	}
+	return redacted
+}
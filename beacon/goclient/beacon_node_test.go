@@ -0,0 +1,29 @@
+package goclient
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBeaconNodeAddresses(t *testing.T) {
+	addresses, err := ParseBeaconNodeAddresses(" http://a:5052, http://b:5052 ,,")
+	require.NoError(t, err)
+	require.Equal(t, []string{"http://a:5052", "http://b:5052"}, addresses)
+
+	_, err = ParseBeaconNodeAddresses(" , ")
+	require.Error(t, err)
+
+	require.Equal(t, "http://user:xxxxx@a:5052", redactAddress("http://user:pass@a:5052"))
+	require.Equal(t, "http://a:5052", redactAddress("http://a:5052"))
+}
+
+func TestHealthyNodes(t *testing.T) {
+	gc := &GoClient{nodes: testNodes("a", "b")}
+	require.Len(t, gc.healthyNodes(), 2, "all nodes are used when none is known to be healthy")
+
+	gc.nodes[0].setHealth(fmt.Errorf("syncing"))
+	gc.nodes[1].setHealth(nil)
+	require.Equal(t, []*beaconNode{gc.nodes[1]}, gc.healthyNodes())
+}
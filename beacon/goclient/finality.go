@@ -0,0 +1,25 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// FinalizedEpoch returns the epoch of the latest finalized checkpoint of the head state.
+func (gc *GoClient) FinalizedEpoch(ctx context.Context) (phase0.Epoch, error) {
+	resp, err := gc.multiClient.Finality(ctx, &api.FinalityOpts{
+		State:  "head",
+		Common: api.CommonOpts{Timeout: gc.commonTimeout},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to obtain finality: %w", err)
+	}
+	if resp == nil || resp.Data == nil || resp.Data.Finalized == nil {
+		return 0, fmt.Errorf("finality response is nil")
+	}
+
+	return resp.Data.Finalized.Epoch, nil
+}
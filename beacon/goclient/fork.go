@@ -0,0 +1,79 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// ForkSchedule returns the past and scheduled forks of the chain.
+// The schedule is fetched from the beacon node once and cached afterwards.
+func (gc *GoClient) ForkSchedule(ctx context.Context) ([]*phase0.Fork, error) {
+	gc.forkMu.Lock()
+	defer gc.forkMu.Unlock()
+
+	if gc.forkSchedule != nil {
+		return gc.forkSchedule, nil
+	}
+
+	resp, err := gc.multiClient.ForkSchedule(ctx, &api.ForkScheduleOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain fork schedule: %w", err)
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("fork schedule response is nil")
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("fork schedule response data is empty")
+	}
+
+	gc.forkSchedule = resp.Data
+	return gc.forkSchedule, nil
+}
+
+// GenesisValidatorsRoot returns the genesis validators root of the chain.
+// The root is fetched from the beacon node once and cached afterwards.
+func (gc *GoClient) GenesisValidatorsRoot(ctx context.Context) (phase0.Root, error) {
+	gc.forkMu.Lock()
+	defer gc.forkMu.Unlock()
+
+	if gc.genesisValidatorsRoot != nil {
+		return *gc.genesisValidatorsRoot, nil
+	}
+
+	resp, err := gc.multiClient.Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("failed to obtain genesis response: %w", err)
+	}
+	if resp == nil {
+		return phase0.Root{}, fmt.Errorf("genesis response is nil")
+	}
+	if resp.Data == nil {
+		return phase0.Root{}, fmt.Errorf("genesis response data is nil")
+	}
+
+	root := resp.Data.GenesisValidatorsRoot
+	gc.genesisValidatorsRoot = &root
+	return root, nil
+}
+
+// FinalizedExecutionBlock returns the execution block number of the latest finalized beacon block.
+func (gc *GoClient) FinalizedExecutionBlock(ctx context.Context) (uint64, error) {
+	resp, err := gc.multiClient.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		Block: "finalized",
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to obtain finalized block: %w", err)
+	}
+	if resp == nil || resp.Data == nil {
+		return 0, fmt.Errorf("finalized block response is nil")
+	}
+
+	blockNumber, err := resp.Data.ExecutionBlockNumber()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get execution block number of finalized block: %w", err)
+	}
+	return blockNumber, nil
+}
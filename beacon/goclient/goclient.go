@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -11,6 +12,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	eth2clienthttp "github.com/attestantio/go-eth2-client/http"
+	eth2clientmulti "github.com/attestantio/go-eth2-client/multi"
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/prometheus/client_golang/prometheus"
@@ -42,11 +44,12 @@ var (
 	allMetrics = []prometheus.Collector{
 		metricsBeaconNodeStatus,
 		metricsBeaconDataRequest,
+		metricsLivenessRequests,
 	}
-	metricsBeaconNodeStatus = promauto.NewGauge(prometheus.GaugeOpts{
+	metricsBeaconNodeStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "ssv_beacon_status",
-		Help: "Status of the connected beacon node",
-	})
+		Help: "Status of the connected beacon nodes",
+	}, []string{"node"})
 
 	// metricsBeaconDataRequest is located here to avoid including waiting for 1/3 or 2/3 of slot time into request duration.
 	metricsBeaconDataRequest = promauto.NewHistogramVec(prometheus.HistogramOpts{
@@ -55,6 +58,11 @@ var (
 		Buckets: []float64{0.02, 0.05, 0.1, 0.2, 0.5, 1, 5},
 	}, []string{"role"})
 
+	metricsLivenessRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_beacon_liveness_requests_total",
+		Help: "Validator liveness requests to the beacon nodes by result",
+	}, []string{"node", "result"})
+
 	metricsAttesterDataRequest                  = metricsBeaconDataRequest.WithLabelValues(spectypes.BNRoleAttester.String())
 	metricsAggregatorDataRequest                = metricsBeaconDataRequest.WithLabelValues(spectypes.BNRoleAggregator.String())
 	metricsProposerDataRequest                  = metricsBeaconDataRequest.WithLabelValues(spectypes.BNRoleProposer.String())
@@ -100,13 +108,16 @@ func ParseNodeClient(version string) NodeClient {
 	}
 }
 
-// Client defines all go-eth2-client interfaces used in ssv
-type Client interface {
+// MultiClient defines all go-eth2-client interfaces used in ssv,
+// which are implemented by both single-node and multi-node clients.
+type MultiClient interface {
 	eth2client.Service
 	eth2client.NodeVersionProvider
-	eth2client.NodeClientProvider
 	eth2client.SpecProvider
 	eth2client.GenesisProvider
+	eth2client.ForkScheduleProvider
+	eth2client.SignedBeaconBlockProvider
+	eth2client.FinalityProvider
 
 	eth2client.AttestationDataProvider
 	eth2client.AttestationsSubmitter
@@ -120,7 +131,6 @@ type Client interface {
 	eth2client.NodeSyncingProvider
 	eth2client.ProposalProvider
 	eth2client.ProposalSubmitter
-	eth2client.BlindedProposalSubmitter
 	eth2client.DomainProvider
 	eth2client.SyncCommitteeMessagesSubmitter
 	eth2client.BeaconBlockRootProvider
@@ -133,6 +143,13 @@ type Client interface {
 	eth2client.VoluntaryExitSubmitter
 }
 
+// Client defines all go-eth2-client interfaces used in ssv for a single beacon node.
+type Client interface {
+	MultiClient
+	eth2client.NodeClientProvider
+	eth2client.BlindedProposalSubmitter
+}
+
 type NodeClientProvider interface {
 	NodeClient() NodeClient
 }
@@ -144,7 +161,8 @@ type GoClient struct {
 	log                  *zap.Logger
 	ctx                  context.Context
 	network              beaconprotocol.Network
-	client               Client
+	nodes                []*beaconNode
+	multiClient          MultiClient
 	nodeVersion          string
 	nodeClient           NodeClient
 	gasLimit             uint64
@@ -154,6 +172,12 @@ type GoClient struct {
 	registrationCache    map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration
 	commonTimeout        time.Duration
 	longTimeout          time.Duration
+	// httpClient requests the endpoints go-eth2-client doesn't support.
+	httpClient *http.Client
+
+	forkMu                sync.Mutex
+	forkSchedule          []*phase0.Fork
+	genesisValidatorsRoot *phase0.Root
 }
 
 // New init new client and go-client instance
@@ -163,7 +187,15 @@ func New(
 	operatorDataStore operatordatastore.OperatorDataStore,
 	slotTickerProvider slotticker.Provider,
 ) (*GoClient, error) {
-	logger.Info("consensus client: connecting", fields.Address(opt.BeaconNodeAddr), fields.Network(string(opt.Network.BeaconNetwork)))
+	addresses, err := ParseBeaconNodeAddresses(opt.BeaconNodeAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("consensus client: connecting",
+		zap.Strings("addresses", redactAddresses(addresses)),
+		fields.Network(string(opt.Network.BeaconNetwork)),
+	)
 
 	commonTimeout := opt.CommonTimeout
 	if commonTimeout == 0 {
@@ -174,86 +206,141 @@ func New(
 		longTimeout = DefaultLongTimeout
 	}
 
-	httpClient, err := eth2clienthttp.New(opt.Context,
-		// WithAddress supplies the address of the beacon node, in host:port format.
-		eth2clienthttp.WithAddress(opt.BeaconNodeAddr),
-		// LogLevel supplies the level of logging to carry out.
-		eth2clienthttp.WithLogLevel(zerolog.DebugLevel),
-		eth2clienthttp.WithTimeout(commonTimeout),
-		eth2clienthttp.WithReducedMemoryUsage(true),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create http client: %w", err)
-	}
-
 	client := &GoClient{
 		log:               logger,
 		ctx:               opt.Context,
 		network:           opt.Network,
-		client:            httpClient.(*eth2clienthttp.Service),
 		gasLimit:          opt.GasLimit,
 		operatorDataStore: operatorDataStore,
 		registrationCache: map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration{},
 		commonTimeout:     commonTimeout,
 		longTimeout:       longTimeout,
+		httpClient:        &http.Client{Timeout: commonTimeout},
 	}
 
-	nodeVersionResp, err := client.client.NodeVersion(opt.Context, &api.NodeVersionOpts{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get node version: %w", err)
+	// With multiple beacon nodes, the node may start as long as any of them is available.
+	allowDelayedStart := len(addresses) > 1
+
+	services := make([]eth2client.Service, 0, len(addresses))
+	for _, address := range addresses {
+		node, err := client.connectNode(opt.Context, address, allowDelayedStart)
+		if err != nil {
+			return nil, err
+		}
+		client.nodes = append(client.nodes, node)
+		services = append(services, node.client)
 	}
-	if nodeVersionResp == nil {
-		return nil, fmt.Errorf("node version response is nil")
+
+	if client.nodeVersion == "" {
+		return nil, fmt.Errorf("failed to get node version from any of the consensus clients")
 	}
-	client.nodeVersion = nodeVersionResp.Data
-	client.nodeClient = ParseNodeClient(nodeVersionResp.Data)
 
-	logger.Info("consensus client connected",
-		fields.Name(httpClient.Name()),
-		fields.Address(httpClient.Address()),
-		zap.String("client", string(client.nodeClient)),
-		zap.String("version", client.nodeVersion),
+	multiClient, err := eth2clientmulti.New(opt.Context,
+		eth2clientmulti.WithName("consensus client"),
+		eth2clientmulti.WithClients(services),
+		eth2clientmulti.WithLogLevel(zerolog.DebugLevel),
+		eth2clientmulti.WithTimeout(commonTimeout),
 	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multi client: %w", err)
+	}
+	client.multiClient = multiClient.(*eth2clientmulti.Service)
 
 	go client.registrationSubmitter(slotTickerProvider)
 
 	return client, nil
 }
 
+// connectNode creates a client of a single beacon node and fetches its version.
+// If delayed start is allowed, an unavailable node doesn't fail the connection,
+// and is expected to be picked up by the multi client once it becomes available.
+func (gc *GoClient) connectNode(ctx context.Context, address string, allowDelayedStart bool) (*beaconNode, error) {
+	httpClient, err := eth2clienthttp.New(ctx,
+		// WithAddress supplies the address of the beacon node, in host:port format.
+		eth2clienthttp.WithAddress(address),
+		// LogLevel supplies the level of logging to carry out.
+		eth2clienthttp.WithLogLevel(zerolog.DebugLevel),
+		eth2clienthttp.WithTimeout(gc.commonTimeout),
+		eth2clienthttp.WithReducedMemoryUsage(true),
+		eth2clienthttp.WithAllowDelayedStart(allowDelayedStart),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http client for %s: %w", redactAddress(address), err)
+	}
+
+	node := &beaconNode{
+		address:    redactAddress(address),
+		rawAddress: address,
+		client:     httpClient.(*eth2clienthttp.Service),
+	}
+
+	nodeVersionResp, err := node.client.NodeVersion(ctx, &api.NodeVersionOpts{})
+	if err == nil && nodeVersionResp == nil {
+		err = fmt.Errorf("node version response is nil")
+	}
+	if err != nil {
+		if !allowDelayedStart {
+			return nil, fmt.Errorf("failed to get node version: %w", err)
+		}
+		gc.log.Warn("consensus client is not available yet", zap.String("address", node.address), zap.Error(err))
+		node.setHealth(err)
+		return node, nil
+	}
+
+	// The first available node determines the reported node version and client.
+	if gc.nodeVersion == "" {
+		gc.nodeVersion = nodeVersionResp.Data
+		gc.nodeClient = ParseNodeClient(nodeVersionResp.Data)
+	}
+	node.setHealth(nil)
+
+	gc.log.Info("consensus client connected",
+		fields.Name(httpClient.Name()),
+		zap.String("address", node.address),
+		zap.String("client", string(ParseNodeClient(nodeVersionResp.Data))),
+		zap.String("version", nodeVersionResp.Data),
+	)
+
+	return node, nil
+}
+
 func (gc *GoClient) NodeClient() NodeClient {
 	return gc.nodeClient
 }
 
-// Healthy returns if beacon node is currently healthy: responds to requests, not in the syncing state, not optimistic
+// Healthy returns if any of the beacon nodes is currently healthy: responds to requests, not in the syncing state, not optimistic
 // (for optimistic see https://github.com/ethereum/consensus-specs/blob/dev/sync/optimistic.md#block-production).
 func (gc *GoClient) Healthy(ctx context.Context) error {
-	nodeSyncingResp, err := gc.client.NodeSyncing(ctx, &api.NodeSyncingOpts{})
-	if err != nil {
-		// TODO: get rid of global variable, pass metrics to goClient
-		metricsBeaconNodeStatus.Set(float64(statusUnknown))
-		return fmt.Errorf("failed to obtain node syncing status: %w", err)
-	}
-	if nodeSyncingResp == nil {
-		metricsBeaconNodeStatus.Set(float64(statusUnknown))
-		return fmt.Errorf("node syncing response is nil")
-	}
-	if nodeSyncingResp.Data == nil {
-		metricsBeaconNodeStatus.Set(float64(statusUnknown))
-		return fmt.Errorf("node syncing data is nil")
-	}
-	syncState := nodeSyncingResp.Data
+	statuses := gc.EndpointsHealth(ctx)
 
-	// TODO: also check if syncState.ElOffline when github.com/attestantio/go-eth2-client supports it
-	metricsBeaconNodeStatus.Set(float64(statusSyncing))
-	if syncState.IsSyncing {
-		return fmt.Errorf("syncing")
+	var errs []string
+	for _, node := range gc.nodes {
+		err := statuses[node.address]
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %s", node.address, err))
 	}
-	if syncState.IsOptimistic {
-		return fmt.Errorf("optimistic")
+	return fmt.Errorf("no healthy consensus client: %s", strings.Join(errs, "; "))
+}
+
+// EndpointsHealth checks the health of each beacon node and returns it by node address.
+func (gc *GoClient) EndpointsHealth(ctx context.Context) map[string]error {
+	var wg sync.WaitGroup
+	for _, node := range gc.nodes {
+		wg.Add(1)
+		go func(node *beaconNode) {
+			defer wg.Done()
+			node.setHealth(node.checkHealth(ctx))
+		}(node)
 	}
+	wg.Wait()
 
-	metricsBeaconNodeStatus.Set(float64(statusOK))
-	return nil
+	statuses := make(map[string]error, len(gc.nodes))
+	for _, node := range gc.nodes {
+		statuses[node.address] = node.health()
+	}
+	return statuses
 }
 
 // GetBeaconNetwork returns the beacon network the node is on
@@ -270,5 +357,5 @@ func (gc *GoClient) slotStartTime(slot phase0.Slot) time.Time {
 }
 
 func (gc *GoClient) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
-	return gc.client.Events(ctx, topics, handler)
+	return gc.multiClient.Events(ctx, topics, handler)
 }
@@ -0,0 +1,99 @@
+package goclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// ValidatorLiveness is whether a validator was seen performing its duties in an epoch.
+type ValidatorLiveness struct {
+	Index  phase0.ValidatorIndex `json:"index,string"`
+	IsLive bool                  `json:"is_live"`
+}
+
+// ValidatorLiveness returns the liveness of the given validators in the given epoch,
+// as observed by the beacon node. The epoch must be the current or the previous one.
+func (gc *GoClient) ValidatorLiveness(ctx context.Context, epoch phase0.Epoch, indices []phase0.ValidatorIndex) ([]*ValidatorLiveness, error) {
+	body := make([]string, 0, len(indices))
+	for _, index := range indices {
+		body = append(body, strconv.FormatUint(uint64(index), 10))
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode validator indices: %w", err)
+	}
+
+	// go-eth2-client doesn't support the liveness endpoint, so it's requested directly,
+	// failing over from node to node like the multi client.
+	var errs []error
+	for _, node := range gc.healthyNodes() {
+		liveness, err := gc.fetchValidatorLiveness(ctx, node, epoch, data)
+		if err == nil {
+			metricsLivenessRequests.WithLabelValues(node.address, "succeeded").Inc()
+			return liveness, nil
+		}
+		metricsLivenessRequests.WithLabelValues(node.address, "failed").Inc()
+		errs = append(errs, fmt.Errorf("%s: %w", node.address, err))
+	}
+	return nil, fmt.Errorf("failed to obtain validator liveness: %w", errors.Join(errs...))
+}
+
+func (gc *GoClient) fetchValidatorLiveness(ctx context.Context, node *beaconNode, epoch phase0.Epoch, body []byte) ([]*ValidatorLiveness, error) {
+	ctx, cancel := context.WithTimeout(ctx, gc.commonTimeout)
+	defer cancel()
+
+	address := node.rawAddress
+	if !strings.HasPrefix(address, "http") {
+		address = "http://" + address
+	}
+	base, err := url.Parse(strings.TrimSuffix(address, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+	endpoint := base.JoinPath("eth/v1/validator/liveness", strconv.FormatUint(uint64(epoch), 10))
+	// Like go-eth2-client, credentials in the address are sent as basic auth.
+	user := endpoint.User
+	endpoint.User = nil
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if user != nil {
+		password, _ := user.Password()
+		req.SetBasicAuth(user.Username(), password)
+	}
+
+	resp, err := gc.httpClient.Do(req)
+	if err != nil {
+		// The node is unreachable, so it's skipped until its next health check.
+		node.setHealth(err)
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var liveness struct {
+		Data []*ValidatorLiveness `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&liveness); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return liveness.Data, nil
+}
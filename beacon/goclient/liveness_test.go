@@ -0,0 +1,70 @@
+package goclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorLiveness(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/eth/v1/validator/liveness/10", r.URL.Path)
+		user, password, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "user", user)
+		require.Equal(t, "secret", password)
+
+		var indices []string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&indices))
+		require.Equal(t, []string{"1", "2"}, indices)
+
+		_, _ = w.Write([]byte(`{"data":[{"index":"1","is_live":true},{"index":"2","is_live":false}]}`))
+	}))
+	defer server.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not implemented", http.StatusNotImplemented)
+	}))
+	defer failing.Close()
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	serverURL.User = url.UserPassword("user", "secret")
+
+	gc := &GoClient{
+		nodes: []*beaconNode{
+			{address: "unreachable", rawAddress: unreachable.URL},
+			{address: "failing", rawAddress: failing.URL},
+			{address: "server", rawAddress: serverURL.String()},
+		},
+		commonTimeout: time.Second,
+		httpClient:    &http.Client{Timeout: time.Second},
+	}
+	for _, node := range gc.nodes {
+		node.setHealth(nil)
+	}
+
+	liveness, err := gc.ValidatorLiveness(context.Background(), 10, []phase0.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, []*ValidatorLiveness{{Index: 1, IsLive: true}, {Index: 2, IsLive: false}}, liveness)
+
+	// An unreachable node is unhealthy until its next health check, while a failing one isn't.
+	require.Error(t, gc.nodes[0].health())
+	require.NoError(t, gc.nodes[1].health())
+	require.Len(t, gc.healthyNodes(), 2)
+
+	gc.nodes = gc.nodes[1:2]
+	_, err = gc.ValidatorLiveness(context.Background(), 10, []phase0.ValidatorIndex{1, 2})
+	require.ErrorContains(t, err, "unexpected status 501")
+}
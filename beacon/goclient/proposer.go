@@ -2,7 +2,9 @@ package goclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/api"
@@ -28,7 +30,7 @@ const (
 
 // ProposerDuties returns proposer duties for the given epoch.
 func (gc *GoClient) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.ProposerDuty, error) {
-	resp, err := gc.client.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+	resp, err := gc.multiClient.ProposerDuties(ctx, &api.ProposerDutiesOpts{
 		Epoch:   epoch,
 		Indices: validatorIndices,
 	})
@@ -44,6 +46,16 @@ func (gc *GoClient) ProposerDuties(ctx context.Context, epoch phase0.Epoch, vali
 
 // GetBeaconBlock returns beacon block by the given slot, graffiti, and randao.
 func (gc *GoClient) GetBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	return gc.getBeaconBlock(slot, graffitiBytes, randao, nil)
+}
+
+// GetBeaconBlockWithBuilderBoost returns beacon block by the given slot, graffiti, and randao,
+// with builder payloads weighted against local ones by the given boost factor.
+func (gc *GoClient) GetBeaconBlockWithBuilderBoost(slot phase0.Slot, graffitiBytes, randao []byte, builderBoostFactor uint64) (ssz.Marshaler, spec.DataVersion, error) {
+	return gc.getBeaconBlock(slot, graffitiBytes, randao, &builderBoostFactor)
+}
+
+func (gc *GoClient) getBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byte, builderBoostFactor *uint64) (ssz.Marshaler, spec.DataVersion, error) {
 	sig := phase0.BLSSignature{}
 	copy(sig[:], randao[:])
 
@@ -51,24 +63,47 @@ func (gc *GoClient) GetBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byt
 	copy(graffiti[:], graffitiBytes[:])
 
 	reqStart := time.Now()
-	proposalResp, err := gc.client.Proposal(gc.ctx, &api.ProposalOpts{
+	opts := &api.ProposalOpts{
 		Slot:                   slot,
 		RandaoReveal:           sig,
 		Graffiti:               graffiti,
 		SkipRandaoVerification: false,
-	})
-	if err != nil {
-		return nil, DataVersionNil, fmt.Errorf("failed to get proposal: %w", err)
+		BuilderBoostFactor:     builderBoostFactor,
 	}
-	if proposalResp == nil {
-		return nil, DataVersionNil, fmt.Errorf("proposal response is nil")
+	fetchProposal := func(ctx context.Context, client MultiClient) (*api.VersionedProposal, error) {
+		proposalResp, err := client.Proposal(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get proposal: %w", err)
+		}
+		if proposalResp == nil {
+			return nil, fmt.Errorf("proposal response is nil")
+		}
+		if proposalResp.Data == nil {
+			return nil, fmt.Errorf("proposal data is nil")
+		}
+		return proposalResp.Data, nil
 	}
-	if proposalResp.Data == nil {
-		return nil, DataVersionNil, fmt.Errorf("proposal data is nil")
+
+	var beaconBlock *api.VersionedProposal
+	var err error
+	if len(gc.nodes) > 1 {
+		// Request all healthy nodes and take the most valuable proposal.
+		beaconBlock, err = bestSuccessful(gc.ctx, gc.healthyNodes(),
+			func(ctx context.Context, client Client) (*api.VersionedProposal, error) {
+				return fetchProposal(ctx, client)
+			},
+			func(*api.VersionedProposal) error { return nil },
+			proposalValue,
+			proposalGracePeriod,
+		)
+	} else {
+		beaconBlock, err = fetchProposal(gc.ctx, gc.multiClient)
+	}
+	if err != nil {
+		return nil, DataVersionNil, err
 	}
 
 	metricsProposerDataRequest.Observe(time.Since(reqStart).Seconds())
-	beaconBlock := proposalResp.Data
 
 	if beaconBlock.Blinded {
 		switch beaconBlock.Version {
@@ -166,7 +201,28 @@ func (gc *GoClient) SubmitBlindedBeaconBlock(block *api.VersionedBlindedProposal
 		Proposal: signedBlock,
 	}
 
-	return gc.client.SubmitBlindedProposal(gc.ctx, opts)
+	// The multi client doesn't support blinded proposals, so try the nodes one by one.
+	var errs []error
+	for _, node := range gc.healthyNodes() {
+		err := node.client.SubmitBlindedProposal(gc.ctx, opts)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", node.address, err))
+	}
+	return errors.Join(errs...)
+}
+
+// proposalValue returns the total value of the proposal to the proposer, used to pick the best proposal.
+func proposalValue(proposal *api.VersionedProposal) *big.Int {
+	value := new(big.Int)
+	if proposal.ExecutionValue != nil {
+		value.Add(value, proposal.ExecutionValue)
+	}
+	if proposal.ConsensusValue != nil {
+		value.Add(value, proposal.ConsensusValue)
+	}
+	return value
 }
 
 // SubmitBeaconBlock submit the block to the node
@@ -212,13 +268,20 @@ func (gc *GoClient) SubmitBeaconBlock(block *api.VersionedProposal, sig phase0.B
 		Proposal: signedBlock,
 	}
 
-	return gc.client.SubmitProposal(gc.ctx, opts)
+	return gc.multiClient.SubmitProposal(gc.ctx, opts)
 }
 
 func (gc *GoClient) SubmitValidatorRegistration(pubkey []byte, feeRecipient bellatrix.ExecutionAddress, sig phase0.BLSSignature) error {
 	return gc.updateBatchRegistrationCache(gc.createValidatorRegistration(pubkey, feeRecipient, sig))
 }
 
+func (gc *GoClient) SubmitSignedValidatorRegistration(registration *eth2apiv1.SignedValidatorRegistration) error {
+	return gc.updateBatchRegistrationCache(&api.VersionedSignedValidatorRegistration{
+		Version: spec.BuilderVersionV1,
+		V1:      registration,
+	})
+}
+
 func (gc *GoClient) SubmitProposalPreparation(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error {
 	var preparations []*eth2apiv1.ProposalPreparation
 	for index, recipient := range feeRecipients {
@@ -227,7 +290,7 @@ func (gc *GoClient) SubmitProposalPreparation(feeRecipients map[phase0.Validator
 			FeeRecipient:   recipient,
 		})
 	}
-	return gc.client.SubmitProposalPreparations(gc.ctx, preparations)
+	return gc.multiClient.SubmitProposalPreparations(gc.ctx, preparations)
 }
 
 func (gc *GoClient) updateBatchRegistrationCache(registration *api.VersionedSignedValidatorRegistration) error {
@@ -333,7 +396,7 @@ func (gc *GoClient) submitBatchedRegistrations(slot phase0.Slot, registrations [
 			bs = len(registrations)
 		}
 
-		if err := gc.client.SubmitValidatorRegistrations(gc.ctx, registrations[0:bs]); err != nil {
+		if err := gc.multiClient.SubmitValidatorRegistrations(gc.ctx, registrations[0:bs]); err != nil {
 			return err
 		}
 
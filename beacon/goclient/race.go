@@ -0,0 +1,119 @@
+package goclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// proposalGracePeriod is how long to wait for better proposals from other beacon nodes
+// after the first valid proposal is received.
+const proposalGracePeriod = 300 * time.Millisecond
+
+type raceResult[T any] struct {
+	address string
+	value   T
+	err     error
+}
+
+// race requests all given nodes concurrently and sends the results to the returned channel,
+// which is closed once every node has responded.
+func race[T any](
+	ctx context.Context,
+	nodes []*beaconNode,
+	fetch func(ctx context.Context, client Client) (T, error),
+	validate func(T) error,
+) <-chan raceResult[T] {
+	results := make(chan raceResult[T], len(nodes))
+	go func() {
+		defer close(results)
+		done := make(chan struct{}, len(nodes))
+		for _, node := range nodes {
+			go func(node *beaconNode) {
+				defer func() { done <- struct{}{} }()
+				value, err := fetch(ctx, node.client)
+				if err == nil {
+					err = validate(value)
+				}
+				results <- raceResult[T]{address: node.address, value: value, err: err}
+			}(node)
+		}
+		for range nodes {
+			<-done
+		}
+	}()
+	return results
+}
+
+// firstSuccessful requests all given nodes concurrently and returns the first valid response.
+func firstSuccessful[T any](
+	ctx context.Context,
+	nodes []*beaconNode,
+	fetch func(ctx context.Context, client Client) (T, error),
+	validate func(T) error,
+) (T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var errs []error
+	for result := range race(ctx, nodes, fetch, validate) {
+		if result.err == nil {
+			return result.value, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", result.address, result.err))
+	}
+
+	var zero T
+	return zero, errors.Join(errs...)
+}
+
+// bestSuccessful requests all given nodes concurrently and returns the valid response with the highest score.
+// Once the first valid response arrives, other nodes are given the grace period to respond with a better one.
+func bestSuccessful[T any](
+	ctx context.Context,
+	nodes []*beaconNode,
+	fetch func(ctx context.Context, client Client) (T, error),
+	validate func(T) error,
+	score func(T) *big.Int,
+	gracePeriod time.Duration,
+) (T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		best      T
+		bestScore *big.Int
+		errs      []error
+		deadline  <-chan time.Time
+	)
+
+	results := race(ctx, nodes, fetch, validate)
+	for {
+		select {
+		case result, ok := <-results:
+			if !ok {
+				if bestScore != nil {
+					return best, nil
+				}
+				var zero T
+				return zero, errors.Join(errs...)
+			}
+			if result.err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", result.address, result.err))
+				continue
+			}
+			if s := score(result.value); bestScore == nil || s.Cmp(bestScore) > 0 {
+				best, bestScore = result.value, s
+			}
+			if deadline == nil {
+				timer := time.NewTimer(gracePeriod)
+				defer timer.Stop()
+				deadline = timer.C
+			}
+		case <-deadline:
+			return best, nil
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testClient identifies the node it belongs to, the actual client methods are never called.
+type testClient struct {
+	Client
+	address string
+}
+
+func testNodes(addresses ...string) []*beaconNode {
+	nodes := make([]*beaconNode, 0, len(addresses))
+	for _, address := range addresses {
+		nodes = append(nodes, &beaconNode{address: address, client: testClient{address: address}})
+	}
+	return nodes
+}
+
+// testFetcher returns a fetch function which responds with the node's response after the node's delay,
+// or fails if the node has no response.
+func testFetcher(responses map[string]int, delays map[string]time.Duration) func(ctx context.Context, client Client) (int, error) {
+	return func(ctx context.Context, client Client) (int, error) {
+		address := client.(testClient).address
+		select {
+		case <-time.After(delays[address]):
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+		value, ok := responses[address]
+		if !ok {
+			return 0, fmt.Errorf("unavailable")
+		}
+		return value, nil
+	}
+}
+
+func TestFirstSuccessful(t *testing.T) {
+	positive := func(v int) error {
+		if v <= 0 {
+			return fmt.Errorf("invalid")
+		}
+		return nil
+	}
+
+	t.Run("fastest valid response wins", func(t *testing.T) {
+		nodes := testNodes("a", "b", "c")
+		fetch := testFetcher(map[string]int{"a": 1, "b": 2, "c": -1},
+			map[string]time.Duration{"a": 200 * time.Millisecond, "b": 50 * time.Millisecond, "c": 0},
+		)
+		v, err := firstSuccessful(context.Background(), nodes, fetch, positive)
+		require.NoError(t, err)
+		require.Equal(t, 2, v)
+	})
+
+	t.Run("all fail", func(t *testing.T) {
+		nodes := testNodes("a", "b")
+		fetch := testFetcher(map[string]int{"b": -1}, nil)
+		_, err := firstSuccessful(context.Background(), nodes, fetch, positive)
+		require.ErrorContains(t, err, "a: unavailable")
+		require.ErrorContains(t, err, "b: invalid")
+	})
+}
+
+func TestBestSuccessful(t *testing.T) {
+	valid := func(int) error { return nil }
+	score := func(v int) *big.Int { return big.NewInt(int64(v)) }
+
+	t.Run("best response within grace period wins", func(t *testing.T) {
+		nodes := testNodes("a", "b", "c")
+		fetch := testFetcher(map[string]int{"a": 1, "b": 3, "c": 2},
+			map[string]time.Duration{"a": 0, "b": 50 * time.Millisecond, "c": 10 * time.Millisecond},
+		)
+		v, err := bestSuccessful(context.Background(), nodes, fetch, valid, score, time.Second)
+		require.NoError(t, err)
+		require.Equal(t, 3, v)
+	})
+
+	t.Run("late response is ignored", func(t *testing.T) {
+		nodes := testNodes("a", "b")
+		fetch := testFetcher(map[string]int{"a": 1, "b": 3},
+			map[string]time.Duration{"a": 0, "b": 5 * time.Second},
+		)
+		start := time.Now()
+		v, err := bestSuccessful(context.Background(), nodes, fetch, valid, score, 50*time.Millisecond)
+		require.NoError(t, err)
+		require.Equal(t, 1, v)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("all fail", func(t *testing.T) {
+		nodes := testNodes("a", "b")
+		fetch := testFetcher(nil, nil)
+		_, err := bestSuccessful(context.Background(), nodes, fetch, valid, score, time.Second)
+		require.ErrorContains(t, err, "unavailable")
+	})
+}
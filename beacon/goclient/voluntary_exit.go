@@ -5,5 +5,5 @@ import (
 )
 
 func (gc *GoClient) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
-	return gc.client.SubmitVoluntaryExit(gc.ctx, voluntaryExit)
+	return gc.multiClient.SubmitVoluntaryExit(gc.ctx, voluntaryExit)
 }
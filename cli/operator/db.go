@@ -0,0 +1,313 @@
+package operator
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/ssvlabs/ssv/cli/config"
+	"github.com/ssvlabs/ssv/operator/backup"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+var (
+	snapshotFile string
+	adminAPIURL  string
+	toEngine     string
+	toPath       string
+)
+
+// DBCmd is the parent command of the node database commands
+var DBCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Backup, restore or convert the node database",
+	Long: "Backup the node database to a snapshot file, restore it from one, or convert it to another storage engine.\n" +
+		"Snapshots include the registry, slashing protection and decided history, and are tagged with the network and the last processed block.",
+}
+
+var backupDBCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Write a consistent snapshot of the node database",
+	Long: "Write a consistent snapshot of the node database.\n" +
+		"With --admin-api, the snapshot is taken by the running node through its admin API, authenticated with the configured admin API token. " +
+		"Otherwise, the node must be stopped.",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+
+		err = writeFileAtomically(snapshotFile, func(w io.Writer) error {
+			if adminAPIURL != "" {
+				return downloadSnapshot(cmd.Context(), logger, w)
+			}
+
+			db, err := kv.Open(logger, cfg.DBOptions)
+			if err != nil {
+				return fmt.Errorf("could not open db, is the node running?: %w", err)
+			}
+			defer func() {
+				if err := db.Close(); err != nil {
+					logger.Error("could not close db", zap.Error(err))
+				}
+			}()
+			nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+			if err != nil {
+				return fmt.Errorf("could not create node storage: %w", err)
+			}
+			_, err = backup.NewSnapshotter(logger, db, nodeStorage).Snapshot(w)
+			return err
+		})
+		if err != nil {
+			logger.Fatal("could not backup db", zap.Error(err))
+		}
+		logger.Info("backed up db", zap.String("file", snapshotFile))
+	},
+}
+
+var restoreDBCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore the node database from a snapshot",
+	Long: "Restore the node database from a snapshot, after verifying it was taken on the configured network by a compatible node version.\n" +
+		"The node must be stopped, and the database path must not contain a database.\n" +
+		"When the node starts, the slashing protection of its shares is bumped to the current slot.",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+		current := &operatorstorage.ConfigLock{
+			NetworkName:           networkConfig.AlanForkNetworkName(),
+			UsingLocalEvents:      len(cfg.LocalEventsPath) != 0,
+			NetworkDefinitionHash: networkConfig.DefinitionHash,
+		}
+
+		if err := restoreDB(logger, current); err != nil {
+			logger.Fatal("could not restore db", zap.Error(err))
+		}
+		logger.Info("restored db", zap.String("file", snapshotFile), zap.String("path", cfg.DBOptions.Path))
+	},
+}
+
+// restoreDB restores the snapshot into a new database next to the database path,
+// and moves it to the database path once it's complete.
+func restoreDB(logger *zap.Logger, current *operatorstorage.ConfigLock) error {
+	dbPath := filepath.Clean(cfg.DBOptions.Path)
+	if entries, err := os.ReadDir(dbPath); err == nil && len(entries) > 0 {
+		return fmt.Errorf("database path %s is not empty, move the existing database away first", dbPath)
+	} else if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not read database path: %w", err)
+	}
+
+	file, err := os.Open(filepath.Clean(snapshotFile))
+	if err != nil {
+		return fmt.Errorf("could not open snapshot: %w", err)
+	}
+	defer file.Close()
+
+	restorePath := dbPath + ".restore"
+	if err := os.RemoveAll(restorePath); err != nil {
+		return fmt.Errorf("could not remove previous restore: %w", err)
+	}
+	opts := cfg.DBOptions
+	opts.Path = restorePath
+	db, err := kv.Open(logger, opts)
+	if err != nil {
+		return fmt.Errorf("could not create db: %w", err)
+	}
+	if _, err := backup.Restore(logger, file, db, current); err != nil {
+		_ = db.Close()
+		_ = os.RemoveAll(restorePath)
+		return err
+	}
+	if err := db.Close(); err != nil {
+		return fmt.Errorf("could not close restored db: %w", err)
+	}
+
+	if err := os.RemoveAll(dbPath); err != nil {
+		return fmt.Errorf("could not remove empty database path: %w", err)
+	}
+	return os.Rename(restorePath, dbPath)
+}
+
+var convertDBCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert the node database to another storage engine",
+	Long: "Copy the node database into a new database of another storage engine, at --to-path.\n" +
+		"The node must be stopped. Once converted, set db.Engine and db.Path to the new database in the node config.",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+
+		if err := convertDB(logger); err != nil {
+			logger.Fatal("could not convert db", zap.Error(err))
+		}
+		logger.Info("converted db, set db.Engine and db.Path in the node config to use it",
+			zap.String("engine", toEngine),
+			zap.String("path", toPath),
+		)
+	},
+}
+
+// convertDB copies the database into a new database next to the destination path,
+// and moves it to the destination path once it's complete.
+func convertDB(logger *zap.Logger) error {
+	dstPath := filepath.Clean(toPath)
+	if dstPath == filepath.Clean(cfg.DBOptions.Path) {
+		return fmt.Errorf("destination path must differ from the database path")
+	}
+	if entries, err := os.ReadDir(dstPath); err == nil && len(entries) > 0 {
+		return fmt.Errorf("destination path %s is not empty", dstPath)
+	} else if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not read destination path: %w", err)
+	}
+
+	// The database is converted from whichever storage engine it is.
+	srcOpts := cfg.DBOptions
+	engine, ok := kv.DetectEngine(srcOpts.Path)
+	if !ok {
+		return fmt.Errorf("no database found at %s", srcOpts.Path)
+	}
+	srcOpts.Engine = engine
+	src, err := kv.Open(logger, srcOpts)
+	if err != nil {
+		return fmt.Errorf("could not open db, is the node running?: %w", err)
+	}
+	defer func() {
+		if err := src.Close(); err != nil {
+			logger.Error("could not close db", zap.Error(err))
+		}
+	}()
+	if src.Engine() == toEngine {
+		return fmt.Errorf("database is already a %s database", toEngine)
+	}
+
+	convertPath := dstPath + ".convert"
+	if err := os.RemoveAll(convertPath); err != nil {
+		return fmt.Errorf("could not remove previous conversion: %w", err)
+	}
+	opts := cfg.DBOptions
+	opts.Engine = toEngine
+	opts.Path = convertPath
+	dst, err := kv.Open(logger, opts)
+	if err != nil {
+		return fmt.Errorf("could not create db: %w", err)
+	}
+
+	start := time.Now()
+	copied, err := kv.Copy(dst, src)
+	if err != nil {
+		_ = dst.Close()
+		_ = os.RemoveAll(convertPath)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return fmt.Errorf("could not close converted db: %w", err)
+	}
+	logger.Info("copied db",
+		zap.String("from", src.Engine()),
+		zap.String("to", toEngine),
+		zap.Int64("keys", copied),
+		zap.Duration("took", time.Since(start)),
+	)
+
+	if err := os.RemoveAll(dstPath); err != nil {
+		return fmt.Errorf("could not remove empty destination path: %w", err)
+	}
+	return os.Rename(convertPath, dstPath)
+}
+
+// downloadSnapshot writes a snapshot taken by the running node through its admin API.
+func downloadSnapshot(ctx context.Context, logger *zap.Logger, w io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(adminAPIURL, "/")+"/v1/admin/db/backup", nil)
+	if err != nil {
+		return err
+	}
+	if cfg.AdminAPI.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.AdminAPI.Token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not request snapshot: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("admin API responded with %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	// Everything read is written, including the metadata which is read to verify it.
+	br := bufio.NewReader(io.TeeReader(resp.Body, w))
+	metadata, err := backup.ReadMetadata(br)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(io.Discard, br); err != nil {
+		return fmt.Errorf("could not download snapshot: %w", err)
+	}
+	logger.Info("downloaded snapshot",
+		zap.String("network", metadata.Config.NetworkName),
+		zap.Uint64("last_processed_block", metadata.LastProcessedBlock),
+	)
+	return nil
+}
+
+// writeFileAtomically writes a file through a temporary file, which is renamed once it's complete.
+func writeFileAtomically(path string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	bw := bufio.NewWriter(tmp)
+	if err := write(bw); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("could not write file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("could not sync file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not close file: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, DBCmd)
+
+	DBCmd.PersistentFlags().StringVar(&snapshotFile, "file", "./ssv-db.snapshot", "Path to the snapshot file")
+	backupDBCmd.Flags().StringVar(&adminAPIURL, "admin-api", "", "URL of the admin API of the running node, e.g. http://localhost:16001")
+	convertDBCmd.Flags().StringVar(&toEngine, "to-engine", "", "Storage engine to convert to, either badger or pebble")
+	convertDBCmd.Flags().StringVar(&toPath, "to-path", "", "Path of the converted database")
+	_ = convertDBCmd.MarkFlagRequired("to-engine")
+	_ = convertDBCmd.MarkFlagRequired("to-path")
+
+	DBCmd.AddCommand(backupDBCmd)
+	DBCmd.AddCommand(restoreDBCmd)
+	DBCmd.AddCommand(convertDBCmd)
+}
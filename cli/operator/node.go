@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,14 +29,15 @@ import (
 	"github.com/ssvlabs/ssv/beacon/goclient/genesisgoclient"
 	global_config "github.com/ssvlabs/ssv/cli/config"
 	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/ekm/web3signer"
 	"github.com/ssvlabs/ssv/eth/eventhandler"
 	"github.com/ssvlabs/ssv/eth/eventparser"
 	"github.com/ssvlabs/ssv/eth/eventsyncer"
 	"github.com/ssvlabs/ssv/eth/executionclient"
 	"github.com/ssvlabs/ssv/eth/localevents"
+	"github.com/ssvlabs/ssv/exporter/analytics"
 	exporterapi "github.com/ssvlabs/ssv/exporter/api"
 	"github.com/ssvlabs/ssv/exporter/api/decided"
-	"github.com/ssvlabs/ssv/exporter/convert"
 	genesisibftstorage "github.com/ssvlabs/ssv/ibft/genesisstorage"
 	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
 	ssv_identity "github.com/ssvlabs/ssv/identity"
@@ -51,16 +54,21 @@ import (
 	"github.com/ssvlabs/ssv/networkconfig"
 	"github.com/ssvlabs/ssv/nodeprobe"
 	"github.com/ssvlabs/ssv/operator"
+	"github.com/ssvlabs/ssv/operator/backup"
 	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
+	"github.com/ssvlabs/ssv/operator/doppelganger"
 	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+	"github.com/ssvlabs/ssv/operator/dutytracer"
 	"github.com/ssvlabs/ssv/operator/keys"
 	"github.com/ssvlabs/ssv/operator/keystore"
+	"github.com/ssvlabs/ssv/operator/proposersettings"
 	"github.com/ssvlabs/ssv/operator/slotticker"
 	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
 	"github.com/ssvlabs/ssv/operator/validator"
 	"github.com/ssvlabs/ssv/operator/validators"
 	genesisssvtypes "github.com/ssvlabs/ssv/protocol/genesis/types"
 	beaconprotocol "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
 	registrystorage "github.com/ssvlabs/ssv/registry/storage"
 	"github.com/ssvlabs/ssv/storage/basedb"
@@ -83,6 +91,7 @@ type config struct {
 	ConsensusClient            beaconprotocol.Options           `yaml:"eth2"` // TODO: consensus_client in yaml
 	P2pNetworkConfig           p2pv1.Config                     `yaml:"p2p"`
 	KeyStore                   KeyStore                         `yaml:"KeyStore"`
+	KeyManager                 ekm.KeyManagerOptions            `yaml:"KeyManager"`
 	Graffiti                   string                           `yaml:"Graffiti" env:"GRAFFITI" env-description:"Custom graffiti for block proposals." env-default:"SSV.Network" `
 	OperatorPrivateKey         string                           `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key, used to decrypt contract events"`
 	MetricsAPIPort             int                              `yaml:"MetricsAPIPort" env:"METRICS_API_PORT" env-description:"Port to listen on for the metrics API."`
@@ -91,7 +100,9 @@ type config struct {
 	WsAPIPort                  int                              `yaml:"WebSocketAPIPort" env:"WS_API_PORT" env-description:"Port to listen on for the websocket API."`
 	WithPing                   bool                             `yaml:"WithPing" env:"WITH_PING" env-description:"Whether to send websocket ping messages'"`
 	SSVAPIPort                 int                              `yaml:"SSVAPIPort" env:"SSV_API_PORT" env-description:"Port to listen on for the SSV API."`
+	AdminAPI                   apiserver.AdminOptions           `yaml:"AdminAPI"`
 	LocalEventsPath            string                           `yaml:"LocalEventsPath" env:"EVENTS_PATH" env-description:"path to local events"`
+	ProposerSettingsFile       string                           `yaml:"ProposerSettingsFile" env:"PROPOSER_SETTINGS_FILE" env-description:"Path to a YAML file with graffiti, gas limit, fee recipient and builder boost factor per validator or owner, reloaded on change"`
 }
 
 var cfg config
@@ -169,20 +180,10 @@ var StartNodeCmd = &cobra.Command{
 
 		usingLocalEvents := len(cfg.LocalEventsPath) != 0
 
-		if err := validateConfig(nodeStorage, networkConfig.AlanForkNetworkName(), usingLocalEvents); err != nil {
+		if err := validateConfig(nodeStorage, networkConfig.AlanForkNetworkName(), networkConfig.DefinitionHash, usingLocalEvents); err != nil {
 			logger.Fatal("failed to validate config", zap.Error(err))
 		}
 
-		ekmHashedKey, err := operatorPrivKey.EKMHash()
-		if err != nil {
-			logger.Fatal("could not get operator private key hash", zap.Error(err))
-		}
-
-		keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkConfig, ekmHashedKey)
-		if err != nil {
-			logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
-		}
-
 		cfg.P2pNetworkConfig.Ctx = cmd.Context()
 
 		slotTickerProvider := func() slotticker.SlotTicker {
@@ -198,22 +199,49 @@ var StartNodeCmd = &cobra.Command{
 
 		consensusClient := setupConsensusClient(logger, operatorDataStore, slotTickerProvider)
 
-		executionClient, err := executionclient.New(
-			cmd.Context(),
-			cfg.ExecutionClient.Addr,
-			ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+		keyManager, remoteSigner := setupKeyManager(logger, db, networkConfig, operatorPrivKey, consensusClient)
+		bumper, ok := keyManager.(backup.SlashingProtectionBumper)
+		if !ok {
+			logger.Fatal("key manager doesn't support bumping slashing protection")
+		}
+		ownShares := nodeStorage.Shares().List(nil, registrystorage.ByOperatorID(operatorDataStore.GetOperatorID()))
+		if _, err := backup.BumpRestoredSlashingProtection(logger, db, bumper, ownShares); err != nil {
+			logger.Fatal("could not bump slashing protection of restored database", zap.Error(err))
+		}
+		if pruner, ok := keyManager.(ekm.AttestationHistoryPruner); ok && cfg.KeyManager.AttestationHistory {
+			go ekm.PruneAttestationHistoryLoop(cmd.Context(), logger, pruner, consensusClient, networkConfig.Beacon)
+		}
+
+		if err := cfg.ExecutionClient.Validate(); err != nil {
+			logger.Fatal("invalid execution client config", zap.Error(err))
+		}
+		executionClientOpts := []executionclient.Option{
 			executionclient.WithLogger(logger),
 			executionclient.WithMetrics(metricsReporter),
 			executionclient.WithFollowDistance(executionclient.DefaultFollowDistance),
 			executionclient.WithConnectionTimeout(cfg.ExecutionClient.ConnectionTimeout),
 			executionclient.WithReconnectionInitialInterval(executionclient.DefaultReconnectionInitialInterval),
 			executionclient.WithReconnectionMaxInterval(executionclient.DefaultReconnectionMaxInterval),
+		}
+		if cfg.ExecutionClient.FollowMode != "" {
+			executionClientOpts = append(executionClientOpts, executionclient.WithFollowMode(cfg.ExecutionClient.FollowMode))
+		}
+		if cfg.ExecutionClient.FinalizedFromBeacon {
+			executionClientOpts = append(executionClientOpts, executionclient.WithFinalizedBlockProvider(consensusClient))
+		}
+
+		executionClient, err := executionclient.New(
+			cmd.Context(),
+			cfg.ExecutionClient.Addr,
+			ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+			executionClientOpts...,
 		)
 		if err != nil {
 			logger.Fatal("could not connect to execution client", zap.Error(err))
 		}
 
 		cfg.P2pNetworkConfig.NodeStorage = nodeStorage
+		cfg.P2pNetworkConfig.DB = db
 		cfg.P2pNetworkConfig.OperatorPubKeyHash = format.OperatorID(operatorData.PublicKey)
 		cfg.P2pNetworkConfig.OperatorDataStore = operatorDataStore
 		cfg.P2pNetworkConfig.FullNode = cfg.SSVOptions.ValidatorOptions.FullNode
@@ -255,10 +283,55 @@ var StartNodeCmd = &cobra.Command{
 			}
 		}
 
+		storageMap := ibftstorage.NewStoresFromRoles(db, ibftstorage.Roles...)
+
+		var decidedPruner *ibftstorage.Pruner
+		if !cfg.SSVOptions.DecidedRetention.Archive() {
+			if cfg.SSVOptions.ValidatorOptions.FullNode || cfg.SSVOptions.ValidatorOptions.Exporter {
+				decidedPruner, err = ibftstorage.NewPruner(logger.Named(logging.NameDecidedPruner), ibftstorage.PrunerOptions{
+					Network:   networkConfig,
+					Stores:    storageMap,
+					Retention: cfg.SSVOptions.DecidedRetention,
+				})
+				if err != nil {
+					logger.Fatal("failed to create decided history pruner", zap.Error(err))
+				}
+				go decidedPruner.Run(cmd.Context())
+			} else {
+				logger.Warn("decided history retention is only supported by full nodes and exporters")
+			}
+		}
+
 		cfg.P2pNetworkConfig.Metrics = metricsReporter
 		cfg.P2pNetworkConfig.MessageValidator = messageValidator
+		cfg.P2pNetworkConfig.DecidedStores = storageMap
 		cfg.SSVOptions.ValidatorOptions.MessageValidator = messageValidator
 
+		if cfg.SSVOptions.DoppelgangerEpochs > 0 {
+			if err := doppelganger.ValidateEpochs(cfg.SSVOptions.DoppelgangerEpochs); err != nil {
+				logger.Fatal("invalid doppelganger config", zap.Error(err))
+			}
+			if networkConfig.PastAlanFork() {
+				doppelgangerService := doppelganger.New(logger.Named(logging.NameDoppelganger), doppelganger.Options{
+					Network:        networkConfig,
+					BeaconNode:     consensusClient,
+					ValidatorStore: validatorStore.WithOperatorID(operatorDataStore.GetOperatorID),
+					OperatorID:     operatorDataStore.GetOperatorID,
+					Epochs:         cfg.SSVOptions.DoppelgangerEpochs,
+				})
+				cfg.P2pNetworkConfig.MessageObserver = doppelgangerService.ObserveMessage
+				cfg.SSVOptions.Doppelganger = doppelgangerService
+			} else {
+				logger.Warn("doppelganger protection is only supported after the Alan fork")
+			}
+		}
+
+		var dutyTracer *dutytracer.Tracer
+		if cfg.SSVOptions.DutyTraceSlots > 0 {
+			dutyTracer = dutytracer.New(cfg.SSVOptions.DutyTraceSlots)
+			cfg.SSVOptions.ValidatorOptions.DutyTracer = dutyTracer
+		}
+
 		p2pNetwork, genesisP2pNetwork := setupP2P(logger, db, metricsReporter)
 
 		cfg.SSVOptions.Context = cmd.Context()
@@ -289,27 +362,33 @@ var StartNodeCmd = &cobra.Command{
 			ws := exporterapi.NewWsServer(cmd.Context(), nil, http.NewServeMux(), cfg.WithPing)
 			cfg.SSVOptions.WS = ws
 			cfg.SSVOptions.WsAPIPort = cfg.WsAPIPort
-			cfg.SSVOptions.ValidatorOptions.NewDecidedHandler = decided.NewStreamPublisher(logger, ws)
+			cfg.SSVOptions.ValidatorOptions.NewDecidedHandler = decided.NewStreamPublisher(logger, ws, nodeStorage.ValidatorStore())
 		}
 
-		cfg.SSVOptions.ValidatorOptions.DutyRoles = []spectypes.BeaconRole{spectypes.BNRoleAttester} // TODO could be better to set in other place
-
-		storageRoles := []convert.RunnerRole{
-			convert.RoleCommittee,
-			convert.RoleAttester,
-			convert.RoleProposer,
-			convert.RoleSyncCommittee,
-			convert.RoleAggregator,
-			convert.RoleSyncCommitteeContribution,
-			convert.RoleValidatorRegistration,
-			convert.RoleVoluntaryExit,
+		var participationAnalytics *analytics.Analytics
+		if cfg.SSVOptions.AnalyticsEpochs > 0 {
+			if cfg.SSVOptions.ValidatorOptions.Exporter {
+				participationAnalytics = analytics.New(logger.Named(logging.NameAnalytics), analytics.Options{
+					Network:    networkConfig,
+					QBFTStores: storageMap,
+					Shares:     nodeStorage.Shares(),
+					Epochs:     cfg.SSVOptions.AnalyticsEpochs,
+				})
+				if newDecidedHandler := cfg.SSVOptions.ValidatorOptions.NewDecidedHandler; newDecidedHandler != nil {
+					cfg.SSVOptions.ValidatorOptions.NewDecidedHandler = func(msg qbftstorage.ParticipantsRangeEntry) {
+						newDecidedHandler(msg)
+						participationAnalytics.HandleDecided(msg)
+					}
+				} else {
+					cfg.SSVOptions.ValidatorOptions.NewDecidedHandler = participationAnalytics.HandleDecided
+				}
+				go participationAnalytics.Run(cmd.Context())
+			} else {
+				logger.Warn("participation analytics are only supported in exporter mode")
+			}
 		}
 
-		storageMap := ibftstorage.NewStores()
-
-		for _, storageRole := range storageRoles {
-			storageMap.Add(storageRole, ibftstorage.New(cfg.SSVOptions.ValidatorOptions.DB, storageRole.String()))
-		}
+		cfg.SSVOptions.ValidatorOptions.DutyRoles = []spectypes.BeaconRole{spectypes.BNRoleAttester} // TODO could be better to set in other place
 
 		genesisStorageRoles := []genesisspectypes.BeaconRole{
 			genesisspectypes.BNRoleAttester,
@@ -331,6 +410,14 @@ var StartNodeCmd = &cobra.Command{
 		cfg.SSVOptions.ValidatorOptions.Metrics = metricsReporter
 		cfg.SSVOptions.ValidatorOptions.Graffiti = []byte(cfg.Graffiti)
 		cfg.SSVOptions.ValidatorOptions.ValidatorStore = nodeStorage.ValidatorStore()
+		if cfg.ProposerSettingsFile != "" {
+			proposerSettings, err := proposersettings.New(logger.Named(logging.NameProposerSettings), cfg.ProposerSettingsFile, nodeStorage.ValidatorStore())
+			if err != nil {
+				logger.Fatal("could not load proposer settings", zap.Error(err))
+			}
+			go proposerSettings.Start(cmd.Context())
+			cfg.SSVOptions.ValidatorOptions.ProposerSettings = proposerSettings
+		}
 		cfg.SSVOptions.ValidatorOptions.OperatorSigner = types.NewSsvOperatorSigner(operatorPrivKey, operatorDataStore.GetOperatorID)
 		cfg.SSVOptions.Metrics = metricsReporter
 
@@ -347,19 +434,24 @@ var StartNodeCmd = &cobra.Command{
 			go startMetricsHandler(cmd.Context(), logger, db, metricsReporter, cfg.MetricsAPIPort, cfg.EnableProfile)
 		}
 
+		probedNodes := map[string]nodeprobe.Node{
+			"execution client": executionClient,
+
+			// Underlying options.Beacon's value implements nodeprobe.StatusChecker.
+			// However, as it uses spec's specssv.BeaconNode interface, avoiding type assertion requires modifications in spec.
+			// If options.Beacon doesn't implement nodeprobe.StatusChecker due to a mistake, this would panic early.
+			"consensus client": consensusClient,
+		}
+		if remoteSigner != nil {
+			probedNodes["remote signer"] = remoteSigner
+		}
+
 		nodeProber := nodeprobe.NewProber(
 			logger,
 			func() {
 				logger.Fatal("ethereum node(s) are either out of sync or down. Ensure the nodes are healthy to resume.")
 			},
-			map[string]nodeprobe.Node{
-				"execution client": executionClient,
-
-				// Underlying options.Beacon's value implements nodeprobe.StatusChecker.
-				// However, as it uses spec's specssv.BeaconNode interface, avoiding type assertion requires modifications in spec.
-				// If options.Beacon doesn't implement nodeprobe.StatusChecker due to a mistake, this would panic early.
-				"consensus client": consensusClient,
-			},
+			probedNodes,
 		)
 
 		nodeProber.Start(cmd.Context())
@@ -395,12 +487,25 @@ var StartNodeCmd = &cobra.Command{
 		}
 
 		if cfg.SSVAPIPort > 0 {
+			var duties *handlers.Duties
+			if dutyTracer != nil {
+				duties = &handlers.Duties{
+					Tracer: dutyTracer,
+					Shares: nodeStorage.Shares(),
+				}
+			}
+			var analyticsHandler *handlers.Analytics
+			if participationAnalytics != nil {
+				analyticsHandler = &handlers.Analytics{
+					Reporter: participationAnalytics,
+				}
+			}
 			apiServer := apiserver.New(
 				logger,
 				fmt.Sprintf(":%d", cfg.SSVAPIPort),
 				&handlers.Node{
 					// TODO: replace with narrower interface! (instead of accessing the entire PeersIndex)
-					ListenAddresses: []string{fmt.Sprintf("tcp://%s:%d", cfg.P2pNetworkConfig.HostAddress, cfg.P2pNetworkConfig.TCPPort), fmt.Sprintf("udp://%s:%d", cfg.P2pNetworkConfig.HostAddress, cfg.P2pNetworkConfig.UDPPort)},
+					ListenAddresses: p2pListenAddresses(cfg.P2pNetworkConfig),
 					PeersIndex:      p2pNetwork.(p2pv1.PeersIndexProvider).PeersIndex(),
 					Network:         p2pNetwork.(p2pv1.HostProvider).Host().Network(),
 					TopicIndex:      p2pNetwork.(handlers.TopicIndex),
@@ -409,6 +514,13 @@ var StartNodeCmd = &cobra.Command{
 				&handlers.Validators{
 					Shares: nodeStorage.Shares(),
 				},
+				&handlers.Exporter{
+					DomainType: networkConfig,
+					QBFTStores: storageMap,
+					Shares:     nodeStorage.Shares(),
+				},
+				duties,
+				analyticsHandler,
 			)
 			go func() {
 				err := apiServer.Run()
@@ -417,21 +529,68 @@ var StartNodeCmd = &cobra.Command{
 				}
 			}()
 		}
+		if cfg.AdminAPI.Port > 0 {
+			adminLogger := logger.Named(logging.NameAdminAPI)
+			admin := &handlers.Admin{
+				Logger:     adminLogger,
+				Validators: validatorCtrl,
+				Events:     eventSyncer,
+				Database:   backup.NewSnapshotter(adminLogger, db, nodeStorage),
+			}
+			if peers, ok := p2pNetwork.(handlers.TrustedPeersDialer); ok {
+				admin.Peers = peers
+			}
+			if decidedPruner != nil {
+				admin.Decided = decidedPruner
+			}
+			adminServer, err := apiserver.NewAdmin(
+				adminLogger,
+				fmt.Sprintf(":%d", cfg.AdminAPI.Port),
+				cfg.AdminAPI,
+				admin,
+			)
+			if err != nil {
+				logger.Fatal("failed to create admin API server", zap.Error(err))
+			}
+			go func() {
+				err := adminServer.Run()
+				if err != nil {
+					logger.Fatal("failed to start admin API server", zap.Error(err))
+				}
+			}()
+		}
 		if err := operatorNode.Start(logger); err != nil {
 			logger.Fatal("failed to start SSV node", zap.Error(err))
 		}
 	},
 }
 
-func validateConfig(nodeStorage operatorstorage.Storage, networkName string, usingLocalEvents bool) error {
+// p2pListenAddresses returns the addresses of the p2p transports on each of the configured host addresses.
+func p2pListenAddresses(p2pCfg p2pv1.Config) []string {
+	var addrs []string
+	for _, host := range strings.Split(p2pCfg.HostAddress, ",") {
+		host = strings.TrimSpace(host)
+		addrs = append(addrs,
+			"tcp://"+net.JoinHostPort(host, strconv.Itoa(int(p2pCfg.TCPPort))),
+			"udp://"+net.JoinHostPort(host, strconv.Itoa(int(p2pCfg.UDPPort))),
+		)
+		if p2pCfg.QUICPort != 0 {
+			addrs = append(addrs, "quic://"+net.JoinHostPort(host, strconv.Itoa(int(p2pCfg.QUICPort))))
+		}
+	}
+	return addrs
+}
+
+func validateConfig(nodeStorage operatorstorage.Storage, networkName, definitionHash string, usingLocalEvents bool) error {
 	storedConfig, foundConfig, err := nodeStorage.GetConfig(nil)
 	if err != nil {
 		return fmt.Errorf("failed to get stored config: %w", err)
 	}
 
 	currentConfig := &operatorstorage.ConfigLock{
-		NetworkName:      networkName,
-		UsingLocalEvents: usingLocalEvents,
+		NetworkName:           networkName,
+		UsingLocalEvents:      usingLocalEvents,
+		NetworkDefinitionHash: definitionHash,
 	}
 
 	if foundConfig {
@@ -481,8 +640,8 @@ func setupGlobal() (*zap.Logger, error) {
 	return zap.L(), nil
 }
 
-func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.BadgerDB, error) {
-	db, err := kv.New(logger, cfg.DBOptions)
+func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (kv.DB, error) {
+	db, err := kv.Open(logger, cfg.DBOptions)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to open db")
 	}
@@ -490,7 +649,7 @@ func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.Badger
 		if err := db.Close(); err != nil {
 			return errors.Wrap(err, "failed to close db")
 		}
-		db, err = kv.New(logger, cfg.DBOptions)
+		db, err = kv.Open(logger, cfg.DBOptions)
 		return errors.Wrap(err, "failed to reopen db")
 	}
 
@@ -503,12 +662,12 @@ func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.Badger
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to run migrations")
 	}
-	if applied == 0 {
+	if _, ok := db.(basedb.GarbageCollector); applied == 0 || !ok {
 		return db, nil
 	}
 
 	// If migrations were applied, we run a full garbage collection cycle
-	// to reclaim any space that may have been freed up.
+	// to reclaim any space that may have been freed up, if the storage engine needs it.
 	// Close & reopen the database to trigger any unknown internal
 	// startup/shutdown procedures that the storage engine may have.
 	start := time.Now()
@@ -519,7 +678,7 @@ func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.Badger
 	// Run a long garbage collection cycle with a timeout.
 	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Minute)
 	defer cancel()
-	if err := db.FullGC(ctx); err != nil {
+	if err := db.(basedb.GarbageCollector).FullGC(ctx); err != nil {
 		return nil, errors.Wrap(err, "failed to collect garbage")
 	}
 
@@ -593,9 +752,22 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey
 }
 
 func setupSSVNetwork(logger *zap.Logger) (networkconfig.NetworkConfig, error) {
-	networkConfig, err := networkconfig.GetNetworkConfigByName(cfg.SSVOptions.NetworkName)
-	if err != nil {
-		return networkconfig.NetworkConfig{}, err
+	var networkConfig networkconfig.NetworkConfig
+	var err error
+	if cfg.SSVOptions.NetworkConfigFile != "" {
+		networkConfig, err = networkconfig.LoadFromFile(cfg.SSVOptions.NetworkConfigFile)
+		if err != nil {
+			return networkconfig.NetworkConfig{}, fmt.Errorf("failed to load network config from %s: %w", cfg.SSVOptions.NetworkConfigFile, err)
+		}
+		logger.Info("loaded network config from file",
+			zap.String("path", cfg.SSVOptions.NetworkConfigFile),
+			zap.String("definition_hash", networkConfig.DefinitionHash),
+		)
+	} else {
+		networkConfig, err = networkconfig.GetNetworkConfigByName(cfg.SSVOptions.NetworkName)
+		if err != nil {
+			return networkconfig.NetworkConfig{}, err
+		}
 	}
 
 	if cfg.SSVOptions.CustomDomainType != "" {
@@ -675,6 +847,56 @@ func setupConsensusClient(
 	return cl
 }
 
+// setupKeyManager creates the share key manager for the configured backend.
+// The remote signer client is returned as well so its health can be probed, or nil for the local backend.
+func setupKeyManager(
+	logger *zap.Logger,
+	db basedb.Database,
+	networkConfig networkconfig.NetworkConfig,
+	operatorPrivKey keys.OperatorPrivateKey,
+	consensusClient *goclient.GoClient,
+) (ekm.KeyManager, *web3signer.Client) {
+	if err := cfg.KeyManager.Validate(); err != nil {
+		logger.Fatal("invalid key manager config", zap.Error(err))
+	}
+
+	var keyManagerOpts []ekm.Option
+	if cfg.KeyManager.AttestationHistory {
+		keyManagerOpts = append(keyManagerOpts, ekm.WithAttestationHistory())
+	}
+
+	if cfg.KeyManager.Backend == ekm.BackendRemote {
+		// Before the Alan fork, SSV messages are signed with share keys, which remote signers can't do.
+		if !networkConfig.PastAlanFork() {
+			logger.Fatal("remote signer can only be used after the Alan fork")
+		}
+
+		remoteSigner := web3signer.New(
+			cfg.KeyManager.RemoteSignerAddr,
+			web3signer.WithLogger(logger),
+			web3signer.WithRequestTimeout(cfg.KeyManager.RemoteSignerTimeout),
+			web3signer.WithAuthToken(cfg.KeyManager.RemoteSignerAuthToken),
+		)
+		keyManager, err := ekm.NewRemoteKeyManager(logger, db, networkConfig, remoteSigner, consensusClient, keyManagerOpts...)
+		if err != nil {
+			logger.Fatal("could not create remote key manager", zap.Error(err))
+		}
+		logger.Info("using remote signer", fields.Address(cfg.KeyManager.RemoteSignerAddr))
+		return keyManager, remoteSigner
+	}
+
+	ekmHashedKey, err := operatorPrivKey.EKMHash()
+	if err != nil {
+		logger.Fatal("could not get operator private key hash", zap.Error(err))
+	}
+
+	keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkConfig, ekmHashedKey, keyManagerOpts...)
+	if err != nil {
+		logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
+	}
+	return keyManager, nil
+}
+
 func setupEventHandling(
 	ctx context.Context,
 	logger *zap.Logger,
@@ -706,6 +928,7 @@ func setupEventHandling(
 		eventhandler.WithFullNode(),
 		eventhandler.WithLogger(logger),
 		eventhandler.WithMetrics(metricsReporter),
+		eventhandler.WithReorgJournal(cfg.ExecutionClient.ReorgDepth),
 	)
 	if err != nil {
 		logger.Fatal("failed to setup event data handler", zap.Error(err))
@@ -1,16 +1,28 @@
 package operator
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/ssvlabs/ssv/ekm"
 	"github.com/ssvlabs/ssv/networkconfig"
 	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	genesisssvtypes "github.com/ssvlabs/ssv/protocol/genesis/types"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/runner"
 	"github.com/ssvlabs/ssv/storage/basedb"
 	"github.com/ssvlabs/ssv/storage/kv"
+	"github.com/ssvlabs/ssv/utils/threshold"
 )
 
 func Test_verifyConfig(t *testing.T) {
@@ -29,7 +41,7 @@ func Test_verifyConfig(t *testing.T) {
 			NetworkName:      testNetworkName,
 			UsingLocalEvents: true,
 		}
-		require.NoError(t, validateConfig(nodeStorage, c.NetworkName, c.UsingLocalEvents))
+		require.NoError(t, validateConfig(nodeStorage, c.NetworkName, "", c.UsingLocalEvents))
 
 		storedConfig, found, err := nodeStorage.GetConfig(nil)
 		require.NoError(t, err)
@@ -45,7 +57,7 @@ func Test_verifyConfig(t *testing.T) {
 			UsingLocalEvents: true,
 		}
 		require.NoError(t, nodeStorage.SaveConfig(nil, c))
-		require.NoError(t, validateConfig(nodeStorage, c.NetworkName, c.UsingLocalEvents))
+		require.NoError(t, validateConfig(nodeStorage, c.NetworkName, "", c.UsingLocalEvents))
 
 		storedConfig, found, err := nodeStorage.GetConfig(nil)
 		require.NoError(t, err)
@@ -62,7 +74,7 @@ func Test_verifyConfig(t *testing.T) {
 		}
 		require.NoError(t, nodeStorage.SaveConfig(nil, c))
 		require.ErrorContains(t,
-			validateConfig(nodeStorage, testNetworkName, true),
+			validateConfig(nodeStorage, testNetworkName, "", true),
 			"incompatible config change: network mismatch. Stored network testnet:alan1 does not match current network testnet:alan. The database must be removed or reinitialized",
 		)
 
@@ -81,7 +93,7 @@ func Test_verifyConfig(t *testing.T) {
 		}
 		require.NoError(t, nodeStorage.SaveConfig(nil, c))
 		require.ErrorContains(t,
-			validateConfig(nodeStorage, testNetworkName, c.UsingLocalEvents),
+			validateConfig(nodeStorage, testNetworkName, "", c.UsingLocalEvents),
 			"incompatible config change: network mismatch. Stored network testnet:alan1 does not match current network testnet:alan. The database must be removed or reinitialized",
 		)
 
@@ -100,7 +112,7 @@ func Test_verifyConfig(t *testing.T) {
 		}
 		require.NoError(t, nodeStorage.SaveConfig(nil, c))
 		require.ErrorContains(t,
-			validateConfig(nodeStorage, c.NetworkName, true),
+			validateConfig(nodeStorage, c.NetworkName, "", true),
 			"incompatible config change: enabling local events is not allowed. The database must be removed or reinitialized",
 		)
 
@@ -119,7 +131,7 @@ func Test_verifyConfig(t *testing.T) {
 		}
 		require.NoError(t, nodeStorage.SaveConfig(nil, c))
 		require.ErrorContains(t,
-			validateConfig(nodeStorage, c.NetworkName, false),
+			validateConfig(nodeStorage, c.NetworkName, "", false),
 			"incompatible config change: disabling local events is not allowed. The database must be removed or reinitialized",
 		)
 
@@ -131,3 +143,80 @@ func Test_verifyConfig(t *testing.T) {
 		require.NoError(t, nodeStorage.DeleteConfig(nil))
 	})
 }
+
+func Test_setupSSVNetworkWithCustomBeaconNetwork(t *testing.T) {
+	logger := zap.New(zapcore.NewNopCore(), zap.WithFatalHook(zapcore.WriteThenPanic))
+
+	// A beacon chain unknown to the spec, with shorter slots and epochs.
+	definition := fmt.Sprintf(`Name: my-devnet
+Beacon:
+  Name: my-devnet
+  GenesisForkVersion: "0x10000038"
+  MinGenesisTime: %d
+  SlotDurationSec: 4
+  SlotsPerEpoch: 8
+GenesisDomainType: "0x00000601"
+AlanDomainType: "0x00000602"
+GenesisEpoch: 1
+AlanForkEpoch: 1
+RegistrySyncOffset: 1
+RegistryContractAddr: "0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA"
+DiscoveryProtocolID: ssvdv5
+Bootnodes:
+  - enr:-Li4QFIQzamdvTxGJhvcXG_DFmCeyggSffDnllY5DiU47pd_K_1MRnSaJimWtfKJ-MD46jUX9TwgW5Jqe0t4pH41RYWGAYuFnlyth2F0dG5ldHOIAAAAAAAAAACEZXRoMpD1pf1CAAAAAP__________gmlkgnY0gmlwhCLdu_SJc2VjcDI1NmsxoQN4v-N9zFYwEqzGPBBX37q24QPFvAVUtokIo1fblIsmTIN0Y3CCE4uDdWRwgg-j
+`, time.Now().Add(-time.Hour).Unix())
+	path := filepath.Join(t.TempDir(), "network.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(definition), 0600))
+
+	prevCfg, prevDomain := cfg, genesisssvtypes.GetDefaultDomain()
+	t.Cleanup(func() {
+		cfg = prevCfg
+		genesisssvtypes.SetDefaultDomain(prevDomain)
+	})
+	cfg.SSVOptions.NetworkConfigFile = path
+
+	networkConfig, err := setupSSVNetwork(logger)
+	require.NoError(t, err)
+	require.Equal(t, spectypes.BeaconNetwork("my-devnet"), networkConfig.Beacon.GetBeaconNetwork())
+
+	// Runners estimate epochs with the parameters of the network.
+	r, err := runner.NewValidatorRegistrationRunner(networkConfig.AlanDomainType, networkConfig.Beacon.GetNetwork(),
+		map[phase0.ValidatorIndex]*spectypes.Share{1: {}}, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Epoch(2), r.GetBaseRunner().BeaconNetwork.EstimatedEpochAtSlot(16))
+
+	// The key manager signs the duties of the network after the slashing protection of the share,
+	// which is bumped to the current epoch of the network, and refuses those too far into its future.
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	km, err := ekm.NewETHKeyManagerSigner(logger, db, networkConfig, "")
+	require.NoError(t, err)
+
+	threshold.Init()
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	require.NoError(t, km.AddShare(sk))
+	pk := sk.GetPublicKey().Serialize()
+
+	slot := networkConfig.Beacon.EstimatedCurrentSlot()
+	epoch := networkConfig.Beacon.EstimatedEpochAtSlot(slot)
+	attestation := func(target phase0.Epoch) *phase0.AttestationData {
+		return &phase0.AttestationData{
+			Slot:   slot,
+			Source: &phase0.Checkpoint{Epoch: target - 1},
+			Target: &phase0.Checkpoint{Epoch: target},
+		}
+	}
+	_, _, err = km.SignBeaconObject(attestation(epoch+1), phase0.Domain{}, pk, spectypes.DomainAttester)
+	require.NoError(t, err)
+	_, _, err = km.SignBeaconObject(attestation(epoch+1000), phase0.Domain{}, pk, spectypes.DomainAttester)
+	require.ErrorContains(t, err, "too far into the future")
+
+	block := *spectestingutils.TestingBeaconBlockCapella
+	block.Slot = slot + 1
+	_, _, err = km.SignBeaconObject(&block, phase0.Domain{}, pk, spectypes.DomainProposer)
+	require.NoError(t, err)
+	block.Slot = slot + 1000
+	_, _, err = km.SignBeaconObject(&block, phase0.Domain{}, pk, spectypes.DomainProposer)
+	require.ErrorContains(t, err, "too far into the future")
+}
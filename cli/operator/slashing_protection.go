@@ -0,0 +1,154 @@
+package operator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	global_config "github.com/ssvlabs/ssv/cli/config"
+	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/networkconfig"
+)
+
+// genesisValidatorsRoots are the genesis validators roots of the known beacon networks.
+var genesisValidatorsRoots = map[spectypes.BeaconNetwork]string{
+	spectypes.MainNetwork:    "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+	spectypes.HoleskyNetwork: "0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1",
+}
+
+var (
+	interchangeFile               string
+	genesisValidatorsRootOverride string
+)
+
+// SlashingProtectionCmd is the parent command of the slashing protection data commands
+var SlashingProtectionCmd = &cobra.Command{
+	Use:   "slashing-protection",
+	Short: "Import or export slashing protection data in the EIP-3076 interchange format",
+	Long: "Import or export the slashing protection data of the node's shares in the EIP-3076 interchange format.\n" +
+		"The node must be stopped while these commands run.",
+}
+
+var exportSlashingProtectionCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export slashing protection data to an EIP-3076 interchange file",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, signerStorage, root, closeDB := setupSlashingProtection(cmd)
+		defer closeDB()
+
+		interchange, err := ekm.ExportSlashingProtection(signerStorage, root)
+		if err != nil {
+			logger.Fatal("could not export slashing protection data", zap.Error(err))
+		}
+
+		data, err := json.MarshalIndent(interchange, "", "  ")
+		if err != nil {
+			logger.Fatal("could not encode interchange data", zap.Error(err))
+		}
+		if err := os.WriteFile(interchangeFile, data, 0600); err != nil {
+			logger.Fatal("could not write interchange file", zap.Error(err))
+		}
+
+		logger.Info("exported slashing protection data",
+			zap.String("file", interchangeFile),
+			zap.Int("keys", len(interchange.Data)))
+	},
+}
+
+var importSlashingProtectionCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import slashing protection data from an EIP-3076 interchange file",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, signerStorage, root, closeDB := setupSlashingProtection(cmd)
+		defer closeDB()
+
+		// nolint: gosec
+		data, err := os.ReadFile(interchangeFile)
+		if err != nil {
+			logger.Fatal("could not read interchange file", zap.Error(err))
+		}
+		interchange := &ekm.Interchange{}
+		if err := json.Unmarshal(data, interchange); err != nil {
+			logger.Fatal("could not decode interchange file", zap.Error(err))
+		}
+
+		imported, err := ekm.ImportSlashingProtection(signerStorage, interchange, root)
+		if err != nil {
+			logger.Fatal("could not import slashing protection data", zap.Int("imported_keys", imported), zap.Error(err))
+		}
+
+		logger.Info("imported slashing protection data",
+			zap.String("file", interchangeFile),
+			zap.Int("keys", imported))
+	},
+}
+
+// setupSlashingProtection opens the node's signer storage and determines the genesis validators root of its network,
+// the returned function closes the database.
+func setupSlashingProtection(cmd *cobra.Command) (*zap.Logger, ekm.Storage, phase0.Root, func()) {
+	logger, err := setupGlobal()
+	if err != nil {
+		log.Fatal("could not create logger", err)
+	}
+
+	networkConfig, err := setupSSVNetwork(logger)
+	if err != nil {
+		logger.Fatal("could not setup network", zap.Error(err))
+	}
+
+	root, err := genesisValidatorsRoot(networkConfig)
+	if err != nil {
+		logger.Fatal("could not determine genesis validators root", zap.Error(err))
+	}
+
+	cfg.DBOptions.Ctx = cmd.Context()
+	db, err := setupDB(logger, networkConfig.Beacon.GetNetwork())
+	if err != nil {
+		logger.Fatal("could not setup db", zap.Error(err))
+	}
+	closeDB := func() {
+		if err := db.Close(); err != nil {
+			logger.Error("could not close db", zap.Error(err))
+		}
+	}
+
+	return logger, ekm.NewSignerStorage(db, networkConfig.Beacon.GetNetwork(), logger), root, closeDB
+}
+
+func genesisValidatorsRoot(networkConfig networkconfig.NetworkConfig) (phase0.Root, error) {
+	rootHex := genesisValidatorsRootOverride
+	if rootHex == "" {
+		known, ok := genesisValidatorsRoots[networkConfig.Beacon.GetBeaconNetwork()]
+		if !ok {
+			return phase0.Root{}, fmt.Errorf("unknown genesis validators root of network %s, please specify it", networkConfig.Beacon.GetBeaconNetwork())
+		}
+		rootHex = known
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(rootHex, "0x"))
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("invalid genesis validators root: %w", err)
+	}
+	if len(b) != len(phase0.Root{}) {
+		return phase0.Root{}, fmt.Errorf("invalid genesis validators root length %d", len(b))
+	}
+	return phase0.Root(b), nil
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, SlashingProtectionCmd)
+
+	SlashingProtectionCmd.PersistentFlags().StringVar(&interchangeFile, "file", "./slashing_protection.json", "Path to the EIP-3076 interchange file")
+	SlashingProtectionCmd.PersistentFlags().StringVar(&genesisValidatorsRootOverride, "genesis-validators-root", "", "Genesis validators root of the network, required for networks other than mainnet and holesky")
+
+	SlashingProtectionCmd.AddCommand(exportSlashingProtectionCmd)
+	SlashingProtectionCmd.AddCommand(importSlashingProtectionCmd)
+}
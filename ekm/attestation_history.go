@@ -0,0 +1,187 @@
+package ekm
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	slashingprotection "github.com/bloxapp/eth2-key-manager/slashing_protection"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+// AttestationRecord is the source and target epochs of a signed attestation.
+type AttestationRecord struct {
+	Source phase0.Epoch
+	Target phase0.Epoch
+}
+
+// historyProtection is a stricter slashing protector which, on top of the highest attestation checks,
+// keeps the source and target epochs of every attestation signed since finality
+// and rejects attestations which double vote, surround or are surrounded by any of them.
+type historyProtection struct {
+	*slashingprotection.NormalProtection
+	store Storage
+	mu    sync.Mutex
+}
+
+func newHistoryProtection(store Storage) *historyProtection {
+	return &historyProtection{
+		NormalProtection: slashingprotection.NewNormalProtection(store),
+		store:            store,
+	}
+}
+
+// IsSlashableAttestation checks the attestation against the whole history before the highest attestation.
+func (p *historyProtection) IsSlashableAttestation(pubKey []byte, attestation *phase0.AttestationData) (*core.AttestationSlashStatus, error) {
+	if attestation == nil {
+		return nil, errors.New("attestation data could not be nil")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	history, err := p.store.RetrieveAttestationHistory(pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve attestation history")
+	}
+	if status := checkAttestationHistory(history, attestation); status != "" {
+		return &core.AttestationSlashStatus{
+			Attestation: attestation,
+			Status:      status,
+		}, nil
+	}
+
+	return p.NormalProtection.IsSlashableAttestation(pubKey, attestation)
+}
+
+// UpdateHighestAttestation records the attestation in the history and updates the highest attestation.
+func (p *historyProtection) UpdateHighestAttestation(pubKey []byte, attestation *phase0.AttestationData) error {
+	if attestation == nil {
+		return errors.New("attestation data could not be nil")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	history, err := p.store.RetrieveAttestationHistory(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve attestation history")
+	}
+
+	record := AttestationRecord{Source: attestation.Source.Epoch, Target: attestation.Target.Epoch}
+	i := sort.Search(len(history), func(i int) bool {
+		return history[i].Target >= record.Target
+	})
+	if i == len(history) || history[i] != record {
+		history = append(history, AttestationRecord{})
+		copy(history[i+1:], history[i:])
+		history[i] = record
+		if err := p.store.SaveAttestationHistory(pubKey, history); err != nil {
+			return errors.Wrap(err, "could not save attestation history")
+		}
+	}
+
+	return p.NormalProtection.UpdateHighestAttestation(pubKey, attestation)
+}
+
+// prune removes the attestations with a target below the finalized epoch from all histories.
+// New attestations can't have a source below the finalized epoch, so they can't conflict with them.
+func (p *historyProtection) prune(finalizedEpoch phase0.Epoch) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pubKeys, err := p.store.ListAttestationHistoryPubKeys()
+	if err != nil {
+		return err
+	}
+	for _, pubKey := range pubKeys {
+		history, err := p.store.RetrieveAttestationHistory(pubKey)
+		if err != nil {
+			return errors.Wrap(err, "could not retrieve attestation history")
+		}
+
+		// History is sorted by target epoch.
+		i := sort.Search(len(history), func(i int) bool {
+			return history[i].Target >= finalizedEpoch
+		})
+		if i == 0 {
+			continue
+		}
+		if err := p.store.SaveAttestationHistory(pubKey, history[i:]); err != nil {
+			return errors.Wrap(err, "could not save attestation history")
+		}
+	}
+	return nil
+}
+
+// checkAttestationHistory returns the kind of slashable vote the attestation makes
+// against the given history, or an empty string if it makes none.
+// Like the highest attestation check, it is strict and treats any other vote for the same target as a double vote,
+// because signing roots aren't kept.
+func checkAttestationHistory(history []AttestationRecord, attestation *phase0.AttestationData) core.VoteDetectionType {
+	source, target := attestation.Source.Epoch, attestation.Target.Epoch
+	for _, record := range history {
+		switch {
+		case target == record.Target:
+			return core.DoubleVote
+		case source < record.Source && target > record.Target:
+			return core.SurroundingVote
+		case source > record.Source && target < record.Target:
+			return core.SurroundedVote
+		}
+	}
+	return ""
+}
+
+// AttestationHistoryPruner prunes attestation histories below the finalized epoch.
+type AttestationHistoryPruner interface {
+	PruneAttestationHistory(finalizedEpoch phase0.Epoch) error
+}
+
+// FinalizedEpochProvider provides the latest finalized epoch.
+type FinalizedEpochProvider interface {
+	FinalizedEpoch(ctx context.Context) (phase0.Epoch, error)
+}
+
+// PruneAttestationHistoryLoop prunes attestation histories every epoch until the context is done.
+func PruneAttestationHistoryLoop(
+	ctx context.Context,
+	logger *zap.Logger,
+	pruner AttestationHistoryPruner,
+	finality FinalizedEpochProvider,
+	network beacon.BeaconNetwork,
+) {
+	ticker := time.NewTicker(network.SlotDurationSec() * time.Duration(network.SlotsPerEpoch()))
+	defer ticker.Stop()
+
+	var lastPruned phase0.Epoch
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		finalizedEpoch, err := finality.FinalizedEpoch(ctx)
+		if err != nil {
+			logger.Warn("could not get finalized epoch to prune attestation history", zap.Error(err))
+			continue
+		}
+		if finalizedEpoch <= lastPruned {
+			continue
+		}
+		if err := pruner.PruneAttestationHistory(finalizedEpoch); err != nil {
+			logger.Error("could not prune attestation history", zap.Error(err))
+			continue
+		}
+		lastPruned = finalizedEpoch
+		logger.Debug("pruned attestation history", fields.Epoch(finalizedEpoch))
+	}
+}
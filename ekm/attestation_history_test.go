@@ -0,0 +1,194 @@
+package ekm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/utils"
+	"github.com/ssvlabs/ssv/utils/threshold"
+)
+
+func testAttestation(source, target phase0.Epoch) *phase0.AttestationData {
+	return &phase0.AttestationData{
+		Slot:   phase0.Slot(target) * 32,
+		Source: &phase0.Checkpoint{Epoch: source},
+		Target: &phase0.Checkpoint{Epoch: target},
+	}
+}
+
+// TestSlashingScenarios runs the slashing scenarios of the e2e slashinginterceptor against the local
+// and remote key managers, with and without the attestation history.
+func TestSlashingScenarios(t *testing.T) {
+	keyManagers := []struct {
+		name string
+		new  func(t *testing.T, opts ...Option) KeyManager
+	}{
+		{
+			name: "local",
+			new: func(t *testing.T, opts ...Option) KeyManager {
+				threshold.Init()
+				logger := logging.TestLogger(t)
+				db, err := getBaseStorage(logger)
+				require.NoError(t, err)
+				t.Cleanup(func() { _ = db.Close() })
+				network := networkconfig.NetworkConfig{
+					Beacon:            utils.SetupMockBeaconNetwork(t, nil),
+					GenesisDomainType: networkconfig.TestNetwork.DomainType(),
+					AlanDomainType:    networkconfig.TestNetwork.DomainType(),
+				}
+				km, err := NewETHKeyManagerSigner(logger, db, network, "", opts...)
+				require.NoError(t, err)
+				return km
+			},
+		},
+		{
+			name: "remote",
+			new: func(t *testing.T, opts ...Option) KeyManager {
+				km, _, _ := testRemoteKeyManager(t, opts...)
+				return km
+			},
+		},
+	}
+
+	// The fork of the remote key manager's schedule at the signed epochs.
+	forkVersion, genesisValidatorsRoot := phase0.Version{0x03}, phase0.Root{0x01, 0x02, 0x03}
+	attesterDomain, err := spectypes.ComputeETHDomain(spectypes.DomainAttester, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	proposerDomain, err := spectypes.ComputeETHDomain(spectypes.DomainProposer, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+
+	const startEpoch = 10
+	for _, keyManager := range keyManagers {
+		for _, history := range []bool{false, true} {
+			var opts []Option
+			if history {
+				opts = append(opts, WithAttestationHistory())
+			}
+			newShare := func(t *testing.T) (KeyManager, []byte) {
+				km := keyManager.new(t, opts...)
+				sk := &bls.SecretKey{}
+				sk.SetByCSPRNG()
+				require.NoError(t, km.AddShare(sk))
+				return km, sk.GetPublicKey().Serialize()
+			}
+
+			t.Run(fmt.Sprintf("%s/history=%t", keyManager.name, history), func(t *testing.T) {
+				for _, test := range attesterSlashingTests {
+					t.Run(test.Name, func(t *testing.T) {
+						km, pk := newShare(t)
+
+						first := &phase0.AttestationData{
+							Slot:            startEpoch * 32,
+							BeaconBlockRoot: phase0.Root{0x01},
+							Source:          &phase0.Checkpoint{Epoch: startEpoch - 1, Root: phase0.Root{0x02}},
+							Target:          &phase0.Checkpoint{Epoch: startEpoch, Root: phase0.Root{0x03}},
+						}
+						_, _, err := km.SignBeaconObject(first, attesterDomain, pk, spectypes.DomainAttester)
+						require.NoError(t, err)
+
+						second := &phase0.AttestationData{
+							Slot:            (startEpoch + startEndEpochsDiff) * 32,
+							BeaconBlockRoot: first.BeaconBlockRoot,
+							Source:          &phase0.Checkpoint{Epoch: first.Source.Epoch, Root: first.Source.Root},
+							Target:          &phase0.Checkpoint{Epoch: first.Target.Epoch, Root: first.Target.Root},
+						}
+						require.NoError(t, test.Apply(second))
+						_, _, err = km.SignBeaconObject(second, attesterDomain, pk, spectypes.DomainAttester)
+						if test.Slashable {
+							require.ErrorContains(t, err, "slashable attestation")
+						} else {
+							require.NoError(t, err)
+						}
+					})
+				}
+
+				for _, test := range proposerSlashingTests {
+					t.Run(test.Name, func(t *testing.T) {
+						km, pk := newShare(t)
+
+						first := &capella.BeaconBlock{
+							Slot:       startEpoch * 32,
+							ParentRoot: phase0.Root{0x04},
+							StateRoot:  phase0.Root{0x05},
+							Body:       testingCapellaBlockBody(),
+						}
+						_, _, err := km.SignBeaconObject(first, proposerDomain, pk, spectypes.DomainProposer)
+						require.NoError(t, err)
+
+						second := *first
+						require.NoError(t, test.Apply(&spec.VersionedBeaconBlock{Version: spec.DataVersionCapella, Capella: &second}))
+						_, _, err = km.SignBeaconObject(&second, proposerDomain, pk, spectypes.DomainProposer)
+						if test.Slashable {
+							require.ErrorContains(t, err, "slashable proposal")
+						} else {
+							require.NoError(t, err)
+						}
+					})
+				}
+			})
+		}
+	}
+}
+
+func TestHistoryProtection(t *testing.T) {
+	storage, done := newStorageForTest(t)
+	defer done()
+	protection := newHistoryProtection(storage)
+
+	pk := _byteArray("8e80066551a81b318258709edaf7dd1f63cd686a0e4db8b29bbb7acfe65608677af5a527d9448ee47835485e02b50bc0")
+	require.NoError(t, storage.SaveHighestAttestation(pk, testAttestation(0, 1)))
+
+	for _, att := range []*phase0.AttestationData{testAttestation(2, 3), testAttestation(6, 7), testAttestation(3, 6)} {
+		require.NoError(t, protection.UpdateHighestAttestation(pk, att))
+	}
+	history, err := storage.RetrieveAttestationHistory(pk)
+	require.NoError(t, err)
+	require.Equal(t, []AttestationRecord{{2, 3}, {3, 6}, {6, 7}}, history, "history is sorted by target")
+
+	// Once the highest attestation no longer covers the history, for example after it was reset,
+	// the history still catches conflicting votes.
+	require.NoError(t, storage.SaveHighestAttestation(pk, testAttestation(0, 1)))
+	tests := []struct {
+		attestation *phase0.AttestationData
+		status      core.VoteDetectionType
+	}{
+		{testAttestation(4, 7), core.DoubleVote},
+		{testAttestation(1, 5), core.SurroundingVote},
+		{testAttestation(4, 5), core.SurroundedVote},
+		{testAttestation(7, 8), ""},
+	}
+	for _, test := range tests {
+		status, err := protection.IsSlashableAttestation(pk, test.attestation)
+		require.NoError(t, err)
+		if test.status == "" {
+			require.Nil(t, status)
+			continue
+		}
+		require.NotNil(t, status)
+		require.Equal(t, test.status, status.Status)
+	}
+
+	// Attestations targeting epochs below the finalized epoch are pruned.
+	require.NoError(t, protection.prune(4))
+	history, err = storage.RetrieveAttestationHistory(pk)
+	require.NoError(t, err)
+	require.Equal(t, []AttestationRecord{{3, 6}, {6, 7}}, history)
+
+	require.NoError(t, protection.prune(8))
+	history, err = storage.RetrieveAttestationHistory(pk)
+	require.NoError(t, err)
+	require.Empty(t, history)
+	pubKeys, err := storage.ListAttestationHistoryPubKeys()
+	require.NoError(t, err)
+	require.Empty(t, pubKeys)
+}
@@ -0,0 +1,52 @@
+package ekm
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// BackendLocal keeps share keys in the node's database and signs locally.
+	BackendLocal = "local"
+	// BackendRemote keeps share keys in a Web3Signer-compatible remote signer.
+	BackendRemote = "remote"
+)
+
+// KeyManagerOptions contains configuration related to the share key manager.
+type KeyManagerOptions struct {
+	Backend               string        `yaml:"Backend" env:"KEY_MANAGER_BACKEND" env-default:"local" env-description:"Where share keys are kept and signed with: 'local' (node database) or 'remote' (Web3Signer-compatible remote signer)"`
+	RemoteSignerAddr      string        `yaml:"RemoteSignerAddr" env:"REMOTE_SIGNER_ADDR" env-description:"Remote signer URL, required by the 'remote' backend"`
+	RemoteSignerAuthToken string        `yaml:"RemoteSignerAuthToken" env:"REMOTE_SIGNER_AUTH_TOKEN" env-description:"Bearer token for the remote signer's keymanager API"`
+	RemoteSignerTimeout   time.Duration `yaml:"RemoteSignerTimeout" env:"REMOTE_SIGNER_TIMEOUT" env-default:"5s" env-description:"Remote signer request timeout"`
+	AttestationHistory    bool          `yaml:"AttestationHistory" env:"KEY_MANAGER_ATTESTATION_HISTORY" env-default:"false" env-description:"Keep the history of signed attestations since finality and reject surround and double votes against all of it"`
+}
+
+// Validate returns an error if the options are inconsistent.
+func (o KeyManagerOptions) Validate() error {
+	switch o.Backend {
+	case BackendLocal, "":
+		return nil
+	case BackendRemote:
+		if o.RemoteSignerAddr == "" {
+			return fmt.Errorf("remote signer address is required by the %q backend", BackendRemote)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown key manager backend %q", o.Backend)
+	}
+}
+
+// Option configures a KeyManager.
+type Option func(*options)
+
+type options struct {
+	attestationHistory bool
+}
+
+// WithAttestationHistory enables the stricter slashing protection,
+// which checks attestations against the history of signed attestations since finality.
+func WithAttestationHistory() Option {
+	return func(o *options) {
+		o.attestationHistory = true
+	}
+}
@@ -0,0 +1,135 @@
+package ekm
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/eth2-key-manager/signer"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+// customNetworkSigner signs attestations and blocks of beacon networks with custom parameters.
+// It does the same checks as the key manager's signer, which estimates the current epoch
+// from the parameters of its own networks and can't be given those of a custom network.
+type customNetworkSigner struct {
+	wallet     core.Wallet
+	protection core.SlashingProtector
+	network    beacon.BeaconNetwork
+
+	attestationLock sync.Mutex
+	proposalLock    sync.Mutex
+}
+
+func newCustomNetworkSigner(wallet core.Wallet, protection core.SlashingProtector, network beacon.BeaconNetwork) *customNetworkSigner {
+	return &customNetworkSigner{
+		wallet:     wallet,
+		protection: protection,
+		network:    network,
+	}
+}
+
+// maxValidSlot returns the latest slot which isn't too far into the future to sign.
+func (s *customNetworkSigner) maxValidSlot() phase0.Slot {
+	return s.network.EstimatedSlotAtTime(time.Now().Unix() + signer.FarFutureMaxValidEpoch)
+}
+
+func (s *customNetworkSigner) signAttestation(data *phase0.AttestationData, domain phase0.Domain, pk []byte) ([]byte, []byte, error) {
+	account, err := s.account(pk)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.attestationLock.Lock()
+	defer s.attestationLock.Unlock()
+
+	maxValidEpoch := s.network.EstimatedEpochAtSlot(s.maxValidSlot())
+	if data.Target.Epoch > maxValidEpoch {
+		return nil, nil, errors.New("target epoch too far into the future")
+	}
+	if data.Source.Epoch > maxValidEpoch {
+		return nil, nil, errors.New("source epoch too far into the future")
+	}
+
+	if val, err := s.protection.IsSlashableAttestation(pk, data); err != nil || val != nil {
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errors.Errorf("slashable attestation (%s), not signing", val.Status)
+	}
+	if err := s.protection.UpdateHighestAttestation(pk, data); err != nil {
+		return nil, nil, err
+	}
+
+	return s.sign(account, data, domain)
+}
+
+func (s *customNetworkSigner) signBlock(obj ssz.HashRoot, domain phase0.Domain, pk []byte) ([]byte, []byte, error) {
+	var slot phase0.Slot
+	switch v := obj.(type) {
+	case *capella.BeaconBlock:
+		slot = v.Slot
+	case *deneb.BeaconBlock:
+		slot = v.Slot
+	case *apiv1capella.BlindedBeaconBlock:
+		slot = v.Slot
+	case *apiv1deneb.BlindedBeaconBlock:
+		slot = v.Slot
+	default:
+		return nil, nil, fmt.Errorf("obj type is unknown: %T", obj)
+	}
+
+	account, err := s.account(pk)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.proposalLock.Lock()
+	defer s.proposalLock.Unlock()
+
+	if slot > s.maxValidSlot() {
+		return nil, nil, errors.New("proposed block slot too far into the future")
+	}
+
+	status, err := s.protection.IsSlashableProposal(pk, slot)
+	if err != nil {
+		return nil, nil, err
+	}
+	if status.Status != core.ValidProposal {
+		return nil, nil, errors.Errorf("slashable proposal (%s), not signing", status.Status)
+	}
+	if err := s.protection.UpdateHighestProposal(pk, slot); err != nil {
+		return nil, nil, err
+	}
+
+	return s.sign(account, obj, domain)
+}
+
+func (s *customNetworkSigner) account(pk []byte) (core.ValidatorAccount, error) {
+	if pk == nil {
+		return nil, errors.New("account was not supplied")
+	}
+	return s.wallet.AccountByPublicKey(hex.EncodeToString(pk))
+}
+
+func (s *customNetworkSigner) sign(account core.ValidatorAccount, obj ssz.HashRoot, domain phase0.Domain) ([]byte, []byte, error) {
+	root, err := signer.ComputeETHSigningRoot(obj, domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get signing root")
+	}
+	sig, err := account.ValidationKeySign(root[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, root[:], nil
+}
@@ -17,7 +17,6 @@ import (
 	eth2keymanager "github.com/bloxapp/eth2-key-manager"
 	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/eth2-key-manager/signer"
-	slashingprotection "github.com/bloxapp/eth2-key-manager/slashing_protection"
 	"github.com/bloxapp/eth2-key-manager/wallets"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/herumi/bls-eth-go-binary/bls"
@@ -30,24 +29,18 @@ import (
 	"github.com/ssvlabs/ssv/storage/basedb"
 )
 
-const (
-	// minSPAttestationEpochGap is the minimum epoch distance used for slashing protection in attestations.
-	// It defines the smallest allowable gap between the source and target epochs in an existing attestation
-	// and those in a new attestation, helping to prevent slashable offenses.
-	minSPAttestationEpochGap = phase0.Epoch(0)
-	// minSPProposalSlotGap is the minimum slot distance used for slashing protection in block proposals.
-	// It defines the smallest allowable gap between the current slot and the slot of a new block proposal,
-	// helping to prevent slashable offenses.
-	minSPProposalSlotGap = phase0.Slot(0)
-)
-
 type ethKeyManagerSigner struct {
-	wallet            core.Wallet
-	walletLock        *sync.RWMutex
-	signer            signer.ValidatorSigner
-	storage           Storage
-	domain            spectypes.DomainType
-	slashingProtector core.SlashingProtector
+	wallet     core.Wallet
+	walletLock *sync.RWMutex
+	signer     signer.ValidatorSigner
+	storage    Storage
+	domain     spectypes.DomainType
+	*slashingProtector
+
+	// customNetwork is the beacon network if it's defined with custom parameters, nil otherwise.
+	// The key manager only knows the parameters of its own networks, so attestations and blocks
+	// of custom networks are signed by customNetworkSigner instead.
+	customNetwork *customNetworkSigner
 }
 
 // StorageProvider provides the underlying KeyManager storage.
@@ -70,7 +63,7 @@ type KeyManager interface {
 }
 
 // NewETHKeyManagerSigner returns a new instance of ethKeyManagerSigner
-func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network networkconfig.NetworkConfig, encryptionKey string) (KeyManager, error) {
+func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network networkconfig.NetworkConfig, encryptionKey string, opts ...Option) (KeyManager, error) {
 	signerStore := NewSignerStorage(db, network.Beacon, logger)
 	if encryptionKey != "" {
 		err := signerStore.SetEncryptionKey(encryptionKey)
@@ -97,31 +90,27 @@ func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network netw
 		}
 	}
 
-	slashingProtector := slashingprotection.NewNormalProtection(signerStore)
+	slashingProtector := newProtection(signerStore, opts...)
 	beaconSigner := signer.NewSimpleSigner(wallet, slashingProtector, core.Network(network.Beacon.GetBeaconNetwork()))
 
-	return &ethKeyManagerSigner{
+	km := &ethKeyManagerSigner{
 		wallet:            wallet,
 		walletLock:        &sync.RWMutex{},
 		signer:            beaconSigner,
 		storage:           signerStore,
 		domain:            network.DomainType(),
-		slashingProtector: slashingProtector,
-	}, nil
+		slashingProtector: newSlashingProtector(signerStore, slashingProtector),
+	}
+	if network.Beacon.GetNetwork().Params != nil {
+		km.customNetwork = newCustomNetworkSigner(wallet, slashingProtector, network.Beacon)
+	}
+	return km, nil
 }
 
 func (km *ethKeyManagerSigner) ListAccounts() ([]core.ValidatorAccount, error) {
 	return km.storage.ListAccounts()
 }
 
-func (km *ethKeyManagerSigner) RetrieveHighestAttestation(pubKey []byte) (*phase0.AttestationData, bool, error) {
-	return km.storage.RetrieveHighestAttestation(pubKey)
-}
-
-func (km *ethKeyManagerSigner) RetrieveHighestProposal(pubKey []byte) (phase0.Slot, bool, error) {
-	return km.storage.RetrieveHighestProposal(pubKey)
-}
-
 func (km *ethKeyManagerSigner) SignBeaconObject(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType) (spectypes.Signature, [32]byte, error) {
 	sig, rootSlice, err := km.signBeaconObject(obj, domain, pk, domainType)
 	if err != nil {
@@ -142,8 +131,14 @@ func (km *ethKeyManagerSigner) signBeaconObject(obj ssz.HashRoot, domain phase0.
 		if !ok {
 			return nil, nil, errors.New("could not cast obj to AttestationData")
 		}
+		if km.customNetwork != nil {
+			return km.customNetwork.signAttestation(data, domain, pk)
+		}
 		return km.signer.SignBeaconAttestation(data, domain, pk)
 	case spectypes.DomainProposer:
+		if km.customNetwork != nil {
+			return km.customNetwork.signBlock(obj, domain, pk)
+		}
 		switch v := obj.(type) {
 		case *capella.BeaconBlock:
 			vBlock := &spec.VersionedBeaconBlock{
@@ -234,28 +229,6 @@ func (km *ethKeyManagerSigner) signBeaconObject(obj ssz.HashRoot, domain phase0.
 	}
 }
 
-func (km *ethKeyManagerSigner) IsAttestationSlashable(pk spectypes.ShareValidatorPK, data *phase0.AttestationData) error {
-	if val, err := km.slashingProtector.IsSlashableAttestation(pk, data); err != nil || val != nil {
-		if err != nil {
-			return err
-		}
-		return errors.Errorf("slashable attestation (%s), not signing", val.Status)
-	}
-	return nil
-}
-
-func (km *ethKeyManagerSigner) IsBeaconBlockSlashable(pk []byte, slot phase0.Slot) error {
-	status, err := km.slashingProtector.IsSlashableProposal(pk, slot)
-	if err != nil {
-		return err
-	}
-	if status.Status != core.ValidProposal {
-		return errors.Errorf("slashable proposal (%s), not signing", status.Status)
-	}
-
-	return nil
-}
-
 func (km *ethKeyManagerSigner) SignRoot(data spectypes.Root, sigType spectypes.SignatureType, pk []byte) (spectypes.Signature, error) {
 	km.walletLock.RLock()
 	defer km.walletLock.RUnlock()
@@ -317,6 +290,9 @@ func (km *ethKeyManagerSigner) RemoveShare(pubKey string) error {
 		if err := km.storage.RemoveHighestProposal(pkDecoded); err != nil {
 			return errors.Wrap(err, "could not remove highest proposal")
 		}
+		if err := km.storage.RemoveAttestationHistory(pkDecoded); err != nil {
+			return errors.Wrap(err, "could not remove attestation history")
+		}
 		if err := km.wallet.DeleteAccountByPublicKey(pubKey); err != nil {
 			return errors.Wrap(err, "could not delete share")
 		}
@@ -324,102 +300,6 @@ func (km *ethKeyManagerSigner) RemoveShare(pubKey string) error {
 	return nil
 }
 
-// BumpSlashingProtection updates the slashing protection data for a given public key.
-func (km *ethKeyManagerSigner) BumpSlashingProtection(pubKey []byte) error {
-	currentSlot := km.storage.BeaconNetwork().EstimatedCurrentSlot()
-
-	// Update highest attestation data for slashing protection.
-	if err := km.updateHighestAttestation(pubKey, currentSlot); err != nil {
-		return err
-	}
-
-	// Update highest proposal data for slashing protection.
-	if err := km.updateHighestProposal(pubKey, currentSlot); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// updateHighestAttestation updates the highest attestation data for slashing protection.
-func (km *ethKeyManagerSigner) updateHighestAttestation(pubKey []byte, slot phase0.Slot) error {
-	// Retrieve the highest attestation data stored for the given public key.
-	retrievedHighAtt, found, err := km.RetrieveHighestAttestation(pubKey)
-	if err != nil {
-		return fmt.Errorf("could not retrieve highest attestation: %w", err)
-	}
-
-	currentEpoch := km.storage.BeaconNetwork().EstimatedEpochAtSlot(slot)
-	minimalSP := km.computeMinimalAttestationSP(currentEpoch)
-
-	// Check if the retrieved highest attestation data is valid and not outdated.
-	if found && retrievedHighAtt != nil {
-		if retrievedHighAtt.Source.Epoch >= minimalSP.Source.Epoch || retrievedHighAtt.Target.Epoch >= minimalSP.Target.Epoch {
-			return nil
-		}
-	}
-
-	// At this point, either the retrieved attestation data was not found, or it was outdated.
-	// In either case, we update it to the minimal slashing protection data.
-	if err := km.storage.SaveHighestAttestation(pubKey, minimalSP); err != nil {
-		return fmt.Errorf("could not save highest attestation: %w", err)
-	}
-
-	return nil
-}
-
-// updateHighestProposal updates the highest proposal slot for slashing protection.
-func (km *ethKeyManagerSigner) updateHighestProposal(pubKey []byte, slot phase0.Slot) error {
-	// Retrieve the highest proposal slot stored for the given public key.
-	retrievedHighProp, found, err := km.RetrieveHighestProposal(pubKey)
-	if err != nil {
-		return fmt.Errorf("could not retrieve highest proposal: %w", err)
-	}
-
-	minimalSPSlot := km.computeMinimalProposerSP(slot)
-
-	// Check if the retrieved highest proposal slot is valid and not outdated.
-	if found && retrievedHighProp != 0 {
-		if retrievedHighProp >= minimalSPSlot {
-			return nil
-		}
-	}
-
-	// At this point, either the retrieved proposal slot was not found, or it was outdated.
-	// In either case, we update it to the minimal slashing protection slot.
-	if err := km.storage.SaveHighestProposal(pubKey, minimalSPSlot); err != nil {
-		return fmt.Errorf("could not save highest proposal: %w", err)
-	}
-
-	return nil
-}
-
-// computeMinimalAttestationSP calculates the minimal safe attestation data for slashing protection.
-// It takes the current epoch as an argument and returns an AttestationData object with the minimal safe source and target epochs.
-func (km *ethKeyManagerSigner) computeMinimalAttestationSP(epoch phase0.Epoch) *phase0.AttestationData {
-	// Calculate the highest safe target epoch based on the current epoch and a predefined minimum distance.
-	highestTarget := epoch + minSPAttestationEpochGap
-	// The highest safe source epoch is one less than the highest target epoch.
-	highestSource := highestTarget - 1
-
-	// Return a new AttestationData object with the calculated source and target epochs.
-	return &phase0.AttestationData{
-		Source: &phase0.Checkpoint{
-			Epoch: highestSource,
-		},
-		Target: &phase0.Checkpoint{
-			Epoch: highestTarget,
-		},
-	}
-}
-
-// computeMinimalProposerSP calculates the minimal safe slot for a block proposal to avoid slashing.
-// It takes the current slot as an argument and returns the minimal safe slot.
-func (km *ethKeyManagerSigner) computeMinimalProposerSP(slot phase0.Slot) phase0.Slot {
-	// Calculate the highest safe proposal slot based on the current slot and a predefined minimum distance.
-	return slot + minSPProposalSlotGap
-}
-
 func (km *ethKeyManagerSigner) saveShare(shareKey *bls.SecretKey) error {
 	key, err := core.NewHDKeyFromPrivateKey(shareKey.Serialize(), "")
 	if err != nil {
@@ -0,0 +1,201 @@
+package ekm
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// InterchangeFormatVersion is the version of the EIP-3076 slashing protection interchange format.
+const InterchangeFormatVersion = "5"
+
+// Interchange is the slashing protection data in the EIP-3076 interchange format.
+// The node keeps only the highest attestation and proposal per share public key,
+// so exported data is in the minimal format, with at most one block and one attestation per key.
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []*InterchangeData  `json:"data"`
+}
+
+// InterchangeMetadata identifies the format and the chain of the interchange data.
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+// InterchangeData is the signing history of a single public key.
+type InterchangeData struct {
+	PubKey             string                    `json:"pubkey"`
+	SignedBlocks       []*InterchangeBlock       `json:"signed_blocks"`
+	SignedAttestations []*InterchangeAttestation `json:"signed_attestations"`
+}
+
+// InterchangeBlock is a signed block proposal.
+type InterchangeBlock struct {
+	Slot        phase0.Slot `json:"slot,string"`
+	SigningRoot string      `json:"signing_root,omitempty"`
+}
+
+// InterchangeAttestation is a signed attestation.
+type InterchangeAttestation struct {
+	SourceEpoch phase0.Epoch `json:"source_epoch,string"`
+	TargetEpoch phase0.Epoch `json:"target_epoch,string"`
+	SigningRoot string       `json:"signing_root,omitempty"`
+}
+
+// ExportSlashingProtection exports the slashing protection data of all share public keys.
+func ExportSlashingProtection(s Storage, genesisValidatorsRoot phase0.Root) (*Interchange, error) {
+	pubKeys, err := s.ListSlashingProtectedPubKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	interchange := &Interchange{
+		Metadata: InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    "0x" + hex.EncodeToString(genesisValidatorsRoot[:]),
+		},
+		Data: make([]*InterchangeData, 0, len(pubKeys)),
+	}
+	for _, pubKey := range pubKeys {
+		data := &InterchangeData{
+			PubKey:             "0x" + hex.EncodeToString(pubKey),
+			SignedBlocks:       []*InterchangeBlock{},
+			SignedAttestations: []*InterchangeAttestation{},
+		}
+
+		slot, found, err := s.RetrieveHighestProposal(pubKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve highest proposal of %s: %w", data.PubKey, err)
+		}
+		if found {
+			data.SignedBlocks = append(data.SignedBlocks, &InterchangeBlock{Slot: slot})
+		}
+
+		attestation, found, err := s.RetrieveHighestAttestation(pubKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve highest attestation of %s: %w", data.PubKey, err)
+		}
+		if found && attestation != nil {
+			data.SignedAttestations = append(data.SignedAttestations, &InterchangeAttestation{
+				SourceEpoch: attestation.Source.Epoch,
+				TargetEpoch: attestation.Target.Epoch,
+			})
+		}
+
+		interchange.Data = append(interchange.Data, data)
+	}
+	return interchange, nil
+}
+
+// ImportSlashingProtection merges the given interchange data into the slashing protection data.
+// Protection is never lowered: the highest source and target epochs and the highest slot
+// of every public key are kept, and are raised to the current minimums afterwards,
+// the same way they are when a share is added.
+// It returns the amount of imported public keys.
+func ImportSlashingProtection(s Storage, interchange *Interchange, genesisValidatorsRoot phase0.Root) (int, error) {
+	if interchange.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return 0, fmt.Errorf("unsupported interchange format version %q", interchange.Metadata.InterchangeFormatVersion)
+	}
+	root, err := decodeHex(interchange.Metadata.GenesisValidatorsRoot, len(genesisValidatorsRoot))
+	if err != nil {
+		return 0, fmt.Errorf("invalid genesis validators root: %w", err)
+	}
+	if phase0.Root(root) != genesisValidatorsRoot {
+		return 0, fmt.Errorf("genesis validators root mismatch: expected 0x%x, got %s",
+			genesisValidatorsRoot, interchange.Metadata.GenesisValidatorsRoot)
+	}
+
+	// Validate everything before saving anything.
+	pubKeys := make([][]byte, 0, len(interchange.Data))
+	for _, data := range interchange.Data {
+		pubKey, err := decodeHex(data.PubKey, phase0.PublicKeyLength)
+		if err != nil {
+			return 0, fmt.Errorf("invalid public key %q: %w", data.PubKey, err)
+		}
+		for _, attestation := range data.SignedAttestations {
+			if attestation.SourceEpoch > attestation.TargetEpoch {
+				return 0, fmt.Errorf("attestation of %s has source epoch %d after target epoch %d",
+					data.PubKey, attestation.SourceEpoch, attestation.TargetEpoch)
+			}
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	sp := newSlashingProtector(s, nil)
+	for i, data := range interchange.Data {
+		if err := importProposals(s, pubKeys[i], data.SignedBlocks); err != nil {
+			return i, fmt.Errorf("could not import proposals of %s: %w", data.PubKey, err)
+		}
+		if err := importAttestations(s, pubKeys[i], data.SignedAttestations); err != nil {
+			return i, fmt.Errorf("could not import attestations of %s: %w", data.PubKey, err)
+		}
+		if err := sp.BumpSlashingProtection(pubKeys[i]); err != nil {
+			return i, fmt.Errorf("could not bump slashing protection of %s: %w", data.PubKey, err)
+		}
+	}
+	return len(interchange.Data), nil
+}
+
+func importProposals(s Storage, pubKey []byte, blocks []*InterchangeBlock) error {
+	var highest phase0.Slot
+	for _, block := range blocks {
+		highest = max(highest, block.Slot)
+	}
+	if highest == 0 {
+		return nil
+	}
+
+	current, found, err := s.RetrieveHighestProposal(pubKey)
+	if err != nil {
+		return err
+	}
+	if found && current >= highest {
+		return nil
+	}
+	return s.SaveHighestProposal(pubKey, highest)
+}
+
+func importAttestations(s Storage, pubKey []byte, attestations []*InterchangeAttestation) error {
+	if len(attestations) == 0 {
+		return nil
+	}
+
+	// The highest source and target may come from different attestations,
+	// which is at least as strict as any of them.
+	var source, target phase0.Epoch
+	for _, attestation := range attestations {
+		source = max(source, attestation.SourceEpoch)
+		target = max(target, attestation.TargetEpoch)
+	}
+
+	current, found, err := s.RetrieveHighestAttestation(pubKey)
+	if err != nil {
+		return err
+	}
+	if found && current != nil {
+		if current.Source.Epoch >= source && current.Target.Epoch >= target {
+			return nil
+		}
+		source = max(source, current.Source.Epoch)
+		target = max(target, current.Target.Epoch)
+	}
+
+	return s.SaveHighestAttestation(pubKey, &phase0.AttestationData{
+		Source: &phase0.Checkpoint{Epoch: source},
+		Target: &phase0.Checkpoint{Epoch: target},
+	})
+}
+
+func decodeHex(s string, length int) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != length {
+		return nil, fmt.Errorf("expected %d bytes, got %d", length, len(b))
+	}
+	return b, nil
+}
@@ -0,0 +1,109 @@
+package ekm
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlashingProtectionInterchange(t *testing.T) {
+	storage, done := newStorageForTest(t)
+	defer done()
+
+	root := phase0.Root{0x1}
+	pk1 := _byteArray("a6d6ba14e20ee5e4ef7c4bd34d4bac8ba8a5ef8e3ec4a7a9d8f1fef7ff0d3cd8a0d8d1e5d9bd8d2c2a5d3b7c48d2e5c1")
+	pk2 := _byteArray("8e80066551a81b318258709edaf7dd1f63cd686a0e4db8b29bbb7acfe65608677af5a527d9448ee47835485e02b50bc0")
+
+	currentEpoch := storage.BeaconNetwork().EstimatedCurrentEpoch()
+	currentSlot := storage.BeaconNetwork().EstimatedCurrentSlot()
+
+	require.NoError(t, storage.SaveHighestAttestation(pk1, &phase0.AttestationData{
+		Source: &phase0.Checkpoint{Epoch: currentEpoch + 9},
+		Target: &phase0.Checkpoint{Epoch: currentEpoch + 10},
+	}))
+	require.NoError(t, storage.SaveHighestProposal(pk1, currentSlot+100))
+
+	t.Run("export", func(t *testing.T) {
+		interchange, err := ExportSlashingProtection(storage, root)
+		require.NoError(t, err)
+		require.Equal(t, InterchangeFormatVersion, interchange.Metadata.InterchangeFormatVersion)
+		require.Equal(t, "0x"+hex.EncodeToString(root[:]), interchange.Metadata.GenesisValidatorsRoot)
+		require.Equal(t, []*InterchangeData{{
+			PubKey:             "0x" + hex.EncodeToString(pk1),
+			SignedBlocks:       []*InterchangeBlock{{Slot: currentSlot + 100}},
+			SignedAttestations: []*InterchangeAttestation{{SourceEpoch: currentEpoch + 9, TargetEpoch: currentEpoch + 10}},
+		}}, interchange.Data)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		interchange := &Interchange{
+			Metadata: InterchangeMetadata{
+				InterchangeFormatVersion: InterchangeFormatVersion,
+				GenesisValidatorsRoot:    "0x" + hex.EncodeToString(root[:]),
+			},
+			Data: []*InterchangeData{
+				{
+					// Lower proposal and source, higher target.
+					PubKey:       "0x" + hex.EncodeToString(pk1),
+					SignedBlocks: []*InterchangeBlock{{Slot: currentSlot + 50}},
+					SignedAttestations: []*InterchangeAttestation{
+						{SourceEpoch: currentEpoch + 5, TargetEpoch: currentEpoch + 6},
+						{SourceEpoch: currentEpoch + 8, TargetEpoch: currentEpoch + 12},
+					},
+				},
+				{
+					// Old history is raised to the current minimums.
+					PubKey:             "0x" + hex.EncodeToString(pk2),
+					SignedBlocks:       []*InterchangeBlock{{Slot: 1}},
+					SignedAttestations: []*InterchangeAttestation{{SourceEpoch: 0, TargetEpoch: 1}},
+				},
+			},
+		}
+
+		imported, err := ImportSlashingProtection(storage, interchange, root)
+		require.NoError(t, err)
+		require.Equal(t, 2, imported)
+
+		att, found, err := storage.RetrieveHighestAttestation(pk1)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, currentEpoch+9, att.Source.Epoch)
+		require.Equal(t, currentEpoch+12, att.Target.Epoch)
+
+		slot, found, err := storage.RetrieveHighestProposal(pk1)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, currentSlot+100, slot)
+
+		att, found, err = storage.RetrieveHighestAttestation(pk2)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.GreaterOrEqual(t, att.Target.Epoch, currentEpoch)
+
+		slot, found, err = storage.RetrieveHighestProposal(pk2)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.GreaterOrEqual(t, slot, currentSlot)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		interchange := &Interchange{
+			Metadata: InterchangeMetadata{
+				InterchangeFormatVersion: InterchangeFormatVersion,
+				GenesisValidatorsRoot:    "0x" + hex.EncodeToString(make([]byte, 32)),
+			},
+		}
+		_, err := ImportSlashingProtection(storage, interchange, root)
+		require.ErrorContains(t, err, "genesis validators root mismatch")
+
+		interchange.Metadata.GenesisValidatorsRoot = "0x" + hex.EncodeToString(root[:])
+		interchange.Data = []*InterchangeData{{
+			PubKey:             "0x" + hex.EncodeToString(pk2),
+			SignedAttestations: []*InterchangeAttestation{{SourceEpoch: 2, TargetEpoch: 1}},
+		}}
+		_, err = ImportSlashingProtection(storage, interchange, root)
+		require.ErrorContains(t, err, "source epoch 2 after target epoch 1")
+	})
+}
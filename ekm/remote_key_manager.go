@@ -0,0 +1,369 @@
+package ekm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/google/uuid"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	"go.uber.org/zap"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/ekm/web3signer"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// ErrSignRootUnsupported is returned when signing SSV messages with a share key,
+// which only happens before the Alan fork and isn't supported by remote signers.
+var ErrSignRootUnsupported = errors.New("signing SSV messages with share keys is not supported by the remote signer")
+
+// ForkInfoProvider provides the fork information a remote signer needs to verify signature domains.
+type ForkInfoProvider interface {
+	ForkSchedule(ctx context.Context) ([]*phase0.Fork, error)
+	GenesisValidatorsRoot(ctx context.Context) (phase0.Root, error)
+}
+
+// remoteKeyManager is a KeyManager which keeps share keys in a Web3Signer-compatible remote signer.
+// Slashing protection data is still kept in the node's storage and checked before every request,
+// on top of any slashing protection done by the remote signer itself.
+type remoteKeyManager struct {
+	logger           *zap.Logger
+	client           *web3signer.Client
+	forkInfoProvider ForkInfoProvider
+	network          networkconfig.NetworkConfig
+	storage          Storage
+	signLock         sync.Mutex
+	*slashingProtector
+}
+
+// NewRemoteKeyManager returns a new instance of remoteKeyManager
+func NewRemoteKeyManager(
+	logger *zap.Logger,
+	db basedb.Database,
+	network networkconfig.NetworkConfig,
+	client *web3signer.Client,
+	forkInfoProvider ForkInfoProvider,
+	opts ...Option,
+) (KeyManager, error) {
+	signerStore := NewSignerStorage(db, network.Beacon, logger)
+	protection := newProtection(signerStore, opts...)
+
+	return &remoteKeyManager{
+		logger:            logger.Named("remote_key_manager"),
+		client:            client,
+		forkInfoProvider:  forkInfoProvider,
+		network:           network,
+		storage:           signerStore,
+		slashingProtector: newSlashingProtector(signerStore, protection),
+	}, nil
+}
+
+// ListAccounts returns the accounts kept in the node's storage, which is always empty for remote key managers.
+func (km *remoteKeyManager) ListAccounts() ([]core.ValidatorAccount, error) {
+	return km.storage.ListAccounts()
+}
+
+func (km *remoteKeyManager) SignBeaconObject(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType) (spectypes.Signature, [32]byte, error) {
+	ctx := context.Background()
+
+	req, err := km.signRequest(obj, domainType)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+
+	root, err := spectypes.ComputeETHSigningRoot(obj, domain)
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "could not compute signing root")
+	}
+	req.SigningRoot = root[:]
+
+	// Validator registrations are signed with the genesis fork version and don't need fork info.
+	if req.Type != web3signer.ValidatorRegistration {
+		req.ForkInfo, err = km.forkInfo(ctx, domain, domainType)
+		if err != nil {
+			return nil, [32]byte{}, errors.Wrap(err, "could not get fork info")
+		}
+	}
+
+	switch req.Type {
+	case web3signer.Attestation:
+		if err := km.protectAttestation(pk, req.Attestation); err != nil {
+			return nil, [32]byte{}, err
+		}
+	case web3signer.BlockV2:
+		if err := km.protectProposal(pk, req.BeaconBlock.BlockHeader.Slot); err != nil {
+			return nil, [32]byte{}, err
+		}
+	}
+
+	sig, err := km.client.Sign(ctx, pk, req)
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "remote signer failed to sign")
+	}
+
+	return sig, root, nil
+}
+
+// protectAttestation checks that the attestation isn't slashable and records it as the highest attestation.
+func (km *remoteKeyManager) protectAttestation(pk []byte, data *phase0.AttestationData) error {
+	km.signLock.Lock()
+	defer km.signLock.Unlock()
+
+	if err := km.IsAttestationSlashable(pk, data); err != nil {
+		return err
+	}
+	if err := km.protection.UpdateHighestAttestation(pk, data); err != nil {
+		return errors.Wrap(err, "could not update highest attestation")
+	}
+	return nil
+}
+
+// protectProposal checks that the proposal isn't slashable and records it as the highest proposal.
+func (km *remoteKeyManager) protectProposal(pk []byte, slot phase0.Slot) error {
+	km.signLock.Lock()
+	defer km.signLock.Unlock()
+
+	if err := km.IsBeaconBlockSlashable(pk, slot); err != nil {
+		return err
+	}
+	if err := km.protection.UpdateHighestProposal(pk, slot); err != nil {
+		return errors.Wrap(err, "could not update highest proposal")
+	}
+	return nil
+}
+
+// signRequest builds a signing request carrying the object the remote signer expects for the given domain.
+func (km *remoteKeyManager) signRequest(obj ssz.HashRoot, domainType phase0.DomainType) (*web3signer.SignRequest, error) {
+	switch domainType {
+	case spectypes.DomainAttester:
+		data, ok := obj.(*phase0.AttestationData)
+		if !ok {
+			return nil, errors.New("could not cast obj to AttestationData")
+		}
+		return &web3signer.SignRequest{Type: web3signer.Attestation, Attestation: data}, nil
+	case spectypes.DomainProposer:
+		block, err := blockData(obj)
+		if err != nil {
+			return nil, err
+		}
+		return &web3signer.SignRequest{Type: web3signer.BlockV2, BeaconBlock: block}, nil
+	case spectypes.DomainVoluntaryExit:
+		data, ok := obj.(*phase0.VoluntaryExit)
+		if !ok {
+			return nil, errors.New("could not cast obj to VoluntaryExit")
+		}
+		return &web3signer.SignRequest{Type: web3signer.VoluntaryExit, VoluntaryExit: data}, nil
+	case spectypes.DomainAggregateAndProof:
+		data, ok := obj.(*phase0.AggregateAndProof)
+		if !ok {
+			return nil, errors.New("could not cast obj to AggregateAndProof")
+		}
+		return &web3signer.SignRequest{Type: web3signer.AggregateAndProof, AggregateAndProof: data}, nil
+	case spectypes.DomainSelectionProof:
+		data, ok := obj.(spectypes.SSZUint64)
+		if !ok {
+			return nil, errors.New("could not cast obj to SSZUint64")
+		}
+		return &web3signer.SignRequest{
+			Type:            web3signer.AggregationSlot,
+			AggregationSlot: &web3signer.AggregationSlotData{Slot: phase0.Slot(data)},
+		}, nil
+	case spectypes.DomainRandao:
+		data, ok := obj.(spectypes.SSZUint64)
+		if !ok {
+			return nil, errors.New("could not cast obj to SSZUint64")
+		}
+		return &web3signer.SignRequest{
+			Type:         web3signer.RandaoReveal,
+			RandaoReveal: &web3signer.RandaoRevealData{Epoch: phase0.Epoch(data)},
+		}, nil
+	case spectypes.DomainSyncCommittee:
+		data, ok := obj.(spectypes.SSZBytes)
+		if !ok {
+			return nil, errors.New("could not cast obj to SSZBytes")
+		}
+		// The signed object is just the block root, so the slot is only used by the remote signer
+		// to pick the fork and is estimated from the current time.
+		return &web3signer.SignRequest{
+			Type: web3signer.SyncCommitteeMessage,
+			SyncCommitteeMessage: &web3signer.SyncCommitteeMessageData{
+				BeaconBlockRoot: []byte(data),
+				Slot:            km.network.Beacon.EstimatedCurrentSlot(),
+			},
+		}, nil
+	case spectypes.DomainSyncCommitteeSelectionProof:
+		data, ok := obj.(*altair.SyncAggregatorSelectionData)
+		if !ok {
+			return nil, errors.New("could not cast obj to SyncAggregatorSelectionData")
+		}
+		return &web3signer.SignRequest{Type: web3signer.SyncCommitteeSelectionProof, SyncAggregatorSelectionData: data}, nil
+	case spectypes.DomainContributionAndProof:
+		data, ok := obj.(*altair.ContributionAndProof)
+		if !ok {
+			return nil, errors.New("could not cast obj to ContributionAndProof")
+		}
+		return &web3signer.SignRequest{Type: web3signer.SyncCommitteeContributionAndProof, ContributionAndProof: data}, nil
+	case spectypes.DomainApplicationBuilder:
+		data, ok := obj.(*eth2apiv1.ValidatorRegistration)
+		if !ok {
+			return nil, fmt.Errorf("obj type is unknown: %T", obj)
+		}
+		return &web3signer.SignRequest{Type: web3signer.ValidatorRegistration, ValidatorRegistration: data}, nil
+	default:
+		return nil, errors.New("domain unknown")
+	}
+}
+
+// blockData returns the header of the given block, which is all the remote signer needs to sign it.
+func blockData(obj ssz.HashRoot) (*web3signer.BeaconBlockData, error) {
+	var (
+		version string
+		header  *phase0.BeaconBlockHeader
+		body    ssz.HashRoot
+	)
+	switch v := obj.(type) {
+	case *capella.BeaconBlock:
+		version = "CAPELLA"
+		header = &phase0.BeaconBlockHeader{Slot: v.Slot, ProposerIndex: v.ProposerIndex, ParentRoot: v.ParentRoot, StateRoot: v.StateRoot}
+		body = v.Body
+	case *deneb.BeaconBlock:
+		version = "DENEB"
+		header = &phase0.BeaconBlockHeader{Slot: v.Slot, ProposerIndex: v.ProposerIndex, ParentRoot: v.ParentRoot, StateRoot: v.StateRoot}
+		body = v.Body
+	case *apiv1capella.BlindedBeaconBlock:
+		version = "CAPELLA"
+		header = &phase0.BeaconBlockHeader{Slot: v.Slot, ProposerIndex: v.ProposerIndex, ParentRoot: v.ParentRoot, StateRoot: v.StateRoot}
+		body = v.Body
+	case *apiv1deneb.BlindedBeaconBlock:
+		version = "DENEB"
+		header = &phase0.BeaconBlockHeader{Slot: v.Slot, ProposerIndex: v.ProposerIndex, ParentRoot: v.ParentRoot, StateRoot: v.StateRoot}
+		body = v.Body
+	default:
+		return nil, fmt.Errorf("obj type is unknown: %T", obj)
+	}
+
+	bodyRoot, err := body.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block body root")
+	}
+	header.BodyRoot = bodyRoot
+
+	return &web3signer.BeaconBlockData{
+		Version:     version,
+		BlockHeader: header,
+	}, nil
+}
+
+// forkInfo finds the fork whose version the given domain was computed with.
+// The remote signer recomputes the domain from the fork info and rejects the request on mismatch.
+func (km *remoteKeyManager) forkInfo(ctx context.Context, domain phase0.Domain, domainType phase0.DomainType) (*web3signer.ForkInfo, error) {
+	schedule, err := km.forkInfoProvider.ForkSchedule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	genesisValidatorsRoot, err := km.forkInfoProvider.GenesisValidatorsRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(schedule) - 1; i >= 0; i-- {
+		forkDomain, err := spectypes.ComputeETHDomain(domainType, schedule[i].CurrentVersion, genesisValidatorsRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute domain")
+		}
+		if forkDomain == domain {
+			return &web3signer.ForkInfo{
+				Fork:                  schedule[i],
+				GenesisValidatorsRoot: genesisValidatorsRoot[:],
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no fork matches domain %x", domain)
+}
+
+func (km *remoteKeyManager) SignRoot(data spectypes.Root, sigType spectypes.SignatureType, pk []byte) (spectypes.Signature, error) {
+	return nil, ErrSignRootUnsupported
+}
+
+// AddShare imports the share key into the remote signer. The key isn't persisted by the node.
+func (km *remoteKeyManager) AddShare(shareKey *bls.SecretKey) error {
+	if err := km.BumpSlashingProtection(shareKey.GetPublicKey().Serialize()); err != nil {
+		return errors.Wrap(err, "could not bump slashing protection")
+	}
+
+	keystore, password, err := encryptShareKeystore(shareKey)
+	if err != nil {
+		return errors.Wrap(err, "could not encrypt share keystore")
+	}
+	if err := km.client.ImportKeystore(context.Background(), keystore, password); err != nil {
+		return errors.Wrap(err, "could not import share to remote signer")
+	}
+
+	return nil
+}
+
+func (km *remoteKeyManager) RemoveShare(pubKey string) error {
+	pkDecoded, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not hex decode share public key")
+	}
+	if err := km.client.DeleteKeystore(context.Background(), pkDecoded); err != nil {
+		return errors.Wrap(err, "could not remove share from remote signer")
+	}
+	if err := km.storage.RemoveHighestAttestation(pkDecoded); err != nil {
+		return errors.Wrap(err, "could not remove highest attestation")
+	}
+	if err := km.storage.RemoveHighestProposal(pkDecoded); err != nil {
+		return errors.Wrap(err, "could not remove highest proposal")
+	}
+	if err := km.storage.RemoveAttestationHistory(pkDecoded); err != nil {
+		return errors.Wrap(err, "could not remove attestation history")
+	}
+	return nil
+}
+
+// encryptShareKeystore encrypts the share key into an EIP-2335 keystore with a random password.
+// The password is only needed by the remote signer, which stores it alongside the keystore.
+func encryptShareKeystore(shareKey *bls.SecretKey) (keystore string, password string, err error) {
+	passwordBytes := make([]byte, 32)
+	if _, err := rand.Read(passwordBytes); err != nil {
+		return "", "", err
+	}
+	password = hex.EncodeToString(passwordBytes)
+
+	crypto, err := keystorev4.New(keystorev4.WithCipher("pbkdf2")).Encrypt(shareKey.Serialize(), password)
+	if err != nil {
+		return "", "", err
+	}
+
+	data, err := json.Marshal(map[string]any{
+		"crypto":  crypto,
+		"pubkey":  shareKey.GetPublicKey().SerializeToHexStr(),
+		"path":    "",
+		"uuid":    uuid.New().String(),
+		"version": 4,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(data), password, nil
+}
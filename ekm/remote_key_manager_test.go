@@ -0,0 +1,350 @@
+package ekm
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/prysmaticlabs/go-bitfield"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/ssvlabs/ssv/ekm/web3signer"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/utils"
+	"github.com/ssvlabs/ssv/utils/threshold"
+)
+
+// fakeRemoteSigner is a minimal Web3Signer-compatible server which signs with imported keystores.
+type fakeRemoteSigner struct {
+	t        *testing.T
+	mu       sync.Mutex
+	keys     map[string]*bls.SecretKey
+	requests []*web3signer.SignRequest
+}
+
+func newFakeRemoteSigner(t *testing.T) (*fakeRemoteSigner, *httptest.Server) {
+	s := &fakeRemoteSigner{
+		t:    t,
+		keys: map[string]*bls.SecretKey{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/upcheck", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/eth/v1/keystores", s.handleKeystores)
+	mux.HandleFunc("/api/v1/eth2/sign/", s.handleSign)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return s, server
+}
+
+func (s *fakeRemoteSigner) handleKeystores(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var resp web3signer.KeystoresResponse
+	switch r.Method {
+	case http.MethodPost:
+		var req web3signer.ImportKeystoresRequest
+		require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+		for i, keystore := range req.Keystores {
+			var data map[string]any
+			require.NoError(s.t, json.Unmarshal([]byte(keystore), &data))
+			secret, err := keystorev4.New().Decrypt(data["crypto"].(map[string]any), req.Passwords[i])
+			require.NoError(s.t, err)
+			sk := &bls.SecretKey{}
+			require.NoError(s.t, sk.Deserialize(secret))
+			require.Equal(s.t, sk.GetPublicKey().SerializeToHexStr(), data["pubkey"])
+
+			status := web3signer.StatusImported
+			if _, ok := s.keys[sk.GetPublicKey().SerializeToHexStr()]; ok {
+				status = web3signer.StatusDuplicate
+			}
+			s.keys[sk.GetPublicKey().SerializeToHexStr()] = sk
+			resp.Data = append(resp.Data, web3signer.KeystoreStatus{Status: status})
+		}
+	case http.MethodDelete:
+		var req web3signer.DeleteKeystoresRequest
+		require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+		for _, pubKey := range req.Pubkeys {
+			status := web3signer.StatusNotFound
+			if _, ok := s.keys[hex.EncodeToString(pubKey)]; ok {
+				status = web3signer.StatusDeleted
+			}
+			delete(s.keys, hex.EncodeToString(pubKey))
+			resp.Data = append(resp.Data, web3signer.KeystoreStatus{Status: status})
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	require.NoError(s.t, json.NewEncoder(w).Encode(resp))
+}
+
+func (s *fakeRemoteSigner) handleSign(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req web3signer.SignRequest
+	require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+	s.requests = append(s.requests, &req)
+
+	sk, ok := s.keys[strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/api/v1/eth2/sign/"), "0x")]
+	if !ok {
+		http.Error(w, "Public Key not found", http.StatusNotFound)
+		return
+	}
+	sig := sk.SignByte(req.SigningRoot)
+	require.NoError(s.t, json.NewEncoder(w).Encode(web3signer.SignResponse{Signature: sig.Serialize()}))
+}
+
+func (s *fakeRemoteSigner) lastRequest() *web3signer.SignRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requests) == 0 {
+		return nil
+	}
+	return s.requests[len(s.requests)-1]
+}
+
+func (s *fakeRemoteSigner) requestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+type fakeForkInfoProvider struct {
+	schedule              []*phase0.Fork
+	genesisValidatorsRoot phase0.Root
+}
+
+func (p *fakeForkInfoProvider) ForkSchedule(context.Context) ([]*phase0.Fork, error) {
+	return p.schedule, nil
+}
+
+func (p *fakeForkInfoProvider) GenesisValidatorsRoot(context.Context) (phase0.Root, error) {
+	return p.genesisValidatorsRoot, nil
+}
+
+func testRemoteKeyManager(t *testing.T, opts ...Option) (KeyManager, *fakeRemoteSigner, *fakeForkInfoProvider) {
+	threshold.Init()
+
+	logger := logging.TestLogger(t)
+
+	db, err := getBaseStorage(logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	network := networkconfig.NetworkConfig{
+		Beacon:            utils.SetupMockBeaconNetwork(t, nil),
+		GenesisDomainType: networkconfig.TestNetwork.DomainType(),
+		AlanDomainType:    networkconfig.TestNetwork.DomainType(),
+	}
+
+	forkInfoProvider := &fakeForkInfoProvider{
+		schedule: []*phase0.Fork{
+			{PreviousVersion: phase0.Version{0x00}, CurrentVersion: phase0.Version{0x00}, Epoch: 0},
+			{PreviousVersion: phase0.Version{0x00}, CurrentVersion: phase0.Version{0x03}, Epoch: 1},
+		},
+		genesisValidatorsRoot: phase0.Root{0x01, 0x02, 0x03},
+	}
+
+	remoteSigner, server := newFakeRemoteSigner(t)
+	client := web3signer.New(server.URL, web3signer.WithLogger(logger))
+
+	km, err := NewRemoteKeyManager(logger, db, network, client, forkInfoProvider, opts...)
+	require.NoError(t, err)
+
+	return km, remoteSigner, forkInfoProvider
+}
+
+func TestRemoteKeyManager_SignBeaconObject(t *testing.T) {
+	km, remoteSigner, forkInfoProvider := testRemoteKeyManager(t)
+
+	sk := &bls.SecretKey{}
+	require.NoError(t, sk.SetHexString(sk1Str))
+	require.NoError(t, km.AddShare(sk))
+	pk := sk.GetPublicKey().Serialize()
+
+	currentFork := forkInfoProvider.schedule[1]
+	domain, err := spectypes.ComputeETHDomain(spectypes.DomainAttester, currentFork.CurrentVersion, forkInfoProvider.genesisValidatorsRoot)
+	require.NoError(t, err)
+
+	attestationData := &phase0.AttestationData{
+		Slot:            100,
+		Index:           2,
+		BeaconBlockRoot: phase0.Root{0x01},
+		Source:          &phase0.Checkpoint{Epoch: 1, Root: phase0.Root{0x02}},
+		Target:          &phase0.Checkpoint{Epoch: 3, Root: phase0.Root{0x03}},
+	}
+
+	t.Run("sign attestation", func(t *testing.T) {
+		sig, root, err := km.SignBeaconObject(attestationData, domain, pk, spectypes.DomainAttester)
+		require.NoError(t, err)
+
+		expectedRoot, err := spectypes.ComputeETHSigningRoot(attestationData, domain)
+		require.NoError(t, err)
+		require.EqualValues(t, expectedRoot, root)
+
+		blsSig := &bls.Sign{}
+		require.NoError(t, blsSig.Deserialize(sig))
+		require.True(t, blsSig.VerifyByte(sk.GetPublicKey(), root[:]))
+
+		req := remoteSigner.lastRequest()
+		require.Equal(t, web3signer.Attestation, req.Type)
+		require.NotNil(t, req.ForkInfo)
+		require.Equal(t, currentFork.CurrentVersion, req.ForkInfo.Fork.CurrentVersion)
+		require.EqualValues(t, forkInfoProvider.genesisValidatorsRoot[:], req.ForkInfo.GenesisValidatorsRoot)
+		require.EqualValues(t, attestationData.Target.Epoch, req.Attestation.Target.Epoch)
+	})
+
+	t.Run("slashable attestation is not sent to the remote signer", func(t *testing.T) {
+		requests := remoteSigner.requestCount()
+		_, _, err := km.SignBeaconObject(attestationData, domain, pk, spectypes.DomainAttester)
+		require.EqualError(t, err, "slashable attestation (HighestAttestationVote), not signing")
+		require.Equal(t, requests, remoteSigner.requestCount())
+	})
+
+	t.Run("sign block", func(t *testing.T) {
+		proposerDomain, err := spectypes.ComputeETHDomain(spectypes.DomainProposer, currentFork.CurrentVersion, forkInfoProvider.genesisValidatorsRoot)
+		require.NoError(t, err)
+
+		block := &capella.BeaconBlock{
+			Slot:          100,
+			ProposerIndex: 5,
+			ParentRoot:    phase0.Root{0x04},
+			StateRoot:     phase0.Root{0x05},
+			Body:          testingCapellaBlockBody(),
+		}
+
+		sig, root, err := km.SignBeaconObject(block, proposerDomain, pk, spectypes.DomainProposer)
+		require.NoError(t, err)
+
+		blsSig := &bls.Sign{}
+		require.NoError(t, blsSig.Deserialize(sig))
+		require.True(t, blsSig.VerifyByte(sk.GetPublicKey(), root[:]))
+
+		bodyRoot, err := block.Body.HashTreeRoot()
+		require.NoError(t, err)
+
+		req := remoteSigner.lastRequest()
+		require.Equal(t, web3signer.BlockV2, req.Type)
+		require.Equal(t, "CAPELLA", req.BeaconBlock.Version)
+		require.Equal(t, block.Slot, req.BeaconBlock.BlockHeader.Slot)
+		require.EqualValues(t, bodyRoot, req.BeaconBlock.BlockHeader.BodyRoot)
+
+		// The header must have the same root as the block, otherwise the remote signer would reject the signing root.
+		headerRoot, err := req.BeaconBlock.BlockHeader.HashTreeRoot()
+		require.NoError(t, err)
+		blockRoot, err := block.HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, blockRoot, headerRoot)
+
+		err = km.IsBeaconBlockSlashable(pk, block.Slot)
+		require.EqualError(t, err, "slashable proposal (HighestProposalVote), not signing")
+	})
+
+	t.Run("sign randao with previous fork", func(t *testing.T) {
+		previousFork := forkInfoProvider.schedule[0]
+		randaoDomain, err := spectypes.ComputeETHDomain(spectypes.DomainRandao, previousFork.CurrentVersion, forkInfoProvider.genesisValidatorsRoot)
+		require.NoError(t, err)
+
+		_, _, err = km.SignBeaconObject(spectypes.SSZUint64(0), randaoDomain, pk, spectypes.DomainRandao)
+		require.NoError(t, err)
+
+		req := remoteSigner.lastRequest()
+		require.Equal(t, web3signer.RandaoReveal, req.Type)
+		require.Equal(t, previousFork.CurrentVersion, req.ForkInfo.Fork.CurrentVersion)
+	})
+
+	t.Run("unknown fork", func(t *testing.T) {
+		unknownDomain, err := spectypes.ComputeETHDomain(spectypes.DomainRandao, phase0.Version{0xff}, forkInfoProvider.genesisValidatorsRoot)
+		require.NoError(t, err)
+
+		_, _, err = km.SignBeaconObject(spectypes.SSZUint64(1), unknownDomain, pk, spectypes.DomainRandao)
+		require.ErrorContains(t, err, "no fork matches domain")
+	})
+
+	t.Run("sign root is unsupported", func(t *testing.T) {
+		_, err := km.SignRoot(nil, spectypes.PartialSignatureType, pk)
+		require.ErrorIs(t, err, ErrSignRootUnsupported)
+	})
+
+	t.Run("remove share", func(t *testing.T) {
+		require.NoError(t, km.RemoveShare(sk.GetPublicKey().SerializeToHexStr()))
+
+		_, found, err := km.(*remoteKeyManager).RetrieveHighestAttestation(pk)
+		require.NoError(t, err)
+		require.False(t, found)
+
+		randaoDomain, err := spectypes.ComputeETHDomain(spectypes.DomainRandao, currentFork.CurrentVersion, forkInfoProvider.genesisValidatorsRoot)
+		require.NoError(t, err)
+		_, _, err = km.SignBeaconObject(spectypes.SSZUint64(2), randaoDomain, pk, spectypes.DomainRandao)
+		require.ErrorContains(t, err, "unexpected status code 404")
+
+		// Removing a share twice isn't an error.
+		require.NoError(t, km.RemoveShare(sk.GetPublicKey().SerializeToHexStr()))
+	})
+}
+
+func TestRemoteKeyManager_AddShare(t *testing.T) {
+	km, remoteSigner, _ := testRemoteKeyManager(t)
+
+	sk := &bls.SecretKey{}
+	require.NoError(t, sk.SetHexString(sk2Str))
+	pk := sk.GetPublicKey().Serialize()
+
+	require.NoError(t, km.AddShare(sk))
+	require.Contains(t, remoteSigner.keys, pk2Str)
+
+	highestAttestation, found, err := km.(*remoteKeyManager).RetrieveHighestAttestation(pk)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 1, highestAttestation.Target.Epoch)
+
+	highestProposal, found, err := km.(*remoteKeyManager).RetrieveHighestProposal(pk)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 32, highestProposal)
+
+	// Adding the same share again is reported as a duplicate by the remote signer, which isn't an error.
+	require.NoError(t, km.AddShare(sk))
+	require.Len(t, remoteSigner.keys, 1)
+}
+
+func testingCapellaBlockBody() *capella.BeaconBlockBody {
+	return &capella.BeaconBlockBody{
+		ETH1Data: &phase0.ETH1Data{
+			DepositRoot: phase0.Root{},
+			BlockHash:   make([]byte, 32),
+		},
+		Graffiti:          [32]byte{},
+		ProposerSlashings: []*phase0.ProposerSlashing{},
+		AttesterSlashings: []*phase0.AttesterSlashing{},
+		Attestations:      []*phase0.Attestation{},
+		Deposits:          []*phase0.Deposit{},
+		VoluntaryExits:    []*phase0.SignedVoluntaryExit{},
+		SyncAggregate: &altair.SyncAggregate{
+			SyncCommitteeBits: bitfield.NewBitvector512(),
+		},
+		ExecutionPayload: &capella.ExecutionPayload{
+			ExtraData:    []byte{},
+			Transactions: []bellatrix.Transaction{},
+			Withdrawals:  []*capella.Withdrawal{},
+		},
+		BLSToExecutionChanges: []*capella.SignedBLSToExecutionChange{},
+	}
+}
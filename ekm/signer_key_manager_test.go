@@ -778,6 +778,35 @@ func TestRemoveShare(t *testing.T) {
 		err := km.RemoveShare(pk.GetPublicKey().GetHexString())
 		require.NoError(t, err)
 	})
+
+	t.Run("attestation history is removed", func(t *testing.T) {
+		logger := logging.TestLogger(t)
+		db, err := getBaseStorage(logger)
+		require.NoError(t, err)
+		network := networkconfig.NetworkConfig{
+			Beacon:            utils.SetupMockBeaconNetwork(t, nil),
+			GenesisDomainType: networkconfig.TestNetwork.DomainType(),
+			AlanDomainType:    networkconfig.TestNetwork.DomainType(),
+		}
+		km, err := NewETHKeyManagerSigner(logger, db, network, "", WithAttestationHistory())
+		require.NoError(t, err)
+
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		require.NoError(t, km.AddShare(sk))
+		_, _, err = km.SignBeaconObject(testAttestation(9, 10), phase0.Domain{}, sk.GetPublicKey().Serialize(), spectypes.DomainAttester)
+		require.NoError(t, err)
+
+		storage := km.(*ethKeyManagerSigner).storage
+		history, err := storage.RetrieveAttestationHistory(sk.GetPublicKey().Serialize())
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+
+		require.NoError(t, km.RemoveShare(sk.GetPublicKey().SerializeToHexStr()))
+		history, err = storage.RetrieveAttestationHistory(sk.GetPublicKey().Serialize())
+		require.NoError(t, err)
+		require.Empty(t, history)
+	})
 }
 
 func TestEkmListAccounts(t *testing.T) {
@@ -34,6 +34,7 @@ const (
 	accountsPath          = "accounts_%s"
 	highestAttPrefix      = prefix + "highest_att-"
 	highestProposalPrefix = prefix + "highest_prop-"
+	attHistoryPrefix      = prefix + "att_history-"
 )
 
 // Storage represents the interface for ssv node storage
@@ -44,6 +45,11 @@ type Storage interface {
 
 	RemoveHighestAttestation(pubKey []byte) error
 	RemoveHighestProposal(pubKey []byte) error
+	ListSlashingProtectedPubKeys() ([][]byte, error)
+	SaveAttestationHistory(pubKey []byte, history []AttestationRecord) error
+	RetrieveAttestationHistory(pubKey []byte) ([]AttestationRecord, error)
+	RemoveAttestationHistory(pubKey []byte) error
+	ListAttestationHistoryPubKeys() ([][]byte, error)
 	SetEncryptionKey(newKey string) error
 	ListAccountsTxn(r basedb.Reader) ([]core.ValidatorAccount, error)
 	SaveAccountTxn(rw basedb.ReadWriter, account core.ValidatorAccount) error
@@ -349,6 +355,103 @@ func (s *storage) RemoveHighestProposal(pubKey []byte) error {
 	return s.db.Delete(s.objPrefix(highestProposalPrefix), pubKey)
 }
 
+// ListSlashingProtectedPubKeys returns the public keys which have a highest attestation or proposal saved.
+func (s *storage) ListSlashingProtectedPubKeys() ([][]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	seen := make(map[string]struct{})
+	var pubKeys [][]byte
+	for _, objPrefix := range []string{highestAttPrefix, highestProposalPrefix} {
+		err := s.db.GetAll(s.objPrefix(objPrefix), func(i int, obj basedb.Obj) error {
+			if _, ok := seen[string(obj.Key)]; ok {
+				return nil
+			}
+			seen[string(obj.Key)] = struct{}{}
+			pubKeys = append(pubKeys, append([]byte(nil), obj.Key...))
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list slashing protection data")
+		}
+	}
+	return pubKeys, nil
+}
+
+// SaveAttestationHistory saves the attestation history of the given public key, removing it if it's empty.
+// Records are kept as consecutive pairs of little-endian source and target epochs.
+func (s *storage) SaveAttestationHistory(pubKey []byte, history []AttestationRecord) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if pubKey == nil {
+		return errors.New("pubKey must not be nil")
+	}
+	if len(history) == 0 {
+		return s.db.Delete(s.objPrefix(attHistoryPrefix), pubKey)
+	}
+
+	data := make([]byte, 0, len(history)*16)
+	for _, record := range history {
+		data = ssz.MarshalUint64(data, uint64(record.Source))
+		data = ssz.MarshalUint64(data, uint64(record.Target))
+	}
+	return s.db.Set(s.objPrefix(attHistoryPrefix), pubKey, data)
+}
+
+// RetrieveAttestationHistory returns the attestation history of the given public key.
+func (s *storage) RetrieveAttestationHistory(pubKey []byte) ([]AttestationRecord, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if pubKey == nil {
+		return nil, errors.New("public key could not be nil")
+	}
+
+	obj, found, err := s.db.Get(s.objPrefix(attHistoryPrefix), pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attestation history from db")
+	}
+	if !found {
+		return nil, nil
+	}
+	if len(obj.Value)%16 != 0 {
+		return nil, errors.Errorf("invalid attestation history length %d", len(obj.Value))
+	}
+
+	history := make([]AttestationRecord, 0, len(obj.Value)/16)
+	for i := 0; i < len(obj.Value); i += 16 {
+		history = append(history, AttestationRecord{
+			Source: phase0.Epoch(ssz.UnmarshallUint64(obj.Value[i : i+8])),
+			Target: phase0.Epoch(ssz.UnmarshallUint64(obj.Value[i+8 : i+16])),
+		})
+	}
+	return history, nil
+}
+
+func (s *storage) RemoveAttestationHistory(pubKey []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Delete(s.objPrefix(attHistoryPrefix), pubKey)
+}
+
+// ListAttestationHistoryPubKeys returns the public keys which have an attestation history saved.
+func (s *storage) ListAttestationHistoryPubKeys() ([][]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var pubKeys [][]byte
+	err := s.db.GetAll(s.objPrefix(attHistoryPrefix), func(i int, obj basedb.Obj) error {
+		pubKeys = append(pubKeys, append([]byte(nil), obj.Key...))
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list attestation histories")
+	}
+	return pubKeys, nil
+}
+
 func (s *storage) decryptData(objectValue []byte) ([]byte, error) {
 	if len(s.encryptionKey) == 0 {
 		return objectValue, nil
@@ -0,0 +1,185 @@
+package ekm
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	slashingprotection "github.com/bloxapp/eth2-key-manager/slashing_protection"
+	"github.com/pkg/errors"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+)
+
+const (
+	// minSPAttestationEpochGap is the minimum epoch distance used for slashing protection in attestations.
+	// It defines the smallest allowable gap between the source and target epochs in an existing attestation
+	// and those in a new attestation, helping to prevent slashable offenses.
+	minSPAttestationEpochGap = phase0.Epoch(0)
+	// minSPProposalSlotGap is the minimum slot distance used for slashing protection in block proposals.
+	// It defines the smallest allowable gap between the current slot and the slot of a new block proposal,
+	// helping to prevent slashable offenses.
+	minSPProposalSlotGap = phase0.Slot(0)
+)
+
+// slashingProtector keeps the slashing protection data of shares in the node's storage.
+// It is shared by all KeyManager implementations, regardless of where the share keys are kept.
+type slashingProtector struct {
+	storage    Storage
+	protection core.SlashingProtector
+}
+
+func newSlashingProtector(signerStore Storage, protection core.SlashingProtector) *slashingProtector {
+	return &slashingProtector{
+		storage:    signerStore,
+		protection: protection,
+	}
+}
+
+// newProtection returns the slashing protection of shares according to the given options.
+func newProtection(signerStore Storage, opts ...Option) core.SlashingProtector {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.attestationHistory {
+		return newHistoryProtection(signerStore)
+	}
+	return slashingprotection.NewNormalProtection(signerStore)
+}
+
+// PruneAttestationHistory removes attestations with a target below the finalized epoch from the attestation history,
+// if the history is kept.
+func (sp *slashingProtector) PruneAttestationHistory(finalizedEpoch phase0.Epoch) error {
+	protection, ok := sp.protection.(*historyProtection)
+	if !ok {
+		return nil
+	}
+	return protection.prune(finalizedEpoch)
+}
+
+func (sp *slashingProtector) RetrieveHighestAttestation(pubKey []byte) (*phase0.AttestationData, bool, error) {
+	return sp.storage.RetrieveHighestAttestation(pubKey)
+}
+
+func (sp *slashingProtector) RetrieveHighestProposal(pubKey []byte) (phase0.Slot, bool, error) {
+	return sp.storage.RetrieveHighestProposal(pubKey)
+}
+
+func (sp *slashingProtector) IsAttestationSlashable(pk spectypes.ShareValidatorPK, data *phase0.AttestationData) error {
+	if val, err := sp.protection.IsSlashableAttestation(pk, data); err != nil || val != nil {
+		if err != nil {
+			return err
+		}
+		return errors.Errorf("slashable attestation (%s), not signing", val.Status)
+	}
+	return nil
+}
+
+func (sp *slashingProtector) IsBeaconBlockSlashable(pk []byte, slot phase0.Slot) error {
+	status, err := sp.protection.IsSlashableProposal(pk, slot)
+	if err != nil {
+		return err
+	}
+	if status.Status != core.ValidProposal {
+		return errors.Errorf("slashable proposal (%s), not signing", status.Status)
+	}
+
+	return nil
+}
+
+// BumpSlashingProtection updates the slashing protection data for a given public key.
+func (sp *slashingProtector) BumpSlashingProtection(pubKey []byte) error {
+	currentSlot := sp.storage.BeaconNetwork().EstimatedCurrentSlot()
+
+	// Update highest attestation data for slashing protection.
+	if err := sp.updateHighestAttestation(pubKey, currentSlot); err != nil {
+		return err
+	}
+
+	// Update highest proposal data for slashing protection.
+	if err := sp.updateHighestProposal(pubKey, currentSlot); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// updateHighestAttestation updates the highest attestation data for slashing protection.
+func (sp *slashingProtector) updateHighestAttestation(pubKey []byte, slot phase0.Slot) error {
+	// Retrieve the highest attestation data stored for the given public key.
+	retrievedHighAtt, found, err := sp.RetrieveHighestAttestation(pubKey)
+	if err != nil {
+		return fmt.Errorf("could not retrieve highest attestation: %w", err)
+	}
+
+	currentEpoch := sp.storage.BeaconNetwork().EstimatedEpochAtSlot(slot)
+	minimalSP := sp.computeMinimalAttestationSP(currentEpoch)
+
+	// Check if the retrieved highest attestation data is valid and not outdated.
+	if found && retrievedHighAtt != nil {
+		if retrievedHighAtt.Source.Epoch >= minimalSP.Source.Epoch || retrievedHighAtt.Target.Epoch >= minimalSP.Target.Epoch {
+			return nil
+		}
+	}
+
+	// At this point, either the retrieved attestation data was not found, or it was outdated.
+	// In either case, we update it to the minimal slashing protection data.
+	if err := sp.storage.SaveHighestAttestation(pubKey, minimalSP); err != nil {
+		return fmt.Errorf("could not save highest attestation: %w", err)
+	}
+
+	return nil
+}
+
+// updateHighestProposal updates the highest proposal slot for slashing protection.
+func (sp *slashingProtector) updateHighestProposal(pubKey []byte, slot phase0.Slot) error {
+	// Retrieve the highest proposal slot stored for the given public key.
+	retrievedHighProp, found, err := sp.RetrieveHighestProposal(pubKey)
+	if err != nil {
+		return fmt.Errorf("could not retrieve highest proposal: %w", err)
+	}
+
+	minimalSPSlot := sp.computeMinimalProposerSP(slot)
+
+	// Check if the retrieved highest proposal slot is valid and not outdated.
+	if found && retrievedHighProp != 0 {
+		if retrievedHighProp >= minimalSPSlot {
+			return nil
+		}
+	}
+
+	// At this point, either the retrieved proposal slot was not found, or it was outdated.
+	// In either case, we update it to the minimal slashing protection slot.
+	if err := sp.storage.SaveHighestProposal(pubKey, minimalSPSlot); err != nil {
+		return fmt.Errorf("could not save highest proposal: %w", err)
+	}
+
+	return nil
+}
+
+// computeMinimalAttestationSP calculates the minimal safe attestation data for slashing protection.
+// It takes the current epoch as an argument and returns an AttestationData object with the minimal safe source and target epochs.
+func (sp *slashingProtector) computeMinimalAttestationSP(epoch phase0.Epoch) *phase0.AttestationData {
+	// Calculate the highest safe target epoch based on the current epoch and a predefined minimum distance.
+	highestTarget := epoch + minSPAttestationEpochGap
+	// The highest safe source epoch is one less than the highest target epoch.
+	highestSource := highestTarget - 1
+
+	// Return a new AttestationData object with the calculated source and target epochs.
+	return &phase0.AttestationData{
+		Source: &phase0.Checkpoint{
+			Epoch: highestSource,
+		},
+		Target: &phase0.Checkpoint{
+			Epoch: highestTarget,
+		},
+	}
+}
+
+// computeMinimalProposerSP calculates the minimal safe slot for a block proposal to avoid slashing.
+// It takes the current slot as an argument and returns the minimal safe slot.
+func (sp *slashingProtector) computeMinimalProposerSP(slot phase0.Slot) phase0.Slot {
+	// Calculate the highest safe proposal slot based on the current slot and a predefined minimum distance.
+	return slot + minSPProposalSlotGap
+}
@@ -0,0 +1,115 @@
+package ekm
+
+// The slashing scenarios which the key managers must handle like the slashinginterceptor of the e2e tests,
+// which keeps its own copy since the e2e module depends on a released version of this one:
+// a first duty is signed in a start epoch, then a second one, modified from the first one, in an end epoch.
+// Signing the second one is slashable or not.
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// startEndEpochsDiff is the number of epochs between the start and end epochs.
+const startEndEpochsDiff = 2
+
+type attesterSlashingTest struct {
+	Name      string
+	Slashable bool
+	Apply     func(*phase0.AttestationData) error
+}
+
+var attesterSlashingTests = []attesterSlashingTest{
+	{
+		Name:      "SameSource_HigherTarget_DifferentRoot",
+		Slashable: false,
+		Apply: func(data *phase0.AttestationData) error {
+			data.Target.Epoch += startEndEpochsDiff
+			_, err := rand.Read(data.BeaconBlockRoot[:])
+			return err
+		},
+	},
+	{
+		Name:      "SameSource_SameTarget_SameRoot",
+		Slashable: true,
+		Apply: func(data *phase0.AttestationData) error {
+			return nil
+		},
+	},
+	{
+		Name:      "SameSource_SameTarget_DifferentRoot",
+		Slashable: true,
+		Apply: func(data *phase0.AttestationData) error {
+			_, err := rand.Read(data.BeaconBlockRoot[:])
+			return err
+		},
+	},
+	{
+		Name:      "LowerSource_HigherTarget_SameRoot",
+		Slashable: true,
+		Apply: func(data *phase0.AttestationData) error {
+			data.Source.Epoch--
+			return nil
+		},
+	},
+	{
+		Name:      "HigherSource_SameTarget_SameRoot",
+		Slashable: true,
+		Apply: func(data *phase0.AttestationData) error {
+			data.Source.Epoch += startEndEpochsDiff
+			return nil
+		},
+	},
+}
+
+type proposerSlashingTest struct {
+	Name      string
+	Slashable bool
+	Apply     func(*spec.VersionedBeaconBlock) error
+}
+
+var proposerSlashingTests = []proposerSlashingTest{
+	{
+		Name:      "HigherSlot_DifferentRoot",
+		Slashable: false,
+		Apply: func(block *spec.VersionedBeaconBlock) error {
+			switch block.Version {
+			case spec.DataVersionCapella:
+				block.Capella.Slot++
+			default:
+				return fmt.Errorf("unsupported version: %s", block.Version)
+			}
+			_, err := rand.Read(block.Capella.ParentRoot[:])
+			return err
+		},
+	},
+	{
+		Name:      "SameSlot_DifferentRoot",
+		Slashable: true,
+		Apply: func(block *spec.VersionedBeaconBlock) error {
+			switch block.Version {
+			case spec.DataVersionCapella:
+				_, err := rand.Read(block.Capella.ParentRoot[:])
+				return err
+			default:
+				return fmt.Errorf("unsupported version: %s", block.Version)
+			}
+		},
+	},
+	{
+		Name:      "LowerSlot_SameRoot",
+		Slashable: true,
+		Apply: func(block *spec.VersionedBeaconBlock) error {
+			switch block.Version {
+			case spec.DataVersionCapella:
+				block.Capella.Slot--
+			default:
+				return fmt.Errorf("unsupported version: %s", block.Version)
+			}
+			return nil
+		},
+	},
+}
@@ -0,0 +1,200 @@
+package web3signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultRequestTimeout is the default timeout for requests to the remote signer.
+	DefaultRequestTimeout = 5 * time.Second
+
+	signPath      = "/api/v1/eth2/sign/"
+	publicKeyPath = "/api/v1/eth2/publicKeys"
+	keystoresPath = "/eth/v1/keystores"
+	upcheckPath   = "/upcheck"
+
+	maxErrorBodySize = 1024
+)
+
+// Client is an HTTP client of a Web3Signer-compatible remote signer.
+// Signing requests go through the eth2 signing API,
+// while keys are managed through the keymanager API.
+type Client struct {
+	logger     *zap.Logger
+	baseURL    string
+	authToken  string
+	httpClient *http.Client
+}
+
+// Option defines a Client configuration option.
+type Option func(*Client)
+
+// WithLogger enables logging.
+func WithLogger(logger *zap.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger.Named("web3signer")
+	}
+}
+
+// WithRequestTimeout sets the timeout of each request to the remote signer.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// WithAuthToken sets the bearer token used to authenticate keymanager API requests.
+func WithAuthToken(token string) Option {
+	return func(c *Client) {
+		c.authToken = token
+	}
+}
+
+// New creates a new remote signer client.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		logger:  zap.NewNop(),
+		baseURL: strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: DefaultRequestTimeout,
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Healthy returns an error if the remote signer isn't reachable or isn't ready to sign.
+func (c *Client) Healthy(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, upcheckPath, nil, nil, false)
+}
+
+// Sign requests a signature of the given object by the key with the given public key.
+func (c *Client) Sign(ctx context.Context, pubKey []byte, req *SignRequest) ([]byte, error) {
+	var resp SignResponse
+	if err := c.do(ctx, http.MethodPost, signPath+hexutil.Encode(pubKey), req, &resp, false); err != nil {
+		return nil, fmt.Errorf("sign %s: %w", req.Type, err)
+	}
+	if len(resp.Signature) == 0 {
+		return nil, fmt.Errorf("sign %s: empty signature", req.Type)
+	}
+	return resp.Signature, nil
+}
+
+// ListKeys returns the public keys the remote signer can sign with.
+func (c *Client) ListKeys(ctx context.Context) ([][]byte, error) {
+	var resp []hexutil.Bytes
+	if err := c.do(ctx, http.MethodGet, publicKeyPath, nil, &resp, false); err != nil {
+		return nil, fmt.Errorf("list keys: %w", err)
+	}
+	keys := make([][]byte, 0, len(resp))
+	for _, key := range resp {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// ImportKeystore imports an EIP-2335 keystore into the remote signer.
+// Importing a keystore which already exists in the remote signer isn't an error.
+func (c *Client) ImportKeystore(ctx context.Context, keystore, password string) error {
+	req := &ImportKeystoresRequest{
+		Keystores: []string{keystore},
+		Passwords: []string{password},
+	}
+	var resp KeystoresResponse
+	if err := c.do(ctx, http.MethodPost, keystoresPath, req, &resp, true); err != nil {
+		return fmt.Errorf("import keystore: %w", err)
+	}
+	if len(resp.Data) != 1 {
+		return fmt.Errorf("import keystore: unexpected number of statuses: %d", len(resp.Data))
+	}
+	switch resp.Data[0].Status {
+	case StatusImported, StatusDuplicate:
+		return nil
+	default:
+		return fmt.Errorf("import keystore: %s: %s", resp.Data[0].Status, resp.Data[0].Message)
+	}
+}
+
+// DeleteKeystore deletes the keystore with the given public key from the remote signer.
+// Deleting a keystore which doesn't exist in the remote signer isn't an error.
+func (c *Client) DeleteKeystore(ctx context.Context, pubKey []byte) error {
+	req := &DeleteKeystoresRequest{
+		Pubkeys: []hexutil.Bytes{pubKey},
+	}
+	var resp KeystoresResponse
+	if err := c.do(ctx, http.MethodDelete, keystoresPath, req, &resp, true); err != nil {
+		return fmt.Errorf("delete keystore: %w", err)
+	}
+	if len(resp.Data) != 1 {
+		return fmt.Errorf("delete keystore: unexpected number of statuses: %d", len(resp.Data))
+	}
+	switch resp.Data[0].Status {
+	case StatusDeleted, StatusNotActive, StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("delete keystore: %s: %s", resp.Data[0].Status, resp.Data[0].Message)
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, path string, reqBody, respBody any, authenticated bool) error {
+	var body io.Reader
+	if reqBody != nil {
+		data, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authenticated && c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	c.logger.Debug("remote signer request",
+		zap.String("method", method),
+		zap.String("path", path),
+		zap.Int("status", resp.StatusCode),
+		zap.Duration("took", time.Since(start)),
+	)
+
+	if resp.StatusCode != http.StatusOK {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(errBody)))
+	}
+	if respBody == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(respBody); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
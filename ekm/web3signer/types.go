@@ -0,0 +1,108 @@
+package web3signer
+
+import (
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SignedObjectType is the type of the object to be signed by the remote signer.
+type SignedObjectType string
+
+const (
+	AggregationSlot                   SignedObjectType = "AGGREGATION_SLOT"
+	AggregateAndProof                 SignedObjectType = "AGGREGATE_AND_PROOF"
+	Attestation                       SignedObjectType = "ATTESTATION"
+	BlockV2                           SignedObjectType = "BLOCK_V2"
+	RandaoReveal                      SignedObjectType = "RANDAO_REVEAL"
+	VoluntaryExit                     SignedObjectType = "VOLUNTARY_EXIT"
+	SyncCommitteeMessage              SignedObjectType = "SYNC_COMMITTEE_MESSAGE"
+	SyncCommitteeSelectionProof       SignedObjectType = "SYNC_COMMITTEE_SELECTION_PROOF"
+	SyncCommitteeContributionAndProof SignedObjectType = "SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF"
+	ValidatorRegistration             SignedObjectType = "VALIDATOR_REGISTRATION"
+)
+
+// ForkInfo is the fork information the remote signer uses to compute the signature domain.
+type ForkInfo struct {
+	Fork                  *phase0.Fork  `json:"fork"`
+	GenesisValidatorsRoot hexutil.Bytes `json:"genesis_validators_root"`
+}
+
+// SignRequest is the body of a signing request.
+// Exactly one of the object fields must be set, matching Type.
+type SignRequest struct {
+	Type        SignedObjectType `json:"type"`
+	ForkInfo    *ForkInfo        `json:"fork_info,omitempty"`
+	SigningRoot hexutil.Bytes    `json:"signingRoot"`
+
+	AggregationSlot             *AggregationSlotData                `json:"aggregation_slot,omitempty"`
+	AggregateAndProof           *phase0.AggregateAndProof           `json:"aggregate_and_proof,omitempty"`
+	Attestation                 *phase0.AttestationData             `json:"attestation,omitempty"`
+	BeaconBlock                 *BeaconBlockData                    `json:"beacon_block,omitempty"`
+	RandaoReveal                *RandaoRevealData                   `json:"randao_reveal,omitempty"`
+	VoluntaryExit               *phase0.VoluntaryExit               `json:"voluntary_exit,omitempty"`
+	SyncCommitteeMessage        *SyncCommitteeMessageData           `json:"sync_committee_message,omitempty"`
+	SyncAggregatorSelectionData *altair.SyncAggregatorSelectionData `json:"sync_aggregator_selection_data,omitempty"`
+	ContributionAndProof        *altair.ContributionAndProof        `json:"contribution_and_proof,omitempty"`
+	ValidatorRegistration       *eth2apiv1.ValidatorRegistration    `json:"validator_registration,omitempty"`
+}
+
+// AggregationSlotData is the object signed for an aggregator selection proof.
+type AggregationSlotData struct {
+	Slot phase0.Slot `json:"slot"`
+}
+
+// RandaoRevealData is the object signed for a RANDAO reveal.
+type RandaoRevealData struct {
+	Epoch phase0.Epoch `json:"epoch"`
+}
+
+// SyncCommitteeMessageData is the object signed for a sync committee message.
+type SyncCommitteeMessageData struct {
+	BeaconBlockRoot hexutil.Bytes `json:"beacon_block_root"`
+	Slot            phase0.Slot   `json:"slot"`
+}
+
+// BeaconBlockData is the object signed for a block proposal.
+// Since Bellatrix, the remote signer only needs the block header.
+type BeaconBlockData struct {
+	Version     string                    `json:"version"`
+	BlockHeader *phase0.BeaconBlockHeader `json:"block_header"`
+}
+
+// SignResponse is the body of a successful signing response.
+type SignResponse struct {
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// ImportKeystoresRequest is the body of a keymanager API keystore import request.
+type ImportKeystoresRequest struct {
+	Keystores []string `json:"keystores"`
+	Passwords []string `json:"passwords"`
+}
+
+// DeleteKeystoresRequest is the body of a keymanager API keystore deletion request.
+type DeleteKeystoresRequest struct {
+	Pubkeys []hexutil.Bytes `json:"pubkeys"`
+}
+
+// KeystoreStatus is the per-key status returned by the keymanager API.
+type KeystoreStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// KeystoresResponse is the body of a keymanager API import or deletion response.
+type KeystoresResponse struct {
+	Data []KeystoreStatus `json:"data"`
+}
+
+const (
+	StatusImported  = "imported"
+	StatusDuplicate = "duplicate"
+	StatusDeleted   = "deleted"
+	StatusNotActive = "not_active"
+	StatusNotFound  = "not_found"
+	StatusError     = "error"
+)
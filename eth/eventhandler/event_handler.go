@@ -66,6 +66,8 @@ type EventHandler struct {
 	keyManager        ekm.KeyManager
 	beacon            beaconprotocol.BeaconNode
 
+	reorgJournalDepth uint64
+
 	fullNode bool
 	logger   *zap.Logger
 	metrics  metrics
@@ -156,15 +158,21 @@ func (eh *EventHandler) processBlockEvents(block executionclient.BlockLogs) ([]T
 		// Same or higher block has already been processed, this should never happen!
 		// Returning an error to signal that we should stop processing and
 		// investigate the issue.
-		//
-		// TODO: this may happen during reorgs, and we should either
-		// implement reorg support or only process finalized blocks.
+		// Reorgs are rolled back by HandleReorg before the blocks of the new chain arrive.
 		return nil, ErrInferiorBlock
 	}
 
+	// Record the changes of the block so that they can be reverted if it's reorged out.
+	var eventsTxn basedb.Txn = txn
+	var journal *journalingTxn
+	if eh.reorgJournalDepth > 0 && block.BlockHash != (ethcommon.Hash{}) {
+		journal = newJournalingTxn(txn)
+		eventsTxn = journal
+	}
+
 	var tasks []Task
 	for _, log := range block.Logs {
-		task, err := eh.processEvent(txn, log)
+		task, err := eh.processEvent(eventsTxn, log)
 		if err != nil {
 			return nil, err
 		}
@@ -173,6 +181,14 @@ func (eh *EventHandler) processBlockEvents(block executionclient.BlockLogs) ([]T
 		}
 	}
 
+	var finalizedRemovals []removedShare
+	if journal != nil {
+		finalizedRemovals, err = eh.journalBlock(journal, journal.entry(block.BlockNumber, block.BlockHash))
+		if err != nil {
+			return nil, fmt.Errorf("journal block: %w", err)
+		}
+	}
+
 	if err := eh.nodeStorage.SaveLastProcessedBlock(txn, new(big.Int).SetUint64(block.BlockNumber)); err != nil {
 		return nil, fmt.Errorf("set last processed block: %w", err)
 	}
@@ -181,6 +197,10 @@ func (eh *EventHandler) processBlockEvents(block executionclient.BlockLogs) ([]T
 		return nil, fmt.Errorf("commit transaction: %w", err)
 	}
 
+	for _, removed := range finalizedRemovals {
+		eh.removeShareKey(removed.ValidatorPubKey, removed.SharePubKey)
+	}
+
 	return tasks, nil
 }
 
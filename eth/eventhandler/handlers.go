@@ -369,8 +369,10 @@ func (eh *EventHandler) handleValidatorRemoved(txn basedb.Txn, event *contract.C
 		logger = logger.With(zap.String("validator_pubkey", hex.EncodeToString(share.ValidatorPubKey[:])))
 	}
 	if isOperatorShare {
-		err := eh.keyManager.RemoveShare(hex.EncodeToString(share.SharePubKey))
-		if err != nil {
+		if journal, ok := txn.(*journalingTxn); ok {
+			// Keep the key until the removal can no longer be rolled back.
+			journal.deferShareRemoval(share.ValidatorPubKey[:], share.SharePubKey)
+		} else if err := eh.keyManager.RemoveShare(hex.EncodeToString(share.SharePubKey)); err != nil {
 			return emptyPK, fmt.Errorf("could not remove share from ekm storage: %w", err)
 		}
 
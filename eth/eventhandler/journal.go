@@ -0,0 +1,172 @@
+package eventhandler
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// journalPrefix is the db prefix of the per-block undo log.
+var journalPrefix = []byte("operator/reorg-journal/")
+
+// undoOp restores a single key to the value it had before a block was processed.
+type undoOp struct {
+	Key     []byte `json:"key"`
+	Value   []byte `json:"value,omitempty"`
+	Existed bool   `json:"existed"`
+}
+
+// removedShare is a share which was removed from the key manager's view,
+// but whose key is kept until its removal can no longer be rolled back.
+type removedShare struct {
+	ValidatorPubKey []byte `json:"validator_pub_key"`
+	SharePubKey     []byte `json:"share_pub_key"`
+}
+
+// journalEntry is the undo log of a single processed block.
+type journalEntry struct {
+	BlockNumber   uint64         `json:"block_number"`
+	BlockHash     ethcommon.Hash `json:"block_hash"`
+	Undo          []undoOp       `json:"undo,omitempty"`
+	RemovedShares []removedShare `json:"removed_shares,omitempty"`
+}
+
+func journalKey(blockNumber uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, blockNumber)
+}
+
+// loadJournal returns the journal entries ordered by block number.
+func loadJournal(r basedb.Reader) ([]*journalEntry, error) {
+	var entries []*journalEntry
+	err := r.GetAll(journalPrefix, func(i int, obj basedb.Obj) error {
+		entry := &journalEntry{}
+		if err := json.Unmarshal(obj.Value, entry); err != nil {
+			return fmt.Errorf("decode journal entry: %w", err)
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].BlockNumber < entries[j].BlockNumber
+	})
+	return entries, nil
+}
+
+func saveJournalEntry(rw basedb.ReadWriter, entry *journalEntry) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode journal entry: %w", err)
+	}
+	return rw.Set(journalPrefix, journalKey(entry.BlockNumber), value)
+}
+
+func deleteJournalEntry(rw basedb.ReadWriter, blockNumber uint64) error {
+	return rw.Delete(journalPrefix, journalKey(blockNumber))
+}
+
+// rollback reverts the changes recorded in the entry.
+func (e *journalEntry) rollback(rw basedb.ReadWriter) error {
+	for i := len(e.Undo) - 1; i >= 0; i-- {
+		op := e.Undo[i]
+		if op.Existed {
+			if err := rw.Set(nil, op.Key, op.Value); err != nil {
+				return fmt.Errorf("restore key: %w", err)
+			}
+		} else {
+			if err := rw.Delete(nil, op.Key); err != nil {
+				return fmt.Errorf("delete key: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// journalingTxn is a basedb.Txn which records the previous value of every key
+// it modifies, so that the changes can be reverted after a reorg.
+type journalingTxn struct {
+	basedb.Txn
+	undo          []undoOp
+	seen          map[string]struct{}
+	removedShares []removedShare
+}
+
+func newJournalingTxn(txn basedb.Txn) *journalingTxn {
+	return &journalingTxn{
+		Txn:  txn,
+		seen: make(map[string]struct{}),
+	}
+}
+
+func (t *journalingTxn) Set(prefix []byte, key []byte, value []byte) error {
+	if err := t.record(prefix, key); err != nil {
+		return err
+	}
+	return t.Txn.Set(prefix, key, value)
+}
+
+func (t *journalingTxn) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	return t.Txn.SetMany(prefix, n, func(i int) (basedb.Obj, error) {
+		obj, err := next(i)
+		if err != nil {
+			return obj, err
+		}
+		return obj, t.record(prefix, obj.Key)
+	})
+}
+
+func (t *journalingTxn) Delete(prefix []byte, key []byte) error {
+	if err := t.record(prefix, key); err != nil {
+		return err
+	}
+	return t.Txn.Delete(prefix, key)
+}
+
+// deferShareRemoval records a share whose key should be removed from the key manager
+// once the block removing it can no longer be rolled back.
+func (t *journalingTxn) deferShareRemoval(validatorPubKey, sharePubKey []byte) {
+	t.removedShares = append(t.removedShares, removedShare{
+		ValidatorPubKey: validatorPubKey,
+		SharePubKey:     sharePubKey,
+	})
+}
+
+// record saves the current value of the key, unless it was already recorded.
+func (t *journalingTxn) record(prefix []byte, key []byte) error {
+	fullKey := make([]byte, 0, len(prefix)+len(key))
+	fullKey = append(fullKey, prefix...)
+	fullKey = append(fullKey, key...)
+	if _, ok := t.seen[string(fullKey)]; ok {
+		return nil
+	}
+
+	obj, found, err := t.Txn.Get(nil, fullKey)
+	if err != nil {
+		return fmt.Errorf("read previous value: %w", err)
+	}
+
+	t.seen[string(fullKey)] = struct{}{}
+	t.undo = append(t.undo, undoOp{
+		Key:     fullKey,
+		Value:   obj.Value,
+		Existed: found,
+	})
+	return nil
+}
+
+func (t *journalingTxn) entry(blockNumber uint64, blockHash ethcommon.Hash) *journalEntry {
+	return &journalEntry{
+		BlockNumber:   blockNumber,
+		BlockHash:     blockHash,
+		Undo:          t.undo,
+		RemovedShares: t.removedShares,
+	}
+}
@@ -0,0 +1,62 @@
+package eventhandler
+
+import (
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestJournalingTxnRollback(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	prefix := []byte("test/")
+	require.NoError(t, db.Set(prefix, []byte("updated"), []byte("old")))
+	require.NoError(t, db.Set(prefix, []byte("deleted"), []byte("old")))
+
+	txn := newJournalingTxn(db.Begin())
+	require.NoError(t, txn.Set(prefix, []byte("updated"), []byte("new")))
+	require.NoError(t, txn.Set(prefix, []byte("updated"), []byte("newer")))
+	require.NoError(t, txn.Delete(prefix, []byte("deleted")))
+	require.NoError(t, txn.SetMany(prefix, 2, func(i int) (basedb.Obj, error) {
+		return basedb.Obj{Key: []byte{'a' + byte(i)}, Value: []byte("created")}, nil
+	}))
+
+	entry := txn.entry(10, ethcommon.HexToHash("0x01"))
+	require.Len(t, entry.Undo, 4)
+	require.NoError(t, saveJournalEntry(txn.Txn, entry))
+	require.NoError(t, txn.Commit())
+
+	// Reload the entry as HandleReorg would.
+	entries, err := loadJournal(db)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, entry.BlockHash, entries[0].BlockHash)
+
+	require.NoError(t, db.Update(func(txn basedb.Txn) error {
+		return entries[0].rollback(txn)
+	}))
+
+	obj, found, err := db.Get(prefix, []byte("updated"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("old"), obj.Value)
+
+	obj, found, err = db.Get(prefix, []byte("deleted"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("old"), obj.Value)
+
+	for _, key := range []string{"a", "b"} {
+		_, found, err = db.Get(prefix, []byte(key))
+		require.NoError(t, err)
+		require.False(t, found)
+	}
+}
@@ -28,3 +28,11 @@ func WithFullNode() Option {
 		eh.fullNode = true
 	}
 }
+
+// WithReorgJournal keeps an undo log of the given number of latest blocks,
+// allowing HandleReorg to roll back their changes.
+func WithReorgJournal(depth uint64) Option {
+	return func(eh *EventHandler) {
+		eh.reorgJournalDepth = depth
+	}
+}
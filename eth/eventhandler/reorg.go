@@ -0,0 +1,325 @@
+package eventhandler
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// ErrReorgTooDeep is returned when none of the journaled blocks is canonical anymore,
+// so the registry state can't be rolled back to a common ancestor.
+var ErrReorgTooDeep = errors.New("reorg is deeper than the journal")
+
+// ErrJournalDisabled is returned when rolling back without a reorg journal.
+var ErrJournalDisabled = errors.New("reorg journal is disabled")
+
+// BlockHashFunc returns the hash of the canonical block with the given number.
+type BlockHashFunc func(ctx context.Context, blockNumber uint64) (ethcommon.Hash, error)
+
+// HandleReorg checks whether the recently processed blocks are still canonical.
+// If some of them were reorged out, it reverts their changes to the registry state,
+// sets the last processed block to their latest canonical ancestor and executes
+// the tasks required to bring the running validators in line with the reverted state.
+func (eh *EventHandler) HandleReorg(ctx context.Context, blockHash BlockHashFunc) (ancestor uint64, reorged bool, err error) {
+	if eh.reorgJournalDepth == 0 {
+		return 0, false, nil
+	}
+
+	txn := eh.nodeStorage.Begin()
+	defer txn.Discard()
+
+	entries, err := loadJournal(txn)
+	if err != nil {
+		return 0, false, fmt.Errorf("load journal: %w", err)
+	}
+
+	// Find the latest journaled block which is still canonical.
+	canonical := len(entries) - 1
+	for ; canonical >= 0; canonical-- {
+		hash, err := blockHash(ctx, entries[canonical].BlockNumber)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return 0, false, fmt.Errorf("get block hash: %w", err)
+		}
+		if hash == entries[canonical].BlockHash {
+			break
+		}
+	}
+	if canonical == len(entries)-1 {
+		return 0, false, nil
+	}
+	if canonical < 0 {
+		return 0, false, fmt.Errorf("%w: none of the last %d blocks is canonical", ErrReorgTooDeep, len(entries))
+	}
+
+	ancestor = entries[canonical].BlockNumber
+	reorgedEntries := entries[canonical+1:]
+
+	logger := eh.logger.With(
+		zap.Uint64("ancestor_block", ancestor),
+		fields.ToBlock(reorgedEntries[len(reorgedEntries)-1].BlockNumber),
+	)
+	logger.Warn("detected reorg, rolling back registry state")
+
+	if err := eh.rollBack(logger, txn, ancestor, reorgedEntries); err != nil {
+		return 0, false, err
+	}
+	return ancestor, true, nil
+}
+
+// RollBack reverts the changes of the processed blocks after the given one to the registry state,
+// so that they can be processed again, and sets the last processed block to it.
+// It fails if the changes of some of these blocks are no longer journaled.
+func (eh *EventHandler) RollBack(toBlock uint64) error {
+	if eh.reorgJournalDepth == 0 {
+		return ErrJournalDisabled
+	}
+
+	txn := eh.nodeStorage.Begin()
+	defer txn.Discard()
+
+	lastProcessedBlock, found, err := eh.nodeStorage.GetLastProcessedBlock(txn)
+	if err != nil {
+		return fmt.Errorf("get last processed block: %w", err)
+	}
+	if !found || lastProcessedBlock.Uint64() <= toBlock {
+		return nil
+	}
+	// Entries are pruned relative to the latest journaled block, which is at most the last processed one.
+	if toBlock+eh.reorgJournalDepth < lastProcessedBlock.Uint64() {
+		return fmt.Errorf("%w: block %d is more than %d blocks before the last processed block %d",
+			ErrReorgTooDeep, toBlock, eh.reorgJournalDepth, lastProcessedBlock.Uint64())
+	}
+
+	entries, err := loadJournal(txn)
+	if err != nil {
+		return fmt.Errorf("load journal: %w", err)
+	}
+	var revertedEntries []*journalEntry
+	for _, entry := range entries {
+		if entry.BlockNumber > toBlock {
+			revertedEntries = append(revertedEntries, entry)
+		}
+	}
+
+	logger := eh.logger.With(
+		zap.Uint64("ancestor_block", toBlock),
+		fields.ToBlock(lastProcessedBlock.Uint64()),
+	)
+	logger.Info("rolling back registry state")
+
+	return eh.rollBack(logger, txn, toBlock, revertedEntries)
+}
+
+// rollBack reverts the given journal entries in the transaction and commits it with the ancestor
+// as the last processed block, then executes the tasks required to bring the running validators
+// in line with the reverted state.
+func (eh *EventHandler) rollBack(logger *zap.Logger, txn basedb.Txn, ancestor uint64, reorgedEntries []*journalEntry) error {
+	before, err := eh.validatorsSnapshot(txn)
+	if err != nil {
+		return err
+	}
+
+	for i := len(reorgedEntries) - 1; i >= 0; i-- {
+		if err := reorgedEntries[i].rollback(txn); err != nil {
+			return fmt.Errorf("roll back block %d: %w", reorgedEntries[i].BlockNumber, err)
+		}
+		if err := deleteJournalEntry(txn, reorgedEntries[i].BlockNumber); err != nil {
+			return fmt.Errorf("delete journal entry: %w", err)
+		}
+	}
+
+	if err := eh.nodeStorage.SaveLastProcessedBlock(txn, new(big.Int).SetUint64(ancestor)); err != nil {
+		return fmt.Errorf("set last processed block: %w", err)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	// The shares cache was updated by the reverted blocks, so it has to be rebuilt from the db.
+	if err := eh.nodeStorage.Shares().Reload(); err != nil {
+		return fmt.Errorf("reload shares: %w", err)
+	}
+
+	after, err := eh.validatorsSnapshot(nil)
+	if err != nil {
+		return err
+	}
+
+	// Shares added by the reverted blocks are no longer ours, and shares removed by them
+	// can now be forgotten unless the rollback restored them.
+	for pk, share := range before.shares {
+		if _, ok := after.shares[pk]; !ok {
+			eh.removeShareKey(share.ValidatorPubKey[:], share.SharePubKey)
+		}
+	}
+	for _, entry := range reorgedEntries {
+		for _, removed := range entry.RemovedShares {
+			eh.removeShareKey(removed.ValidatorPubKey, removed.SharePubKey)
+		}
+	}
+
+	tasks := eh.reorgTasks(before, after)
+	logger.Info("rolled back registry state",
+		zap.Int("reverted_blocks", len(reorgedEntries)),
+		zap.Int("tasks", len(tasks)))
+
+	for _, task := range tasks {
+		taskLogger := logger.With(fields.Type(task))
+		if err := task.Execute(); err != nil {
+			taskLogger.Error("failed to execute task", zap.Error(err))
+		} else {
+			taskLogger.Debug("executed task")
+		}
+	}
+
+	return nil
+}
+
+// journalBlock saves the undo log of the given block and prunes the entries which are
+// too old to be rolled back, returning the shares whose removal became final.
+func (eh *EventHandler) journalBlock(txn *journalingTxn, entry *journalEntry) ([]removedShare, error) {
+	if err := saveJournalEntry(txn.Txn, entry); err != nil {
+		return nil, err
+	}
+
+	if entry.BlockNumber < eh.reorgJournalDepth {
+		return nil, nil
+	}
+	oldest := entry.BlockNumber - eh.reorgJournalDepth
+
+	entries, err := loadJournal(txn.Txn)
+	if err != nil {
+		return nil, fmt.Errorf("load journal: %w", err)
+	}
+
+	var finalized []removedShare
+	for _, e := range entries {
+		if e.BlockNumber > oldest {
+			break
+		}
+		if err := deleteJournalEntry(txn.Txn, e.BlockNumber); err != nil {
+			return nil, fmt.Errorf("delete journal entry: %w", err)
+		}
+		finalized = append(finalized, e.RemovedShares...)
+	}
+	return finalized, nil
+}
+
+// removeShareKey removes the share's key from the key manager unless the share is still stored.
+func (eh *EventHandler) removeShareKey(validatorPubKey, sharePubKey []byte) {
+	if share, exists := eh.nodeStorage.Shares().Get(nil, validatorPubKey); exists &&
+		share.BelongsToOperator(eh.operatorDataStore.GetOperatorID()) {
+		return
+	}
+
+	if err := eh.keyManager.RemoveShare(hex.EncodeToString(sharePubKey)); err != nil {
+		eh.logger.Error("could not remove share from ekm storage",
+			fields.PubKey(validatorPubKey),
+			zap.Error(err))
+	}
+}
+
+// validatorsSnapshot captures the state of the operator's validators
+// which is relevant to the running validators.
+type validatorsSnapshot struct {
+	shares     map[string]snapshotShare
+	recipients map[ethcommon.Address]bellatrix.ExecutionAddress
+}
+
+// snapshotShare holds the share along with its liquidation status at the time of the snapshot.
+type snapshotShare struct {
+	*ssvtypes.SSVShare
+	liquidated bool
+}
+
+func (eh *EventHandler) validatorsSnapshot(r basedb.Reader) (*validatorsSnapshot, error) {
+	snapshot := &validatorsSnapshot{
+		shares: make(map[string]snapshotShare),
+	}
+
+	var owners []ethcommon.Address
+	ownersSet := make(map[ethcommon.Address]struct{})
+	operatorID := eh.operatorDataStore.GetOperatorID()
+	for _, share := range eh.nodeStorage.Shares().List(r) {
+		if !share.BelongsToOperator(operatorID) {
+			continue
+		}
+		snapshot.shares[hex.EncodeToString(share.ValidatorPubKey[:])] = snapshotShare{
+			SSVShare:   share,
+			liquidated: share.Liquidated,
+		}
+		if _, ok := ownersSet[share.OwnerAddress]; !ok {
+			ownersSet[share.OwnerAddress] = struct{}{}
+			owners = append(owners, share.OwnerAddress)
+		}
+	}
+
+	recipients, err := eh.nodeStorage.GetRecipientDataMany(r, owners)
+	if err != nil {
+		return nil, fmt.Errorf("get recipients: %w", err)
+	}
+	snapshot.recipients = recipients
+
+	return snapshot, nil
+}
+
+// reorgTasks returns the tasks which bring the running validators from the before state to the after state.
+func (eh *EventHandler) reorgTasks(before, after *validatorsSnapshot) []Task {
+	var tasks []Task
+
+	for pk, share := range before.shares {
+		afterShare, ok := after.shares[pk]
+		switch {
+		case !ok:
+			tasks = append(tasks, NewStopValidatorTask(eh.taskExecutor, share.ValidatorPubKey))
+		case !share.liquidated && afterShare.liquidated:
+			tasks = append(tasks, NewLiquidateClusterTask(eh.taskExecutor, afterShare.OwnerAddress, afterShare.OperatorIDs(), []*ssvtypes.SSVShare{afterShare.SSVShare}))
+		}
+	}
+
+	for pk, share := range after.shares {
+		if share.liquidated {
+			continue
+		}
+		if beforeShare, ok := before.shares[pk]; !ok || beforeShare.liquidated {
+			tasks = append(tasks, NewReactivateClusterTask(eh.taskExecutor, share.OwnerAddress, share.OperatorIDs(), []*ssvtypes.SSVShare{share.SSVShare}))
+		}
+	}
+
+	for owner := range ownersOf(after.shares) {
+		recipient, ok := after.recipients[owner]
+		if recipient == before.recipients[owner] {
+			continue
+		}
+		if !ok {
+			// Without a recipient set in the registry, the owner address is used.
+			recipient = bellatrix.ExecutionAddress(owner)
+		}
+		tasks = append(tasks, NewUpdateFeeRecipientTask(eh.taskExecutor, owner, ethcommon.Address(recipient)))
+	}
+
+	return tasks
+}
+
+func ownersOf(shares map[string]snapshotShare) map[ethcommon.Address]struct{} {
+	owners := make(map[ethcommon.Address]struct{})
+	for _, share := range shares {
+		owners[share.OwnerAddress] = struct{}{}
+	}
+	return owners
+}
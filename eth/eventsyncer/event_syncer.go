@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"time"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"go.uber.org/zap"
 
+	"github.com/ssvlabs/ssv/eth/eventhandler"
 	"github.com/ssvlabs/ssv/eth/executionclient"
 	"github.com/ssvlabs/ssv/logging/fields"
 	nodestorage "github.com/ssvlabs/ssv/operator/storage"
@@ -26,10 +28,13 @@ var (
 type ExecutionClient interface {
 	FetchHistoricalLogs(ctx context.Context, fromBlock uint64) (logs <-chan executionclient.BlockLogs, errors <-chan error, err error)
 	StreamLogs(ctx context.Context, fromBlock uint64) <-chan executionclient.BlockLogs
+	BlockHash(ctx context.Context, blockNumber uint64) (ethcommon.Hash, error)
 }
 
 type EventHandler interface {
 	HandleBlockEventsStream(logs <-chan executionclient.BlockLogs, executeTasks bool) (uint64, error)
+	HandleReorg(ctx context.Context, blockHash eventhandler.BlockHashFunc) (ancestor uint64, reorged bool, err error)
+	RollBack(toBlock uint64) error
 }
 
 // EventSyncer syncs registry contract events from the given ExecutionClient
@@ -45,6 +50,16 @@ type EventSyncer struct {
 
 	lastProcessedBlock       uint64
 	lastProcessedBlockChange time.Time
+
+	// resync receives the requests to restart the ongoing sync from a block.
+	resync chan resyncRequest
+}
+
+// resyncRequest requests the ongoing sync to restart from the given block,
+// and receives the outcome of rolling back the registry state to it.
+type resyncRequest struct {
+	fromBlock uint64
+	result    chan error
 }
 
 func New(nodeStorage nodestorage.Storage, executionClient ExecutionClient, eventHandler EventHandler, opts ...Option) *EventSyncer {
@@ -56,6 +71,7 @@ func New(nodeStorage nodestorage.Storage, executionClient ExecutionClient, event
 		logger:             zap.NewNop(),
 		metrics:            nopMetrics{},
 		stalenessThreshold: 150 * time.Second,
+		resync:             make(chan resyncRequest),
 	}
 
 	for _, opt := range opts {
@@ -86,10 +102,46 @@ func (es *EventSyncer) Healthy(ctx context.Context) error {
 }
 
 // SyncHistory reads and processes historical events since the given fromBlock.
+// The recently processed blocks are checked against the canonical chain first, so that
+// a reorg which happened while the node was down is rolled back and its events are read again
+// since the latest canonical ancestor. The check is repeated after reading, in case a reorg
+// happened meanwhile. It returns ErrNothingToSync only if nothing was rolled back or read.
 func (es *EventSyncer) SyncHistory(ctx context.Context, fromBlock uint64) (lastProcessedBlock uint64, err error) {
+	synced := false
+	for {
+		ancestor, reorged, err := es.eventHandler.HandleReorg(ctx, es.executionClient.BlockHash)
+		if err != nil {
+			return 0, fmt.Errorf("failed to handle reorg before syncing historical events: %w", err)
+		}
+		if !reorged && synced {
+			return lastProcessedBlock, nil
+		}
+		if reorged {
+			es.logger.Info("registry events were reorged", zap.Uint64("ancestor_block", ancestor))
+			fromBlock = ancestor + 1
+			lastProcessedBlock = ancestor
+		}
+
+		syncedBlock, err := es.syncHistory(ctx, fromBlock)
+		if errors.Is(err, executionclient.ErrNothingToSync) {
+			if lastProcessedBlock == 0 {
+				// Nothing to sync, should keep ongoing sync from the given fromBlock.
+				return 0, executionclient.ErrNothingToSync
+			}
+			// Ongoing sync should go on from the block after the rolled back ones.
+			return lastProcessedBlock, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		lastProcessedBlock = syncedBlock
+		synced = true
+	}
+}
+
+func (es *EventSyncer) syncHistory(ctx context.Context, fromBlock uint64) (lastProcessedBlock uint64, err error) {
 	fetchLogs, fetchError, err := es.executionClient.FetchHistoricalLogs(ctx, fromBlock)
 	if errors.Is(err, executionclient.ErrNothingToSync) {
-		// Nothing to sync, should keep ongoing sync from the given fromBlock.
 		return 0, executionclient.ErrNothingToSync
 	}
 	if err != nil {
@@ -120,10 +172,111 @@ func (es *EventSyncer) SyncHistory(ctx context.Context, fromBlock uint64) (lastP
 }
 
 // SyncOngoing streams and processes ongoing events as they come since the given fromBlock.
+// Before each block is processed, the recently processed blocks are checked against
+// the canonical chain, and if they were reorged out, streaming restarts from their
+// latest canonical ancestor. Streaming also restarts from the block requested by Resync.
+// It fails if the check fails or the reorg can't be rolled back, since the events of
+// the new branch must not be applied on top of those of the reorged one.
 func (es *EventSyncer) SyncOngoing(ctx context.Context, fromBlock uint64) error {
-	es.logger.Info("subscribing to ongoing registry events", fields.FromBlock(fromBlock))
+	for {
+		es.logger.Info("subscribing to ongoing registry events", fields.FromBlock(fromBlock))
+
+		restartBlock, restart, err := es.syncOngoing(ctx, fromBlock)
+		if err != nil || !restart {
+			return err
+		}
+
+		es.logger.Info("restarting ongoing sync", fields.FromBlock(restartBlock))
+		fromBlock = restartBlock
+	}
+}
+
+// Resync requests the ongoing sync to restart from the given block, reprocessing the events since then.
+// The changes of the processed blocks since then are rolled back first, so that no event is applied twice.
+// It fails if the block is after the next block to process, if the changes of the processed blocks since then
+// are no longer journaled, or if the ongoing sync doesn't handle the request before the context is done.
+func (es *EventSyncer) Resync(ctx context.Context, fromBlock uint64) error {
+	if fromBlock == 0 {
+		return fmt.Errorf("from block must be positive")
+	}
+	lastProcessedBlock, found, err := es.nodeStorage.GetLastProcessedBlock(nil)
+	if err != nil {
+		return fmt.Errorf("failed to read last processed block: %w", err)
+	}
+	if !found || lastProcessedBlock == nil {
+		return ErrNodeNotReady
+	}
+	if fromBlock > lastProcessedBlock.Uint64()+1 {
+		return fmt.Errorf("from block (%d) is after the next block to process (%d)", fromBlock, lastProcessedBlock.Uint64()+1)
+	}
+
+	request := resyncRequest{
+		fromBlock: fromBlock,
+		result:    make(chan error, 1),
+	}
+	select {
+	case es.resync <- request:
+		es.logger.Info("requested resync of registry events", fields.FromBlock(fromBlock))
+	case <-ctx.Done():
+		return fmt.Errorf("ongoing sync is not running: %w", ctx.Err())
+	}
+
+	select {
+	case err := <-request.result:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("resync is still in progress: %w", ctx.Err())
+	}
+}
+
+// syncOngoing processes the streamed blocks until either the stream ends,
+// a reorg is rolled back or a resync is requested, in which case it returns the block to restart from.
+func (es *EventSyncer) syncOngoing(ctx context.Context, fromBlock uint64) (restartBlock uint64, restart bool, err error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	logs := es.executionClient.StreamLogs(streamCtx, fromBlock)
+	defer func() {
+		cancel()
+		// Unblock the stream so that it can observe the cancellation.
+		for range logs {
+		}
+	}()
+
+	for {
+		select {
+		case block, ok := <-logs:
+			if !ok {
+				return 0, false, nil
+			}
+
+			ancestor, reorged, err := es.eventHandler.HandleReorg(ctx, es.executionClient.BlockHash)
+			if err != nil {
+				return 0, false, fmt.Errorf("failed to handle reorg before block %d: %w", block.BlockNumber, err)
+			}
+			if reorged {
+				es.logger.Info("registry events were reorged", zap.Uint64("ancestor_block", ancestor))
+				return ancestor + 1, true, nil
+			}
+
+			if err := es.handleBlock(block); err != nil {
+				return 0, false, err
+			}
+
+		case request := <-es.resync:
+			if err := es.eventHandler.RollBack(request.fromBlock - 1); err != nil {
+				request.result <- fmt.Errorf("failed to roll back registry state: %w", err)
+				continue
+			}
+			request.result <- nil
+			return request.fromBlock, true, nil
+		}
+	}
+}
+
+func (es *EventSyncer) handleBlock(block executionclient.BlockLogs) error {
+	blocks := make(chan executionclient.BlockLogs, 1)
+	blocks <- block
+	close(blocks)
 
-	logs := es.executionClient.StreamLogs(ctx, fromBlock)
-	_, err := es.eventHandler.HandleBlockEventsStream(logs, true)
+	_, err := es.eventHandler.HandleBlockEventsStream(blocks, true)
 	return err
 }
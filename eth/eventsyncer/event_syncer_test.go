@@ -3,12 +3,14 @@ package eventsyncer
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
 	"math/big"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
@@ -151,6 +153,7 @@ func setupEventHandler(
 	nodeStorage operatorstorage.Storage,
 	operatorData *registrystorage.OperatorData,
 	privateKey keys.OperatorPrivateKey,
+	opts ...eventhandler.Option,
 ) *eventhandler.EventHandler {
 	operatorDataStore := operatordatastore.New(operatorData)
 	testNetworkConfig := networkconfig.TestNetwork
@@ -186,8 +189,10 @@ func setupEventHandler(
 		privateKey,
 		keyManager,
 		bc,
-		eventhandler.WithFullNode(),
-		eventhandler.WithLogger(logger))
+		append([]eventhandler.Option{
+			eventhandler.WithFullNode(),
+			eventhandler.WithLogger(logger),
+		}, opts...)...)
 
 	if err != nil {
 		t.Fatal(err)
@@ -240,3 +245,204 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.Database, privKey keys.O
 
 	return nodeStorage, operatorData
 }
+
+type streamingClient struct {
+	ExecutionClient
+	fromBlocks chan uint64
+}
+
+func (c *streamingClient) StreamLogs(ctx context.Context, fromBlock uint64) <-chan executionclient.BlockLogs {
+	c.fromBlocks <- fromBlock
+	logs := make(chan executionclient.BlockLogs)
+	go func() {
+		<-ctx.Done()
+		close(logs)
+	}()
+	return logs
+}
+
+type rollingBackHandler struct {
+	EventHandler
+	toBlocks []uint64
+	err      error
+}
+
+func (h *rollingBackHandler) RollBack(toBlock uint64) error {
+	h.toBlocks = append(h.toBlocks, toBlock)
+	return h.err
+}
+
+func TestEventSyncer_Resync(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	client := &streamingClient{fromBlocks: make(chan uint64, 1)}
+	handler := &rollingBackHandler{}
+	eventSyncer := New(nodeStorage, client, handler, WithLogger(logger))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.ErrorIs(t, eventSyncer.Resync(ctx, 1), ErrNodeNotReady)
+
+	require.NoError(t, nodeStorage.SaveLastProcessedBlock(nil, big.NewInt(100)))
+	require.ErrorContains(t, eventSyncer.Resync(ctx, 102), "is after the next block to process")
+
+	// Resync can't be requested when ongoing sync isn't running.
+	resyncCtx, resyncCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer resyncCancel()
+	require.ErrorIs(t, eventSyncer.Resync(resyncCtx, 50), context.DeadlineExceeded)
+
+	syncCtx, syncCancel := context.WithCancel(ctx)
+	syncErr := make(chan error, 1)
+	go func() {
+		syncErr <- eventSyncer.SyncOngoing(syncCtx, 101)
+	}()
+	require.Equal(t, uint64(101), <-client.fromBlocks)
+
+	require.ErrorContains(t, eventSyncer.Resync(ctx, 0), "must be positive")
+
+	// The registry state is rolled back to the block before the resync.
+	require.NoError(t, eventSyncer.Resync(ctx, 50))
+	require.Equal(t, uint64(50), <-client.fromBlocks)
+	require.Equal(t, []uint64{49}, handler.toBlocks)
+
+	// The ongoing sync goes on without restarting if the registry state can't be rolled back.
+	handler.err = eventhandler.ErrReorgTooDeep
+	require.ErrorIs(t, eventSyncer.Resync(ctx, 10), eventhandler.ErrReorgTooDeep)
+	require.Equal(t, []uint64{49, 9}, handler.toBlocks)
+	require.Empty(t, client.fromBlocks)
+
+	syncCancel()
+	require.NoError(t, <-syncErr)
+}
+
+type streamedBlockClient struct {
+	ExecutionClient
+	block executionclient.BlockLogs
+}
+
+func (c *streamedBlockClient) StreamLogs(ctx context.Context, fromBlock uint64) <-chan executionclient.BlockLogs {
+	logs := make(chan executionclient.BlockLogs)
+	go func() {
+		defer close(logs)
+		select {
+		case logs <- c.block:
+		case <-ctx.Done():
+			return
+		}
+		<-ctx.Done()
+	}()
+	return logs
+}
+
+type failingReorgHandler struct {
+	EventHandler
+	handledBlocks int
+}
+
+func (h *failingReorgHandler) HandleReorg(context.Context, eventhandler.BlockHashFunc) (uint64, bool, error) {
+	return 0, false, fmt.Errorf("%w: none of the last 64 blocks is canonical", eventhandler.ErrReorgTooDeep)
+}
+
+func (h *failingReorgHandler) HandleBlockEventsStream(logs <-chan executionclient.BlockLogs, executeTasks bool) (uint64, error) {
+	for range logs {
+		h.handledBlocks++
+	}
+	return 0, nil
+}
+
+func TestEventSyncer_ReorgFailure(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	client := &streamedBlockClient{block: executionclient.BlockLogs{BlockNumber: 101}}
+	handler := &failingReorgHandler{}
+	eventSyncer := New(nodeStorage, client, handler, WithLogger(logger))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The block isn't processed on top of the reorged blocks which couldn't be rolled back.
+	err = eventSyncer.SyncOngoing(ctx, 101)
+	require.ErrorIs(t, err, eventhandler.ErrReorgTooDeep)
+	require.Zero(t, handler.handledBlocks)
+}
+
+type historicalChainClient struct {
+	ExecutionClient
+	hashes     map[uint64]ethcommon.Hash
+	head       uint64
+	fromBlocks []uint64
+}
+
+func (c *historicalChainClient) FetchHistoricalLogs(ctx context.Context, fromBlock uint64) (<-chan executionclient.BlockLogs, <-chan error, error) {
+	c.fromBlocks = append(c.fromBlocks, fromBlock)
+	if fromBlock > c.head {
+		return nil, nil, executionclient.ErrNothingToSync
+	}
+	logs := make(chan executionclient.BlockLogs, c.head-fromBlock+1)
+	for n := fromBlock; n <= c.head; n++ {
+		logs <- executionclient.BlockLogs{BlockNumber: n, BlockHash: c.hashes[n]}
+	}
+	close(logs)
+	errs := make(chan error)
+	close(errs)
+	return logs, errs, nil
+}
+
+func (c *historicalChainClient) BlockHash(ctx context.Context, blockNumber uint64) (ethcommon.Hash, error) {
+	hash, ok := c.hashes[blockNumber]
+	if !ok {
+		return ethcommon.Hash{}, ethereum.NotFound
+	}
+	return hash, nil
+}
+
+func TestEventSyncer_SyncHistoryAfterReorg(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	db, err := kv.NewInMemory(logger, basedb.Options{Ctx: ctx})
+	require.NoError(t, err)
+	privateKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	nodeStorage, operatorData := setupOperatorStorage(logger, db, privateKey)
+	eh := setupEventHandler(t, ctx, logger, db, nodeStorage, operatorData, privateKey, eventhandler.WithReorgJournal(8))
+
+	// The previous run processed and journaled blocks 1 to 3.
+	client := &historicalChainClient{
+		hashes: map[uint64]ethcommon.Hash{1: {0x1}, 2: {0x2}, 3: {0x3}},
+		head:   3,
+	}
+	eventSyncer := New(nodeStorage, client, eh, WithLogger(logger))
+	lastProcessedBlock, err := eventSyncer.SyncHistory(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), lastProcessedBlock)
+
+	// Blocks 2 and 3 were reorged out while the node was down.
+	client.hashes = map[uint64]ethcommon.Hash{1: {0x1}, 2: {0x2, 0x1}, 3: {0x3, 0x1}, 4: {0x4, 0x1}}
+	client.head = 4
+	client.fromBlocks = nil
+
+	// The restart rolls back to the canonical ancestor and syncs the new branch from the block after it.
+	lastProcessedBlock, err = eventSyncer.SyncHistory(ctx, 4)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), lastProcessedBlock)
+	require.Equal(t, []uint64{2}, client.fromBlocks)
+
+	storedBlock, found, err := nodeStorage.GetLastProcessedBlock(nil)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, uint64(4), storedBlock.Uint64())
+
+	// Nothing is synced again once the journal matches the canonical chain.
+	_, err = eventSyncer.SyncHistory(ctx, 5)
+	require.ErrorIs(t, err, executionclient.ErrNothingToSync)
+}
@@ -1,6 +1,7 @@
 package executionclient
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -8,6 +9,23 @@ import (
 
 // ExecutionOptions contains config configurations related to Ethereum execution client.
 type ExecutionOptions struct {
-	Addr              string        `yaml:"ETH1Addr" env:"ETH_1_ADDR" env-required:"true" env-description:"Execution client WebSocket address"`
-	ConnectionTimeout time.Duration `yaml:"ETH1ConnectionTimeout" env:"ETH_1_CONNECTION_TIMEOUT" env-default:"10s" env-description:"Execution client connection timeout"`
+	Addr                string        `yaml:"ETH1Addr" env:"ETH_1_ADDR" env-required:"true" env-description:"Execution client WebSocket address(es), comma-separated for multiple clients"`
+	ConnectionTimeout   time.Duration `yaml:"ETH1ConnectionTimeout" env:"ETH_1_CONNECTION_TIMEOUT" env-default:"10s" env-description:"Execution client connection timeout"`
+	FollowMode          FollowMode    `yaml:"ETH1FollowMode" env:"ETH_1_FOLLOW_MODE" env-default:"distance" env-description:"Which blocks to process contract events up to: distance (fixed distance behind head), finalized or safe"`
+	FinalizedFromBeacon bool          `yaml:"ETH1FinalizedFromBeacon" env:"ETH_1_FINALIZED_FROM_BEACON" env-description:"Read the finalized block from the consensus client instead of the execution client, with the finalized follow mode"`
+	ReorgDepth          uint64        `yaml:"ETH1ReorgDepth" env:"ETH_1_REORG_DEPTH" env-default:"64" env-description:"Number of latest processed blocks whose registry changes can be rolled back on a reorg (0 to disable)"`
+}
+
+// Validate returns an error if the options are inconsistent.
+func (o ExecutionOptions) Validate() error {
+	if o.FollowMode != "" {
+		if err := o.FollowMode.Validate(); err != nil {
+			return err
+		}
+	}
+	if o.FinalizedFromBeacon && o.FollowMode != FollowFinalized {
+		// The consensus client provides the finalized block only, which would be behind the safe block.
+		return fmt.Errorf("reading the finalized block from the consensus client requires the %q follow mode", FollowFinalized)
+	}
+	return nil
 }
@@ -0,0 +1,19 @@
+package executionclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionOptions_Validate(t *testing.T) {
+	require.NoError(t, ExecutionOptions{}.Validate())
+	require.NoError(t, ExecutionOptions{FollowMode: FollowSafe}.Validate())
+	require.NoError(t, ExecutionOptions{FollowMode: FollowFinalized, FinalizedFromBeacon: true}.Validate())
+
+	require.ErrorContains(t, ExecutionOptions{FollowMode: "head"}.Validate(), "unknown follow mode")
+
+	// The finalized block from the consensus client can't be followed in other modes.
+	require.ErrorContains(t, ExecutionOptions{FollowMode: FollowSafe, FinalizedFromBeacon: true}.Validate(), "requires the \"finalized\" follow mode")
+	require.ErrorContains(t, ExecutionOptions{FinalizedFromBeacon: true}.Validate(), "requires the \"finalized\" follow mode")
+}
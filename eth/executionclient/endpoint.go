@@ -0,0 +1,259 @@
+package executionclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+)
+
+// errNotChecked is reported for endpoints whose health hasn't been checked yet.
+var errNotChecked = errors.New("health not checked yet")
+
+// endpoint is a single execution client endpoint with its last known health.
+type endpoint struct {
+	addr     string // redacted, safe for logs
+	fullAddr string
+
+	mu      sync.RWMutex
+	client  *ethclient.Client
+	lastErr error
+}
+
+func newEndpoint(addr string) *endpoint {
+	return &endpoint{
+		addr:     redactAddress(addr),
+		fullAddr: addr,
+		lastErr:  errNotChecked,
+	}
+}
+
+// dial (re)connects to the endpoint, closing the previous connection if any.
+func (e *endpoint) dial(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client, err := ethclient.DialContext(ctx, e.fullAddr)
+	if err != nil {
+		e.setHealth(err)
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.client != nil {
+		e.client.Close()
+	}
+	e.client = client
+	e.lastErr = nil
+	return nil
+}
+
+// checkHealth checks whether the endpoint responds to requests and isn't syncing.
+func (e *endpoint) checkHealth(ctx context.Context) error {
+	client := e.ethClient()
+	if client == nil {
+		return ErrNotConnected
+	}
+
+	sp, err := client.SyncProgress(ctx)
+	if err != nil {
+		return err
+	}
+	if sp != nil {
+		return errSyncing
+	}
+	return nil
+}
+
+func (e *endpoint) ethClient() *ethclient.Client {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.client
+}
+
+func (e *endpoint) setHealth(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.lastErr = err
+}
+
+// health returns the result of the last health check or request.
+func (e *endpoint) health() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.lastErr
+}
+
+func (e *endpoint) close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.client != nil {
+		e.client.Close()
+	}
+}
+
+// connect connects to the execution client endpoints and activates the first one which is in sync.
+// With a single endpoint, it's activated as soon as it's connected.
+func (ec *ExecutionClient) connect(ctx context.Context) error {
+	var errs []error
+	for _, e := range ec.endpoints {
+		start := time.Now()
+		if err := e.dial(ctx, ec.connectionTimeout); err != nil {
+			if len(ec.endpoints) == 1 {
+				return err
+			}
+			ec.logger.Warn("could not connect to execution client", fields.Address(e.addr), zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s: %w", e.addr, err))
+			continue
+		}
+		ec.logger.Info("connected to execution client", fields.Address(e.addr), zap.Duration("took", time.Since(start)))
+	}
+	if len(errs) == len(ec.endpoints) {
+		return errors.Join(errs...)
+	}
+
+	if len(ec.endpoints) == 1 {
+		ec.setActive(ec.endpoints[0])
+		return nil
+	}
+
+	if err := ec.switchEndpoint(ctx, 0); err != nil {
+		// None of the endpoints is in sync yet, so start with the first connected one.
+		for _, e := range ec.endpoints {
+			if e.ethClient() != nil {
+				ec.logger.Warn("no execution client is in sync, using the first connected one",
+					fields.Address(e.addr), zap.Error(err))
+				ec.setActive(e)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// switchEndpoint activates the next endpoint which is healthy and has reached at least minBlock,
+// reconnecting to endpoints that have failed before. The currently active endpoint is tried last.
+// With a single endpoint, it's reconnected without checking its sync status.
+func (ec *ExecutionClient) switchEndpoint(ctx context.Context, minBlock uint64) error {
+	var errs []error
+	for _, e := range ec.failoverOrder() {
+		if e.health() != nil || e.ethClient() == nil {
+			if err := e.dial(ctx, ec.connectionTimeout); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", e.addr, err))
+				continue
+			}
+		}
+
+		if len(ec.endpoints) > 1 {
+			if err := ec.checkInSync(ctx, e, minBlock); err != nil {
+				e.setHealth(err)
+				errs = append(errs, fmt.Errorf("%s: %w", e.addr, err))
+				continue
+			}
+		}
+
+		if previous := ec.activeEndpoint(); previous != nil && previous != e {
+			ec.logger.Info("switched execution client",
+				zap.String("from", previous.addr),
+				zap.String("to", e.addr))
+		}
+		ec.setActive(e)
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+// checkInSync checks that the endpoint is healthy and has reached at least minBlock,
+// so that switching to it neither skips nor reprocesses any blocks.
+func (ec *ExecutionClient) checkInSync(ctx context.Context, e *endpoint, minBlock uint64) error {
+	ctx, cancel := context.WithTimeout(ctx, ec.connectionTimeout)
+	defer cancel()
+
+	if err := e.checkHealth(ctx); err != nil {
+		return err
+	}
+	blockNumber, err := e.ethClient().BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current block: %w", err)
+	}
+	if blockNumber < minBlock {
+		return fmt.Errorf("behind: at block %d, expected at least %d", blockNumber, minBlock)
+	}
+	return nil
+}
+
+// failoverOrder returns the endpoints following the active one, with the active one last.
+func (ec *ExecutionClient) failoverOrder() []*endpoint {
+	active := ec.activeEndpoint()
+	start := 0
+	for i, e := range ec.endpoints {
+		if e == active {
+			start = i + 1
+			break
+		}
+	}
+
+	order := make([]*endpoint, 0, len(ec.endpoints))
+	for i := range ec.endpoints {
+		order = append(order, ec.endpoints[(start+i)%len(ec.endpoints)])
+	}
+	return order
+}
+
+func (ec *ExecutionClient) activeEndpoint() *endpoint {
+	ec.activeMu.RLock()
+	defer ec.activeMu.RUnlock()
+
+	return ec.active
+}
+
+func (ec *ExecutionClient) setActive(e *endpoint) {
+	ec.activeMu.Lock()
+	defer ec.activeMu.Unlock()
+
+	ec.active = e
+}
+
+// client returns the client of the active endpoint.
+func (ec *ExecutionClient) client() *ethclient.Client {
+	return ec.activeEndpoint().ethClient()
+}
+
+// ParseAddresses splits a comma-separated list of execution client addresses.
+func ParseAddresses(addresses string) ([]string, error) {
+	var parsed []string
+	for _, addr := range strings.Split(addresses, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		parsed = append(parsed, addr)
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("no execution client address provided")
+	}
+	return parsed, nil
+}
+
+// redactAddress strips credentials from the address, so it can be logged.
+func redactAddress(addr string) string {
+	u, err := url.Parse(addr)
+	if err != nil || u.User == nil {
+		return addr
+	}
+	return u.Redacted()
+}
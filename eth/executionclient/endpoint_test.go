@@ -0,0 +1,189 @@
+package executionclient
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// killableServer is a test server whose connections, including hijacked WebSocket ones, can be dropped at once.
+type killableServer struct {
+	*httptest.Server
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func newKillableServer(t *testing.T, handler http.Handler) *killableServer {
+	s := &killableServer{Server: httptest.NewUnstartedServer(handler)}
+	s.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			s.mu.Lock()
+			s.conns = append(s.conns, conn)
+			s.mu.Unlock()
+		}
+	}
+	s.Start()
+	t.Cleanup(s.kill)
+	return s
+}
+
+func (s *killableServer) kill() {
+	s.Listener.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.conns = nil
+}
+
+func TestStreamLogsFailover(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	const testTimeout = 10 * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	sim := simTestBackend(testAddr)
+
+	// Serve the same chain from two endpoints.
+	rpcServer, _ := sim.Node().RPCHandler()
+	defer rpcServer.Stop()
+	primary := newKillableServer(t, rpcServer.WebsocketHandler([]string{"*"}))
+	secondary := httptest.NewServer(rpcServer.WebsocketHandler([]string{"*"}))
+	defer secondary.Close()
+
+	parsed, _ := abi.JSON(strings.NewReader(callableAbi))
+	auth, _ := bind.NewKeyedTransactorWithChainID(testKey, big.NewInt(1337))
+	contractAddr, _, contract, err := bind.DeployContract(auth, parsed, ethcommon.FromHex(callableBin), sim.Client())
+	require.NoError(t, err)
+	sim.Commit()
+
+	addrs := httpToWebSocketURL(primary.URL) + "," + httpToWebSocketURL(secondary.URL)
+	client, err := New(ctx, addrs, contractAddr,
+		WithLogger(logger),
+		WithFollowDistance(0),
+		WithReconnectionInitialInterval(10*time.Millisecond),
+		WithReconnectionMaxInterval(time.Second),
+	)
+	require.NoError(t, err)
+	require.Equal(t, client.endpoints[0], client.activeEndpoint())
+
+	emit := func(n int) {
+		for i := 0; i < n; i++ {
+			_, err := contract.Transact(auth, "Call")
+			require.NoError(t, err)
+			sim.Commit()
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	logs := client.StreamLogs(ctx, 0)
+	seenBlocks := map[uint64]int{}
+	receive := func(expected int) {
+		received := 0
+		for received < expected {
+			select {
+			case block := <-logs:
+				seenBlocks[block.BlockNumber]++
+				received += len(block.Logs)
+			case <-ctx.Done():
+				require.FailNow(t, "timed out waiting for logs", "received %d of %d", received, expected)
+			}
+		}
+	}
+
+	emit(5)
+	receive(5)
+
+	// Take the primary endpoint down, streaming should continue from the secondary.
+	primary.kill()
+
+	emit(5)
+	receive(5)
+
+	require.Equal(t, client.endpoints[1], client.activeEndpoint())
+	for block, count := range seenBlocks {
+		require.Equal(t, 1, count, "block %d was streamed more than once", block)
+	}
+
+	statuses := client.EndpointsHealth(ctx)
+	require.Error(t, statuses[client.endpoints[0].addr])
+	require.NoError(t, statuses[client.endpoints[1].addr])
+	require.NoError(t, client.Healthy(ctx))
+
+	require.NoError(t, client.Close())
+	require.NoError(t, sim.Close())
+}
+
+func TestFetchHistoricalLogsFailover(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	const testTimeout = 5 * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	sim := simTestBackend(testAddr)
+
+	rpcServer, _ := sim.Node().RPCHandler()
+	defer rpcServer.Stop()
+	primary := newKillableServer(t, rpcServer.WebsocketHandler([]string{"*"}))
+	secondary := httptest.NewServer(rpcServer.WebsocketHandler([]string{"*"}))
+	defer secondary.Close()
+
+	parsed, _ := abi.JSON(strings.NewReader(callableAbi))
+	auth, _ := bind.NewKeyedTransactorWithChainID(testKey, big.NewInt(1337))
+	contractAddr, _, contract, err := bind.DeployContract(auth, parsed, ethcommon.FromHex(callableBin), sim.Client())
+	require.NoError(t, err)
+	sim.Commit()
+
+	for i := 0; i < blocksWithLogsLength; i++ {
+		_, err := contract.Transact(auth, "Call")
+		require.NoError(t, err)
+		sim.Commit()
+	}
+
+	addrs := httpToWebSocketURL(primary.URL) + "," + httpToWebSocketURL(secondary.URL)
+	client, err := New(ctx, addrs, contractAddr, WithLogger(logger), WithFollowDistance(0), WithLogBatchSize(4))
+	require.NoError(t, err)
+
+	primary.kill()
+
+	logs, fetchErrors, err := client.FetchHistoricalLogs(ctx, 0)
+	require.NoError(t, err)
+
+	var fetchedLogs int
+	var lastBlock uint64
+	for block := range logs {
+		require.GreaterOrEqual(t, block.BlockNumber, lastBlock)
+		lastBlock = block.BlockNumber
+		fetchedLogs += len(block.Logs)
+	}
+	require.NoError(t, <-fetchErrors)
+	require.Equal(t, blocksWithLogsLength, fetchedLogs)
+	require.Equal(t, client.endpoints[1], client.activeEndpoint())
+
+	require.NoError(t, client.Close())
+	require.NoError(t, sim.Close())
+}
+
+func TestParseAddresses(t *testing.T) {
+	addrs, err := ParseAddresses(" ws://a:8546, ws://b:8546 ,")
+	require.NoError(t, err)
+	require.Equal(t, []string{"ws://a:8546", "ws://b:8546"}, addrs)
+
+	_, err = ParseAddresses(",")
+	require.Error(t, err)
+
+	require.Equal(t, "ws://user:xxxxx@a:8546", redactAddress("ws://user:pass@a:8546"))
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -24,46 +25,71 @@ var (
 	ErrNotConnected  = fmt.Errorf("not connected")
 	ErrBadInput      = fmt.Errorf("bad input")
 	ErrNothingToSync = errors.New("nothing to sync")
+
+	errSyncing         = errors.New("syncing")
+	errSwitchRequested = errors.New("switching to a healthy endpoint")
 )
 
 // ExecutionClient represents a client for interacting with Ethereum execution client.
 type ExecutionClient struct {
 	// mandatory
-	nodeAddr        string
+	endpoints       []*endpoint
 	contractAddress ethcommon.Address
 
 	// optional
 	logger                      *zap.Logger
 	metrics                     metrics
-	followDistance              uint64 // TODO: consider reading the finalized checkpoint from consensus layer
+	followMode                  FollowMode
+	followDistance              uint64
+	finalizedBlockProvider      FinalizedBlockProvider
 	connectionTimeout           time.Duration
 	reconnectionInitialInterval time.Duration
 	reconnectionMaxInterval     time.Duration
 	logBatchSize                uint64
 
 	// variables
-	client *ethclient.Client
-	closed chan struct{}
+	activeMu        sync.RWMutex
+	active          *endpoint
+	switchRequested chan struct{}
+	closed          chan struct{}
 }
 
 // New creates a new instance of ExecutionClient.
+// nodeAddr may contain multiple comma-separated endpoints, which are failed over to when the active one fails.
 func New(ctx context.Context, nodeAddr string, contractAddr ethcommon.Address, opts ...Option) (*ExecutionClient, error) {
+	addrs, err := ParseAddresses(nodeAddr)
+	if err != nil {
+		return nil, err
+	}
+	endpoints := make([]*endpoint, 0, len(addrs))
+	for _, addr := range addrs {
+		endpoints = append(endpoints, newEndpoint(addr))
+	}
+
 	client := &ExecutionClient{
-		nodeAddr:                    nodeAddr,
+		endpoints:                   endpoints,
 		contractAddress:             contractAddr,
 		logger:                      zap.NewNop(),
 		metrics:                     nopMetrics{},
+		followMode:                  FollowDistance,
 		followDistance:              DefaultFollowDistance,
 		connectionTimeout:           DefaultConnectionTimeout,
 		reconnectionInitialInterval: DefaultReconnectionInitialInterval,
 		reconnectionMaxInterval:     DefaultReconnectionMaxInterval,
 		logBatchSize:                DefaultHistoricalLogsBatchSize, // TODO Make batch of logs adaptive depending on "websocket: read limit"
+		switchRequested:             make(chan struct{}, 1),
 		closed:                      make(chan struct{}),
 	}
 	for _, opt := range opts {
 		opt(client)
 	}
-	err := client.connect(ctx)
+	if err := client.followMode.Validate(); err != nil {
+		return nil, err
+	}
+	if client.finalizedBlockProvider != nil && client.followMode != FollowFinalized {
+		return nil, fmt.Errorf("a finalized block provider requires the %q follow mode", FollowFinalized)
+	}
+	err = client.connect(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to execution client: %w", err)
 	}
@@ -73,20 +99,26 @@ func New(ctx context.Context, nodeAddr string, contractAddr ethcommon.Address, o
 // Close shuts down ExecutionClient.
 func (ec *ExecutionClient) Close() error {
 	close(ec.closed)
-	ec.client.Close()
+	for _, e := range ec.endpoints {
+		e.close()
+	}
 	return nil
 }
 
 // FetchHistoricalLogs retrieves historical logs emitted by the contract starting from fromBlock.
 func (ec *ExecutionClient) FetchHistoricalLogs(ctx context.Context, fromBlock uint64) (logs <-chan BlockLogs, errors <-chan error, err error) {
-	currentBlock, err := ec.client.BlockNumber(ctx)
+	var currentBlock uint64
+	err = ec.withFailover(ctx, 0, func(client *ethclient.Client) (err error) {
+		currentBlock, err = client.BlockNumber(ctx)
+		return err
+	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get current block: %w", err)
 	}
-	if currentBlock < ec.followDistance {
-		return nil, nil, ErrNothingToSync
+	toBlock, err := ec.followedBlock(ctx, currentBlock)
+	if err != nil {
+		return nil, nil, err
 	}
-	toBlock := currentBlock - ec.followDistance
 	if toBlock < fromBlock {
 		return nil, nil, ErrNothingToSync
 	}
@@ -116,10 +148,14 @@ func (ec *ExecutionClient) fetchLogsInBatches(ctx context.Context, startBlock, e
 			}
 
 			start := time.Now()
-			results, err := ec.client.FilterLogs(ctx, ethereum.FilterQuery{
-				Addresses: []ethcommon.Address{ec.contractAddress},
-				FromBlock: new(big.Int).SetUint64(fromBlock),
-				ToBlock:   new(big.Int).SetUint64(toBlock),
+			var results []ethtypes.Log
+			err := ec.withFailover(ctx, toBlock, func(client *ethclient.Client) (err error) {
+				results, err = client.FilterLogs(ctx, ethereum.FilterQuery{
+					Addresses: []ethcommon.Address{ec.contractAddress},
+					FromBlock: new(big.Int).SetUint64(fromBlock),
+					ToBlock:   new(big.Int).SetUint64(toBlock),
+				})
+				return err
 			})
 			if err != nil {
 				errors <- err
@@ -148,7 +184,7 @@ func (ec *ExecutionClient) fetchLogsInBatches(ctx context.Context, startBlock, e
 				validLogs := make([]ethtypes.Log, 0, len(results))
 				for _, log := range results {
 					if log.Removed {
-						// This shouldn't happen unless there was a reorg!
+						// The log belongs to a reorged-out block, reorgs are rolled back by the event syncer.
 						ec.logger.Warn("log is removed",
 							zap.String("block_hash", log.BlockHash.Hex()),
 							fields.TxHash(log.TxHash),
@@ -159,7 +195,12 @@ func (ec *ExecutionClient) fetchLogsInBatches(ctx context.Context, startBlock, e
 				}
 				if len(validLogs) == 0 {
 					// Emit empty block logs to indicate that we have advanced to this block.
-					logs <- BlockLogs{BlockNumber: toBlock}
+					blockHash, err := ec.BlockHash(ctx, toBlock)
+					if err != nil {
+						errors <- fmt.Errorf("get block hash: %w", err)
+						return
+					}
+					logs <- BlockLogs{BlockNumber: toBlock, BlockHash: blockHash}
 				} else {
 					for _, blockLogs := range PackLogs(validLogs) {
 						logs <- blockLogs
@@ -188,12 +229,21 @@ func (ec *ExecutionClient) StreamLogs(ctx context.Context, fromBlock uint64) <-c
 			case <-ec.closed:
 				return
 			default:
-				lastBlock, err := ec.streamLogsToChan(ctx, logs, fromBlock)
+				nextBlock, err := ec.streamLogsToChan(ctx, logs, fromBlock)
 				if errors.Is(err, ErrClosed) || errors.Is(err, context.Canceled) {
 					// Closed gracefully.
 					return
 				}
 
+				if errors.Is(err, errSwitchRequested) {
+					// The active endpoint became unhealthy while another one is healthy.
+					if err := ec.switchEndpoint(ctx, lastFetchedBlock(nextBlock)); err != nil {
+						ec.logger.Warn("could not switch execution client", zap.Error(err))
+					}
+					fromBlock = nextBlock
+					continue
+				}
+
 				// streamLogsToChan should never return without an error,
 				// so we treat a nil error as an error by itself.
 				if err == nil {
@@ -204,14 +254,17 @@ func (ec *ExecutionClient) StreamLogs(ctx context.Context, fromBlock uint64) <-c
 				if tries > 2 {
 					ec.logger.Fatal("failed to stream registry events", zap.Error(err))
 				}
-				if lastBlock > fromBlock {
+				if nextBlock > fromBlock {
 					// Successfully streamed some logs, reset tries.
 					tries = 0
 				}
 
 				ec.logger.Error("failed to stream registry events, reconnecting", zap.Error(err))
-				ec.reconnect(ctx)
-				fromBlock = lastBlock + 1
+				if active := ec.activeEndpoint(); active != nil {
+					active.setHealth(err)
+				}
+				ec.reconnect(ctx, lastFetchedBlock(nextBlock))
+				fromBlock = nextBlock
 			}
 		}
 	}()
@@ -219,24 +272,52 @@ func (ec *ExecutionClient) StreamLogs(ctx context.Context, fromBlock uint64) <-c
 	return logs
 }
 
+// lastFetchedBlock returns the block preceding nextBlock, which an endpoint must have reached
+// to continue streaming from nextBlock.
+func lastFetchedBlock(nextBlock uint64) uint64 {
+	if nextBlock == 0 {
+		return 0
+	}
+	return nextBlock - 1
+}
+
 // Healthy returns if execution client is currently healthy: responds to requests and not in the syncing state.
+// With multiple endpoints, it's healthy if any of them is. If the active endpoint isn't healthy
+// while another one is, switching to the healthy one is requested.
 func (ec *ExecutionClient) Healthy(ctx context.Context) error {
 	if ec.isClosed() {
 		return ErrClosed
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, ec.connectionTimeout)
-	defer cancel()
+	statuses := ec.EndpointsHealth(ctx)
 
-	sp, err := ec.client.SyncProgress(ctx)
-	if err != nil {
-		ec.metrics.ExecutionClientFailure()
-		return err
+	active := ec.activeEndpoint()
+	activeErr := statuses[active.addr]
+	var healthyFound bool
+	for _, err := range statuses {
+		if err == nil {
+			healthyFound = true
+			break
+		}
+	}
+
+	if !healthyFound {
+		if errors.Is(activeErr, errSyncing) {
+			ec.metrics.ExecutionClientSyncing()
+		} else {
+			ec.metrics.ExecutionClientFailure()
+		}
+		if len(ec.endpoints) == 1 {
+			return activeErr
+		}
+		return fmt.Errorf("no healthy execution client: %w", activeErr)
 	}
 
-	if sp != nil {
-		ec.metrics.ExecutionClientSyncing()
-		return fmt.Errorf("syncing")
+	if activeErr != nil {
+		select {
+		case ec.switchRequested <- struct{}{}:
+		default:
+		}
 	}
 
 	ec.metrics.ExecutionClientReady()
@@ -244,8 +325,77 @@ func (ec *ExecutionClient) Healthy(ctx context.Context) error {
 	return nil
 }
 
+// EndpointsHealth checks the health of each execution client endpoint and returns it by endpoint address.
+func (ec *ExecutionClient) EndpointsHealth(ctx context.Context) map[string]error {
+	ctx, cancel := context.WithTimeout(ctx, ec.connectionTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, e := range ec.endpoints {
+		wg.Add(1)
+		go func(e *endpoint) {
+			defer wg.Done()
+			e.setHealth(e.checkHealth(ctx))
+		}(e)
+	}
+	wg.Wait()
+
+	statuses := make(map[string]error, len(ec.endpoints))
+	for _, e := range ec.endpoints {
+		statuses[e.addr] = e.health()
+	}
+	return statuses
+}
+
 func (ec *ExecutionClient) BlockByNumber(ctx context.Context, blockNumber *big.Int) (*ethtypes.Block, error) {
-	return ec.client.BlockByNumber(ctx, blockNumber)
+	var minBlock uint64
+	if blockNumber != nil && blockNumber.IsUint64() {
+		minBlock = blockNumber.Uint64()
+	}
+
+	var block *ethtypes.Block
+	err := ec.withFailover(ctx, minBlock, func(client *ethclient.Client) (err error) {
+		block, err = client.BlockByNumber(ctx, blockNumber)
+		return err
+	})
+	return block, err
+}
+
+// BlockHash returns the hash of the canonical block with the given number.
+func (ec *ExecutionClient) BlockHash(ctx context.Context, blockNumber uint64) (ethcommon.Hash, error) {
+	var header *ethtypes.Header
+	err := ec.withFailover(ctx, blockNumber, func(client *ethclient.Client) (err error) {
+		header, err = client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+		return err
+	})
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+	return header.Hash(), nil
+}
+
+// withFailover calls f with the active endpoint's client. If it fails and there are other endpoints,
+// it switches to the next endpoint which has reached at least minBlock and retries,
+// until f succeeds or every endpoint has been tried.
+func (ec *ExecutionClient) withFailover(ctx context.Context, minBlock uint64, f func(client *ethclient.Client) error) error {
+	var err error
+	for range ec.endpoints {
+		active := ec.activeEndpoint()
+		if err = f(active.ethClient()); err == nil {
+			return nil
+		}
+		if len(ec.endpoints) == 1 || ctx.Err() != nil || ec.isClosed() {
+			return err
+		}
+
+		ec.logger.Warn("execution client request failed, switching endpoint",
+			fields.Address(active.addr), zap.Error(err))
+		active.setHealth(err)
+		if switchErr := ec.switchEndpoint(ctx, minBlock); switchErr != nil {
+			return fmt.Errorf("%w (failover: %w)", err, switchErr)
+		}
+	}
+	return err
 }
 
 func (ec *ExecutionClient) isClosed() bool {
@@ -258,12 +408,13 @@ func (ec *ExecutionClient) isClosed() bool {
 }
 
 // streamLogsToChan streams ongoing logs from the given block to the given channel.
-// streamLogsToChan *always* returns the last block it fetched, even if it errored.
+// streamLogsToChan *always* returns the next block to fetch, even if it errored,
+// so that streaming can be resumed without skipping or reprocessing blocks.
 // TODO: consider handling "websocket: read limit exceeded" error and reducing batch size (syncSmartContractsEvents has code for this)
-func (ec *ExecutionClient) streamLogsToChan(ctx context.Context, logs chan<- BlockLogs, fromBlock uint64) (lastBlock uint64, err error) {
+func (ec *ExecutionClient) streamLogsToChan(ctx context.Context, logs chan<- BlockLogs, fromBlock uint64) (nextBlock uint64, err error) {
 	heads := make(chan *ethtypes.Header)
 
-	sub, err := ec.client.SubscribeNewHead(ctx, heads)
+	sub, err := ec.client().SubscribeNewHead(ctx, heads)
 	if err != nil {
 		return fromBlock, fmt.Errorf("subscribe heads: %w", err)
 	}
@@ -277,6 +428,11 @@ func (ec *ExecutionClient) streamLogsToChan(ctx context.Context, logs chan<- Blo
 		case <-ec.closed:
 			return fromBlock, ErrClosed
 
+		case <-ec.switchRequested:
+			if len(ec.endpoints) > 1 {
+				return fromBlock, errSwitchRequested
+			}
+
 		case err := <-sub.Err():
 			if err == nil {
 				return fromBlock, ErrClosed
@@ -284,21 +440,30 @@ func (ec *ExecutionClient) streamLogsToChan(ctx context.Context, logs chan<- Blo
 			return fromBlock, fmt.Errorf("subscription: %w", err)
 
 		case header := <-heads:
-			if header.Number.Uint64() < ec.followDistance {
+			toBlock, err := ec.followedBlock(ctx, header.Number.Uint64())
+			if errors.Is(err, ErrNothingToSync) {
+				continue
+			}
+			if err != nil {
+				// The finalized or safe block is queried from the consensus client or through failover,
+				// so a failure doesn't mean the subscribed endpoint is unhealthy: retry on the next head.
+				ec.logger.Warn("could not get followed block, retrying on next head",
+					fields.BlockNumber(header.Number.Uint64()), zap.Error(err))
 				continue
 			}
-			toBlock := header.Number.Uint64() - ec.followDistance
 			if toBlock < fromBlock {
 				continue
 			}
 			logStream, fetchErrors := ec.fetchLogsInBatches(ctx, fromBlock, toBlock)
 			for block := range logStream {
 				logs <- block
-				lastBlock = block.BlockNumber
+				// Blocks are sent in order and all logs of a block are sent at once,
+				// so the following block is the next one to fetch.
+				fromBlock = block.BlockNumber + 1
 			}
 			if err := <-fetchErrors; err != nil {
-				// If we get an error while fetching, we return the last block we fetched.
-				return lastBlock, fmt.Errorf("fetch logs: %w", err)
+				// If we get an error while fetching, we return the next block to fetch.
+				return fromBlock, fmt.Errorf("fetch logs: %w", err)
 			}
 			fromBlock = toBlock + 1
 			ec.metrics.ExecutionClientLastFetchedBlock(fromBlock)
@@ -306,35 +471,17 @@ func (ec *ExecutionClient) streamLogsToChan(ctx context.Context, logs chan<- Blo
 	}
 }
 
-// connect connects to Ethereum execution client.
-// It must not be called twice in parallel.
-func (ec *ExecutionClient) connect(ctx context.Context) error {
-	logger := ec.logger.With(fields.Address(ec.nodeAddr))
-
-	ctx, cancel := context.WithTimeout(ctx, ec.connectionTimeout)
-	defer cancel()
-
-	start := time.Now()
-	var err error
-	ec.client, err = ethclient.DialContext(ctx, ec.nodeAddr)
-	if err != nil {
-		return err
-	}
-
-	logger.Info("connected to execution client", zap.Duration("took", time.Since(start)))
-	return nil
-}
-
-// reconnect tries to reconnect multiple times with an exponent interval.
+// reconnect tries to reconnect multiple times with an exponent interval,
+// switching to another endpoint which has reached at least minBlock if there are multiple.
 // It panics when reconnecting limit is reached.
 // It must not be called twice in parallel.
-func (ec *ExecutionClient) reconnect(ctx context.Context) {
-	logger := ec.logger.With(fields.Address(ec.nodeAddr))
+func (ec *ExecutionClient) reconnect(ctx context.Context, minBlock uint64) {
+	logger := ec.logger.With(fields.Address(ec.activeEndpoint().addr))
 
 	start := time.Now()
 	tasks.ExecWithInterval(func(lastTick time.Duration) (stop bool, cont bool) {
 		logger.Info("reconnecting")
-		if err := ec.connect(ctx); err != nil {
+		if err := ec.switchEndpoint(ctx, minBlock); err != nil {
 			if ec.isClosed() {
 				return true, false
 			}
@@ -349,9 +496,11 @@ func (ec *ExecutionClient) reconnect(ctx context.Context) {
 		return true, false
 	}, ec.reconnectionInitialInterval, ec.reconnectionMaxInterval+(ec.reconnectionInitialInterval))
 
-	logger.Info("reconnected to execution client", zap.Duration("took", time.Since(start)))
+	logger.Info("reconnected to execution client",
+		zap.String("endpoint", ec.activeEndpoint().addr),
+		zap.Duration("took", time.Since(start)))
 }
 
 func (ec *ExecutionClient) Filterer() (*contract.ContractFilterer, error) {
-	return contract.NewContractFilterer(ec.contractAddress, ec.client)
+	return contract.NewContractFilterer(ec.contractAddress, ec.client())
 }
@@ -0,0 +1,88 @@
+package executionclient
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// FollowMode defines which blocks are considered safe to process contract events from.
+type FollowMode string
+
+const (
+	// FollowDistance processes blocks which are at least followDistance blocks behind the head.
+	FollowDistance FollowMode = "distance"
+	// FollowFinalized processes blocks up to the latest finalized block.
+	FollowFinalized FollowMode = "finalized"
+	// FollowSafe processes blocks up to the latest safe (justified) block.
+	FollowSafe FollowMode = "safe"
+)
+
+// Validate checks that the follow mode is known.
+func (m FollowMode) Validate() error {
+	switch m {
+	case FollowDistance, FollowFinalized, FollowSafe:
+		return nil
+	default:
+		return fmt.Errorf("unknown follow mode %q", m)
+	}
+}
+
+// FinalizedBlockProvider provides the execution block number of the latest finalized beacon checkpoint.
+type FinalizedBlockProvider interface {
+	FinalizedExecutionBlock(ctx context.Context) (uint64, error)
+}
+
+// followedBlock returns the last block which may be processed given the current head block.
+// It returns ErrNothingToSync if there is no such block yet.
+func (ec *ExecutionClient) followedBlock(ctx context.Context, head uint64) (uint64, error) {
+	switch ec.followMode {
+	case FollowFinalized, FollowSafe:
+		var block uint64
+		var err error
+		if ec.finalizedBlockProvider != nil {
+			block, err = ec.finalizedBlockProvider.FinalizedExecutionBlock(ctx)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get finalized block from consensus client: %w", err)
+			}
+		} else {
+			block, err = ec.taggedBlock(ctx, ec.followMode)
+			if err != nil {
+				return 0, err
+			}
+		}
+		if block == 0 {
+			return 0, ErrNothingToSync
+		}
+		// The execution client may be lagging behind the consensus client.
+		return min(block, head), nil
+
+	default:
+		if head < ec.followDistance {
+			return 0, ErrNothingToSync
+		}
+		return head - ec.followDistance, nil
+	}
+}
+
+// taggedBlock returns the number of the block with the given tag (finalized or safe).
+func (ec *ExecutionClient) taggedBlock(ctx context.Context, mode FollowMode) (uint64, error) {
+	tag := rpc.FinalizedBlockNumber
+	if mode == FollowSafe {
+		tag = rpc.SafeBlockNumber
+	}
+
+	var header *ethtypes.Header
+	err := ec.withFailover(ctx, 0, func(client *ethclient.Client) (err error) {
+		header, err = client.HeaderByNumber(ctx, big.NewInt(tag.Int64()))
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s block: %w", mode, err)
+	}
+	return header.Number.Uint64(), nil
+}
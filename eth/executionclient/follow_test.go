@@ -0,0 +1,172 @@
+package executionclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type fixedFinalizedBlock uint64
+
+func (b fixedFinalizedBlock) FinalizedExecutionBlock(context.Context) (uint64, error) {
+	return uint64(b), nil
+}
+
+// flakyFinalizedBlock fails the given number of times before returning the block.
+type flakyFinalizedBlock struct {
+	failures atomic.Int32
+	block    uint64
+}
+
+func (b *flakyFinalizedBlock) FinalizedExecutionBlock(context.Context) (uint64, error) {
+	if b.failures.Add(-1) >= 0 {
+		return 0, errors.New("beacon node unavailable")
+	}
+	return b.block, nil
+}
+
+func TestFetchHistoricalLogsFollowMode(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	const testTimeout = 5 * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	sim := simTestBackend(testAddr)
+
+	rpcServer, _ := sim.Node().RPCHandler()
+	httpsrv := httptest.NewServer(rpcServer.WebsocketHandler([]string{"*"}))
+	defer rpcServer.Stop()
+	defer httpsrv.Close()
+	addr := httpToWebSocketURL(httpsrv.URL)
+
+	parsed, _ := abi.JSON(strings.NewReader(callableAbi))
+	auth, _ := bind.NewKeyedTransactorWithChainID(testKey, big.NewInt(1337))
+	contractAddr, _, contract, err := bind.DeployContract(auth, parsed, ethcommon.FromHex(callableBin), sim.Client())
+	require.NoError(t, err)
+	sim.Commit()
+
+	// The simulated beacon finalizes every 32 blocks, so the head is at 41 and the finalized block is 32.
+	const blocks = 40
+	for i := 0; i < blocks; i++ {
+		_, err := contract.Transact(auth, "Call")
+		require.NoError(t, err)
+		sim.Commit()
+	}
+
+	fetchLastBlock := func(t *testing.T, opts ...Option) uint64 {
+		client, err := New(ctx, addr, contractAddr, append([]Option{WithLogger(logger)}, opts...)...)
+		require.NoError(t, err)
+		defer client.Close()
+
+		logs, fetchErrors, err := client.FetchHistoricalLogs(ctx, 0)
+		require.NoError(t, err)
+
+		var lastBlock uint64
+		for block := range logs {
+			lastBlock = block.BlockNumber
+		}
+		require.NoError(t, <-fetchErrors)
+		return lastBlock
+	}
+
+	t.Run("distance", func(t *testing.T) {
+		require.Equal(t, uint64(blocks+1-3), fetchLastBlock(t, WithFollowDistance(3)))
+	})
+
+	t.Run("finalized tag", func(t *testing.T) {
+		require.Equal(t, uint64(32), fetchLastBlock(t, WithFollowMode(FollowFinalized)))
+	})
+
+	t.Run("safe tag", func(t *testing.T) {
+		require.Equal(t, uint64(blocks+1), fetchLastBlock(t, WithFollowMode(FollowSafe)))
+	})
+
+	t.Run("finalized from consensus client", func(t *testing.T) {
+		require.Equal(t, uint64(10), fetchLastBlock(t,
+			WithFollowMode(FollowFinalized),
+			WithFinalizedBlockProvider(fixedFinalizedBlock(10)),
+		))
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		_, err := New(ctx, addr, contractAddr, WithFollowMode("latest"))
+		require.ErrorContains(t, err, "unknown follow mode")
+	})
+
+	t.Run("consensus client in safe mode", func(t *testing.T) {
+		_, err := New(ctx, addr, contractAddr,
+			WithFollowMode(FollowSafe),
+			WithFinalizedBlockProvider(fixedFinalizedBlock(10)),
+		)
+		require.ErrorContains(t, err, "requires the \"finalized\" follow mode")
+	})
+
+	require.NoError(t, sim.Close())
+}
+
+func TestStreamLogsFollowedBlockErrors(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	const testTimeout = 5 * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	sim := simTestBackend(testAddr)
+
+	rpcServer, _ := sim.Node().RPCHandler()
+	httpsrv := httptest.NewServer(rpcServer.WebsocketHandler([]string{"*"}))
+	defer rpcServer.Stop()
+	defer httpsrv.Close()
+	addr := httpToWebSocketURL(httpsrv.URL)
+
+	parsed, _ := abi.JSON(strings.NewReader(callableAbi))
+	auth, _ := bind.NewKeyedTransactorWithChainID(testKey, big.NewInt(1337))
+	contractAddr, _, contract, err := bind.DeployContract(auth, parsed, ethcommon.FromHex(callableBin), sim.Client())
+	require.NoError(t, err)
+	sim.Commit()
+
+	// The consensus client fails more times than the streaming retries allow,
+	// which must not stop the stream. Every block is finalized once it answers.
+	finalized := &flakyFinalizedBlock{block: 1000}
+	finalized.failures.Store(5)
+	client, err := New(ctx, addr, contractAddr,
+		WithLogger(logger),
+		WithFollowMode(FollowFinalized),
+		WithFinalizedBlockProvider(finalized),
+	)
+	require.NoError(t, err)
+
+	logs := client.StreamLogs(ctx, 0)
+
+	const blocks = 10
+	for i := 0; i < blocks; i++ {
+		_, err := contract.Transact(auth, "Call")
+		require.NoError(t, err)
+		sim.Commit()
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	received := 0
+	for received < blocks {
+		select {
+		case block := <-logs:
+			received += len(block.Logs)
+		case <-ctx.Done():
+			require.FailNow(t, "timed out waiting for logs", "received %d of %d", received, blocks)
+		}
+	}
+	require.Negative(t, finalized.failures.Load())
+
+	require.NoError(t, client.Close())
+	require.NoError(t, sim.Close())
+}
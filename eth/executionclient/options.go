@@ -31,6 +31,22 @@ func WithFollowDistance(offset uint64) Option {
 	}
 }
 
+// WithFollowMode sets which blocks are considered safe to process.
+// FollowDistance is used by default, see WithFollowDistance.
+func WithFollowMode(mode FollowMode) Option {
+	return func(s *ExecutionClient) {
+		s.followMode = mode
+	}
+}
+
+// WithFinalizedBlockProvider sets the consensus client to read the finalized block from in FollowFinalized mode.
+// Without it, the finalized block is read from the execution client.
+func WithFinalizedBlockProvider(provider FinalizedBlockProvider) Option {
+	return func(s *ExecutionClient) {
+		s.finalizedBlockProvider = provider
+	}
+}
+
 // WithConnectionTimeout sets timeout for network connection to eth1 node.
 func WithConnectionTimeout(timeout time.Duration) Option {
 	return func(s *ExecutionClient) {
@@ -0,0 +1,304 @@
+package simulator_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/eth/contract"
+	"github.com/ssvlabs/ssv/eth/eventhandler"
+	"github.com/ssvlabs/ssv/eth/eventparser"
+	"github.com/ssvlabs/ssv/eth/eventsyncer"
+	"github.com/ssvlabs/ssv/eth/executionclient"
+	"github.com/ssvlabs/ssv/eth/simulator"
+	"github.com/ssvlabs/ssv/eth/simulator/simcontract"
+	"github.com/ssvlabs/ssv/networkconfig"
+	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
+	"github.com/ssvlabs/ssv/operator/keys"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/operator/validator"
+	"github.com/ssvlabs/ssv/operator/validators"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr   = crypto.PubkeyToAddress(testKey.PublicKey)
+)
+
+func TestReorgRollsBackRegistryState(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	sim := simulator.NewBackend(
+		types.GenesisAlloc{
+			testAddr: {Balance: big.NewInt(10000000000000000)},
+		}, simulated.WithBlockGasLimit(10000000),
+	)
+	defer sim.Close()
+
+	rpcServer, _ := sim.Node().RPCHandler()
+	httpSrv := httptest.NewServer(rpcServer.WebsocketHandler([]string{"*"}))
+	defer rpcServer.Stop()
+	defer httpSrv.Close()
+
+	parsed, err := abi.JSON(strings.NewReader(simcontract.SimcontractMetaData.ABI))
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(testKey, big.NewInt(1337))
+	require.NoError(t, err)
+	contractAddr, _, _, err := bind.DeployContract(auth, parsed, ethcommon.FromHex(simcontract.SimcontractMetaData.Bin), sim.Client())
+	require.NoError(t, err)
+	sim.Commit()
+
+	boundContract, err := simcontract.NewSimcontract(contractAddr, sim.Client())
+	require.NoError(t, err)
+
+	commitTx := func(tx *types.Transaction, err error) {
+		require.NoError(t, err)
+		sim.Commit()
+		receipt, err := sim.Client().TransactionReceipt(ctx, tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	}
+
+	// Operator 1 is registered in the block which remains canonical.
+	_, packedPubKey := operatorPublicKey(t)
+	commitTx(boundContract.RegisterOperator(auth, packedPubKey, big.NewInt(100_000_000)))
+	ancestor, err := sim.Client().HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+
+	client, err := executionclient.New(ctx, "ws:"+strings.TrimPrefix(httpSrv.URL, "http:"), contractAddr,
+		executionclient.WithLogger(logger),
+		executionclient.WithFollowDistance(0),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	db, err := kv.NewInMemory(logger, basedb.Options{Ctx: ctx})
+	require.NoError(t, err)
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	eh := setupEventHandler(t, ctx, db, nodeStorage)
+	eventSyncer := eventsyncer.New(nodeStorage, client, eh, eventsyncer.WithLogger(logger))
+
+	lastProcessedBlock, err := eventSyncer.SyncHistory(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, ancestor.Number.Uint64(), lastProcessedBlock)
+
+	go func() {
+		_ = eventSyncer.SyncOngoing(ctx, lastProcessedBlock+1)
+	}()
+
+	// Operator 2 and the fee recipient are registered in a block which is reorged out later.
+	reorgedPubKey, packedPubKey := operatorPublicKey(t)
+	reorgedRecipient := ethcommon.HexToAddress("0x1111111111111111111111111111111111111111")
+	_, err = boundContract.RegisterOperator(auth, packedPubKey, big.NewInt(100_000_000))
+	require.NoError(t, err)
+	commitTx(boundContract.SetFeeRecipientAddress(auth, reorgedRecipient))
+
+	require.Eventually(t, func() bool {
+		recipient, found, err := nodeStorage.GetRecipientData(nil, testAddr)
+		return err == nil && found && recipient.FeeRecipient == bellatrix.ExecutionAddress(reorgedRecipient)
+	}, 10*time.Second, 50*time.Millisecond)
+
+	operator, found, err := nodeStorage.GetOperatorData(nil, 2)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, reorgedPubKey, operator.PublicKey)
+
+	// Replace the block with a longer chain registering a different operator 2.
+	require.NoError(t, sim.Fork(ancestor.Hash()))
+	auth.Nonce = nil
+	canonicalPubKey, packedPubKey := operatorPublicKey(t)
+	commitTx(boundContract.RegisterOperator(auth, packedPubKey, big.NewInt(100_000_000)))
+
+	require.Eventually(t, func() bool {
+		// New heads keep the stream going until the reorg is detected and rolled back.
+		sim.Commit()
+
+		operator, found, err := nodeStorage.GetOperatorData(nil, 2)
+		return err == nil && found && bytes.Equal(operator.PublicKey, canonicalPubKey)
+	}, 10*time.Second, 50*time.Millisecond)
+
+	_, found, err = nodeStorage.GetRecipientData(nil, testAddr)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	_, found, err = nodeStorage.GetOperatorDataByPubKey(nil, reorgedPubKey)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	_, found, err = nodeStorage.GetOperatorData(nil, 1)
+	require.NoError(t, err)
+	require.True(t, found)
+}
+
+func TestResyncReprocessesEventsOnce(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	sim := simulator.NewBackend(
+		types.GenesisAlloc{
+			testAddr: {Balance: big.NewInt(10000000000000000)},
+		}, simulated.WithBlockGasLimit(10000000),
+	)
+	defer sim.Close()
+
+	rpcServer, _ := sim.Node().RPCHandler()
+	httpSrv := httptest.NewServer(rpcServer.WebsocketHandler([]string{"*"}))
+	defer rpcServer.Stop()
+	defer httpSrv.Close()
+
+	parsed, err := abi.JSON(strings.NewReader(simcontract.SimcontractMetaData.ABI))
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(testKey, big.NewInt(1337))
+	require.NoError(t, err)
+	contractAddr, _, _, err := bind.DeployContract(auth, parsed, ethcommon.FromHex(simcontract.SimcontractMetaData.Bin), sim.Client())
+	require.NoError(t, err)
+	sim.Commit()
+
+	boundContract, err := simcontract.NewSimcontract(contractAddr, sim.Client())
+	require.NoError(t, err)
+
+	commitTx := func(tx *types.Transaction, err error) uint64 {
+		require.NoError(t, err)
+		sim.Commit()
+		receipt, err := sim.Client().TransactionReceipt(ctx, tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		return receipt.BlockNumber.Uint64()
+	}
+
+	_, packedPubKey := operatorPublicKey(t)
+	registrationBlock := commitTx(boundContract.RegisterOperator(auth, packedPubKey, big.NewInt(100_000_000)))
+	recipient := ethcommon.HexToAddress("0x1111111111111111111111111111111111111111")
+	commitTx(boundContract.SetFeeRecipientAddress(auth, recipient))
+
+	client, err := executionclient.New(ctx, "ws:"+strings.TrimPrefix(httpSrv.URL, "http:"), contractAddr,
+		executionclient.WithLogger(logger),
+		executionclient.WithFollowDistance(0),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	db, err := kv.NewInMemory(logger, basedb.Options{Ctx: ctx})
+	require.NoError(t, err)
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	eh := setupEventHandler(t, ctx, db, nodeStorage)
+	eventSyncer := eventsyncer.New(nodeStorage, client, eh, eventsyncer.WithLogger(logger))
+
+	lastProcessedBlock, err := eventSyncer.SyncHistory(ctx, 0)
+	require.NoError(t, err)
+
+	syncErr := make(chan error, 1)
+	go func() {
+		syncErr <- eventSyncer.SyncOngoing(ctx, lastProcessedBlock+1)
+	}()
+
+	// Reprocessing the registration would fail if it was applied on top of itself.
+	require.NoError(t, eventSyncer.Resync(ctx, registrationBlock))
+
+	// The ongoing sync keeps processing new events after reprocessing the old ones.
+	newPubKey, packedPubKey := operatorPublicKey(t)
+	commitTx(boundContract.RegisterOperator(auth, packedPubKey, big.NewInt(100_000_000)))
+	require.Eventually(t, func() bool {
+		operator, found, err := nodeStorage.GetOperatorData(nil, 2)
+		return err == nil && found && bytes.Equal(operator.PublicKey, newPubKey)
+	}, 10*time.Second, 50*time.Millisecond)
+
+	_, found, err := nodeStorage.GetOperatorData(nil, 1)
+	require.NoError(t, err)
+	require.True(t, found)
+	recipientData, found, err := nodeStorage.GetRecipientData(nil, testAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, bellatrix.ExecutionAddress(recipient), recipientData.FeeRecipient)
+
+	select {
+	case err := <-syncErr:
+		require.FailNow(t, "ongoing sync stopped", err)
+	default:
+	}
+}
+
+// operatorPublicKey returns a new operator public key and its contract encoding.
+func operatorPublicKey(t *testing.T) (encoded, packed []byte) {
+	privateKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	encoded, err = privateKey.Public().Base64()
+	require.NoError(t, err)
+
+	packed, err = eventparser.PackOperatorPublicKey(encoded)
+	require.NoError(t, err)
+	return encoded, packed
+}
+
+func setupEventHandler(
+	t *testing.T,
+	ctx context.Context,
+	db *kv.BadgerDB,
+	nodeStorage operatorstorage.Storage,
+) *eventhandler.EventHandler {
+	logger := zaptest.NewLogger(t)
+
+	privateKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	encodedPubKey, err := privateKey.Public().Base64()
+	require.NoError(t, err)
+	operatorDataStore := operatordatastore.New(&registrystorage.OperatorData{PublicKey: encodedPubKey})
+
+	keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkconfig.TestNetwork, "")
+	require.NoError(t, err)
+
+	validatorCtrl := validator.NewController(logger, validator.ControllerOptions{
+		Context:           ctx,
+		DB:                db,
+		RegistryStorage:   nodeStorage,
+		OperatorDataStore: operatorDataStore,
+		ValidatorsMap:     validators.New(ctx),
+	})
+
+	contractFilterer, err := contract.NewContractFilterer(ethcommon.Address{}, nil)
+	require.NoError(t, err)
+
+	eh, err := eventhandler.New(
+		nodeStorage,
+		eventparser.New(contractFilterer),
+		validatorCtrl,
+		networkconfig.TestNetwork,
+		operatorDataStore,
+		privateKey,
+		keyManager,
+		beacon.NewMockBeaconNode(gomock.NewController(t)),
+		eventhandler.WithFullNode(),
+		eventhandler.WithLogger(logger),
+		eventhandler.WithReorgJournal(16),
+	)
+	require.NoError(t, err)
+	return eh
+}
@@ -0,0 +1,480 @@
+// Package analytics computes the participation of operators in the duties of their validators,
+// from the participants recorded by the exporter.
+package analytics
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/networkconfig"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+// ErrEpochsOutOfRange is returned when a report is requested for more epochs than configured.
+var ErrEpochsOutOfRange = errors.New("epochs out of range")
+
+// Options holds the dependencies of the analytics.
+type Options struct {
+	Network    networkconfig.NetworkConfig
+	QBFTStores *storage.QBFTStores
+	Shares     registrystorage.Shares
+	// Epochs is the number of recent epochs covered by the metrics,
+	// and the maximum number of epochs a report can cover.
+	Epochs uint64
+}
+
+// Analytics computes the participation of operators over windows of recent epochs.
+//
+// A duty counts once its post-consensus quorum is recorded, and an operator missed it
+// if its signature isn't among the recorded ones. Duties which never reached a quorum aren't recorded,
+// so they aren't counted.
+//
+// The report of the configured epochs is computed once per epoch, and reports of these epochs
+// are served from it, so only reports of fewer epochs are computed on request.
+type Analytics struct {
+	logger *zap.Logger
+	opts   Options
+
+	// cached is the report of the configured epochs, including the stats of each validator.
+	cached atomic.Pointer[cachedReport]
+
+	mu sync.Mutex
+	// signers holds the signers observed so far for each duty.
+	signers map[dutyKey]map[spectypes.OperatorID]struct{}
+	// latencies holds the latencies of the signatures of each operator per epoch.
+	latencies map[phase0.Epoch]map[spectypes.OperatorID]*latency
+}
+
+type dutyKey struct {
+	identifier convert.MessageID
+	slot       phase0.Slot
+}
+
+type latency struct {
+	total time.Duration
+	count uint64
+}
+
+type cachedReport struct {
+	report     *Report
+	validators map[string]*ValidatorStats
+}
+
+// New creates a new analytics instance.
+func New(logger *zap.Logger, opts Options) *Analytics {
+	return &Analytics{
+		logger:    logger,
+		opts:      opts,
+		signers:   make(map[dutyKey]map[spectypes.OperatorID]struct{}),
+		latencies: make(map[phase0.Epoch]map[spectypes.OperatorID]*latency),
+	}
+}
+
+// HandleDecided observes the signers of a duty as they are recorded,
+// measuring the time from the start of the duty's slot until each operator's signature is first recorded.
+func (a *Analytics) HandleDecided(entry qbftstorage.ParticipantsRangeEntry) {
+	a.handleDecided(entry, time.Now())
+}
+
+func (a *Analytics) handleDecided(entry qbftstorage.ParticipantsRangeEntry, now time.Time) {
+	beaconNetwork := a.opts.Network.Beacon
+	sinceSlotStart := now.Sub(beaconNetwork.GetSlotStartTime(entry.Slot))
+	epoch := beaconNetwork.EstimatedEpochAtSlot(entry.Slot)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := dutyKey{identifier: entry.Identifier, slot: entry.Slot}
+	seen, ok := a.signers[key]
+	if !ok {
+		seen = make(map[spectypes.OperatorID]struct{})
+		a.signers[key] = seen
+	}
+	latencies, ok := a.latencies[epoch]
+	if !ok {
+		latencies = make(map[spectypes.OperatorID]*latency)
+		a.latencies[epoch] = latencies
+	}
+	for _, signer := range entry.Signers {
+		if _, ok := seen[signer]; ok {
+			continue
+		}
+		seen[signer] = struct{}{}
+		l, ok := latencies[signer]
+		if !ok {
+			l = &latency{}
+			latencies[signer] = l
+		}
+		l.total += sinceSlotStart
+		l.count++
+	}
+}
+
+// Run computes the report and updates the metrics on start and at the start of every epoch,
+// until the context is done.
+func (a *Analytics) Run(ctx context.Context) {
+	beaconNetwork := a.opts.Network.Beacon
+	for {
+		if err := a.refresh(); err != nil {
+			a.logger.Warn("could not compute participation report", zap.Error(err))
+		}
+
+		// Reports are computed a slot into the epoch, to let the signatures of the previous one arrive.
+		next := beaconNetwork.EpochStartTime(beaconNetwork.EstimatedCurrentEpoch() + 1).Add(beaconNetwork.SlotDurationSec())
+		select {
+		case <-time.After(time.Until(next)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refresh computes the report of the configured epochs, caches it and updates the metrics.
+func (a *Analytics) refresh() error {
+	start := time.Now()
+	report, err := a.compute(ReportOptions{Epochs: a.opts.Epochs, Validators: true})
+	if err != nil {
+		return err
+	}
+	validators := make(map[string]*ValidatorStats, len(report.Validators))
+	for _, validator := range report.Validators {
+		validators[validator.PublicKey] = validator
+	}
+	a.cached.Store(&cachedReport{report: report, validators: validators})
+
+	updateMetrics(report)
+	a.prune(report.FromEpoch)
+	a.logger.Debug("updated participation metrics",
+		zap.Uint64("from_epoch", uint64(report.FromEpoch)),
+		zap.Uint64("to_epoch", uint64(report.ToEpoch)),
+		zap.Int("operators", len(report.Operators)),
+		fields.Took(time.Since(start)))
+	return nil
+}
+
+// prune removes the observations of epochs before the given one.
+func (a *Analytics) prune(epoch phase0.Epoch) {
+	firstSlot := a.opts.Network.Beacon.FirstSlotAtEpoch(epoch)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for key := range a.signers {
+		if key.slot < firstSlot {
+			delete(a.signers, key)
+		}
+	}
+	for e := range a.latencies {
+		if e < epoch {
+			delete(a.latencies, e)
+		}
+	}
+}
+
+// ReportOptions selects what a report covers.
+type ReportOptions struct {
+	// Epochs is the number of completed epochs to cover, up to the configured epochs (which is the default).
+	Epochs uint64
+	// Filters select the validators to cover, all validators are covered if empty.
+	Filters []registrystorage.SharesFilter
+	// Validators includes the stats of each validator.
+	Validators bool
+}
+
+// Report is the participation of operators over a window of epochs.
+type Report struct {
+	FromEpoch  phase0.Epoch      `json:"from_epoch"`
+	ToEpoch    phase0.Epoch      `json:"to_epoch"`
+	Operators  []*OperatorStats  `json:"operators"`
+	Committees []*CommitteeStats `json:"committees"`
+	Validators []*ValidatorStats `json:"validators,omitempty"`
+}
+
+// ParticipationStats counts the duties an operator participated in and missed.
+type ParticipationStats struct {
+	Duties            uint64            `json:"duties"`
+	Missed            uint64            `json:"missed"`
+	MissedByRole      map[string]uint64 `json:"missed_by_role,omitempty"`
+	ParticipationRate float64           `json:"participation_rate"`
+}
+
+// OperatorStats is the participation of an operator.
+type OperatorStats struct {
+	OperatorID spectypes.OperatorID `json:"operator_id"`
+	ParticipationStats
+	// AverageLatency is the average time from the start of the slot until the exporter recorded the operator's
+	// post-consensus signature, which is once a quorum of signatures was seen or later. It includes the time
+	// to reach the quorum and to propagate to the exporter, so it isn't the operator's decision latency.
+	// It's only set for the operators of the whole report.
+	AverageLatency *float64 `json:"average_latency_seconds,omitempty"`
+}
+
+// CommitteeStats is the participation of the operators of a committee.
+type CommitteeStats struct {
+	CommitteeID string           `json:"committee_id"`
+	Validators  int              `json:"validators"`
+	Operators   []*OperatorStats `json:"operators"`
+}
+
+// ValidatorStats is the participation of the operators of a validator.
+type ValidatorStats struct {
+	PublicKey   string           `json:"public_key"`
+	CommitteeID string           `json:"committee_id"`
+	Operators   []*OperatorStats `json:"operators"`
+}
+
+// Report returns the participation of operators in the last completed epochs.
+// Reports of the configured epochs are aggregated from the cached report once it's computed,
+// so validators added since then are only covered from the next epoch.
+func (a *Analytics) Report(opts ReportOptions) (*Report, error) {
+	if opts.Epochs == 0 {
+		opts.Epochs = a.opts.Epochs
+	}
+	if opts.Epochs > a.opts.Epochs {
+		return nil, fmt.Errorf("%w: at most %d epochs are covered", ErrEpochsOutOfRange, a.opts.Epochs)
+	}
+	if cached := a.cached.Load(); cached != nil && opts.Epochs == a.opts.Epochs {
+		return a.fromCache(cached, opts), nil
+	}
+	return a.compute(opts)
+}
+
+// fromCache returns the report of the matching validators, aggregated from the cached report.
+func (a *Analytics) fromCache(cached *cachedReport, opts ReportOptions) *Report {
+	report := *cached.report
+	if len(opts.Filters) == 0 {
+		if !opts.Validators {
+			report.Validators = nil
+		}
+		return &report
+	}
+
+	report.Validators = nil
+	operators := make(statsByOperator)
+	committees := make(map[string]*CommitteeStats)
+	committeeOperators := make(map[string]statsByOperator)
+	for _, share := range a.opts.Shares.List(nil, opts.Filters...) {
+		validator, ok := cached.validators[hex.EncodeToString(share.ValidatorPubKey[:])]
+		if !ok {
+			continue
+		}
+		committee, ok := committees[validator.CommitteeID]
+		if !ok {
+			committee = &CommitteeStats{CommitteeID: validator.CommitteeID}
+			committees[validator.CommitteeID] = committee
+			committeeOperators[validator.CommitteeID] = make(statsByOperator)
+		}
+		committee.Validators++
+		for _, stats := range validator.Operators {
+			operators.add(stats)
+			committeeOperators[validator.CommitteeID].add(stats)
+		}
+		if opts.Validators {
+			report.Validators = append(report.Validators, validator)
+		}
+	}
+
+	report.Operators = operators.list(nil)
+	latencies := make(map[spectypes.OperatorID]*float64, len(cached.report.Operators))
+	for _, operator := range cached.report.Operators {
+		latencies[operator.OperatorID] = operator.AverageLatency
+	}
+	for _, operator := range report.Operators {
+		operator.AverageLatency = latencies[operator.OperatorID]
+	}
+	report.Committees = nil
+	for committeeID, committee := range committees {
+		committee.Operators = committeeOperators[committeeID].list(nil)
+		report.Committees = append(report.Committees, committee)
+	}
+	sortReport(&report)
+	return &report
+}
+
+// compute computes the report from the recorded participants.
+func (a *Analytics) compute(opts ReportOptions) (*Report, error) {
+	beaconNetwork := a.opts.Network.Beacon
+
+	report := &Report{}
+	currentEpoch := beaconNetwork.EstimatedCurrentEpoch()
+	if currentEpoch == 0 {
+		return report, nil
+	}
+	report.ToEpoch = currentEpoch - 1
+	if uint64(currentEpoch) > opts.Epochs {
+		report.FromEpoch = currentEpoch - phase0.Epoch(opts.Epochs)
+	}
+	fromSlot := beaconNetwork.FirstSlotAtEpoch(report.FromEpoch)
+	toSlot := beaconNetwork.FirstSlotAtEpoch(currentEpoch) - 1
+
+	operators := make(statsByOperator)
+	committees := make(map[spectypes.CommitteeID]*CommitteeStats)
+	committeeOperators := make(map[spectypes.CommitteeID]statsByOperator)
+
+	for _, share := range a.opts.Shares.List(nil, opts.Filters...) {
+		committeeID := share.CommitteeID()
+		committee, ok := committees[committeeID]
+		if !ok {
+			committee = &CommitteeStats{CommitteeID: hex.EncodeToString(committeeID[:])}
+			committees[committeeID] = committee
+			committeeOperators[committeeID] = make(statsByOperator)
+		}
+		committee.Validators++
+
+		validatorOperators := make(statsByOperator)
+		err := a.opts.QBFTStores.Each(func(role convert.RunnerRole, store qbftstorage.QBFTStore) error {
+			// Participants of committee duties are recorded per validator, as attester and sync committee duties.
+			if role == convert.RoleCommittee {
+				return nil
+			}
+			msgID := convert.NewMsgID(a.opts.Network.DomainType(), share.ValidatorPubKey[:], role)
+			entries, err := store.GetParticipantsInRange(msgID, fromSlot, toSlot)
+			if err != nil {
+				return fmt.Errorf("could not get participants: %w", err)
+			}
+			for _, entry := range entries {
+				for _, member := range share.Committee {
+					participated := containsSigner(entry.Signers, member.Signer)
+					operators.record(member.Signer, role, participated)
+					committeeOperators[committeeID].record(member.Signer, role, participated)
+					validatorOperators.record(member.Signer, role, participated)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.Validators {
+			report.Validators = append(report.Validators, &ValidatorStats{
+				PublicKey:   hex.EncodeToString(share.ValidatorPubKey[:]),
+				CommitteeID: committee.CommitteeID,
+				Operators:   validatorOperators.list(share),
+			})
+		}
+	}
+
+	report.Operators = operators.list(nil)
+	a.setLatencies(report.Operators, report.FromEpoch, report.ToEpoch)
+	for committeeID, committee := range committees {
+		committee.Operators = committeeOperators[committeeID].list(nil)
+		report.Committees = append(report.Committees, committee)
+	}
+	sortReport(report)
+	return report, nil
+}
+
+func sortReport(report *Report) {
+	sort.Slice(report.Committees, func(i, j int) bool {
+		return report.Committees[i].CommitteeID < report.Committees[j].CommitteeID
+	})
+	sort.Slice(report.Validators, func(i, j int) bool {
+		return report.Validators[i].PublicKey < report.Validators[j].PublicKey
+	})
+}
+
+func (a *Analytics) setLatencies(operators []*OperatorStats, fromEpoch, toEpoch phase0.Epoch) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, operator := range operators {
+		var total latency
+		for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+			if l, ok := a.latencies[epoch][operator.OperatorID]; ok {
+				total.total += l.total
+				total.count += l.count
+			}
+		}
+		if total.count > 0 {
+			average := (total.total / time.Duration(total.count)).Seconds()
+			operator.AverageLatency = &average
+		}
+	}
+}
+
+type statsByOperator map[spectypes.OperatorID]*OperatorStats
+
+func (s statsByOperator) record(operatorID spectypes.OperatorID, role convert.RunnerRole, participated bool) {
+	stats, ok := s[operatorID]
+	if !ok {
+		stats = &OperatorStats{OperatorID: operatorID}
+		s[operatorID] = stats
+	}
+	stats.Duties++
+	if !participated {
+		stats.Missed++
+		if stats.MissedByRole == nil {
+			stats.MissedByRole = make(map[string]uint64)
+		}
+		stats.MissedByRole[role.String()]++
+	}
+}
+
+// add adds the duties of the given stats to the stats of its operator.
+func (s statsByOperator) add(stats *OperatorStats) {
+	// Like record, operators without duties aren't listed.
+	if stats.Duties == 0 {
+		return
+	}
+	total, ok := s[stats.OperatorID]
+	if !ok {
+		total = &OperatorStats{OperatorID: stats.OperatorID}
+		s[stats.OperatorID] = total
+	}
+	total.Duties += stats.Duties
+	total.Missed += stats.Missed
+	for role, missed := range stats.MissedByRole {
+		if total.MissedByRole == nil {
+			total.MissedByRole = make(map[string]uint64)
+		}
+		total.MissedByRole[role] += missed
+	}
+}
+
+// list returns the stats ordered by operator ID, including the operators of the share
+// which had no duties, if given.
+func (s statsByOperator) list(share *types.SSVShare) []*OperatorStats {
+	if share != nil {
+		for _, member := range share.Committee {
+			if _, ok := s[member.Signer]; !ok {
+				s[member.Signer] = &OperatorStats{OperatorID: member.Signer}
+			}
+		}
+	}
+	list := make([]*OperatorStats, 0, len(s))
+	for _, stats := range s {
+		if stats.Duties > 0 {
+			stats.ParticipationRate = float64(stats.Duties-stats.Missed) / float64(stats.Duties)
+		}
+		list = append(list, stats)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].OperatorID < list[j].OperatorID
+	})
+	return list
+}
+
+func containsSigner(signers []spectypes.OperatorID, signer spectypes.OperatorID) bool {
+	for _, s := range signers {
+		if s == signer {
+			return true
+		}
+	}
+	return false
+}
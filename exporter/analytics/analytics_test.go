@@ -0,0 +1,148 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestAnalytics_Report(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+
+	// The network is in the middle of epoch 10.
+	network := networkconfig.TestNetwork
+	network.Beacon = beacon.NewCustomNetwork(spectypes.BeaconTestNetwork, beacon.NetworkParams{
+		MinGenesisTime: time.Now().Add(-(10*32*12 + 100) * time.Second).Unix(),
+		SlotDuration:   12 * time.Second,
+		SlotsPerEpoch:  32,
+	})
+
+	shares, _, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	share1, share2 := mockShare(0x1, 1, 2, 3, 4), mockShare(0x2, 1, 2, 3, 5)
+	require.NoError(t, shares.Save(nil, share1, share2))
+
+	stores := storage.NewStoresFromRoles(db, convert.RoleCommittee, convert.RoleAttester, convert.RoleProposer)
+	save := func(share *types.SSVShare, role convert.RunnerRole, slot phase0.Slot, signers ...spectypes.OperatorID) {
+		msgID := convert.NewMsgID(network.DomainType(), share.ValidatorPubKey[:], role)
+		require.NoError(t, stores.Get(role).SaveParticipants(msgID, slot, signers))
+	}
+	// A duty per epoch in epochs 6-9, and one before.
+	save(share1, convert.RoleAttester, 192, 1, 2, 3)
+	save(share2, convert.RoleProposer, 100, 1, 2, 3)
+	for slot := phase0.Slot(192); slot < 320; slot += 32 {
+		if slot != 192 {
+			save(share1, convert.RoleAttester, slot, 1, 2, 3, 4)
+		}
+		save(share2, convert.RoleProposer, slot, 1, 2, 3)
+	}
+
+	a := New(logger, Options{
+		Network:    network,
+		QBFTStores: stores,
+		Shares:     shares,
+		Epochs:     4,
+	})
+	slotStart := network.Beacon.GetSlotStartTime(300)
+	msgID := convert.NewMsgID(network.DomainType(), share1.ValidatorPubKey[:], convert.RoleAttester)
+	a.handleDecided(qbftstorage.ParticipantsRangeEntry{Slot: 300, Signers: []spectypes.OperatorID{1, 2, 3}, Identifier: msgID}, slotStart.Add(2*time.Second))
+	a.handleDecided(qbftstorage.ParticipantsRangeEntry{Slot: 300, Signers: []spectypes.OperatorID{1, 2, 3, 4}, Identifier: msgID}, slotStart.Add(3*time.Second))
+
+	report, err := a.Report(ReportOptions{})
+	require.NoError(t, err)
+	require.Equal(t, phase0.Epoch(6), report.FromEpoch)
+	require.Equal(t, phase0.Epoch(9), report.ToEpoch)
+	require.Empty(t, report.Validators)
+
+	require.Len(t, report.Operators, 5)
+	operator1, operator4, operator5 := report.Operators[0], report.Operators[3], report.Operators[4]
+	require.Equal(t, ParticipationStats{Duties: 8, ParticipationRate: 1}, operator1.ParticipationStats)
+	require.Equal(t, 2.0, *operator1.AverageLatency)
+	require.Equal(t, ParticipationStats{Duties: 4, Missed: 1, MissedByRole: map[string]uint64{"ATTESTER": 1}, ParticipationRate: 0.75}, operator4.ParticipationStats)
+	require.Equal(t, 3.0, *operator4.AverageLatency)
+	require.Equal(t, ParticipationStats{Duties: 4, Missed: 4, MissedByRole: map[string]uint64{"PROPOSER": 4}}, operator5.ParticipationStats)
+	require.Nil(t, operator5.AverageLatency)
+
+	require.Len(t, report.Committees, 2)
+	for _, committee := range report.Committees {
+		require.Equal(t, 1, committee.Validators)
+		require.Len(t, committee.Operators, 4)
+		for _, operator := range committee.Operators {
+			require.Equal(t, uint64(4), operator.Duties)
+		}
+	}
+
+	report, err = a.Report(ReportOptions{
+		Epochs:     1,
+		Filters:    []registrystorage.SharesFilter{func(share *types.SSVShare) bool { return share.ValidatorPubKey == share2.ValidatorPubKey }},
+		Validators: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, phase0.Epoch(9), report.FromEpoch)
+	require.Len(t, report.Committees, 1)
+	require.Len(t, report.Validators, 1)
+	require.Len(t, report.Validators[0].Operators, 4)
+	require.Equal(t, spectypes.OperatorID(5), report.Validators[0].Operators[3].OperatorID)
+	require.Equal(t, uint64(1), report.Validators[0].Operators[3].Missed)
+
+	_, err = a.Report(ReportOptions{Epochs: 5})
+	require.ErrorIs(t, err, ErrEpochsOutOfRange)
+
+	// Once computed, reports of the configured epochs are aggregated from the cached report.
+	require.NoError(t, a.refresh())
+	for _, opts := range []ReportOptions{
+		{},
+		{Validators: true},
+		{Filters: []registrystorage.SharesFilter{func(share *types.SSVShare) bool { return share.ValidatorPubKey == share2.ValidatorPubKey }}},
+		{Filters: []registrystorage.SharesFilter{func(*types.SSVShare) bool { return true }}, Validators: true},
+	} {
+		opts.Epochs = 4
+		computed, err := a.compute(opts)
+		require.NoError(t, err)
+		cached, err := a.Report(opts)
+		require.NoError(t, err)
+		require.Equal(t, computed, cached)
+	}
+	// Participants recorded since aren't included until the next epoch.
+	save(share2, convert.RoleProposer, 300, 1, 2, 3, 5)
+	cached, err := a.Report(ReportOptions{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), cached.Operators[4].Missed)
+	// Reports of fewer epochs are computed, so they include it.
+	report, err = a.Report(ReportOptions{Epochs: 1})
+	require.NoError(t, err)
+	require.Equal(t, ParticipationStats{Duties: 2, Missed: 1, MissedByRole: map[string]uint64{"PROPOSER": 1}, ParticipationRate: 0.5}, report.Operators[4].ParticipationStats)
+
+	a.prune(10)
+	require.Len(t, a.signers, 0)
+	require.Len(t, a.latencies, 0)
+}
+
+func mockShare(pubKey byte, operatorIDs ...spectypes.OperatorID) *types.SSVShare {
+	committee := make([]*spectypes.ShareMember, len(operatorIDs))
+	for i, id := range operatorIDs {
+		committee[i] = &spectypes.ShareMember{Signer: id}
+	}
+	return &types.SSVShare{
+		Share: spectypes.Share{
+			ValidatorPubKey: spectypes.ValidatorPK{pubKey},
+			Committee:       committee,
+		},
+	}
+}
@@ -0,0 +1,53 @@
+package analytics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metricsOperatorParticipationRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_analytics_operator_participation_rate",
+		Help: "Rate of duties the operator participated in over the recent epochs",
+	}, []string{"operator_id"})
+	metricsOperatorMissedDuties = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_analytics_operator_missed_duties",
+		Help: "Number of duties the operator missed over the recent epochs",
+	}, []string{"operator_id", "role"})
+	metricsOperatorLatency = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_analytics_operator_latency_seconds",
+		Help: "Average time from the start of the slot until the exporter recorded the operator's post-consensus signature, with the quorum or later, over the recent epochs (seconds)",
+	}, []string{"operator_id"})
+	metricsCommitteeParticipationRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_analytics_committee_participation_rate",
+		Help: "Rate of the committee's duties each operator participated in over the recent epochs",
+	}, []string{"committee_id", "operator_id"})
+)
+
+// updateMetrics replaces the metrics with the ones of the report.
+// Validators aren't exposed as metrics, as there can be too many of them.
+func updateMetrics(report *Report) {
+	metricsOperatorParticipationRate.Reset()
+	metricsOperatorMissedDuties.Reset()
+	metricsOperatorLatency.Reset()
+	metricsCommitteeParticipationRate.Reset()
+
+	for _, operator := range report.Operators {
+		operatorID := strconv.FormatUint(operator.OperatorID, 10)
+		metricsOperatorParticipationRate.WithLabelValues(operatorID).Set(operator.ParticipationRate)
+		for role, missed := range operator.MissedByRole {
+			metricsOperatorMissedDuties.WithLabelValues(operatorID, role).Set(float64(missed))
+		}
+		if operator.AverageLatency != nil {
+			metricsOperatorLatency.WithLabelValues(operatorID).Set(*operator.AverageLatency)
+		}
+	}
+	for _, committee := range report.Committees {
+		for _, operator := range committee.Operators {
+			operatorID := strconv.FormatUint(operator.OperatorID, 10)
+			metricsCommitteeParticipationRate.WithLabelValues(committee.CommitteeID, operatorID).Set(operator.ParticipationRate)
+		}
+	}
+}
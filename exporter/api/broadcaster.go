@@ -19,7 +19,9 @@ type Broadcaster interface {
 
 type broadcasted interface {
 	ID() string
+	// Send must not block, so that slow connections don't stall the broadcaster
 	Send([]byte)
+	Subscribed(msg Message) bool
 }
 
 type broadcaster struct {
@@ -44,11 +46,10 @@ func (b *broadcaster) FromFeed(logger *zap.Logger, msgFeed *event.Feed) error {
 	for {
 		select {
 		case msg := <-cn:
-			go func(msg Message) {
-				if err := b.Broadcast(msg); err != nil {
-					logger.Error("could not broadcast message", zap.Error(err))
-				}
-			}(msg)
+			// broadcasting in order, as connections drop messages rather than block when they fall behind
+			if err := b.Broadcast(msg); err != nil {
+				logger.Error("could not broadcast message", zap.Error(err))
+			}
 		case err := <-sub.Err():
 			logger.Warn("could not read messages from msgFeed", zap.Error(err))
 			return err
@@ -56,22 +57,27 @@ func (b *broadcaster) FromFeed(logger *zap.Logger, msgFeed *event.Feed) error {
 	}
 }
 
-// Broadcast broadcasts a message to all available connections
+// Broadcast broadcasts a message to all available connections subscribed to it
 func (b *broadcaster) Broadcast(msg Message) error {
-	data, err := json.Marshal(&msg)
-	if err != nil {
-		return errors.Wrap(err, "could not marshal msg")
-	}
-
 	// lock is applied only when reading from the connections map
 	// therefore a new temp slice is created to hold all current connections and avoid concurrency issues
 	b.mut.Lock()
 	var conns []broadcasted
 	for _, c := range b.connections {
-		conns = append(conns, c)
+		if c.Subscribed(msg) {
+			conns = append(conns, c)
+		}
 	}
 	b.mut.Unlock()
-	// send to all connections
+	if len(conns) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(&msg)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal msg")
+	}
+	// send to all subscribed connections
 	for _, c := range conns {
 		c.Send(data)
 	}
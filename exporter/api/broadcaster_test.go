@@ -18,6 +18,7 @@ func TestConn_Send_FullQueue(t *testing.T) {
 	for i := 0; i < chanSize+2; i++ {
 		c.Send([]byte(fmt.Sprintf("test-%d", i)))
 	}
+	require.Equal(t, uint64(2), c.Dropped())
 }
 
 func TestBroadcaster(t *testing.T) {
@@ -76,6 +77,10 @@ func (b *broadcastedMock) Send(msg []byte) {
 	b.msgs = append(b.msgs, msg)
 }
 
+func (b *broadcastedMock) Subscribed(msg Message) bool {
+	return true
+}
+
 func (b *broadcastedMock) Size() int {
 	b.mut.Lock()
 	defer b.mut.Unlock()
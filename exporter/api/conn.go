@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -21,8 +22,8 @@ var (
 	// pingInterval period to send ping messages. Must be less than pingTimeout.
 	pingInterval = (pingTimeout * 8) / 10
 
-	// maxMessageSize max msg size allowed from peer.
-	maxMessageSize = int64(1024)
+	// maxMessageSize max msg size allowed from peer, large enough for subscriptions to many validators.
+	maxMessageSize = int64(64 * 1024)
 
 	chanSize = 256
 
@@ -43,6 +44,9 @@ type Conn interface {
 	ID() string
 	ReadNext() []byte
 	Send(msg []byte)
+	Subscribe(filter MessageFilter) error
+	Subscribed(msg Message) bool
+	Dropped() uint64
 	WriteLoop(logger *zap.Logger)
 	ReadLoop(logger *zap.Logger)
 	Close() error
@@ -58,13 +62,21 @@ type conn struct {
 
 	read chan []byte
 	send chan []byte
+	// replies is the queue of the replies to the connection's requests, which are written
+	// ahead of the queued messages and are never dropped
+	replies chan []byte
 
 	writeLock sync.Locker
 
 	withPing bool
+
+	// subscription is the criteria of the messages to send, or nil to send all messages
+	subscription atomic.Pointer[subscription]
+	// dropped is the number of messages that were dropped as the send queue was full
+	dropped atomic.Uint64
 }
 
-func newConn(ctx context.Context, ws *websocket.Conn, id string, writeTimeout time.Duration, withPing bool) Conn {
+func newConn(ctx context.Context, ws *websocket.Conn, id string, writeTimeout time.Duration, withPing bool) *conn {
 	return &conn{
 		ctx:          ctx,
 		id:           id,
@@ -72,6 +84,7 @@ func newConn(ctx context.Context, ws *websocket.Conn, id string, writeTimeout ti
 		writeTimeout: writeTimeout,
 		read:         make(chan []byte, chanSize),
 		send:         make(chan []byte, chanSize),
+		replies:      make(chan []byte),
 		writeLock:    &sync.Mutex{},
 		withPing:     withPing,
 	}
@@ -97,13 +110,47 @@ func (c *conn) ReadNext() []byte {
 	return <-c.read
 }
 
-// Send sends the given message
+// Send queues the given message, dropping it if the queue is full
+// so that a slow connection doesn't block the sender
 func (c *conn) Send(msg []byte) {
-	if len(c.send) >= chanSize {
-		// don't send on full channel
-		return
+	select {
+	case c.send <- msg:
+	default:
+		c.dropped.Add(1)
+		metricStreamDroppedCount.Inc()
+	}
+}
+
+// Reply queues the given reply to a request of the connection ahead of the queued messages,
+// blocking until it is taken by the write loop or the connection's context is done
+func (c *conn) Reply(msg []byte) error {
+	select {
+	case c.replies <- msg:
+		return nil
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	}
+}
+
+// Subscribe replaces the criteria of the messages to send with the given filter
+func (c *conn) Subscribe(filter MessageFilter) error {
+	s, err := newSubscription(filter)
+	if err != nil {
+		return err
 	}
-	c.send <- msg
+	c.subscription.Store(s)
+	return nil
+}
+
+// Subscribed returns whether the given message matches the connection's subscription, if any
+func (c *conn) Subscribed(msg Message) bool {
+	s := c.subscription.Load()
+	return s == nil || s.matches(msg)
+}
+
+// Dropped returns the number of messages that were dropped
+func (c *conn) Dropped() uint64 {
+	return c.dropped.Load()
 }
 
 // WriteLoop a loop to activate writes on the socket
@@ -125,6 +172,16 @@ func (c *conn) WriteLoop(logger *zap.Logger) {
 	}
 
 	for {
+		// replies are written before the queued messages
+		select {
+		case message := <-c.replies:
+			if err := c.write(logger, message); err != nil {
+				return
+			}
+			continue
+		default:
+		}
+
 		select {
 		case <-ctx.Done():
 			c.writeLock.Lock()
@@ -135,20 +192,32 @@ func (c *conn) WriteLoop(logger *zap.Logger) {
 				logger.Error("could not send close message", zap.Error(err))
 				return
 			}
+		case message := <-c.replies:
+			if err := c.write(logger, message); err != nil {
+				return
+			}
 		case message := <-c.send:
-			c.writeLock.Lock()
-			n, err := c.sendMsg(message)
-			c.writeLock.Unlock()
-			reportStreamOutbound(c.ws.RemoteAddr().String(), err)
-			if err != nil {
-				logger.Warn("failed to send message", zap.Error(err))
+			if err := c.write(logger, message); err != nil {
 				return
 			}
-			c.logMsg(logger, message, n)
 		}
 	}
 }
 
+// write writes the given message to the socket
+func (c *conn) write(logger *zap.Logger, message []byte) error {
+	c.writeLock.Lock()
+	n, err := c.sendMsg(message)
+	c.writeLock.Unlock()
+	reportStreamOutbound(c.ws.RemoteAddr().String(), err)
+	if err != nil {
+		logger.Warn("failed to send message", zap.Error(err))
+		return err
+	}
+	c.logMsg(logger, message, n)
+	return nil
+}
+
 // ReadLoop is a loop to read messages from the socket
 func (c *conn) ReadLoop(logger *zap.Logger) {
 	defer func() {
@@ -3,20 +3,25 @@ package decided
 import (
 	"encoding/hex"
 	"fmt"
-	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
 	"time"
 
 	"github.com/patrickmn/go-cache"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/exporter/api"
+	"github.com/ssvlabs/ssv/exporter/convert"
 	"github.com/ssvlabs/ssv/logging/fields"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/controller"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
 )
 
 // NewStreamPublisher handles incoming newly decided messages.
-// it forward messages to websocket stream, where messages are cached (1m TTL) to avoid flooding
-func NewStreamPublisher(logger *zap.Logger, ws api.WebSocketServer) controller.NewDecidedHandler {
+// it forward messages to websocket stream, where messages are cached (1m TTL) to avoid flooding.
+// Messages are described by their validators, committee and operators, so that they're only
+// pushed to the stream connections subscribed to them.
+func NewStreamPublisher(logger *zap.Logger, ws api.WebSocketServer, validators registrystorage.BaseValidatorStore) controller.NewDecidedHandler {
 	c := cache.New(time.Minute, time.Minute*3/2)
 	feed := ws.BroadcastFeed()
 	return func(msg qbftstorage.ParticipantsRangeEntry) {
@@ -29,6 +34,50 @@ func NewStreamPublisher(logger *zap.Logger, ws api.WebSocketServer) controller.N
 		c.SetDefault(key, true)
 
 		logger.Debug("broadcast decided stream", zap.String("identifier", identifier), fields.Slot(msg.Slot))
-		feed.Send(api.NewParticipantsAPIMsg(msg))
+		apiMsg := api.NewParticipantsAPIMsg(msg)
+		apiMsg.Subject = streamSubject(validators, msg.Identifier)
+		feed.Send(apiMsg)
+	}
+}
+
+// streamSubject describes the validators, committee and operators of the duty with the given identifier.
+func streamSubject(validators registrystorage.BaseValidatorStore, msgID convert.MessageID) *api.StreamSubject {
+	role := msgID.GetRoleType()
+	dutyExecutorID := msgID.GetDutyExecutorID()
+	subject := &api.StreamSubject{Role: role.String()}
+
+	if role == convert.RoleCommittee {
+		// Committee IDs are padded to the size of validator public keys.
+		var committeeID spectypes.CommitteeID
+		copy(committeeID[:], dutyExecutorID[len(dutyExecutorID)-len(committeeID):])
+		subject.CommitteeID = hex.EncodeToString(committeeID[:])
+
+		committee, ok := validators.Committee(committeeID)
+		if !ok {
+			return subject
+		}
+		subject.OperatorIDs = committee.Operators
+		owners := make(map[string]struct{})
+		for _, share := range committee.Validators {
+			subject.PublicKeys = append(subject.PublicKeys, hex.EncodeToString(share.ValidatorPubKey[:]))
+			owners[hex.EncodeToString(share.OwnerAddress[:])] = struct{}{}
+		}
+		for owner := range owners {
+			subject.Owners = append(subject.Owners, owner)
+		}
+		return subject
+	}
+
+	subject.PublicKeys = []string{hex.EncodeToString(dutyExecutorID)}
+	share, ok := validators.Validator(dutyExecutorID)
+	if !ok {
+		return subject
+	}
+	committeeID := share.CommitteeID()
+	subject.CommitteeID = hex.EncodeToString(committeeID[:])
+	subject.Owners = []string{hex.EncodeToString(share.OwnerAddress[:])}
+	for _, member := range share.Committee {
+		subject.OperatorIDs = append(subject.OperatorIDs, member.Signer)
 	}
+	return subject
 }
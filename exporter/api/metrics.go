@@ -14,6 +14,10 @@ var (
 		Name: "ssv:exporter:stream_outbound_errors",
 		Help: "count the outbound messages failures on stream channel",
 	}, []string{"cid"})
+	metricStreamDroppedCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:exporter:stream_dropped",
+		Help: "count the outbound messages dropped on stream channel due to slow connections",
+	})
 )
 
 func reportStreamOutbound(cid string, err error) {
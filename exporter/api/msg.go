@@ -19,6 +19,8 @@ type Message struct {
 	Filter MessageFilter `json:"filter"`
 	// Values holds the results, optional as it's relevant for response
 	Data interface{} `json:"data,omitempty"`
+	// Subject is what a stream message is about, used to match it against stream subscriptions
+	Subject *StreamSubject `json:"-"`
 }
 
 type ParticipantsAPI struct {
@@ -103,6 +105,19 @@ type MessageFilter struct {
 	Role string `json:"role,omitempty"`
 	// PublicKey is optional, used for fetching decided messages or information about specific validator/operator
 	PublicKey string `json:"publicKey,omitempty"`
+
+	// The following are optional criteria of stream subscriptions, see TypeSubscribe.
+
+	// PublicKeys are the public keys of the validators to stream messages of
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	// Owners are the addresses of the owners of the validators to stream messages of
+	Owners []string `json:"owners,omitempty"`
+	// OperatorIDs are the operators to stream messages of
+	OperatorIDs []uint64 `json:"operatorIds,omitempty"`
+	// Roles are the duty types to stream messages of
+	Roles []string `json:"roles,omitempty"`
+	// CommitteeIDs are the committees to stream messages of
+	CommitteeIDs []string `json:"committeeIds,omitempty"`
 }
 
 // MessageType is the type of message being sent
@@ -119,4 +134,6 @@ const (
 	TypeError MessageType = "error"
 	// TypeParticipants is an enum for participants type messages
 	TypeParticipants MessageType = "participants"
+	// TypeSubscribe is an enum for stream subscription messages, which replace the stream's criteria with their filter
+	TypeSubscribe MessageType = "subscribe"
 )
@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -152,15 +154,18 @@ func (ws *wsServer) handleQuery(logger *zap.Logger, conn *websocket.Conn) {
 	}
 }
 
-// handleStream registers the connection for broadcasting of stream messages
+// handleStream registers the connection for broadcasting of stream messages,
+// which are limited to the ones matching the connection's latest subscription message, if any
 func (ws *wsServer) handleStream(logger *zap.Logger, wsc *websocket.Conn) {
 	cid := ConnectionID(wsc)
 	logger = logger.With(fields.ConnectionID(cid))
-	defer logger.Debug("stream handler done")
 
 	ctx, cancel := context.WithCancel(ws.ctx)
 	c := newConn(ctx, wsc, cid, sendTimeout, ws.withPing)
 	defer cancel()
+	defer func() {
+		logger.Debug("stream handler done", zap.Uint64("dropped_messages", c.Dropped()))
+	}()
 
 	if !ws.broadcaster.Register(c) {
 		logger.Warn("known connection")
@@ -169,6 +174,50 @@ func (ws *wsServer) handleStream(logger *zap.Logger, wsc *websocket.Conn) {
 	defer ws.broadcaster.Deregister(c)
 
 	go c.ReadLoop(logger)
+	go ws.handleSubscriptions(ctx, logger, c)
 
 	c.WriteLoop(logger)
 }
+
+// handleSubscriptions applies the subscription messages of the connection and acknowledges them
+func (ws *wsServer) handleSubscriptions(ctx context.Context, logger *zap.Logger, c *conn) {
+	for {
+		var raw []byte
+		select {
+		case <-ctx.Done():
+			return
+		case raw = <-c.read:
+		}
+
+		var msg Message
+		err := json.Unmarshal(raw, &msg)
+		if err == nil && msg.Type != TypeSubscribe {
+			err = fmt.Errorf("bad request - unknown message type '%s'", msg.Type)
+		}
+		if err == nil {
+			err = c.Subscribe(msg.Filter)
+		}
+
+		res := Message{Type: TypeSubscribe, Filter: msg.Filter}
+		if err != nil {
+			logger.Warn("invalid stream subscription", zap.Error(err))
+			res = Message{Type: TypeError, Data: []string{err.Error()}}
+		} else {
+			logger.Debug("stream subscription updated",
+				zap.Int("public_keys", len(msg.Filter.PublicKeys)),
+				zap.Int("owners", len(msg.Filter.Owners)),
+				zap.Int("operator_ids", len(msg.Filter.OperatorIDs)),
+				zap.Int("committee_ids", len(msg.Filter.CommitteeIDs)),
+				zap.Strings("roles", msg.Filter.Roles))
+		}
+		data, err := json.Marshal(&res)
+		if err != nil {
+			logger.Error("could not marshal subscription response", zap.Error(err))
+			continue
+		}
+		// the ack is not dropped with the stream messages when the connection's queue is full
+		if err := c.Reply(data); err != nil {
+			return
+		}
+	}
+}
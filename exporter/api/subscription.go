@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// StreamSubject describes the validators, committee and operators a stream message is about,
+// so that it's only pushed to the connections subscribed to any of them.
+type StreamSubject struct {
+	// PublicKeys are the hex-encoded public keys of the validators.
+	PublicKeys []string
+	// Owners are the hex-encoded addresses of the validators' owners.
+	Owners []string
+	// CommitteeID is the hex-encoded ID of the committee.
+	CommitteeID string
+	OperatorIDs []uint64
+	Role        string
+}
+
+// subscription holds the criteria of a stream connection.
+// Messages match when they match every given criterion, and a criterion matches when any of its values matches.
+type subscription struct {
+	publicKeys   map[string]struct{}
+	owners       map[string]struct{}
+	committeeIDs map[string]struct{}
+	operatorIDs  map[uint64]struct{}
+	roles        map[string]struct{}
+}
+
+func newSubscription(filter MessageFilter) (*subscription, error) {
+	var s subscription
+	var err error
+	if s.publicKeys, err = hexSet(filter.PublicKeys, 48); err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if s.owners, err = hexSet(filter.Owners, 20); err != nil {
+		return nil, fmt.Errorf("invalid owner: %w", err)
+	}
+	if s.committeeIDs, err = hexSet(filter.CommitteeIDs, 32); err != nil {
+		return nil, fmt.Errorf("invalid committee id: %w", err)
+	}
+	if len(filter.OperatorIDs) > 0 {
+		s.operatorIDs = make(map[uint64]struct{}, len(filter.OperatorIDs))
+		for _, id := range filter.OperatorIDs {
+			s.operatorIDs[id] = struct{}{}
+		}
+	}
+	if len(filter.Roles) > 0 {
+		s.roles = make(map[string]struct{}, len(filter.Roles))
+		for _, role := range filter.Roles {
+			s.roles[strings.ToUpper(role)] = struct{}{}
+		}
+	}
+	return &s, nil
+}
+
+// matches returns whether the message matches the subscription.
+// Messages without a subject only match by the public key and role of their filter.
+func (s *subscription) matches(msg Message) bool {
+	subject := msg.Subject
+	if subject == nil {
+		subject = &StreamSubject{Role: msg.Filter.Role}
+		if msg.Filter.PublicKey != "" {
+			subject.PublicKeys = []string{msg.Filter.PublicKey}
+		}
+	}
+
+	if s.publicKeys != nil && !containsAny(s.publicKeys, subject.PublicKeys...) {
+		return false
+	}
+	if s.owners != nil && !containsAny(s.owners, subject.Owners...) {
+		return false
+	}
+	if s.committeeIDs != nil && !containsAny(s.committeeIDs, subject.CommitteeID) {
+		return false
+	}
+	if s.operatorIDs != nil && !containsAny(s.operatorIDs, subject.OperatorIDs...) {
+		return false
+	}
+	if s.roles != nil && !containsAny(s.roles, strings.ToUpper(subject.Role)) {
+		return false
+	}
+	return true
+}
+
+func containsAny[T comparable](set map[T]struct{}, values ...T) bool {
+	for _, value := range values {
+		if _, ok := set[value]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hexSet decodes the given hex strings, optionally prefixed with 0x, into a set of normalized hex strings.
+func hexSet(values []string, size int) (map[string]struct{}, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		b, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", size, len(b))
+		}
+		set[hex.EncodeToString(b)] = struct{}{}
+	}
+	return set, nil
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestSubscription_Matches(t *testing.T) {
+	pubKey1, pubKey2 := strings.Repeat("01", 48), strings.Repeat("02", 48)
+	owner := strings.Repeat("aa", 20)
+	committeeID := strings.Repeat("cc", 32)
+
+	committeeMsg := Message{Type: TypeDecided, Subject: &StreamSubject{
+		PublicKeys:  []string{pubKey1, pubKey2},
+		Owners:      []string{owner},
+		CommitteeID: committeeID,
+		OperatorIDs: []uint64{1, 2, 3, 4},
+		Role:        "COMMITTEE",
+	}}
+	proposerMsg := Message{Type: TypeDecided, Subject: &StreamSubject{
+		PublicKeys:  []string{pubKey2},
+		CommitteeID: committeeID,
+		OperatorIDs: []uint64{1, 2, 3, 4},
+		Role:        "PROPOSER",
+	}}
+	// Messages without a subject are matched by their filter.
+	filterMsg := Message{Type: TypeDecided, Filter: MessageFilter{PublicKey: pubKey1, Role: "ATTESTER"}}
+
+	tests := []struct {
+		name    string
+		filter  MessageFilter
+		matches []bool
+	}{
+		{"empty", MessageFilter{}, []bool{true, true, true}},
+		{"public key", MessageFilter{PublicKeys: []string{"0x" + pubKey1}}, []bool{true, false, true}},
+		{"owner", MessageFilter{Owners: []string{strings.ToUpper(owner)}}, []bool{true, false, false}},
+		{"operator", MessageFilter{OperatorIDs: []uint64{4, 5}}, []bool{true, true, false}},
+		{"committee", MessageFilter{CommitteeIDs: []string{committeeID}}, []bool{true, true, false}},
+		{"role", MessageFilter{Roles: []string{"proposer", "attester"}}, []bool{false, true, true}},
+		{"all criteria", MessageFilter{PublicKeys: []string{pubKey2}, Roles: []string{"PROPOSER"}}, []bool{false, true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newSubscription(tt.filter)
+			require.NoError(t, err)
+			for i, msg := range []Message{committeeMsg, proposerMsg, filterMsg} {
+				require.Equal(t, tt.matches[i], s.matches(msg), i)
+			}
+		})
+	}
+
+	_, err := newSubscription(MessageFilter{PublicKeys: []string{"01"}})
+	require.Error(t, err)
+	_, err = newSubscription(MessageFilter{Owners: []string{"zz"}})
+	require.Error(t, err)
+}
+
+func TestHandleSubscriptions(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ws := &wsServer{ctx: ctx}
+	c := newConn(ctx, nil, "test", 0, false)
+	go ws.handleSubscriptions(ctx, logger, c)
+
+	msg := Message{Type: TypeDecided, Subject: &StreamSubject{OperatorIDs: []uint64{1}}}
+	require.True(t, c.Subscribed(msg))
+
+	subscribe := func(raw string) Message {
+		c.read <- []byte(raw)
+		var res Message
+		require.NoError(t, json.Unmarshal(<-c.replies, &res))
+		return res
+	}
+
+	res := subscribe(`{"type":"subscribe","filter":{"operatorIds":[2]}}`)
+	require.Equal(t, TypeSubscribe, res.Type)
+	require.Equal(t, []uint64{2}, res.Filter.OperatorIDs)
+	require.False(t, c.Subscribed(msg))
+
+	res = subscribe(`{"type":"subscribe","filter":{"publicKeys":["01"]}}`)
+	require.Equal(t, TypeError, res.Type)
+	require.False(t, c.Subscribed(msg))
+
+	res = subscribe(`{"type":"decided"}`)
+	require.Equal(t, TypeError, res.Type)
+
+	res = subscribe(`{"type":"subscribe","filter":{}}`)
+	require.Equal(t, TypeSubscribe, res.Type)
+	require.True(t, c.Subscribed(msg))
+}
+
+func TestHandleSubscriptions_FullQueue(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ws := &wsServer{ctx: ctx}
+	conns := make(chan *conn, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wsConn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conns <- newConn(ctx, wsConn, "test", time.Second, false)
+	}))
+	defer srv.Close()
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	defer client.Close()
+	c := <-conns
+
+	// saturate the stream queue, so that further stream messages are dropped
+	for i := 0; i <= chanSize; i++ {
+		c.Send([]byte(fmt.Sprintf(`{"type":"decided","data":%d}`, i)))
+	}
+	require.Equal(t, uint64(1), c.Dropped())
+
+	go ws.handleSubscriptions(ctx, logger, c)
+	c.read <- []byte(`{"type":"subscribe","filter":{"operatorIds":[2]}}`)
+	require.Eventually(t, func() bool {
+		return !c.Subscribed(Message{Type: TypeDecided, Subject: &StreamSubject{OperatorIDs: []uint64{1}}})
+	}, time.Second, time.Millisecond)
+	// the write loop outlives the test, so it doesn't log to it
+	go c.WriteLoop(zap.NewNop())
+
+	// the ack isn't dropped and is written ahead of the queued stream messages
+	require.NoError(t, client.SetReadDeadline(time.Now().Add(5*time.Second)))
+	ackAt := -1
+	for i := 0; i <= chanSize; i++ {
+		_, data, err := client.ReadMessage()
+		require.NoError(t, err)
+		var res Message
+		require.NoError(t, json.Unmarshal(data, &res))
+		if res.Type == TypeSubscribe {
+			require.Equal(t, -1, ackAt)
+			ackAt = i
+		}
+	}
+	require.NotEqual(t, -1, ackAt)
+	require.Less(t, ackAt, chanSize)
+	require.Equal(t, uint64(1), c.Dropped())
+}
@@ -0,0 +1,260 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/networkconfig"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+)
+
+// archiveWindow is the retention window which keeps the whole history.
+const archiveWindow = "archive"
+
+var (
+	metricsPrunedKeys = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_decided_pruning_pruned_keys_total",
+		Help: "Number of historical instances and participants removed by the decided history pruner",
+	}, []string{"role"})
+	metricsPruningCutoff = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_decided_pruning_cutoff_slot",
+		Help: "Slot below which the decided history of the role was last pruned",
+	}, []string{"role"})
+	metricsPruningInProgress = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv_decided_pruning_in_progress",
+		Help: "Whether the decided history pruner is currently pruning",
+	})
+	metricsPruningDuration = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv_decided_pruning_last_duration_seconds",
+		Help: "Duration of the last run of the decided history pruner (seconds)",
+	})
+)
+
+// RetentionOptions configures how long the decided history of each role is kept by full nodes and exporters.
+// Windows are given in slots or epochs, such as "8192 slots" or "256 epochs", or as "archive" to keep the whole history,
+// which is the default.
+type RetentionOptions struct {
+	Window    string            `yaml:"Window" env:"DECIDED_RETENTION" env-default:"archive" env-description:"Retention window of the decided history, such as '256 epochs' or '8192 slots', or 'archive' to keep it all"`
+	Roles     map[string]string `yaml:"Roles" env:"DECIDED_RETENTION_ROLES" env-description:"Retention windows overriding the default one per role, such as 'COMMITTEE:64 epochs,PROPOSER:archive'"`
+	Interval  time.Duration     `yaml:"Interval" env:"DECIDED_RETENTION_INTERVAL" env-default:"10m" env-description:"Interval between runs of the decided history pruner"`
+	BatchSize int               `yaml:"BatchSize" env:"DECIDED_RETENTION_BATCH_SIZE" env-default:"1000" env-description:"Number of keys the decided history pruner deletes per transaction"`
+}
+
+// Archive returns whether the whole history of every role is kept.
+func (o RetentionOptions) Archive() bool {
+	if !isArchive(o.Window) {
+		return false
+	}
+	for _, window := range o.Roles {
+		if !isArchive(window) {
+			return false
+		}
+	}
+	return true
+}
+
+func isArchive(window string) bool {
+	window = strings.TrimSpace(window)
+	return window == "" || strings.EqualFold(window, archiveWindow)
+}
+
+// ParseWindow parses a retention window into a number of slots, and returns false if it keeps the whole history.
+func ParseWindow(window string, slotsPerEpoch uint64) (uint64, bool, error) {
+	if isArchive(window) {
+		return 0, false, nil
+	}
+	parts := strings.Fields(window)
+	if len(parts) != 2 {
+		return 0, false, fmt.Errorf("invalid retention window %q, expected a number of slots or epochs", window)
+	}
+	n, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || n == 0 {
+		return 0, false, fmt.Errorf("invalid retention window %q, expected a positive number", window)
+	}
+	switch strings.ToLower(parts[1]) {
+	case "slot", "slots":
+		return n, true, nil
+	case "epoch", "epochs":
+		return n * slotsPerEpoch, true, nil
+	default:
+		return 0, false, fmt.Errorf("invalid retention window %q, expected slots or epochs", window)
+	}
+}
+
+// prunableStore is a store whose decided history can be pruned.
+type prunableStore interface {
+	PruneBefore(ctx context.Context, slot phase0.Slot, batchSize int) (int, error)
+}
+
+// PrunerOptions holds the dependencies of the pruner.
+type PrunerOptions struct {
+	Network   networkconfig.NetworkConfig
+	Stores    *QBFTStores
+	Retention RetentionOptions
+}
+
+// Pruner periodically removes the decided history older than the retention window of each role.
+type Pruner struct {
+	logger    *zap.Logger
+	network   networkconfig.NetworkConfig
+	stores    *QBFTStores
+	interval  time.Duration
+	batchSize int
+	// windows holds the retention window in slots of each pruned role.
+	windows map[convert.RunnerRole]uint64
+	trigger chan struct{}
+}
+
+// PruneResult is the outcome of pruning the decided history of a role.
+type PruneResult struct {
+	Role   convert.RunnerRole
+	Before phase0.Slot
+	Pruned int
+}
+
+// NewPruner creates a pruner of the given stores, failing if the retention options are invalid.
+func NewPruner(logger *zap.Logger, opts PrunerOptions) (*Pruner, error) {
+	slotsPerEpoch := opts.Network.Beacon.SlotsPerEpoch()
+	defaultWindow, prune, err := ParseWindow(opts.Retention.Window, slotsPerEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make(map[string]convert.RunnerRole)
+	windows := make(map[convert.RunnerRole]uint64)
+	_ = opts.Stores.Each(func(role convert.RunnerRole, _ qbftstorage.QBFTStore) error {
+		roles[role.String()] = role
+		if prune {
+			windows[role] = defaultWindow
+		}
+		return nil
+	})
+	for name, window := range opts.Retention.Roles {
+		role, ok := roles[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown role %q in retention windows", name)
+		}
+		slots, prune, err := ParseWindow(window, slotsPerEpoch)
+		if err != nil {
+			return nil, fmt.Errorf("role %s: %w", role, err)
+		}
+		if prune {
+			windows[role] = slots
+		} else {
+			delete(windows, role)
+		}
+	}
+
+	if opts.Retention.Interval <= 0 {
+		return nil, fmt.Errorf("invalid pruning interval %v", opts.Retention.Interval)
+	}
+	if opts.Retention.BatchSize <= 0 {
+		return nil, fmt.Errorf("invalid pruning batch size %d", opts.Retention.BatchSize)
+	}
+
+	return &Pruner{
+		logger:    logger,
+		network:   opts.Network,
+		stores:    opts.Stores,
+		interval:  opts.Retention.Interval,
+		batchSize: opts.Retention.BatchSize,
+		windows:   windows,
+		trigger:   make(chan struct{}, 1),
+	}, nil
+}
+
+// Run prunes the decided history on start, then at every interval or when triggered, until the context is done.
+func (p *Pruner) Run(ctx context.Context) {
+	for _, role := range sortedRoles(p.windows) {
+		p.logger.Info("pruning decided history",
+			zap.String("role", role.String()),
+			zap.Uint64("window_slots", p.windows[role]))
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := p.prune(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			p.logger.Warn("could not prune decided history", zap.Error(err))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-p.trigger:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Trigger schedules a run of the pruner as soon as the current one, if any, is done,
+// and returns false if one is already scheduled.
+func (p *Pruner) Trigger() bool {
+	select {
+	case p.trigger <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// prune removes the decided history older than the retention window of each role, in batches.
+// Consensus keeps writing meanwhile, since every batch is deleted in its own transaction.
+func (p *Pruner) prune(ctx context.Context) ([]PruneResult, error) {
+	metricsPruningInProgress.Set(1)
+	defer metricsPruningInProgress.Set(0)
+
+	start := time.Now()
+	currentSlot := p.network.Beacon.EstimatedCurrentSlot()
+	var results []PruneResult
+	for _, role := range sortedRoles(p.windows) {
+		window := p.windows[role]
+		if uint64(currentSlot) <= window {
+			continue
+		}
+		store, ok := p.stores.Get(role).(prunableStore)
+		if !ok {
+			continue
+		}
+
+		before := currentSlot - phase0.Slot(window)
+		roleStart := time.Now()
+		pruned, err := store.PruneBefore(ctx, before, p.batchSize)
+		if err != nil {
+			return results, fmt.Errorf("could not prune %s history: %w", role, err)
+		}
+		metricsPruningCutoff.WithLabelValues(role.String()).Set(float64(before))
+		results = append(results, PruneResult{Role: role, Before: before, Pruned: pruned})
+		p.logger.Debug("pruned decided history",
+			zap.String("role", role.String()),
+			zap.Uint64("before_slot", uint64(before)),
+			fields.Count(pruned),
+			fields.Took(time.Since(roleStart)))
+	}
+	metricsPruningDuration.Set(time.Since(start).Seconds())
+	return results, nil
+}
+
+// sortedRoles returns the roles of the windows in a stable order.
+func sortedRoles(windows map[convert.RunnerRole]uint64) []convert.RunnerRole {
+	roles := make([]convert.RunnerRole, 0, len(windows))
+	for role := range windows {
+		roles = append(roles, role)
+	}
+	slices.Sort(roles)
+	return roles
+}
@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		window string
+		slots  uint64
+		prune  bool
+		err    bool
+	}{
+		{window: "", prune: false},
+		{window: "archive", prune: false},
+		{window: "Archive", prune: false},
+		{window: "8192 slots", slots: 8192, prune: true},
+		{window: "1 slot", slots: 1, prune: true},
+		{window: "256 epochs", slots: 256 * 32, prune: true},
+		{window: "1 Epoch", slots: 32, prune: true},
+		{window: "256", err: true},
+		{window: "0 epochs", err: true},
+		{window: "-1 slots", err: true},
+		{window: "2 days", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.window, func(t *testing.T) {
+			slots, prune, err := ParseWindow(tt.window, 32)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.prune, prune)
+			require.Equal(t, tt.slots, slots)
+		})
+	}
+}
+
+func TestPruneBefore(t *testing.T) {
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			db, err := kv.OpenInMemory(logging.TestLogger(t), basedb.Options{Engine: engine})
+			require.NoError(t, err)
+			defer db.Close()
+
+			// Keys of the contribution role start with the name of the sync committee role.
+			syncCommittee := New(db, convert.RoleSyncCommittee.String())
+			contribution := New(db, convert.RoleSyncCommitteeContribution.String())
+
+			var identifiers []spectypes.MessageID
+			for _, pk := range []string{"pk1", "pk2", "pk3"} {
+				identifiers = append(identifiers, spectypes.NewMsgID(networkconfig.TestNetwork.DomainType(), []byte(pk), spectypes.RoleCommittee))
+			}
+			for _, store := range []qbftstorage.QBFTStore{syncCommittee, contribution} {
+				for _, id := range identifiers {
+					for _, slot := range []uint64{1, 255, 256, 257, 1000} {
+						require.NoError(t, store.SaveHighestAndHistoricalInstance(&qbftstorage.StoredInstance{
+							State: &specqbft.State{ID: id[:], Height: specqbft.Height(slot)},
+						}))
+						require.NoError(t, store.SaveParticipants(convert.MessageID(id), phase0.Slot(slot), []spectypes.OperatorID{1, 2, 3}))
+					}
+				}
+			}
+
+			pruned, err := syncCommittee.(*ibftStorage).PruneBefore(context.Background(), 257, 2)
+			require.NoError(t, err)
+			require.Equal(t, len(identifiers)*3*2, pruned)
+
+			for _, id := range identifiers {
+				instances, err := syncCommittee.GetInstancesInRange(id[:], 0, 1000)
+				require.NoError(t, err)
+				var heights []specqbft.Height
+				for _, instance := range instances {
+					heights = append(heights, instance.State.Height)
+				}
+				require.Equal(t, []specqbft.Height{257, 1000}, heights)
+
+				participants, err := syncCommittee.GetParticipantsInRange(convert.MessageID(id), 0, 1000)
+				require.NoError(t, err)
+				require.Len(t, participants, 2)
+				require.Equal(t, phase0.Slot(257), participants[0].Slot)
+
+				highest, err := syncCommittee.GetHighestInstance(id[:])
+				require.NoError(t, err)
+				require.Equal(t, specqbft.Height(1000), highest.State.Height)
+
+				// The history of the other role is untouched.
+				instances, err = contribution.GetInstancesInRange(id[:], 0, 1000)
+				require.NoError(t, err)
+				require.Len(t, instances, 5)
+				participants, err = contribution.GetParticipantsInRange(convert.MessageID(id), 0, 1000)
+				require.NoError(t, err)
+				require.Len(t, participants, 5)
+			}
+
+			// Pruning again removes nothing.
+			pruned, err = syncCommittee.(*ibftStorage).PruneBefore(context.Background(), 257, 2)
+			require.NoError(t, err)
+			require.Zero(t, pruned)
+		})
+	}
+}
+
+func TestPruner(t *testing.T) {
+	db, err := kv.OpenInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	stores := NewStoresFromRoles(db, convert.RoleCommittee, convert.RoleProposer, convert.RoleAggregator)
+
+	_, err = NewPruner(logging.TestLogger(t), PrunerOptions{
+		Network:   networkconfig.TestNetwork,
+		Stores:    stores,
+		Retention: RetentionOptions{Window: "2 epochs", Roles: map[string]string{"VOLUNTARY_EXIT": "archive"}, Interval: 1, BatchSize: 1},
+	})
+	require.ErrorContains(t, err, "unknown role")
+
+	pruner, err := NewPruner(logging.TestLogger(t), PrunerOptions{
+		Network: networkconfig.TestNetwork,
+		Stores:  stores,
+		Retention: RetentionOptions{
+			Window:    "500 slots",
+			Roles:     map[string]string{"proposer": "archive", "AGGREGATOR": "1 epoch"},
+			Interval:  1,
+			BatchSize: 10,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[convert.RunnerRole]uint64{
+		convert.RoleCommittee:  500,
+		convert.RoleAggregator: 32,
+	}, pruner.windows)
+
+	currentSlot := networkconfig.TestNetwork.Beacon.EstimatedCurrentSlot()
+	identifier := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType(), []byte("pk"), spectypes.RoleCommittee)
+	for _, role := range []convert.RunnerRole{convert.RoleCommittee, convert.RoleProposer, convert.RoleAggregator} {
+		for _, slot := range []phase0.Slot{currentSlot - 1000, currentSlot - 100, currentSlot} {
+			require.NoError(t, stores.Get(role).SaveParticipants(convert.MessageID(identifier), slot, []spectypes.OperatorID{1, 2, 3}))
+		}
+	}
+
+	results, err := pruner.prune(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	pruned := make(map[convert.RunnerRole]int)
+	for _, result := range results {
+		pruned[result.Role] = result.Pruned
+	}
+	require.Equal(t, map[convert.RunnerRole]int{convert.RoleCommittee: 1, convert.RoleAggregator: 2}, pruned)
+
+	for role, remaining := range map[convert.RunnerRole]int{
+		convert.RoleCommittee:  2,
+		convert.RoleProposer:   3,
+		convert.RoleAggregator: 1,
+	} {
+		participants, err := stores.Get(role).GetParticipantsInRange(convert.MessageID(identifier), 0, currentSlot)
+		require.NoError(t, err)
+		require.Len(t, participants, remaining, role.String())
+	}
+
+	require.True(t, pruner.Trigger())
+	require.False(t, pruner.Trigger())
+}
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"bytes"
+	"context"
 	"encoding/binary"
 	"fmt"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -17,12 +19,20 @@ import (
 	"github.com/ssvlabs/ssv/storage/basedb"
 )
 
+// Historical instances and participants are keyed by big-endian heights and slots under these keys,
+// so that ranges of them are ordered ranges of keys.
 const (
 	highestInstanceKey = "highest_instance"
-	instanceKey        = "instance"
-	participantsKey    = "participants"
+	instanceKey        = "instance/"
+	participantsKey    = "participants/"
 )
 
+// identifierSize is the size of the identifiers which keys are stored under.
+const identifierSize = len(convert.MessageID{})
+
+// heightSize is the size of the heights and slots which keys end with.
+const heightSize = 8
+
 var (
 	metricsHighestDecided = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "ssv:validator:ibft_highest_decided",
@@ -96,7 +106,7 @@ func (i *ibftStorage) saveInstance(inst *qbftstorage.StoredInstance, toHistory,
 	}
 
 	if toHistory {
-		err = i.save(value, instanceKey, inst.State.ID, uInt64ToByteSlice(uint64(inst.State.Height)))
+		err = i.save(value, instanceKey, inst.State.ID, heightKey(uint64(inst.State.Height)))
 		if err != nil {
 			return errors.Wrap(err, "could not save historical instance")
 		}
@@ -107,7 +117,7 @@ func (i *ibftStorage) saveInstance(inst *qbftstorage.StoredInstance, toHistory,
 
 // GetInstance returns historical StoredInstance for the given identifier and height.
 func (i *ibftStorage) GetInstance(identifier []byte, height specqbft.Height) (*qbftstorage.StoredInstance, error) {
-	val, found, err := i.get(instanceKey, identifier[:], uInt64ToByteSlice(uint64(height)))
+	val, found, err := i.get(instanceKey, identifier[:], heightKey(uint64(height)))
 	if !found {
 		return nil, nil
 	}
@@ -123,21 +133,33 @@ func (i *ibftStorage) GetInstance(identifier []byte, height specqbft.Height) (*q
 
 // GetInstancesInRange returns historical StoredInstance's in the given range.
 func (i *ibftStorage) GetInstancesInRange(identifier []byte, from specqbft.Height, to specqbft.Height) ([]*qbftstorage.StoredInstance, error) {
+	return i.GetInstancesPage(identifier, from, to, 0, 0)
+}
+
+// GetInstancesPage returns a page of the historical StoredInstance's in the given range.
+func (i *ibftStorage) GetInstancesPage(identifier []byte, from specqbft.Height, to specqbft.Height, offset, limit int) ([]*qbftstorage.StoredInstance, error) {
 	instances := make([]*qbftstorage.StoredInstance, 0)
 
-	for seq := from; seq <= to; seq++ {
-		instance, err := i.GetInstance(identifier, seq)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get instance")
-		}
-		if instance != nil {
-			instances = append(instances, instance)
+	err := i.iterateRange(instanceKey, identifier, uint64(from), uint64(to), offset, limit, func(_ uint64, value []byte) error {
+		instance := &qbftstorage.StoredInstance{}
+		if err := instance.Decode(value); err != nil {
+			return errors.Wrap(err, "could not decode instance")
 		}
+		instances = append(instances, instance)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instances")
 	}
 
 	return instances, nil
 }
 
+// CountInstancesInRange returns the number of historical StoredInstance's in the given range.
+func (i *ibftStorage) CountInstancesInRange(identifier []byte, from specqbft.Height, to specqbft.Height) (int, error) {
+	return i.countRange(instanceKey, identifier, uint64(from), uint64(to))
+}
+
 // CleanAllInstances removes all StoredInstance's & highest StoredInstance's for msgID.
 func (i *ibftStorage) CleanAllInstances(logger *zap.Logger, msgID []byte) error {
 	prefix := i.prefix
@@ -154,12 +176,108 @@ func (i *ibftStorage) CleanAllInstances(logger *zap.Logger, msgID []byte) error
 	return nil
 }
 
+// PruneBefore removes the historical instances and participants of all identifiers
+// below the given slot, deleting up to batchSize keys per transaction, and returns the number of removed keys.
+// Highest instances are kept. Heights of instances are their slots.
+func (i *ibftStorage) PruneBefore(ctx context.Context, slot phase0.Slot, batchSize int) (int, error) {
+	var (
+		pruned int
+		batch  [][]byte
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := i.db.Update(func(txn basedb.Txn) error {
+			for _, key := range batch {
+				if err := txn.Delete(i.prefix, key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		pruned += len(batch)
+		metricsPrunedKeys.WithLabelValues(string(i.prefix)).Add(float64(len(batch)))
+		batch = batch[:0]
+		return nil
+	}
+
+	// Skip from one identifier to the next, scanning only the pruned range of each.
+	it := i.db.Iterator(i.prefix, basedb.IteratorOptions{})
+	for it.Valid() {
+		if err := ctx.Err(); err != nil {
+			_ = it.Close()
+			return pruned, err
+		}
+		key := it.Key()
+		if len(key) < identifierSize {
+			it.Next()
+			continue
+		}
+		identifier := key[:identifierSize]
+
+		for _, id := range []string{instanceKey, participantsKey} {
+			err := i.iterateKeysBefore(id, identifier, uint64(slot), func(key []byte) error {
+				batch = append(batch, key)
+				if len(batch) < batchSize {
+					return nil
+				}
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				return flush()
+			})
+			if err != nil {
+				_ = it.Close()
+				return pruned, err
+			}
+		}
+
+		next := prefixUpperBound(identifier)
+		if next == nil {
+			break
+		}
+		it.Seek(next)
+	}
+	if err := it.Close(); err != nil {
+		return pruned, err
+	}
+	if err := flush(); err != nil {
+		return pruned, err
+	}
+	return pruned, nil
+}
+
+// iterateKeysBefore calls fn with the keys, under the prefix of the storage, of the given key under the identifier
+// below the given height.
+func (i *ibftStorage) iterateKeysBefore(id string, pk []byte, height uint64, fn func(key []byte) error) error {
+	prefix := append(append([]byte{}, i.prefix...), pk...)
+	it := i.db.Iterator(prefix, basedb.IteratorOptions{
+		Start: []byte(id),
+		End:   i.key(id, heightKey(height)),
+	})
+	for ; it.Valid(); it.Next() {
+		key := append(bytes.Clone(pk), it.Key()...)
+		if !IsHeightKey(key, id) {
+			continue
+		}
+		if err := fn(key); err != nil {
+			_ = it.Close()
+			return err
+		}
+	}
+	return it.Close()
+}
+
 func (i *ibftStorage) SaveParticipants(identifier convert.MessageID, slot phase0.Slot, operators []spectypes.OperatorID) error {
 	bytes, err := encodeOperators(operators)
 	if err != nil {
 		return err
 	}
-	if err := i.save(bytes, participantsKey, identifier[:], uInt64ToByteSlice(uint64(slot))); err != nil {
+	if err := i.save(bytes, participantsKey, identifier[:], heightKey(uint64(slot))); err != nil {
 		return fmt.Errorf("could not save participants: %w", err)
 	}
 
@@ -167,30 +285,38 @@ func (i *ibftStorage) SaveParticipants(identifier convert.MessageID, slot phase0
 }
 
 func (i *ibftStorage) GetParticipantsInRange(identifier convert.MessageID, from, to phase0.Slot) ([]qbftstorage.ParticipantsRangeEntry, error) {
-	participantsRange := make([]qbftstorage.ParticipantsRangeEntry, 0)
+	return i.GetParticipantsPage(identifier, from, to, 0, 0)
+}
 
-	for slot := from; slot <= to; slot++ {
-		participants, err := i.GetParticipants(identifier, slot)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get participants: %w", err)
-		}
+func (i *ibftStorage) GetParticipantsPage(identifier convert.MessageID, from, to phase0.Slot, offset, limit int) ([]qbftstorage.ParticipantsRangeEntry, error) {
+	participantsRange := make([]qbftstorage.ParticipantsRangeEntry, 0)
 
+	err := i.iterateRange(participantsKey, identifier[:], uint64(from), uint64(to), offset, limit, func(slot uint64, value []byte) error {
+		participants := decodeOperators(value)
 		if len(participants) == 0 {
-			continue
+			return nil
 		}
 
 		participantsRange = append(participantsRange, qbftstorage.ParticipantsRangeEntry{
-			Slot:       slot,
+			Slot:       phase0.Slot(slot),
 			Signers:    participants,
 			Identifier: identifier,
 		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
 	}
 
 	return participantsRange, nil
 }
 
+func (i *ibftStorage) CountParticipantsInRange(identifier convert.MessageID, from, to phase0.Slot) (int, error) {
+	return i.countRange(participantsKey, identifier[:], uint64(from), uint64(to))
+}
+
 func (i *ibftStorage) GetParticipants(identifier convert.MessageID, slot phase0.Slot) ([]spectypes.OperatorID, error) {
-	val, found, err := i.get(participantsKey, identifier[:], uInt64ToByteSlice(uint64(slot)))
+	val, found, err := i.get(participantsKey, identifier[:], heightKey(uint64(slot)))
 	if err != nil {
 		return nil, err
 	}
@@ -227,6 +353,60 @@ func (i *ibftStorage) delete(id string, pk []byte, keyParams ...[]byte) error {
 	return i.db.Delete(prefix, key)
 }
 
+// iterateRange calls fn with the heights and values of the given key under the identifier,
+// from the given height to the given height (inclusive) in order, in a single scan.
+// The first offset heights are skipped without reading their values, and at most limit are read unless it's 0.
+func (i *ibftStorage) iterateRange(id string, pk []byte, from, to uint64, offset, limit int, fn func(height uint64, value []byte) error) error {
+	if from > to {
+		return nil
+	}
+	opts := i.rangeOptions(id, from, to)
+	if limit > 0 {
+		opts.Limit = offset + limit
+	}
+	it := i.db.Iterator(append(append([]byte{}, i.prefix...), pk...), opts)
+	for n := 0; it.Valid(); it.Next() {
+		if n++; n <= offset {
+			continue
+		}
+		value, err := it.Value()
+		if err != nil {
+			_ = it.Close()
+			return err
+		}
+		height := binary.BigEndian.Uint64(it.Key()[len(id):])
+		if err := fn(height, value); err != nil {
+			_ = it.Close()
+			return err
+		}
+	}
+	return it.Close()
+}
+
+// countRange returns the number of heights of the given key under the identifier,
+// from the given height to the given height (inclusive), without reading their values.
+func (i *ibftStorage) countRange(id string, pk []byte, from, to uint64) (int, error) {
+	if from > to {
+		return 0, nil
+	}
+	it := i.db.Iterator(append(append([]byte{}, i.prefix...), pk...), i.rangeOptions(id, from, to))
+	count := 0
+	for ; it.Valid(); it.Next() {
+		count++
+	}
+	return count, it.Close()
+}
+
+// rangeOptions returns the options of an iterator over the heights of the given key,
+// from the given height to the given height (inclusive).
+func (i *ibftStorage) rangeOptions(id string, from, to uint64) basedb.IteratorOptions {
+	return basedb.IteratorOptions{
+		Start: i.key(id, heightKey(from)),
+		// Keys are of a fixed length, so the one right after the last key of the range ends it.
+		End: append(i.key(id, heightKey(to)), 0),
+	}
+}
+
 func (i *ibftStorage) key(id string, params ...[]byte) []byte {
 	ret := []byte(id)
 	for _, p := range params {
@@ -235,14 +415,42 @@ func (i *ibftStorage) key(id string, params ...[]byte) []byte {
 	return ret
 }
 
-func uInt64ToByteSlice(n uint64) []byte {
-	b := make([]byte, 8)
-	binary.LittleEndian.PutUint64(b, n)
-	return b
+// prefixUpperBound returns the smallest key greater than all the keys with the given prefix,
+// or nil if there is none.
+func prefixUpperBound(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// IsHeightKey reports whether a key under the prefix of a store is the key of an identifier
+// followed by the given key ID and a height or slot.
+//
+// Prefixes of stores are their role names, so the keys of roles whose names start with the name
+// of another one, such as SYNC_COMMITTEE_CONTRIBUTION and SYNC_COMMITTEE, are under the prefix
+// of the other one as well. They're told apart by their length, since identifiers have a fixed size.
+func IsHeightKey(key []byte, id string) bool {
+	return len(key) == identifierSize+len(id)+heightSize &&
+		string(key[identifierSize:identifierSize+len(id)]) == id
+}
+
+// heightKey encodes a height or slot as a key, in big-endian so that keys are ordered by it.
+func heightKey(n uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, n)
 }
 
+// maxCommitteeSize is the number of operators of the largest committees.
+const maxCommitteeSize = 13
+
+// encodeOperators encodes the signers of a quorum, which may be a partial one of any size
+// since signers are recorded as soon as a quorum is reached, such as 3 of a 4 operators committee.
 func encodeOperators(operators []spectypes.OperatorID) ([]byte, error) {
-	if len(operators) != 4 && len(operators) != 7 && len(operators) != 13 {
+	if len(operators) == 0 || len(operators) > maxCommitteeSize {
 		return nil, fmt.Errorf("invalid operators list size: %d", len(operators))
 	}
 	encoded := make([]byte, len(operators)*8)
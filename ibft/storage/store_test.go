@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
@@ -12,6 +13,7 @@ import (
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"github.com/ssvlabs/ssv-spec/types/testingutils"
 
+	"github.com/ssvlabs/ssv/exporter/convert"
 	"github.com/ssvlabs/ssv/logging"
 	"github.com/ssvlabs/ssv/networkconfig"
 	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
@@ -20,158 +22,236 @@ import (
 )
 
 func TestCleanInstances(t *testing.T) {
-	ks := testingutils.Testing4SharesSet()
-	logger := logging.TestLogger(t)
-	msgID := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType(), []byte("pk"), spectypes.RoleCommittee)
-	storage, err := newTestIbftStorage(logger, "test")
-	require.NoError(t, err)
-
-	generateInstance := func(id spectypes.MessageID, h specqbft.Height) *qbftstorage.StoredInstance {
-		return &qbftstorage.StoredInstance{
-			State: &specqbft.State{
-				ID:                   id[:],
-				Round:                1,
-				Height:               h,
-				LastPreparedRound:    1,
-				LastPreparedValue:    []byte("value"),
-				Decided:              true,
-				DecidedValue:         []byte("value"),
-				ProposeContainer:     specqbft.NewMsgContainer(),
-				PrepareContainer:     specqbft.NewMsgContainer(),
-				CommitContainer:      specqbft.NewMsgContainer(),
-				RoundChangeContainer: specqbft.NewMsgContainer(),
-			},
-			DecidedMessage: testingutils.TestingCommitMultiSignerMessageWithHeightAndIdentifier(
-				[]*rsa.PrivateKey{ks.OperatorKeys[1], ks.OperatorKeys[2], ks.OperatorKeys[3]},
-				[]spectypes.OperatorID{1, 2, 3},
-				h,
-				msgID[:],
-			),
-		}
-	}
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			ks := testingutils.Testing4SharesSet()
+			logger := logging.TestLogger(t)
+			msgID := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType(), []byte("pk"), spectypes.RoleCommittee)
+			storage, err := newTestIbftStorage(logger, engine, "test")
+			require.NoError(t, err)
+
+			generateInstance := func(id spectypes.MessageID, h specqbft.Height) *qbftstorage.StoredInstance {
+				return &qbftstorage.StoredInstance{
+					State: &specqbft.State{
+						ID:                   id[:],
+						Round:                1,
+						Height:               h,
+						LastPreparedRound:    1,
+						LastPreparedValue:    []byte("value"),
+						Decided:              true,
+						DecidedValue:         []byte("value"),
+						ProposeContainer:     specqbft.NewMsgContainer(),
+						PrepareContainer:     specqbft.NewMsgContainer(),
+						CommitContainer:      specqbft.NewMsgContainer(),
+						RoundChangeContainer: specqbft.NewMsgContainer(),
+					},
+					DecidedMessage: testingutils.TestingCommitMultiSignerMessageWithHeightAndIdentifier(
+						[]*rsa.PrivateKey{ks.OperatorKeys[1], ks.OperatorKeys[2], ks.OperatorKeys[3]},
+						[]spectypes.OperatorID{1, 2, 3},
+						h,
+						msgID[:],
+					),
+				}
+			}
+
+			msgsCount := 10
+			for i := 0; i < msgsCount; i++ {
+				require.NoError(t, storage.SaveInstance(generateInstance(msgID, specqbft.Height(i))))
+			}
+			require.NoError(t, storage.SaveHighestInstance(generateInstance(msgID, specqbft.Height(msgsCount))))
+
+			// add different msgID
+			differMsgID := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType(), []byte("differ_pk"), spectypes.RoleCommittee)
+			require.NoError(t, storage.SaveInstance(generateInstance(differMsgID, specqbft.Height(1))))
+			require.NoError(t, storage.SaveHighestInstance(generateInstance(differMsgID, specqbft.Height(msgsCount))))
+			require.NoError(t, storage.SaveHighestAndHistoricalInstance(generateInstance(differMsgID, specqbft.Height(1))))
+
+			res, err := storage.GetInstancesInRange(msgID[:], 0, specqbft.Height(msgsCount))
+			require.NoError(t, err)
+			require.Equal(t, msgsCount, len(res))
+
+			last, err := storage.GetHighestInstance(msgID[:])
+			require.NoError(t, err)
+			require.NotNil(t, last)
+			require.Equal(t, specqbft.Height(msgsCount), last.State.Height)
+
+			// remove all instances
+			require.NoError(t, storage.CleanAllInstances(logger, msgID[:]))
+			res, err = storage.GetInstancesInRange(msgID[:], 0, specqbft.Height(msgsCount))
+			require.NoError(t, err)
+			require.Equal(t, 0, len(res))
+
+			last, err = storage.GetHighestInstance(msgID[:])
+			require.NoError(t, err)
+			require.Nil(t, last)
 
-	msgsCount := 10
-	for i := 0; i < msgsCount; i++ {
-		require.NoError(t, storage.SaveInstance(generateInstance(msgID, specqbft.Height(i))))
+			// check other msgID
+			res, err = storage.GetInstancesInRange(differMsgID[:], 0, specqbft.Height(msgsCount))
+			require.NoError(t, err)
+			require.Equal(t, 1, len(res))
+
+			last, err = storage.GetHighestInstance(differMsgID[:])
+			require.NoError(t, err)
+			require.NotNil(t, last)
+		})
 	}
-	require.NoError(t, storage.SaveHighestInstance(generateInstance(msgID, specqbft.Height(msgsCount))))
-
-	// add different msgID
-	differMsgID := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType(), []byte("differ_pk"), spectypes.RoleCommittee)
-	require.NoError(t, storage.SaveInstance(generateInstance(differMsgID, specqbft.Height(1))))
-	require.NoError(t, storage.SaveHighestInstance(generateInstance(differMsgID, specqbft.Height(msgsCount))))
-	require.NoError(t, storage.SaveHighestAndHistoricalInstance(generateInstance(differMsgID, specqbft.Height(1))))
-
-	res, err := storage.GetInstancesInRange(msgID[:], 0, specqbft.Height(msgsCount))
-	require.NoError(t, err)
-	require.Equal(t, msgsCount, len(res))
-
-	last, err := storage.GetHighestInstance(msgID[:])
-	require.NoError(t, err)
-	require.NotNil(t, last)
-	require.Equal(t, specqbft.Height(msgsCount), last.State.Height)
-
-	// remove all instances
-	require.NoError(t, storage.CleanAllInstances(logger, msgID[:]))
-	res, err = storage.GetInstancesInRange(msgID[:], 0, specqbft.Height(msgsCount))
-	require.NoError(t, err)
-	require.Equal(t, 0, len(res))
-
-	last, err = storage.GetHighestInstance(msgID[:])
-	require.NoError(t, err)
-	require.Nil(t, last)
-
-	// check other msgID
-	res, err = storage.GetInstancesInRange(differMsgID[:], 0, specqbft.Height(msgsCount))
-	require.NoError(t, err)
-	require.Equal(t, 1, len(res))
-
-	last, err = storage.GetHighestInstance(differMsgID[:])
-	require.NoError(t, err)
-	require.NotNil(t, last)
 }
 
 func TestSaveAndFetchLastState(t *testing.T) {
-	identifier := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType(), []byte("pk"), spectypes.RoleCommittee)
-
-	instance := &qbftstorage.StoredInstance{
-		State: &specqbft.State{
-			CommitteeMember:                 nil,
-			ID:                              identifier[:],
-			Round:                           1,
-			Height:                          1,
-			LastPreparedRound:               1,
-			LastPreparedValue:               []byte("value"),
-			ProposalAcceptedForCurrentRound: nil,
-			Decided:                         true,
-			DecidedValue:                    []byte("value"),
-			ProposeContainer:                specqbft.NewMsgContainer(),
-			PrepareContainer:                specqbft.NewMsgContainer(),
-			CommitContainer:                 specqbft.NewMsgContainer(),
-			RoundChangeContainer:            specqbft.NewMsgContainer(),
-		},
-	}
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			identifier := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType(), []byte("pk"), spectypes.RoleCommittee)
+
+			instance := &qbftstorage.StoredInstance{
+				State: &specqbft.State{
+					CommitteeMember:                 nil,
+					ID:                              identifier[:],
+					Round:                           1,
+					Height:                          1,
+					LastPreparedRound:               1,
+					LastPreparedValue:               []byte("value"),
+					ProposalAcceptedForCurrentRound: nil,
+					Decided:                         true,
+					DecidedValue:                    []byte("value"),
+					ProposeContainer:                specqbft.NewMsgContainer(),
+					PrepareContainer:                specqbft.NewMsgContainer(),
+					CommitContainer:                 specqbft.NewMsgContainer(),
+					RoundChangeContainer:            specqbft.NewMsgContainer(),
+				},
+			}
 
-	storage, err := newTestIbftStorage(logging.TestLogger(t), "test")
-	require.NoError(t, err)
-
-	require.NoError(t, storage.SaveHighestInstance(instance))
-
-	savedInstance, err := storage.GetHighestInstance(identifier[:])
-	require.NoError(t, err)
-	require.NotNil(t, savedInstance)
-	require.Equal(t, specqbft.Height(1), savedInstance.State.Height)
-	require.Equal(t, specqbft.Round(1), savedInstance.State.Round)
-	require.Equal(t, identifier.String(), specqbft.ControllerIdToMessageID(savedInstance.State.ID).String())
-	require.Equal(t, specqbft.Round(1), savedInstance.State.LastPreparedRound)
-	require.Equal(t, true, savedInstance.State.Decided)
-	require.Equal(t, []byte("value"), savedInstance.State.LastPreparedValue)
-	require.Equal(t, []byte("value"), savedInstance.State.DecidedValue)
+			storage, err := newTestIbftStorage(logging.TestLogger(t), engine, "test")
+			require.NoError(t, err)
+
+			require.NoError(t, storage.SaveHighestInstance(instance))
+
+			savedInstance, err := storage.GetHighestInstance(identifier[:])
+			require.NoError(t, err)
+			require.NotNil(t, savedInstance)
+			require.Equal(t, specqbft.Height(1), savedInstance.State.Height)
+			require.Equal(t, specqbft.Round(1), savedInstance.State.Round)
+			require.Equal(t, identifier.String(), specqbft.ControllerIdToMessageID(savedInstance.State.ID).String())
+			require.Equal(t, specqbft.Round(1), savedInstance.State.LastPreparedRound)
+			require.Equal(t, true, savedInstance.State.Decided)
+			require.Equal(t, []byte("value"), savedInstance.State.LastPreparedValue)
+			require.Equal(t, []byte("value"), savedInstance.State.DecidedValue)
+		})
+	}
 }
 
 func TestSaveAndFetchState(t *testing.T) {
-	identifier := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType(), []byte("pk"), spectypes.RoleCommittee)
-
-	instance := &qbftstorage.StoredInstance{
-		State: &specqbft.State{
-			CommitteeMember:                 nil,
-			ID:                              identifier[:],
-			Round:                           1,
-			Height:                          1,
-			LastPreparedRound:               1,
-			LastPreparedValue:               []byte("value"),
-			ProposalAcceptedForCurrentRound: nil,
-			Decided:                         true,
-			DecidedValue:                    []byte("value"),
-			ProposeContainer:                specqbft.NewMsgContainer(),
-			PrepareContainer:                specqbft.NewMsgContainer(),
-			CommitContainer:                 specqbft.NewMsgContainer(),
-			RoundChangeContainer:            specqbft.NewMsgContainer(),
-		},
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			identifier := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType(), []byte("pk"), spectypes.RoleCommittee)
+
+			instance := &qbftstorage.StoredInstance{
+				State: &specqbft.State{
+					CommitteeMember:                 nil,
+					ID:                              identifier[:],
+					Round:                           1,
+					Height:                          1,
+					LastPreparedRound:               1,
+					LastPreparedValue:               []byte("value"),
+					ProposalAcceptedForCurrentRound: nil,
+					Decided:                         true,
+					DecidedValue:                    []byte("value"),
+					ProposeContainer:                specqbft.NewMsgContainer(),
+					PrepareContainer:                specqbft.NewMsgContainer(),
+					CommitContainer:                 specqbft.NewMsgContainer(),
+					RoundChangeContainer:            specqbft.NewMsgContainer(),
+				},
+			}
+
+			storage, err := newTestIbftStorage(logging.TestLogger(t), engine, "test")
+			require.NoError(t, err)
+
+			require.NoError(t, storage.SaveInstance(instance))
+
+			savedInstances, err := storage.GetInstancesInRange(identifier[:], 1, 1)
+			require.NoError(t, err)
+			require.NotNil(t, savedInstances)
+			require.Len(t, savedInstances, 1)
+			savedInstance := savedInstances[0]
+
+			require.Equal(t, specqbft.Height(1), savedInstance.State.Height)
+			require.Equal(t, specqbft.Round(1), savedInstance.State.Round)
+			require.Equal(t, identifier.String(), specqbft.ControllerIdToMessageID(savedInstance.State.ID).String())
+			require.Equal(t, specqbft.Round(1), savedInstance.State.LastPreparedRound)
+			require.Equal(t, true, savedInstance.State.Decided)
+			require.Equal(t, []byte("value"), savedInstance.State.LastPreparedValue)
+			require.Equal(t, []byte("value"), savedInstance.State.DecidedValue)
+		})
 	}
+}
+
+func TestGetInRange(t *testing.T) {
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			storage, err := newTestIbftStorage(logging.TestLogger(t), engine, "test")
+			require.NoError(t, err)
 
-	storage, err := newTestIbftStorage(logging.TestLogger(t), "test")
-	require.NoError(t, err)
+			identifier := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType(), []byte("pk"), spectypes.RoleCommittee)
+			other := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType(), []byte("other_pk"), spectypes.RoleCommittee)
 
-	require.NoError(t, storage.SaveInstance(instance))
+			// Heights and slots which aren't ordered in little-endian, with gaps.
+			for _, height := range []uint64{1, 255, 256, 257, 1000, 1002} {
+				for _, id := range []spectypes.MessageID{identifier, other} {
+					require.NoError(t, storage.SaveInstance(&qbftstorage.StoredInstance{
+						State: &specqbft.State{ID: id[:], Height: specqbft.Height(height)},
+					}))
+					require.NoError(t, storage.SaveParticipants(convert.MessageID(id), phase0.Slot(height), []spectypes.OperatorID{1, 2, 3}))
+				}
+			}
 
-	savedInstances, err := storage.GetInstancesInRange(identifier[:], 1, 1)
-	require.NoError(t, err)
-	require.NotNil(t, savedInstances)
-	require.Len(t, savedInstances, 1)
-	savedInstance := savedInstances[0]
+			instances, err := storage.GetInstancesInRange(identifier[:], 2, 1001)
+			require.NoError(t, err)
+			var heights []specqbft.Height
+			for _, instance := range instances {
+				require.Equal(t, identifier[:], instance.State.ID)
+				heights = append(heights, instance.State.Height)
+			}
+			require.Equal(t, []specqbft.Height{255, 256, 257, 1000}, heights)
 
-	require.Equal(t, specqbft.Height(1), savedInstance.State.Height)
-	require.Equal(t, specqbft.Round(1), savedInstance.State.Round)
-	require.Equal(t, identifier.String(), specqbft.ControllerIdToMessageID(savedInstance.State.ID).String())
-	require.Equal(t, specqbft.Round(1), savedInstance.State.LastPreparedRound)
-	require.Equal(t, true, savedInstance.State.Decided)
-	require.Equal(t, []byte("value"), savedInstance.State.LastPreparedValue)
-	require.Equal(t, []byte("value"), savedInstance.State.DecidedValue)
+			participants, err := storage.GetParticipantsInRange(convert.MessageID(identifier), 256, 2000)
+			require.NoError(t, err)
+			var slots []phase0.Slot
+			for _, entry := range participants {
+				require.Equal(t, convert.MessageID(identifier), entry.Identifier)
+				require.Equal(t, []spectypes.OperatorID{1, 2, 3}, entry.Signers)
+				slots = append(slots, entry.Slot)
+			}
+			require.Equal(t, []phase0.Slot{256, 257, 1000, 1002}, slots)
+
+			instances, err = storage.GetInstancesInRange(identifier[:], 3, 2)
+			require.NoError(t, err)
+			require.Empty(t, instances)
+
+			// Pages skip and limit the heights within the range.
+			count, err := storage.CountInstancesInRange(identifier[:], 2, 1001)
+			require.NoError(t, err)
+			require.Equal(t, 4, count)
+			instances, err = storage.GetInstancesPage(identifier[:], 2, 1001, 1, 2)
+			require.NoError(t, err)
+			heights = nil
+			for _, instance := range instances {
+				heights = append(heights, instance.State.Height)
+			}
+			require.Equal(t, []specqbft.Height{256, 257}, heights)
+
+			count, err = storage.CountParticipantsInRange(convert.MessageID(identifier), 256, 2000)
+			require.NoError(t, err)
+			require.Equal(t, 4, count)
+			participants, err = storage.GetParticipantsPage(convert.MessageID(identifier), 256, 2000, 3, 10)
+			require.NoError(t, err)
+			require.Len(t, participants, 1)
+			require.Equal(t, phase0.Slot(1002), participants[0].Slot)
+		})
+	}
 }
 
-func newTestIbftStorage(logger *zap.Logger, prefix string) (qbftstorage.QBFTStore, error) {
-	db, err := kv.NewInMemory(logger.Named(logging.NameBadgerDBLog), basedb.Options{
+func newTestIbftStorage(logger *zap.Logger, engine, prefix string) (qbftstorage.QBFTStore, error) {
+	db, err := kv.OpenInMemory(logger, basedb.Options{
+		Engine:    engine,
 		Reporting: true,
 	})
 	if err != nil {
@@ -192,6 +272,9 @@ func TestEncodeDecodeOperators(t *testing.T) {
 		// Valid sizes: 7
 		{[]uint64{1, 2, 3, 4, 5, 6, 7},
 			[]byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0, 6, 0, 0, 0, 0, 0, 0, 0, 7}},
+		// Valid sizes: 3 (quorum of a 4 operators committee)
+		{[]uint64{1, 2, 3},
+			[]byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 3}},
 		// Valid sizes: 13
 		{[]uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
 			[]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0, 6, 0, 0, 0, 0, 0, 0, 0, 7, 0, 0, 0, 0, 0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 9, 0, 0, 0, 0, 0, 0, 0, 10, 0, 0, 0, 0, 0, 0, 0, 11, 0, 0, 0, 0, 0, 0, 0, 12}},
@@ -208,3 +291,10 @@ func TestEncodeDecodeOperators(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeOperatorsInvalidSize(t *testing.T) {
+	for _, size := range []int{0, maxCommitteeSize + 1} {
+		_, err := encodeOperators(make([]uint64, size))
+		require.ErrorContains(t, err, "invalid operators list size")
+	}
+}
@@ -8,6 +8,18 @@ import (
 	"github.com/ssvlabs/ssv/storage/basedb"
 )
 
+// Roles are the roles which the node keeps a store of, with the role names as prefixes.
+var Roles = []convert.RunnerRole{
+	convert.RoleCommittee,
+	convert.RoleAttester,
+	convert.RoleProposer,
+	convert.RoleSyncCommittee,
+	convert.RoleAggregator,
+	convert.RoleSyncCommitteeContribution,
+	convert.RoleValidatorRegistration,
+	convert.RoleVoluntaryExit,
+}
+
 // QBFTStores wraps sync map with cast functions to qbft store
 type QBFTStores struct {
 	m *hashmap.Map[convert.RunnerRole, qbftstorage.QBFTStore]
@@ -13,37 +13,42 @@ import (
 )
 
 func TestQBFTStores(t *testing.T) {
-	logger := logging.TestLogger(t)
-
-	qbftMap := NewStores()
-
-	store, err := newTestIbftStorage(logger, "")
-	require.NoError(t, err)
-	qbftMap.Add(convert.RoleCommittee, store)
-	qbftMap.Add(convert.RoleCommittee, store)
-
-	require.NotNil(t, qbftMap.Get(convert.RoleCommittee))
-	require.NotNil(t, qbftMap.Get(convert.RoleCommittee))
-
-	db, err := kv.NewInMemory(logger.Named(logging.NameBadgerDBLog), basedb.Options{
-		Reporting: true,
-	})
-	require.NoError(t, err)
-	qbftMap = NewStoresFromRoles(db, convert.RoleCommittee, convert.RoleProposer)
-
-	require.NotNil(t, qbftMap.Get(convert.RoleCommittee))
-	require.NotNil(t, qbftMap.Get(convert.RoleCommittee))
-
-	id := []byte{1, 2, 3}
-
-	err = qbftMap.Each(func(role convert.RunnerRole, store qbftstorage.QBFTStore) error {
-		return store.SaveInstance(&qbftstorage.StoredInstance{State: &specqbft.State{Height: 1, ID: id}})
-	})
-	require.NoError(t, err)
-
-	instance, err := qbftMap.Get(convert.RoleCommittee).GetInstance(id, 1)
-	require.NoError(t, err)
-	require.NotNil(t, instance)
-	require.Equal(t, specqbft.Height(1), instance.State.Height)
-	require.Equal(t, id, instance.State.ID)
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+
+			qbftMap := NewStores()
+
+			store, err := newTestIbftStorage(logger, engine, "")
+			require.NoError(t, err)
+			qbftMap.Add(convert.RoleCommittee, store)
+			qbftMap.Add(convert.RoleCommittee, store)
+
+			require.NotNil(t, qbftMap.Get(convert.RoleCommittee))
+			require.NotNil(t, qbftMap.Get(convert.RoleCommittee))
+
+			db, err := kv.OpenInMemory(logger, basedb.Options{
+				Engine:    engine,
+				Reporting: true,
+			})
+			require.NoError(t, err)
+			qbftMap = NewStoresFromRoles(db, convert.RoleCommittee, convert.RoleProposer)
+
+			require.NotNil(t, qbftMap.Get(convert.RoleCommittee))
+			require.NotNil(t, qbftMap.Get(convert.RoleCommittee))
+
+			id := []byte{1, 2, 3}
+
+			err = qbftMap.Each(func(role convert.RunnerRole, store qbftstorage.QBFTStore) error {
+				return store.SaveInstance(&qbftstorage.StoredInstance{State: &specqbft.State{Height: 1, ID: id}})
+			})
+			require.NoError(t, err)
+
+			instance, err := qbftMap.Get(convert.RoleCommittee).GetInstance(id, 1)
+			require.NoError(t, err)
+			require.NotNil(t, instance)
+			require.Equal(t, specqbft.Height(1), instance.State.Height)
+			require.Equal(t, id, instance.State.ID)
+		})
+	}
 }
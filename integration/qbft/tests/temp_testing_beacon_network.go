@@ -2,6 +2,7 @@ package tests
 
 import (
 	"github.com/attestantio/go-eth2-client/api"
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
@@ -53,9 +54,15 @@ func (bn *TestingBeaconNodeWrapped) GetBeaconNetwork() spectypes.BeaconNetwork {
 func (bn *TestingBeaconNodeWrapped) GetBeaconBlock(slot phase0.Slot, graffiti, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
 	return bn.Bn.GetBeaconBlock(slot, graffiti, randao)
 }
+func (bn *TestingBeaconNodeWrapped) GetBeaconBlockWithBuilderBoost(slot phase0.Slot, graffiti, randao []byte, builderBoostFactor uint64) (ssz.Marshaler, spec.DataVersion, error) {
+	return bn.Bn.GetBeaconBlock(slot, graffiti, randao)
+}
 func (bn *TestingBeaconNodeWrapped) SubmitValidatorRegistration(pubkey []byte, feeRecipient bellatrix.ExecutionAddress, sig phase0.BLSSignature) error {
 	return bn.Bn.SubmitValidatorRegistration(pubkey, feeRecipient, sig)
 }
+func (bn *TestingBeaconNodeWrapped) SubmitSignedValidatorRegistration(registration *v1.SignedValidatorRegistration) error {
+	return bn.Bn.SubmitValidatorRegistration(registration.Message.Pubkey[:], registration.Message.FeeRecipient, registration.Signature)
+}
 func (bn *TestingBeaconNodeWrapped) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
 	return bn.Bn.SubmitVoluntaryExit(voluntaryExit)
 }
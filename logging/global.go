@@ -48,9 +48,7 @@ func SetGlobalLogger(levelName string, levelEncoderName string, logFormat string
 
 	levelEncoder := parseConfigLevelEncoder(levelEncoderName)
 
-	lv := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-		return lvl >= level
-	})
+	levels.set("", level)
 
 	cfg := zap.Config{
 		Encoding:    logFormat,
@@ -74,10 +72,14 @@ func SetGlobalLogger(levelName string, levelEncoderName string, logFormat string
 
 	var usedcore zapcore.Core
 
+	// The level of the console logs is decided per logger name by levels.
 	if logFormat == "console" {
-		usedcore = zapcore.NewCore(zapcore.NewConsoleEncoder(cfg.EncoderConfig), os.Stdout, lv)
+		usedcore = zapcore.NewCore(zapcore.NewConsoleEncoder(cfg.EncoderConfig), os.Stdout, zapcore.DebugLevel)
 	} else if logFormat == "json" {
-		usedcore = zapcore.NewCore(zapcore.NewJSONEncoder(cfg.EncoderConfig), os.Stdout, lv)
+		usedcore = zapcore.NewCore(zapcore.NewJSONEncoder(cfg.EncoderConfig), os.Stdout, zapcore.DebugLevel)
+	}
+	if usedcore != nil {
+		usedcore = &namedLevelCore{Core: usedcore, levels: levels}
 	}
 
 	if fileOptions == nil {
@@ -91,7 +93,8 @@ func SetGlobalLogger(levelName string, levelEncoderName string, logFormat string
 
 	dev := zapcore.NewJSONEncoder(zap.NewDevelopmentEncoderConfig())
 	fileWriter := fileOptions.writer(fileOptions)
-	fileCore := zapcore.NewCore(dev, zapcore.AddSync(fileWriter), lv2)
+	// The file logs ignore the global level, but not the overrides per logger name.
+	fileCore := &namedLevelCore{Core: zapcore.NewCore(dev, zapcore.AddSync(fileWriter), lv2), levels: levels, base: lv2}
 
 	zap.ReplaceGlobals(zap.New(zapcore.NewTee(usedcore, fileCore)))
 	return nil
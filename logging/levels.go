@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// levels holds the level of the logs, globally and per logger name.
+// The global level only applies to the console logs, while the overrides also apply to the file logs.
+var levels = newNamedLevels()
+
+// SetLevel sets the level of the loggers with the given name and their children
+// (e.g. "P2PNetwork" also applies to "P2PNetwork.DiscoveryService"), overriding the global level.
+// An empty name sets the global level.
+func SetLevel(name string, level zapcore.Level) {
+	levels.set(name, level)
+}
+
+// ResetLevel removes the level override of the loggers with the given name.
+func ResetLevel(name string) {
+	levels.reset(name)
+}
+
+// Levels returns the level overrides by logger name, with the global level under the empty name.
+func Levels() map[string]zapcore.Level {
+	return levels.all()
+}
+
+type namedLevels struct {
+	global zap.AtomicLevel
+	// min is the lowest of the global level and the overrides.
+	min zap.AtomicLevel
+
+	mu        sync.RWMutex
+	overrides map[string]zapcore.Level
+	// hasOverrides allows skipping the lookup of overrides when there are none.
+	hasOverrides atomic.Bool
+}
+
+func newNamedLevels() *namedLevels {
+	return &namedLevels{
+		global:    zap.NewAtomicLevel(),
+		min:       zap.NewAtomicLevel(),
+		overrides: make(map[string]zapcore.Level),
+	}
+}
+
+func (l *namedLevels) set(name string, level zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if name == "" {
+		l.global.SetLevel(level)
+	} else {
+		l.overrides[name] = level
+	}
+	l.update()
+}
+
+func (l *namedLevels) reset(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.overrides, name)
+	l.update()
+}
+
+// update must be called with the lock held.
+func (l *namedLevels) update() {
+	minLevel := l.global.Level()
+	for _, level := range l.overrides {
+		if level < minLevel {
+			minLevel = level
+		}
+	}
+	l.min.SetLevel(minLevel)
+	l.hasOverrides.Store(len(l.overrides) > 0)
+}
+
+func (l *namedLevels) all() map[string]zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	all := make(map[string]zapcore.Level, len(l.overrides)+1)
+	for name, level := range l.overrides {
+		all[name] = level
+	}
+	all[""] = l.global.Level()
+	return all
+}
+
+// enabled returns whether the level is enabled for the logger with the given name,
+// by the override of its closest ancestor or by the given base level.
+func (l *namedLevels) enabled(name string, level zapcore.Level, base zapcore.LevelEnabler) bool {
+	if !l.hasOverrides.Load() {
+		return base.Enabled(level)
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for {
+		if override, ok := l.overrides[name]; ok {
+			return level >= override
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return base.Enabled(level)
+		}
+		name = name[:i]
+	}
+}
+
+// namedLevelCore filters the entries of a core by the level of their logger name.
+// Loggers without an override are filtered by the base level, or by the global level if it's nil.
+type namedLevelCore struct {
+	zapcore.Core
+	levels *namedLevels
+	base   zapcore.LevelEnabler
+}
+
+func (c *namedLevelCore) baseLevel() zapcore.LevelEnabler {
+	if c.base == nil {
+		return c.levels.global
+	}
+	return c.base
+}
+
+func (c *namedLevelCore) Enabled(level zapcore.Level) bool {
+	if c.base == nil {
+		return c.levels.min.Enabled(level)
+	}
+	return c.base.Enabled(level) || c.levels.min.Enabled(level)
+}
+
+func (c *namedLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &namedLevelCore{Core: c.Core.With(fields), levels: c.levels, base: c.base}
+}
+
+func (c *namedLevelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.enabled(entry.LoggerName, entry.Level, c.baseLevel()) {
+		return checked
+	}
+	return checked.AddCore(entry, c)
+}
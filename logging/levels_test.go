@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNamedLevelCore(t *testing.T) {
+	levels := newNamedLevels()
+	levels.set("", zapcore.InfoLevel)
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(&namedLevelCore{Core: core, levels: levels})
+
+	p2p := logger.Named("P2PNetwork")
+	discovery := p2p.Named("DiscoveryService")
+	validator := logger.Named("Validator").With(zap.String("field", "value"))
+	log := func() {
+		for _, l := range []*zap.Logger{logger, p2p, discovery, validator} {
+			l.Debug("debug")
+			l.Info("info")
+		}
+	}
+
+	log()
+	require.Equal(t, 4, logs.Len())
+
+	levels.set("P2PNetwork", zapcore.DebugLevel)
+	levels.set("Validator", zapcore.WarnLevel)
+	require.Equal(t, map[string]zapcore.Level{
+		"":           zapcore.InfoLevel,
+		"P2PNetwork": zapcore.DebugLevel,
+		"Validator":  zapcore.WarnLevel,
+	}, levels.all())
+	logs.TakeAll()
+	log()
+	// Info of the root logger, and debug and info of P2PNetwork and its child.
+	require.Equal(t, 5, logs.Len())
+	loggerNames := map[string]int{}
+	for _, entry := range logs.All() {
+		loggerNames[entry.LoggerName]++
+	}
+	require.Equal(t, map[string]int{"": 1, "P2PNetwork": 2, "P2PNetwork.DiscoveryService": 2}, loggerNames)
+
+	levels.reset("P2PNetwork")
+	levels.reset("Validator")
+	logs.TakeAll()
+	log()
+	require.Equal(t, 4, logs.Len())
+	require.False(t, levels.hasOverrides.Load())
+}
+
+func TestNamedLevelCore_Base(t *testing.T) {
+	levels := newNamedLevels()
+	levels.set("", zapcore.WarnLevel)
+	core, logs := observer.New(zapcore.DebugLevel)
+	// Like the file logs, which ignore the global level.
+	logger := zap.New(&namedLevelCore{Core: core, levels: levels, base: zapcore.DebugLevel})
+
+	p2p := logger.Named("P2PNetwork")
+	logger.Debug("debug")
+	p2p.Debug("debug")
+	require.Equal(t, 2, logs.Len())
+
+	levels.set("P2PNetwork", zapcore.ErrorLevel)
+	logs.TakeAll()
+	logger.Debug("debug")
+	p2p.Debug("debug")
+	p2p.Warn("warn")
+	p2p.Error("error")
+	require.Equal(t, 2, logs.Len())
+	require.Equal(t, "", logs.All()[0].LoggerName)
+	require.Equal(t, zapcore.ErrorLevel, logs.All()[1].Level)
+}
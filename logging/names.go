@@ -13,9 +13,17 @@ const (
 	NameValidator        = "Validator"
 	NameWSServer         = "WSServer"
 	NameConnHandler      = "ConnHandler"
+	NameDecidedSyncer    = "DecidedSyncer"
+	NameDoppelganger     = "Doppelganger"
+	NameAnalytics        = "Analytics"
+	NameAdminAPI         = "AdminAPI"
+	NameProposerSettings = "ProposerSettings"
+	NameDecidedPruner    = "DecidedPruner"
 
 	NameBadgerDBLog       = "BadgerDBLog"
 	NameBadgerDBReporting = "BadgerDBReporting"
+	NamePebbleDBLog       = "PebbleDBLog"
+	NamePebbleDBReporting = "PebbleDBReporting"
 	NameCreateThreshold   = "CreateThreshold"
 	NameDiscoveryV5Logger = "DiscoveryV5Logger"
 	NameExportKeys        = "ExportKeys"
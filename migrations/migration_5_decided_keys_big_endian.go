@@ -0,0 +1,126 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// decidedKeysMigrationBatchSize is the number of keys rewritten per transaction.
+const decidedKeysMigrationBatchSize = 1000
+
+// decidedKeyRewrites maps the keys of historical instances and participants, which were followed by
+// little-endian heights and slots, to their new keys, which are followed by big-endian ones.
+var decidedKeyRewrites = map[string]string{
+	"instance":     "instance/",
+	"participants": "participants/",
+}
+
+// This migration rewrites the keys of historical instances and participants of the decided stores
+// with big-endian heights and slots, so that ranges of them can be read in a single ordered scan.
+// Each batch of keys is rewritten atomically, and rewritten keys no longer match the old layout,
+// so the migration may be interrupted and run again.
+var migration_5_decided_keys_big_endian = Migration{
+	Name: "migration_5_decided_keys_big_endian",
+	Run: func(ctx context.Context, logger *zap.Logger, opt Options, key []byte, completed CompletedFunc) error {
+		for _, role := range ibftstorage.Roles {
+			// Keys of other roles under this role's prefix are rewritten with their own role.
+			rewritten, err := rewriteDecidedKeys(ctx, opt.Db, []byte(role.String()))
+			if err != nil {
+				return fmt.Errorf("failed to rewrite %s keys: %w", role, err)
+			}
+			logger.Debug("rewrote decided keys", zap.String("role", role.String()), fields.Count(rewritten))
+		}
+
+		return completed(opt.Db)
+	},
+}
+
+// rewriteDecidedKeys rewrites the keys under the prefix in batches, and returns the number of rewritten keys.
+func rewriteDecidedKeys(ctx context.Context, db basedb.Database, prefix []byte) (int, error) {
+	type rewrite struct {
+		oldKey, newKey, value []byte
+	}
+
+	var (
+		rewritten int
+		next      []byte
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return rewritten, err
+		}
+
+		var batch []rewrite
+		it := db.Iterator(prefix, basedb.IteratorOptions{})
+		if next != nil {
+			it.Seek(next)
+		}
+		next = nil
+		for ; it.Valid(); it.Next() {
+			if len(batch) == decidedKeysMigrationBatchSize {
+				next = it.Key()
+				break
+			}
+			key := it.Key()
+			newKey, ok := bigEndianDecidedKey(key)
+			if !ok {
+				continue
+			}
+			value, err := it.Value()
+			if err != nil {
+				_ = it.Close()
+				return rewritten, err
+			}
+			batch = append(batch, rewrite{oldKey: key, newKey: newKey, value: value})
+		}
+		if err := it.Close(); err != nil {
+			return rewritten, err
+		}
+		if len(batch) == 0 {
+			return rewritten, nil
+		}
+
+		err := db.Update(func(txn basedb.Txn) error {
+			for _, r := range batch {
+				if err := txn.Set(prefix, r.newKey, r.value); err != nil {
+					return err
+				}
+				if err := txn.Delete(prefix, r.oldKey); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return rewritten, err
+		}
+		rewritten += len(batch)
+
+		if next == nil {
+			return rewritten, nil
+		}
+	}
+}
+
+// bigEndianDecidedKey returns the new key of an old historical instance or participants key,
+// which is an identifier followed by the old key ID and a little-endian height or slot.
+func bigEndianDecidedKey(key []byte) ([]byte, bool) {
+	const heightSize = 8
+	for oldID, newID := range decidedKeyRewrites {
+		if !ibftstorage.IsHeightKey(key, oldID) {
+			continue
+		}
+		end := len(key) - heightSize
+		newKey := append(bytes.Clone(key[:end-len(oldID)]), newID...)
+		return binary.BigEndian.AppendUint64(newKey, binary.LittleEndian.Uint64(key[end:])), true
+	}
+	return nil, false
+}
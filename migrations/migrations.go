@@ -26,6 +26,7 @@ var (
 		migration_2_encrypt_shares,
 		migration_3_drop_registry_data,
 		migration_4_configlock_add_alan_fork_to_network_name,
+		migration_5_decided_keys_big_endian,
 	}
 )
 
@@ -34,6 +35,17 @@ func Run(ctx context.Context, logger *zap.Logger, opt Options) (applied int, err
 	return defaultMigrations.Run(ctx, logger, opt)
 }
 
+// Version returns the number of default migrations applied to the database.
+func Version(db basedb.Reader) (int, error) {
+	return defaultMigrations.Version(db)
+}
+
+// LatestVersion returns the number of default migrations,
+// which is the version of a database fully migrated by this version of the node.
+func LatestVersion() int {
+	return len(defaultMigrations)
+}
+
 // CompletedFunc is a function that marks a migration as completed.
 type CompletedFunc func(rw basedb.ReadWriter) error
 
@@ -68,6 +80,21 @@ func (o Options) signerStorage(logger *zap.Logger) ekm.Storage {
 	return ekm.NewSignerStorage(o.Db, o.Network, logger)
 }
 
+// Version returns the number of migrations applied to the database, counting from the first
+// until one which isn't applied, since migrations are applied in order.
+func (m Migrations) Version(db basedb.Reader) (int, error) {
+	for i, migration := range m {
+		obj, _, err := db.Get(migrationsPrefix, []byte(migration.Name))
+		if err != nil {
+			return 0, err
+		}
+		if !bytes.Equal(obj.Value, migrationCompleted) {
+			return i, nil
+		}
+	}
+	return len(m), nil
+}
+
 // Run executes the migrations.
 func (m Migrations) Run(ctx context.Context, logger *zap.Logger, opt Options) (applied int, err error) {
 	logger.Info("applying migrations", fields.Count(len(m)))
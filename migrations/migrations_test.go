@@ -1,15 +1,23 @@
 package migrations
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
 	"testing"
 
 	"github.com/pkg/errors"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/ssvlabs/ssv/exporter/convert"
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
 	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
 	"github.com/ssvlabs/ssv/storage/basedb"
 	"github.com/ssvlabs/ssv/storage/kv"
 )
@@ -107,6 +115,35 @@ func Test_NextMigrationNotExecutedOnFailure(t *testing.T) {
 	require.False(t, found)
 }
 
+func Test_Version(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.TestLogger(t)
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+
+	migrations := Migrations{
+		fakeMigration("first", nil),
+		fakeMigration("second", errors.New("fake error")),
+		fakeMigration("third", nil),
+	}
+	version, err := migrations.Version(opt.Db)
+	require.NoError(t, err)
+	require.Equal(t, 0, version)
+
+	_, err = migrations.Run(ctx, logger, opt)
+	require.Error(t, err)
+	version, err = migrations.Version(opt.Db)
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+
+	migrations[1] = fakeMigration("second", nil)
+	_, err = migrations.Run(ctx, logger, opt)
+	require.NoError(t, err)
+	version, err = migrations.Version(opt.Db)
+	require.NoError(t, err)
+	require.Equal(t, 3, version)
+}
+
 func fakeMigration(name string, returnErr error) Migration {
 	return Migration{
 		Name: name,
@@ -121,3 +158,108 @@ func fakeMigration(name string, returnErr error) Migration {
 		},
 	}
 }
+
+func Test_DecidedKeysBigEndian(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.TestLogger(t)
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+
+	const heights = 2500
+	littleEndian := func(n uint64) []byte {
+		return binary.LittleEndian.AppendUint64(nil, n)
+	}
+	encodedOperators := binary.BigEndian.AppendUint64(nil, 1)
+	domain := networkconfig.TestNetwork.DomainType()
+	pubKey := bytes.Repeat([]byte{1}, 48)
+
+	// Old keys of the roles whose names are prefixes of each other, and of the genesis stores which keep them.
+	roles := []convert.RunnerRole{convert.RoleSyncCommittee, convert.RoleSyncCommitteeContribution}
+	identifiers := make(map[convert.RunnerRole]convert.MessageID)
+	for _, role := range roles {
+		identifier := convert.NewMsgID(domain, pubKey, role)
+		identifiers[role] = identifier
+		pk := append([]byte(role.String()), identifier[:]...)
+		require.NoError(t, opt.Db.Set(pk, []byte("highest_instance"), []byte("highest")))
+		for height := uint64(0); height < heights; height++ {
+			instance, err := (&qbftstorage.StoredInstance{
+				State: &specqbft.State{ID: identifier[:], Height: specqbft.Height(height)},
+			}).Encode()
+			require.NoError(t, err)
+			require.NoError(t, opt.Db.Set(pk, append([]byte("instance"), littleEndian(height)...), instance))
+			require.NoError(t, opt.Db.Set(pk, append([]byte("participants"), littleEndian(height)...), encodedOperators))
+		}
+	}
+	genesisPK := append([]byte("genesis_ATTESTER"), bytes.Repeat([]byte{1}, 56)...)
+	require.NoError(t, opt.Db.Set(genesisPK, append([]byte("instance"), littleEndian(1)...), []byte("instance")))
+
+	// The first run is interrupted in the middle of the SYNC_COMMITTEE keys, which are rewritten
+	// a batch at a time, and the next one resumes it.
+	interrupted := opt
+	interrupted.Db = &failingUpdateDB{Database: opt.Db, updates: 2}
+	_, err = Migrations{migration_5_decided_keys_big_endian}.Run(ctx, logger, interrupted)
+	require.ErrorIs(t, err, errInterrupted)
+	version, err := Migrations{migration_5_decided_keys_big_endian}.Version(opt.Db)
+	require.NoError(t, err)
+	require.Zero(t, version)
+	for _, role := range roles {
+		identifier := identifiers[role]
+		count, err := opt.Db.CountPrefix(append([]byte(role.String()), identifier[:]...))
+		require.NoError(t, err)
+		require.EqualValues(t, 1+2*heights, count)
+	}
+
+	applied, err := Migrations{migration_5_decided_keys_big_endian}.Run(ctx, logger, opt)
+	require.NoError(t, err)
+	require.Equal(t, 1, applied)
+
+	for _, role := range roles {
+		identifier := identifiers[role]
+		pk := append([]byte(role.String()), identifier[:]...)
+		count, err := opt.Db.CountPrefix(pk)
+		require.NoError(t, err)
+		require.EqualValues(t, 1+2*heights, count)
+
+		store := ibftstorage.New(opt.Db, role.String())
+		participants, err := store.GetParticipantsInRange(identifier, 250, 260)
+		require.NoError(t, err)
+		require.Len(t, participants, 11)
+		for i, entry := range participants {
+			require.EqualValues(t, 250+i, entry.Slot)
+			require.Equal(t, []spectypes.OperatorID{1}, entry.Signers)
+		}
+		instances, err := store.GetInstancesInRange(identifier[:], 0, heights)
+		require.NoError(t, err)
+		require.Len(t, instances, heights)
+		for i, instance := range instances {
+			require.EqualValues(t, i, instance.State.Height)
+			require.Equal(t, identifier[:], instance.State.ID)
+		}
+
+		obj, found, err := opt.Db.Get(pk, []byte("highest_instance"))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, []byte("highest"), obj.Value)
+	}
+
+	// Genesis stores are left as they are.
+	_, found, err := opt.Db.Get(genesisPK, append([]byte("instance"), littleEndian(1)...))
+	require.NoError(t, err)
+	require.True(t, found)
+}
+
+var errInterrupted = errors.New("interrupted")
+
+// failingUpdateDB fails the updates after the given number of them.
+type failingUpdateDB struct {
+	basedb.Database
+	updates int
+}
+
+func (db *failingUpdateDB) Update(fn func(basedb.Txn) error) error {
+	if db.updates == 0 {
+		return errInterrupted
+	}
+	db.updates--
+	return db.Database.Update(fn)
+}
@@ -3,6 +3,7 @@ package commons
 import (
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -47,3 +48,47 @@ func BuildMultiAddress(ipAddr, protocol string, port uint, id peer.ID) (ma.Multi
 	}
 	return ma.NewMultiaddr(maStr)
 }
+
+// IPv6Addr returns the first global unicast IPv6 address of the node's interfaces, or nil if it has none.
+func IPv6Addr() (net.IP, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get interface addresses")
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.To4() != nil {
+			continue
+		}
+		if ipNet.IP.IsGlobalUnicast() {
+			return ipNet.IP, nil
+		}
+	}
+	return nil, nil
+}
+
+// ParseHostAddresses parses a comma-separated list of at most one IPv4 and one IPv6 address,
+// returning nil for the versions which aren't given.
+func ParseHostAddresses(hostAddress string) (ip4, ip6 net.IP, err error) {
+	if hostAddress == "" {
+		return nil, nil, nil
+	}
+	for _, s := range strings.Split(hostAddress, ",") {
+		ip := net.ParseIP(strings.TrimSpace(s))
+		switch {
+		case ip == nil:
+			return nil, nil, errors.Errorf("invalid host address: %s", s)
+		case ip.To4() != nil:
+			if ip4 != nil {
+				return nil, nil, errors.Errorf("more than one IPv4 host address: %s", hostAddress)
+			}
+			ip4 = ip.To4()
+		default:
+			if ip6 != nil {
+				return nil, nil, errors.Errorf("more than one IPv6 host address: %s", hostAddress)
+			}
+			ip6 = ip
+		}
+	}
+	return ip4, ip6, nil
+}
@@ -31,3 +31,25 @@ func Test_BuildMultiAddress(t *testing.T) {
 		require.Equal(t, expected, ma.String())
 	})
 }
+
+func Test_ParseHostAddresses(t *testing.T) {
+	ip4, ip6, err := ParseHostAddresses("")
+	require.NoError(t, err)
+	require.Nil(t, ip4)
+	require.Nil(t, ip6)
+
+	ip4, ip6, err = ParseHostAddresses("192.168.1.1")
+	require.NoError(t, err)
+	require.Equal(t, "192.168.1.1", ip4.String())
+	require.Nil(t, ip6)
+
+	ip4, ip6, err = ParseHostAddresses("2001:db8::1, 192.168.1.1")
+	require.NoError(t, err)
+	require.Equal(t, "192.168.1.1", ip4.String())
+	require.Equal(t, "2001:db8::1", ip6.String())
+
+	_, _, err = ParseHostAddresses("192.168.1.1,192.168.1.2")
+	require.Error(t, err)
+	_, _, err = ParseHostAddresses("example.com")
+	require.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"slices"
 	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/discover"
@@ -65,6 +66,8 @@ type DiscV5Service struct {
 	subnets       []byte
 
 	publishLock chan struct{}
+
+	externalIP func() net.IP
 }
 
 func newDiscV5Service(pctx context.Context, logger *zap.Logger, discOpts *Options) (Service, error) {
@@ -77,12 +80,18 @@ func newDiscV5Service(pctx context.Context, logger *zap.Logger, discOpts *Option
 		networkConfig: discOpts.NetworkConfig,
 		subnets:       discOpts.DiscV5Opts.Subnets,
 		publishLock:   make(chan struct{}, 1),
+		externalIP:    discOpts.DiscV5Opts.ExternalIP,
 	}
 
 	logger.Debug("configuring discv5 discovery", zap.Any("discOpts", discOpts))
 	if err := dvs.initDiscV5Listener(logger, discOpts); err != nil {
 		return nil, err
 	}
+	// The address of the node is known if configured, otherwise it's detected.
+	if discOpts.HostAddress == "" && discOpts.HostDNS == "" {
+		localIP, _, _ := discOpts.DiscV5Opts.IPs()
+		go dvs.watchExternalAddress(logger, localIP)
+	}
 	return &dvs, nil
 }
 
@@ -131,6 +140,11 @@ func (dvs *DiscV5Service) Node(logger *zap.Logger, info peer.AddrInfo) (*enode.N
 	return node, nil
 }
 
+// Nodes returns the nodes in the node table.
+func (dvs *DiscV5Service) Nodes() []*enode.Node {
+	return dvs.dv5Listener.AllNodes()
+}
+
 // Bootstrap start looking for new nodes, note that this function blocks.
 // if we reached peers limit, make sure to accept peers with more than 1 shared subnet,
 // which lets other components to determine whether we'll want to connect to this node or not.
@@ -241,6 +255,11 @@ func (dvs *DiscV5Service) initDiscV5Listener(logger *zap.Logger, discOpts *Optio
 	if err != nil {
 		return err
 	}
+	dvs.bootnodes = dv5PostForkCfg.Bootnodes
+
+	// Nodes known from previous runs seed the node table along with the bootnodes.
+	knownNodes := parseKnownNodes(logger, opts.KnownNodes)
+	dv5PostForkCfg.Bootnodes = append(slices.Clip(dv5PostForkCfg.Bootnodes), knownNodes...)
 
 	dv5PostForkListener, err := discover.ListenV5(udpConn, localNode, *dv5PostForkCfg)
 	if err != nil {
@@ -260,6 +279,7 @@ func (dvs *DiscV5Service) initDiscV5Listener(logger *zap.Logger, discOpts *Optio
 	if err != nil {
 		return err
 	}
+	dv5PreForkCfg.Bootnodes = append(slices.Clip(dv5PreForkCfg.Bootnodes), knownNodes...)
 
 	dv5PreForkListener, err := discover.ListenV5(sharedConn, localNode, *dv5PreForkCfg)
 	if err != nil {
@@ -274,7 +294,6 @@ func (dvs *DiscV5Service) initDiscV5Listener(logger *zap.Logger, discOpts *Optio
 	)
 
 	dvs.dv5Listener = NewForkingDV5Listener(logger, dv5PreForkListener, dv5PostForkListener, 5*time.Second, dvs.networkConfig)
-	dvs.bootnodes = dv5PreForkCfg.Bootnodes // Just take bootnodes from one of the config since they're equal
 
 	return nil
 }
@@ -427,7 +446,17 @@ func (dvs *DiscV5Service) createLocalNode(logger *zap.Logger, discOpts *Options,
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create local node")
 	}
-	err = addAddresses(localNode, discOpts.HostAddress, discOpts.HostDNS)
+	if opts.IP6 != "" {
+		ip6 := net.ParseIP(opts.IP6)
+		if ip6 == nil || ip6.To4() != nil {
+			return nil, errors.Errorf("invalid IPv6 address: %s", opts.IP6)
+		}
+		setIPv6(localNode, ip6, opts.TCPPort)
+	}
+	if opts.QUICPort != 0 {
+		localNode.Set(quicEntry(opts.QUICPort))
+	}
+	err = addAddresses(localNode, discOpts.HostAddress, discOpts.HostDNS, opts.IP6 != "")
 	if err != nil {
 		return nil, errors.Wrap(err, "could not add configured addresses")
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/network/commons"
 )
@@ -33,40 +34,125 @@ func createLocalNode(privKey *ecdsa.PrivateKey, storagePath string, ipAddr net.I
 	return localNode, nil
 }
 
-// addAddresses adds configured address and/or dns if configured
-func addAddresses(localNode *enode.LocalNode, hostAddr, hostDNS string) error {
-	if len(hostAddr) > 0 {
-		hostIP := net.ParseIP(hostAddr)
-		if hostIP.To4() == nil && hostIP.To16() == nil {
-			return fmt.Errorf("invalid host address given: %s", hostIP.String())
+// quicEntry is the ENR entry of the UDP port of the QUIC transport, as used by Ethereum consensus clients.
+type quicEntry uint16
+
+func (quicEntry) ENRKey() string { return "quic" }
+
+// quic6Entry is the ENR entry of the QUIC port over IPv6, which defaults to the quic entry if missing.
+type quic6Entry uint16
+
+func (quic6Entry) ENRKey() string { return "quic6" }
+
+// setIPv6 advertises the IPv6 endpoint of the node, which uses the same ports as IPv4.
+// udp6 is set by the local node only when it differs from udp.
+func setIPv6(localNode *enode.LocalNode, ip6 net.IP, tcpPort uint16) {
+	localNode.SetFallbackIP(ip6)
+	localNode.Set(enr.TCP6(tcpPort))
+}
+
+// addAddresses adds configured address and/or dns if configured.
+// hostAddr is a comma-separated list of at most one IPv4 and one IPv6 address,
+// and the IPv6 addresses hostDNS resolves to are used only if ipv6 is enabled, or if it has no IPv4 address.
+func addAddresses(localNode *enode.LocalNode, hostAddr, hostDNS string, ipv6 bool) error {
+	ip4, ip6, err := commons.ParseHostAddresses(hostAddr)
+	if err != nil {
+		return err
+	}
+	for _, hostIP := range []net.IP{ip4, ip6} {
+		if hostIP != nil {
+			localNode.SetFallbackIP(hostIP)
+			localNode.SetStaticIP(hostIP)
 		}
-		localNode.SetFallbackIP(hostIP)
-		localNode.SetStaticIP(hostIP)
 	}
 	if len(hostDNS) > 0 {
 		ips, err := net.LookupIP(hostDNS)
 		if err != nil {
 			return errors.Wrap(err, "could not resolve host address")
 		}
-		if len(ips) > 0 {
-			firstIP := ips[0]
-			localNode.SetFallbackIP(firstIP)
+		var dnsIP4, dnsIP6 net.IP
+		for _, ip := range ips {
+			if ip.To4() != nil && dnsIP4 == nil {
+				dnsIP4 = ip
+			} else if ip.To4() == nil && dnsIP6 == nil {
+				dnsIP6 = ip
+			}
+		}
+		if dnsIP4 != nil {
+			localNode.SetFallbackIP(dnsIP4)
+		}
+		if dnsIP6 != nil && (ipv6 || dnsIP4 == nil) {
+			localNode.SetFallbackIP(dnsIP6)
 		}
 	}
 	return nil
 }
 
-// ToPeer creates peer info from the given node
+// ToPeer creates peer info from the given node,
+// with an address for each transport (TCP and QUIC) and IP version the node advertises.
 func ToPeer(node *enode.Node) (*peer.AddrInfo, error) {
-	m, err := ToMultiAddr(node)
+	id, err := PeerID(node)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not create multiaddr")
+		return nil, errors.Wrap(err, "could not create peer id")
 	}
-	pi, err := peer.AddrInfoFromP2pAddr(m)
+	addrs, err := nodeAddrs(node)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not create peer info")
+		return nil, errors.Wrap(err, "could not create multiaddr")
+	}
+	return &peer.AddrInfo{ID: id, Addrs: addrs}, nil
+}
+
+func nodeAddrs(node *enode.Node) ([]ma.Multiaddr, error) {
+	var (
+		ip4   enr.IPv4
+		ip6   enr.IPv6
+		tcp   enr.TCP
+		tcp6  enr.TCP6
+		quic  quicEntry
+		quic6 quic6Entry
+	)
+	hasTCP := node.Load(&tcp) == nil
+	hasQUIC := node.Load(&quic) == nil
+	// IPv6 ports default to the IPv4 ports.
+	hasTCP6 := node.Load(&tcp6) == nil
+	if !hasTCP6 {
+		tcp6, hasTCP6 = enr.TCP6(tcp), hasTCP
+	}
+	hasQUIC6 := node.Load(&quic6) == nil
+	if !hasQUIC6 {
+		quic6, hasQUIC6 = quic6Entry(quic), hasQUIC
+	}
+
+	var endpoints []string
+	if node.Load(&ip4) == nil {
+		if hasTCP {
+			endpoints = append(endpoints, fmt.Sprintf("/ip4/%s/tcp/%d", net.IP(ip4), tcp))
+		}
+		if hasQUIC {
+			endpoints = append(endpoints, fmt.Sprintf("/ip4/%s/udp/%d/quic-v1", net.IP(ip4), quic))
+		}
+	}
+	if node.Load(&ip6) == nil {
+		if hasTCP6 {
+			endpoints = append(endpoints, fmt.Sprintf("/ip6/%s/tcp/%d", net.IP(ip6), tcp6))
+		}
+		if hasQUIC6 {
+			endpoints = append(endpoints, fmt.Sprintf("/ip6/%s/udp/%d/quic-v1", net.IP(ip6), quic6))
+		}
+	}
+	if len(endpoints) == 0 {
+		return nil, errors.New("node has no ip address with a tcp or quic port")
 	}
-	return pi, nil
+
+	addrs := make([]ma.Multiaddr, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		addr, err := ma.NewMultiaddr(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
 }
 
 // PeerID returns the peer id of the node
@@ -102,6 +188,20 @@ func ToMultiAddr(node *enode.Node) (ma.Multiaddr, error) {
 	return ma.NewMultiaddr(s)
 }
 
+// parseKnownNodes parses the given ENRs, skipping the invalid ones.
+func parseKnownNodes(logger *zap.Logger, enrs []string) []*enode.Node {
+	nodes := make([]*enode.Node, 0, len(enrs))
+	for _, e := range enrs {
+		node, err := enode.Parse(enode.ValidSchemes, e)
+		if err != nil {
+			logger.Debug("skipping invalid known node", zap.String("enr", e), zap.Error(err))
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
 // ParseENR takes a list of ENR strings and returns
 // the corresponding enode.Node objects.
 // it also accepts custom schemes, defaults to enode.ValidSchemes (v4)
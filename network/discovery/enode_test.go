@@ -2,13 +2,16 @@ package discovery
 
 import (
 	crand "crypto/rand"
+	"net"
 	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/ssvlabs/ssv/network/commons"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func Test_ToMultiAddr(t *testing.T) {
@@ -29,6 +32,48 @@ func Test_ToPeer(t *testing.T) {
 	require.Equal(t, 1, len(ai.Addrs))
 }
 
+func Test_ToPeer_DualStackQUIC(t *testing.T) {
+	sk, _, err := crypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+	pk, err := commons.ECDSAPrivFromInterface(sk)
+	require.NoError(t, err)
+	node, err := createLocalNode(pk, "", net.ParseIP("10.0.0.1"), 12000, 13000)
+	require.NoError(t, err)
+	setIPv6(node, net.ParseIP("2001:db8::1"), 13000)
+	node.Set(quicEntry(13002))
+
+	var ip6 enr.IPv6
+	require.NoError(t, node.Node().Load(&ip6))
+	require.Equal(t, "2001:db8::1", net.IP(ip6).String())
+	var tcp6 enr.TCP6
+	require.NoError(t, node.Node().Load(&tcp6))
+	require.EqualValues(t, 13000, tcp6)
+
+	ai, err := ToPeer(node.Node())
+	require.NoError(t, err)
+	var addrs []string
+	for _, addr := range ai.Addrs {
+		addrs = append(addrs, addr.String())
+	}
+	require.ElementsMatch(t, []string{
+		"/ip4/10.0.0.1/tcp/13000",
+		"/ip4/10.0.0.1/udp/13002/quic-v1",
+		"/ip6/2001:db8::1/tcp/13000",
+		"/ip6/2001:db8::1/udp/13002/quic-v1",
+	}, addrs)
+}
+
+func Test_AddAddresses(t *testing.T) {
+	node := localNodeMock(t)
+	require.NoError(t, addAddresses(node, "203.0.113.1,2001:db8::2", "", false))
+	require.Equal(t, "203.0.113.1", node.Node().IP().String())
+	var ip6 enr.IPv6
+	require.NoError(t, node.Node().Load(&ip6))
+	require.Equal(t, "2001:db8::2", net.IP(ip6).String())
+
+	require.Error(t, addAddresses(localNodeMock(t), "203.0.113.1,203.0.113.2", "", false))
+}
+
 func Test_ParseENR(t *testing.T) {
 	nodes, err := ParseENR(nil, true,
 		"enr:-Km4QH9oua5xsG_0IN3oxiv5PBb10QXMkMvDeg2IrSSDlRxtONu9hShTmAZm2LjjADQOxGzBxd8VzXYFukmJULzcwrkBh2"+
@@ -40,6 +85,14 @@ func Test_ParseENR(t *testing.T) {
 	require.Equal(t, "3.101.138.183", nodes[0].IP().String())
 }
 
+func Test_parseKnownNodes(t *testing.T) {
+	node := localNodeMock(t).Node()
+
+	nodes := parseKnownNodes(zap.NewNop(), []string{"enr:invalid", node.String()})
+	require.Len(t, nodes, 1)
+	require.Equal(t, node.ID(), nodes[0].ID())
+}
+
 func localNodeMock(t *testing.T) *enode.LocalNode {
 	sk, _, err := crypto.GenerateSecp256k1Key(crand.Reader)
 	require.NoError(t, err)
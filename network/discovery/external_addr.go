@@ -0,0 +1,68 @@
+package discovery
+
+import (
+	"net"
+	"net/netip"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+)
+
+// externalAddressInterval is the interval at which the endpoint of the ENR is checked for changes.
+var externalAddressInterval = time.Minute
+
+// watchExternalAddress keeps the IP of the ENR up to date with the external address of the node,
+// and publishes the ENR whenever its endpoint changes.
+//
+// The endpoint predicted by discv5 from the PONGs of other nodes takes precedence,
+// then the IP returned by the ExternalIP option (e.g. observed by libp2p peers or reported by the NAT gateway),
+// and finally the IP of the local interface.
+func (dvs *DiscV5Service) watchExternalAddress(logger *zap.Logger, localIP net.IP) {
+	endpoint := nodeEndpoint(dvs.dv5Listener.LocalNode().Node())
+
+	ticker := time.NewTicker(externalAddressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-dvs.ctx.Done():
+			return
+		case <-ticker.C:
+			endpoint = dvs.updateExternalAddress(logger, localIP, endpoint)
+		}
+	}
+}
+
+// updateExternalAddress sets the fallback IP of the ENR to the detected external IP, if any,
+// and publishes the ENR if its endpoint differs from the previous one. It returns the current endpoint.
+func (dvs *DiscV5Service) updateExternalAddress(logger *zap.Logger, localIP net.IP, prev netip.AddrPort) netip.AddrPort {
+	localNode := dvs.dv5Listener.LocalNode()
+
+	fallbackIP := localIP
+	if dvs.externalIP != nil {
+		// Only the IP of the same version is replaced, the other one is set separately (see setIPv6).
+		if ip := dvs.externalIP(); ip != nil && (ip.To4() != nil) == (localIP.To4() != nil) {
+			fallbackIP = ip
+		}
+	}
+	localNode.SetFallbackIP(fallbackIP)
+
+	endpoint := nodeEndpoint(localNode.Node())
+	if endpoint == prev {
+		return prev
+	}
+	logger.Info("external address changed, publishing ENR",
+		zap.Stringer("previous", prev),
+		zap.Stringer("current", endpoint),
+		fields.ENRLocalNode(localNode))
+	dvs.PublishENR(logger)
+	return endpoint
+}
+
+// nodeEndpoint returns the discovery endpoint of the node.
+func nodeEndpoint(node *enode.Node) netip.AddrPort {
+	ip, _ := netip.AddrFromSlice(node.IP())
+	return netip.AddrPortFrom(ip.Unmap(), uint16(node.UDP()))
+}
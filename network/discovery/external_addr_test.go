@@ -0,0 +1,66 @@
+package discovery
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateExternalAddress(t *testing.T) {
+	defer func(timeout time.Duration) { publishENRTimeout = timeout }(publishENRTimeout)
+	publishENRTimeout = 10 * time.Millisecond
+
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	localIP := net.ParseIP("192.168.1.2")
+	localNode, err := createLocalNode(privKey, t.TempDir(), localIP, testPort, testTCPPort)
+	require.NoError(t, err)
+
+	var externalIP net.IP
+	dvs := testingDiscovery(t)
+	defer dvs.Close()
+	dvs.dv5Listener = NewMockListener(localNode, []*enode.Node{})
+	dvs.externalIP = func() net.IP { return externalIP }
+
+	localEndpoint := netip.MustParseAddrPort("192.168.1.2:12001")
+	endpoint := nodeEndpoint(localNode.Node())
+	require.Equal(t, localEndpoint, endpoint)
+
+	// Nothing detected.
+	endpoint = dvs.updateExternalAddress(testLogger, localIP, endpoint)
+	require.Equal(t, localEndpoint, endpoint)
+	seq := localNode.Node().Seq()
+
+	// Detected external IP.
+	externalIP = net.ParseIP("203.0.113.7")
+	endpoint = dvs.updateExternalAddress(testLogger, localIP, endpoint)
+	require.Equal(t, netip.MustParseAddrPort("203.0.113.7:12001"), endpoint)
+	require.Equal(t, externalIP.To4(), localNode.Node().IP())
+	require.Greater(t, localNode.Node().Seq(), seq)
+
+	// Unchanged.
+	seq = localNode.Node().Seq()
+	endpoint = dvs.updateExternalAddress(testLogger, localIP, endpoint)
+	require.Equal(t, netip.MustParseAddrPort("203.0.113.7:12001"), endpoint)
+	require.Equal(t, seq, localNode.Node().Seq())
+
+	// IP of another version is ignored.
+	externalIP = net.ParseIP("2001:db8::7")
+	endpoint = dvs.updateExternalAddress(testLogger, localIP, endpoint)
+	require.Equal(t, localEndpoint, endpoint)
+
+	// Endpoint predicted by discv5 takes precedence.
+	externalIP = net.ParseIP("203.0.113.7")
+	predicted := netip.MustParseAddrPort("198.51.100.9:30000")
+	for i := 0; i < 10; i++ {
+		localNode.UDPEndpointStatement(netip.AddrPortFrom(netip.AddrFrom4([4]byte{10, 0, 0, byte(i)}), 12001), predicted)
+	}
+	endpoint = dvs.updateExternalAddress(testLogger, localIP, endpoint)
+	require.Equal(t, predicted, endpoint)
+	require.Equal(t, uint16(30000), uint16(localNode.Node().UDP()))
+}
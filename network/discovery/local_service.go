@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/ethereum/go-ethereum/p2p/enode"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	"github.com/libp2p/go-libp2p/core/discovery"
 	"github.com/libp2p/go-libp2p/core/host"
@@ -109,6 +110,11 @@ func (md *localDiscovery) PublishENR(logger *zap.Logger) {
 	// TODO
 }
 
+// Nodes implements Service, mDNS has no node records.
+func (md *localDiscovery) Nodes() []*enode.Node {
+	return nil
+}
+
 // discoveryNotifee gets notified when we find a new peer via mDNS discovery
 type discoveryNotifee struct {
 	handler HandleNewPeer
@@ -24,20 +24,29 @@ type DiscV5Options struct {
 	StoragePath string
 	// IP of the node
 	IP string
+	// IP6 is the IPv6 address of the node, advertised in addition to IP if set
+	IP6 string
 	// BindIP is the IP to bind to the UDP listener
 	BindIP string
 	// Port is the UDP port used by discv5
 	Port uint16
 	// TCPPort is the TCP port exposed in the ENR
 	TCPPort uint16
+	// QUICPort is the UDP port of the QUIC transport exposed in the ENR, if enabled
+	QUICPort uint16
 	// NetworkKey is the private key used to create the peer.ID if the node
 	NetworkKey *ecdsa.PrivateKey
 	// Bootnodes is a list of bootstrapper nodes
 	Bootnodes []string
+	// KnownNodes is a list of ENRs of nodes known from previous runs, which seed the node table
+	KnownNodes []string
 	// Subnets is a bool slice represents all the subnets the node is intreseted in
 	Subnets []byte
 	// EnableLogging when true enables logs to be emitted
 	EnableLogging bool
+	// ExternalIP returns the external IP of the node as detected outside of discovery, or nil if unknown.
+	// It's advertised in the ENR unless discv5 predicts another endpoint, or a host address is configured.
+	ExternalIP func() net.IP `json:"-"`
 }
 
 // DefaultOptions returns the default options
@@ -80,6 +89,9 @@ func (opts *DiscV5Options) IPs() (net.IP, net.IP, string) {
 		}
 	} else if bindIP.To4() != nil {
 		n = "udp4"
+	} else if bindIP.IsUnspecified() {
+		// Listens on both IPv4 and IPv6.
+		n = "udp"
 	}
 	return ipAddr, bindIP, n
 }
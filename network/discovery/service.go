@@ -51,6 +51,8 @@ type Service interface {
 	DeregisterSubnets(logger *zap.Logger, subnets ...uint64) (updated bool, err error)
 	Bootstrap(logger *zap.Logger, handler HandleNewPeer) error
 	PublishENR(logger *zap.Logger)
+	// Nodes returns the nodes known to discovery, to be persisted across restarts.
+	Nodes() []*enode.Node
 }
 
 // NewService creates new discovery.Service
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
@@ -11,11 +12,13 @@ import (
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/security/noise"
+	libp2pquic "github.com/libp2p/go-libp2p/p2p/transport/quic"
 	libp2ptcp "github.com/libp2p/go-libp2p/p2p/transport/tcp"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
 	"github.com/ssvlabs/ssv/message/validation"
 	"github.com/ssvlabs/ssv/monitoring/metricsreporter"
 	"github.com/ssvlabs/ssv/network"
@@ -24,6 +27,8 @@ import (
 	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
 	"github.com/ssvlabs/ssv/operator/keys"
 	"github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+	"github.com/ssvlabs/ssv/storage/basedb"
 	uc "github.com/ssvlabs/ssv/utils/commons"
 )
 
@@ -41,13 +46,17 @@ type Config struct {
 
 	TCPPort     uint16 `yaml:"TcpPort" env:"TCP_PORT" env-default:"13001" env-description:"TCP port for p2p transport"`
 	UDPPort     uint16 `yaml:"UdpPort" env:"UDP_PORT" env-default:"12001" env-description:"UDP port for discovery"`
-	HostAddress string `yaml:"HostAddress" env:"HOST_ADDRESS" env-description:"External ip node is exposed for discovery"`
+	QUICPort    uint16 `yaml:"QuicPort" env:"QUIC_PORT" env-description:"UDP port for QUIC p2p transport other than UdpPort, which discovery can't share with it. Disabled if not set"`
+	EnableIPv6  bool   `yaml:"EnableIPv6" env:"P2P_ENABLE_IPV6" env-description:"Flag to listen and advertise on IPv6 in addition to IPv4"`
+	HostAddress string `yaml:"HostAddress" env:"HOST_ADDRESS" env-description:"External ip node is exposed for discovery, or comma-separated IPv4 and IPv6 addresses"`
 	HostDNS     string `yaml:"HostDNS" env:"HOST_DNS" env-description:"External DNS node is exposed for discovery"`
+	NAT         string `yaml:"NAT" env:"P2P_NAT" env-description:"Port mapping of TcpPort, UdpPort and QuicPort on the router: any, upnp, pmp, pmp:<gateway IP> or extip:<IP> (no mapping, only advertises the IP). Disabled if not set"`
 
 	RequestTimeout   time.Duration `yaml:"RequestTimeout" env:"P2P_REQUEST_TIMEOUT"  env-default:"10s"`
 	MaxBatchResponse uint64        `yaml:"MaxBatchResponse" env:"P2P_MAX_BATCH_RESPONSE" env-default:"25" env-description:"Maximum number of returned objects in a batch"`
 	MaxPeers         int           `yaml:"MaxPeers" env:"P2P_MAX_PEERS" env-default:"60" env-description:"Connected peers limit for connections"`
 	TopicMaxPeers    int           `yaml:"TopicMaxPeers" env:"P2P_TOPIC_MAX_PEERS" env-default:"10" env-description:"Connected peers limit per pubsub topic"`
+	PeerStoreTTL     time.Duration `yaml:"PeerStoreTTL" env:"P2P_PEER_STORE_TTL" env-default:"24h" env-description:"How long known peers and discovery nodes are kept since last seen, to reconnect to them after a restart"`
 
 	// Subnets is a static bit list of subnets that this node will register upon start.
 	Subnets string `yaml:"Subnets" env:"SUBNETS" env-description:"Hex string that represents the subnets that this node will join upon start"`
@@ -71,20 +80,25 @@ type Config struct {
 	UserAgent string
 	// NodeStorage is used to get operator metadata.
 	NodeStorage storage.Storage
+	// DB persists the known peers and discovery nodes across restarts, if set.
+	DB basedb.Database
 	// Network defines a network configuration.
 	Network networkconfig.NetworkConfig
 	// MessageValidator validates incoming messages.
 	MessageValidator validation.MessageValidator
 	// Metrics report metrics.
 	Metrics metricsreporter.MetricsReporter
+	// DecidedStores holds the decided instances served to peers, if nil they are not served.
+	DecidedStores *ibftstorage.QBFTStores
+	// MessageObserver is notified of every validated message received from other peers, if set.
+	MessageObserver func(from peer.ID, msg *queue.SSVMessage)
 
 	PubsubMsgCacheTTL         time.Duration `yaml:"PubsubMsgCacheTTL" env:"PUBSUB_MSG_CACHE_TTL" env-description:"How long a message ID will be remembered as seen"`
 	PubsubOutQueueSize        int           `yaml:"PubsubOutQueueSize" env:"PUBSUB_OUT_Q_SIZE" env-description:"The size that we assign to the outbound pubsub message queue"`
 	PubsubValidationQueueSize int           `yaml:"PubsubValidationQueueSize" env:"PUBSUB_VAL_Q_SIZE" env-description:"The size that we assign to the pubsub validation queue"`
 	PubsubValidateThrottle    int           `yaml:"PubsubPubsubValidateThrottle" env:"PUBSUB_VAL_THROTTLE" env-description:"The amount of goroutines used for pubsub msg validation"`
 
-	// FullNode determines whether the network should sync decided history from peers.
-	// If false, SyncDecidedByRange becomes a no-op.
+	// FullNode determines whether the network should serve decided history to peers.
 	FullNode bool
 
 	DisableIPRateLimit bool `yaml:"DisableIPRateLimit" env:"DISABLE_IP_RATE_LIMIT" default:"false" env-description:"Flag to turn on/off IP rate limiting"`
@@ -115,6 +129,9 @@ func (c *Config) Libp2pOptions(logger *zap.Logger) ([]libp2p.Option, error) {
 		libp2p.Transport(libp2ptcp.NewTCPTransport),
 		libp2p.UserAgent(c.UserAgent),
 	}
+	if c.QUICPort != 0 {
+		opts = append(opts, libp2p.Transport(libp2pquic.NewTransport))
+	}
 
 	opts, err = c.configureAddrs(logger, opts)
 	if err != nil {
@@ -130,11 +147,17 @@ func (c *Config) Libp2pOptions(logger *zap.Logger) ([]libp2p.Option, error) {
 
 func (c *Config) configureAddrs(logger *zap.Logger, opts []libp2p.Option) ([]libp2p.Option, error) {
 	addrs := make([]ma.Multiaddr, 0)
-	maZero, err := commons.BuildMultiAddress("0.0.0.0", "tcp", uint(c.TCPPort), "")
-	if err != nil {
-		return opts, errors.Wrap(err, "could not build multi address for zero address")
+	zeroIPs := []string{net.IPv4zero.String()}
+	if c.EnableIPv6 {
+		zeroIPs = append(zeroIPs, net.IPv6zero.String())
+	}
+	for _, zeroIP := range zeroIPs {
+		maZero, err := c.buildMultiAddresses(zeroIP)
+		if err != nil {
+			return opts, errors.Wrap(err, "could not build multi address for zero address")
+		}
+		addrs = append(addrs, maZero...)
 	}
-	addrs = append(addrs, maZero)
 	ipAddr, err := commons.IPAddr()
 	if err != nil {
 		return opts, errors.Wrap(err, "could not get ip addr")
@@ -149,34 +172,69 @@ func (c *Config) configureAddrs(logger *zap.Logger, opts []libp2p.Option) ([]lib
 	}
 	opts = append(opts, libp2p.ListenAddrs(addrs...))
 
-	// AddrFactory for host address if provided
-	if c.HostAddress != "" {
-		opts = append(opts, libp2p.AddrsFactory(func(addrs []ma.Multiaddr) []ma.Multiaddr {
-			external, err := commons.BuildMultiAddress(c.HostAddress, "tcp", uint(c.TCPPort), "")
-			if err != nil {
-				logger.Error("unable to create external multiaddress", zap.Error(err))
-			} else {
-				addrs = append(addrs, external)
-			}
-			return addrs
-		}))
+	hostIP4, hostIP6, err := commons.ParseHostAddresses(c.HostAddress)
+	if err != nil {
+		return opts, errors.Wrap(err, "could not parse host address")
+	}
+	var external []ma.Multiaddr
+	// External addresses of the host addresses if provided
+	for _, hostIP := range []net.IP{hostIP4, hostIP6} {
+		if hostIP == nil {
+			continue
+		}
+		hostAddrs, err := c.buildMultiAddresses(hostIP.String())
+		if err != nil {
+			return opts, errors.Wrap(err, "could not build multi address for host address")
+		}
+		external = append(external, hostAddrs...)
 	}
-	// AddrFactory for DNS address if provided
+	// External addresses of the DNS address if provided
 	if c.HostDNS != "" {
-		opts = append(opts, libp2p.AddrsFactory(func(addrs []ma.Multiaddr) []ma.Multiaddr {
-			external, err := ma.NewMultiaddr(fmt.Sprintf("/dns4/%s/tcp/%d", c.HostDNS, c.TCPPort))
-			if err != nil {
-				logger.Warn("unable to create external multiaddress", zap.Error(err))
-			} else {
-				addrs = append(addrs, external)
+		dnsProtocols := []string{"dns4"}
+		if c.EnableIPv6 {
+			dnsProtocols = append(dnsProtocols, "dns6")
+		}
+		for _, dnsProtocol := range dnsProtocols {
+			endpoints := []string{fmt.Sprintf("/%s/%s/tcp/%d", dnsProtocol, c.HostDNS, c.TCPPort)}
+			if c.QUICPort != 0 {
+				endpoints = append(endpoints, fmt.Sprintf("/%s/%s/udp/%d/quic-v1", dnsProtocol, c.HostDNS, c.QUICPort))
 			}
-			return addrs
+			for _, endpoint := range endpoints {
+				dnsAddr, err := ma.NewMultiaddr(endpoint)
+				if err != nil {
+					logger.Warn("unable to create external multiaddress", zap.Error(err))
+					continue
+				}
+				external = append(external, dnsAddr)
+			}
+		}
+	}
+	if len(external) > 0 {
+		opts = append(opts, libp2p.AddrsFactory(func(addrs []ma.Multiaddr) []ma.Multiaddr {
+			return append(addrs, external...)
 		}))
 	}
 
 	return opts, nil
 }
 
+// buildMultiAddresses returns the addresses of the enabled transports on the given IP.
+func (c *Config) buildMultiAddresses(ip string) ([]ma.Multiaddr, error) {
+	tcpAddr, err := commons.BuildMultiAddress(ip, "tcp", uint(c.TCPPort), "")
+	if err != nil {
+		return nil, err
+	}
+	addrs := []ma.Multiaddr{tcpAddr}
+	if c.QUICPort != 0 {
+		udpAddr, err := commons.BuildMultiAddress(ip, "udp", uint(c.QUICPort), "")
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, udpAddr.Encapsulate(ma.StringCast("/quic-v1")))
+	}
+	return addrs, nil
+}
+
 // TransformBootnodes converts bootnodes string and convert it to slice
 func (c *Config) TransformBootnodes() []string {
 
@@ -10,11 +10,14 @@ import (
 
 	ma "github.com/multiformats/go-multiaddr"
 
+	"github.com/ethereum/go-ethereum/p2p/nat"
 	"github.com/libp2p/go-libp2p/core/connmgr"
 	connmgrcore "github.com/libp2p/go-libp2p/core/connmgr"
 	"github.com/libp2p/go-libp2p/core/host"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	libp2pdiscbackoff "github.com/libp2p/go-libp2p/p2p/discovery/backoff"
+	"github.com/libp2p/go-libp2p/p2p/protocol/identify"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/utils/hashmap"
@@ -85,6 +88,9 @@ type p2pNetwork struct {
 	msgValidator validation.MessageValidator
 	connHandler  connections.ConnHandler
 	connGater    connmgr.ConnectionGater
+	idService    identify.IDService
+	nat          nat.Interface
+	peerStore    *peers.Store
 	trustedPeers []*peer.AddrInfo
 	metrics      Metrics
 
@@ -187,8 +193,12 @@ func (n *p2pNetwork) PeersByTopic() ([]peer.ID, map[string][]peer.ID) {
 
 // Close implements io.Closer
 func (n *p2pNetwork) Close() error {
-	atomic.SwapInt32(&n.state, stateClosing)
+	wasReady := atomic.SwapInt32(&n.state, stateClosing) == stateReady
 	defer atomic.StoreInt32(&n.state, stateClosed)
+	// Saved while the peers are still connected.
+	if wasReady && n.peerStore != nil {
+		n.saveKnownPeers(n.interfaceLogger)
+	}
 	n.cancel()
 	if err := n.libConnManager.Close(); err != nil {
 		n.interfaceLogger.Warn("could not close discovery", zap.Error(err))
@@ -205,7 +215,7 @@ func (n *p2pNetwork) Close() error {
 	return n.host.Close()
 }
 
-func (n *p2pNetwork) getConnector() (chan peer.AddrInfo, error) {
+func (n *p2pNetwork) getConnector(knownPeers []peer.AddrInfo) (chan peer.AddrInfo, error) {
 	connector := make(chan peer.AddrInfo, connectorQueueSize)
 	go func() {
 		// Wait for own subnets to be subscribed to and updated.
@@ -216,16 +226,41 @@ func (n *p2pNetwork) getConnector() (chan peer.AddrInfo, error) {
 		n.backoffConnector.Connect(ctx, connector)
 	}()
 
-	// Connect to trusted peers first.
+	// Connect to trusted peers first, then to the best peers known from previous runs.
 	go func() {
 		for _, addrInfo := range n.trustedPeers {
 			connector <- *addrInfo
 		}
+		for _, addrInfo := range knownPeers {
+			connector <- addrInfo
+		}
 	}()
 
 	return connector, nil
 }
 
+// DialTrustedPeers connects to the trusted peers which aren't connected,
+// bypassing the backoff of the discovery connector.
+// It returns the number of peers which were dialed.
+func (n *p2pNetwork) DialTrustedPeers(ctx context.Context) (int, error) {
+	if !n.isReady() {
+		return 0, errors.New("network is not ready")
+	}
+
+	var dialed int
+	var errs []error
+	for _, addrInfo := range n.trustedPeers {
+		if n.host.Network().Connectedness(addrInfo.ID) == libp2pnetwork.Connected {
+			continue
+		}
+		dialed++
+		if err := n.host.Connect(ctx, *addrInfo); err != nil {
+			errs = append(errs, fmt.Errorf("could not connect to %s: %w", addrInfo.ID, err))
+		}
+	}
+	return dialed, errors.Join(errs...)
+}
+
 // Start starts the discovery service, garbage collector (peer index), and reporting.
 func (n *p2pNetwork) Start(logger *zap.Logger) error {
 	logger = logger.Named(logging.NameP2PNetwork)
@@ -235,7 +270,11 @@ func (n *p2pNetwork) Start(logger *zap.Logger) error {
 		return nil
 	}
 
-	connector, err := n.getConnector()
+	var knownPeers []peer.AddrInfo
+	if n.peerStore != nil {
+		knownPeers = n.loadKnownPeers(logger)
+	}
+	connector, err := n.getConnector(knownPeers)
 	if err != nil {
 		return err
 	}
@@ -256,9 +295,16 @@ func (n *p2pNetwork) Start(logger *zap.Logger) error {
 		zap.Int("trusted_peers", len(n.trustedPeers)),
 	)
 
+	if n.nat != nil {
+		n.mapPorts(logger)
+	}
+
 	go n.startDiscovery(logger, connector)
 
 	async.Interval(n.ctx, connManagerBalancingInterval, n.peersBalancing(logger))
+	if n.peerStore != nil {
+		async.Interval(n.ctx, peerStoreInterval, func() { n.saveKnownPeers(logger) })
+	}
 	// don't report metrics in tests
 	if n.cfg.Metrics != nil {
 		async.Interval(n.ctx, peersReportingInterval, n.reportAllPeers(logger))
@@ -0,0 +1,102 @@
+package p2pv1
+
+import (
+	"net"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/nat"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"go.uber.org/zap"
+)
+
+const (
+	// portMappingLifetime is the lifetime of the port mappings on the router, which are renewed before they expire.
+	portMappingLifetime = nat.DefaultMapTimeout
+	// portMappingRenewal is the interval at which the port mappings are renewed.
+	portMappingRenewal = portMappingLifetime / 2
+)
+
+type portMapping struct {
+	protocol string
+	port     uint16
+	name     string
+}
+
+// mapPorts maps the ports of the p2p transports and of discovery on the router,
+// and keeps the mappings alive until the network is closed.
+func (n *p2pNetwork) mapPorts(logger *zap.Logger) {
+	mappings := []portMapping{{protocol: "TCP", port: n.cfg.TCPPort, name: "ssv p2p"}}
+	if n.cfg.Discovery != localDiscvery {
+		mappings = append(mappings, portMapping{protocol: "UDP", port: n.cfg.UDPPort, name: "ssv discovery"})
+	}
+	if n.cfg.QUICPort != 0 {
+		mappings = append(mappings, portMapping{protocol: "UDP", port: n.cfg.QUICPort, name: "ssv quic"})
+	}
+
+	logger = logger.With(zap.Stringer("nat", n.nat))
+	for _, mapping := range mappings {
+		go n.mapPort(logger.With(zap.String("protocol", mapping.protocol), zap.Uint16("port", mapping.port)), mapping)
+	}
+}
+
+func (n *p2pNetwork) mapPort(logger *zap.Logger, mapping portMapping) {
+	port := int(mapping.port)
+	defer func() {
+		if err := n.nat.DeleteMapping(mapping.protocol, port, port); err != nil {
+			logger.Debug("could not delete port mapping", zap.Error(err))
+		}
+	}()
+
+	add := func() {
+		extPort, err := n.nat.AddMapping(mapping.protocol, port, port, mapping.name, portMappingLifetime)
+		if err != nil {
+			logger.Warn("could not map port", zap.Error(err))
+			return
+		}
+		// The external port is advertised as the local one, so a different port isn't reachable by peers.
+		if extPort != 0 && extPort != mapping.port {
+			logger.Warn("router mapped a different external port, set it manually to be reachable", zap.Uint16("external_port", extPort))
+			return
+		}
+		logger.Debug("mapped port")
+	}
+
+	add()
+	ticker := time.NewTicker(portMappingRenewal)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-ticker.C:
+			add()
+		}
+	}
+}
+
+// externalIP returns the public IPv4 address of the node as observed by its libp2p peers (see identify),
+// or else the external IP of the router if port mapping is enabled. Private router IPs (e.g. behind
+// another NAT) are ignored, unless configured with extip.
+func (n *p2pNetwork) externalIP() net.IP {
+	if n.idService != nil {
+		for _, addr := range n.idService.OwnObservedAddrs() {
+			ip, err := manet.ToIP(addr)
+			if err == nil && ip.To4() != nil && manet.IsPublicAddr(addr) {
+				return ip
+			}
+		}
+	}
+	if n.nat != nil {
+		ip, err := n.nat.ExternalIP()
+		if err != nil || ip.To4() == nil {
+			return nil
+		}
+		if _, static := n.nat.(nat.ExtIP); static {
+			return ip
+		}
+		if addr, err := manet.FromIP(ip); err == nil && manet.IsPublicAddr(addr) {
+			return ip
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package p2pv1
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/nat"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testNAT struct {
+	mu       sync.Mutex
+	ip       net.IP
+	mappings map[string]string
+}
+
+func (m *testNAT) AddMapping(protocol string, extport, intport int, name string, lifetime time.Duration) (uint16, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mappings[fmt.Sprintf("%s:%d", protocol, extport)] = name
+	return uint16(extport), nil
+}
+
+func (m *testNAT) DeleteMapping(protocol string, extport, intport int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.mappings, fmt.Sprintf("%s:%d", protocol, extport))
+	return nil
+}
+
+func (m *testNAT) ExternalIP() (net.IP, error) { return m.ip, nil }
+func (m *testNAT) String() string              { return "test" }
+
+func (m *testNAT) Mappings() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mappings := make(map[string]string, len(m.mappings))
+	for k, v := range m.mappings {
+		mappings[k] = v
+	}
+	return mappings
+}
+
+func TestP2pNetwork_MapPorts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	gateway := &testNAT{mappings: map[string]string{}}
+	n := &p2pNetwork{
+		ctx: ctx,
+		cfg: &Config{TCPPort: 13001, UDPPort: 12001, QUICPort: 14001},
+		nat: gateway,
+	}
+
+	n.mapPorts(zap.NewNop())
+	expected := map[string]string{
+		"TCP:13001": "ssv p2p",
+		"UDP:12001": "ssv discovery",
+		"UDP:14001": "ssv quic",
+	}
+	require.Eventually(t, func() bool {
+		return len(gateway.Mappings()) == len(expected)
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, expected, gateway.Mappings())
+
+	// Mappings are deleted once the network is closed.
+	cancel()
+	require.Eventually(t, func() bool {
+		return len(gateway.Mappings()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestP2pNetwork_ExternalIP(t *testing.T) {
+	n := &p2pNetwork{}
+	require.Nil(t, n.externalIP())
+
+	n.nat = &testNAT{ip: net.ParseIP("1.2.3.4")}
+	require.Equal(t, net.ParseIP("1.2.3.4"), n.externalIP())
+
+	// Private IPs of the router are ignored, unless configured.
+	n.nat = &testNAT{ip: net.ParseIP("192.168.1.1")}
+	require.Nil(t, n.externalIP())
+
+	n.nat = nat.ExtIP(net.ParseIP("192.168.1.1"))
+	require.Equal(t, net.ParseIP("192.168.1.1"), n.externalIP())
+}
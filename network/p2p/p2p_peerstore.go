@@ -0,0 +1,115 @@
+package p2pv1
+
+import (
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/network/peers"
+	"github.com/ssvlabs/ssv/network/records"
+)
+
+// peerStoreInterval is the interval at which the known peers and discovery nodes are persisted.
+const peerStoreInterval = 5 * time.Minute
+
+// saveKnownPeers persists the connected peers which completed the handshake and aren't bad,
+// along with the nodes known to discovery.
+func (n *p2pNetwork) saveKnownPeers(logger *zap.Logger) {
+	now := time.Now()
+	var known []peers.KnownPeer
+	for _, id := range n.host.Network().Peers() {
+		nodeInfo := n.idx.NodeInfo(id)
+		if nodeInfo == nil || nodeInfo.Metadata == nil || n.idx.IsBad(logger, id) {
+			continue
+		}
+		addrs := n.host.Peerstore().Addrs(id)
+		if len(addrs) == 0 {
+			continue
+		}
+		score, _ := n.idx.GetGossipScore(id)
+		known = append(known, peers.KnownPeer{
+			ID:       id,
+			Addrs:    addrs,
+			Subnets:  nodeInfo.Metadata.Subnets,
+			Score:    score,
+			LastSeen: now,
+		})
+	}
+	if err := n.peerStore.SavePeers(known); err != nil {
+		logger.Warn("could not save known peers", zap.Error(err))
+		return
+	}
+
+	nodes := n.disc.Nodes()
+	if err := n.peerStore.SaveNodes(nodes, now); err != nil {
+		logger.Warn("could not save known discovery nodes", zap.Error(err))
+		return
+	}
+	logger.Debug("saved known peers", zap.Int("peers", len(known)), zap.Int("nodes", len(nodes)))
+}
+
+// loadKnownPeers returns the best persisted peers to reconnect to, up to the peers limit,
+// and restores their subnets in the subnets index.
+func (n *p2pNetwork) loadKnownPeers(logger *zap.Logger) []peer.AddrInfo {
+	known, err := n.peerStore.Peers()
+	if err != nil {
+		logger.Warn("could not load known peers", zap.Error(err))
+		return nil
+	}
+
+	trusted := make(map[peer.ID]struct{}, len(n.trustedPeers))
+	for _, addrInfo := range n.trustedPeers {
+		trusted[addrInfo.ID] = struct{}{}
+	}
+	known = bestKnownPeers(known, n.cfg.MaxPeers)
+
+	addrInfos := make([]peer.AddrInfo, 0, len(known))
+	for _, p := range known {
+		if _, ok := trusted[p.ID]; ok || p.ID == n.host.ID() {
+			continue
+		}
+		if subnets, err := (records.Subnets{}).FromString(p.Subnets); err == nil {
+			n.idx.UpdatePeerSubnets(p.ID, subnets)
+		}
+		addrInfos = append(addrInfos, peer.AddrInfo{ID: p.ID, Addrs: p.Addrs})
+	}
+	logger.Debug("loaded known peers", zap.Int("peers", len(addrInfos)))
+	return addrInfos
+}
+
+// loadKnownNodes returns the ENRs of the persisted discovery nodes.
+func (n *p2pNetwork) loadKnownNodes(logger *zap.Logger) []string {
+	enrs, err := n.peerStore.Nodes()
+	if err != nil {
+		logger.Warn("could not load known discovery nodes", zap.Error(err))
+		return nil
+	}
+	return enrs
+}
+
+// bestKnownPeers returns up to limit of the given peers, ordered by their gossip score,
+// then by their number of subnets, and then by when they were last seen.
+func bestKnownPeers(known []peers.KnownPeer, limit int) []peers.KnownPeer {
+	activeSubnets := func(s string) int {
+		subnets, err := (records.Subnets{}).FromString(s)
+		if err != nil {
+			return 0
+		}
+		return subnets.Active()
+	}
+	sort.SliceStable(known, func(i, j int) bool {
+		if known[i].Score != known[j].Score {
+			return known[i].Score > known[j].Score
+		}
+		if si, sj := activeSubnets(known[i].Subnets), activeSubnets(known[j].Subnets); si != sj {
+			return si > sj
+		}
+		return known[i].LastSeen.After(known[j].LastSeen)
+	})
+	if len(known) > limit {
+		known = known[:limit]
+	}
+	return known
+}
@@ -0,0 +1,29 @@
+package p2pv1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/network/peers"
+)
+
+func TestBestKnownPeers(t *testing.T) {
+	now := time.Now()
+	known := []peers.KnownPeer{
+		{ID: "a", Score: 1, Subnets: "ffffffffffffffffffffffffffffffff", LastSeen: now},
+		{ID: "b", Score: 5, Subnets: "00000000000000000000000000000000", LastSeen: now},
+		{ID: "c", Score: 1, Subnets: "ffffffffffffffffffffffffffffffff", LastSeen: now.Add(-time.Hour)},
+		{ID: "d", Score: 1, Subnets: "0f000000000000000000000000000000", LastSeen: now},
+		{ID: "e", Score: -10, Subnets: "ffffffffffffffffffffffffffffffff", LastSeen: now},
+	}
+
+	best := bestKnownPeers(known, 4)
+	ids := make([]peer.ID, 0, len(best))
+	for _, p := range best {
+		ids = append(ids, p.ID)
+	}
+	require.Equal(t, []peer.ID{"b", "a", "c", "d"}, ids)
+}
@@ -232,6 +232,9 @@ func (n *p2pNetwork) handlePubsubMessages(logger *zap.Logger) func(ctx context.C
 		case *queue.SSVMessage:
 			decodedMsg = m
 			metricsRouterIncoming.WithLabelValues(message.MsgTypeToString(m.MsgType)).Inc()
+			if n.cfg.MessageObserver != nil && msg.ReceivedFrom != n.host.ID() {
+				n.cfg.MessageObserver(msg.ReceivedFrom, m)
+			}
 		case *genesisqueue.GenesisSSVMessage:
 			decodedMsg = m
 			metricsRouterIncoming.WithLabelValues(genesismessage.MsgTypeToString(m.MsgType)).Inc()
@@ -0,0 +1,87 @@
+//go:build !go1.23
+
+package p2pv1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/security/noise"
+	libp2pquic "github.com/libp2p/go-libp2p/p2p/transport/quic"
+	libp2ptcp "github.com/libp2p/go-libp2p/p2p/transport/tcp"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+)
+
+// TestP2pNetwork_QUIC only runs with the Go version the node is built with,
+// since quic-go v0.45 relies on the crypto/tls behavior of Go 1.22 and panics on handshakes with newer versions.
+func TestP2pNetwork_QUIC(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	logger := logging.TestLogger(t)
+	ln, err := CreateAndStartLocalNet(ctx, logger, LocalNetOptions{
+		Nodes:        2,
+		MinConnected: 1,
+		QUIC:         true,
+		IPv6:         true,
+	})
+	require.NoError(t, err)
+	defer func() {
+		for _, node := range ln.Nodes {
+			require.NoError(t, node.(*p2pNetwork).Close())
+		}
+	}()
+
+	// The nodes listen on TCP and QUIC over both IPv4 and IPv6.
+	node := ln.Nodes[0].(*p2pNetwork)
+	var listening []string
+	for _, addr := range node.host.Network().ListenAddresses() {
+		listening = append(listening, transportOf(t, addr))
+	}
+	require.Subset(t, listening, []string{"ip4/tcp", "ip4/quic-v1", "ip6/tcp", "ip6/quic-v1"})
+
+	// Other hosts can connect with each of them.
+	dialer, err := libp2p.New(
+		libp2p.NoListenAddrs,
+		libp2p.Transport(libp2ptcp.NewTCPTransport),
+		libp2p.Transport(libp2pquic.NewTransport),
+		libp2p.Security(noise.ID, noise.New),
+	)
+	require.NoError(t, err)
+	defer dialer.Close()
+
+	port := func(addr ma.Multiaddr, code int) string {
+		value, err := addr.ValueForProtocol(code)
+		require.NoError(t, err)
+		return value
+	}
+	for _, addr := range node.host.Network().ListenAddresses() {
+		var endpoint string
+		switch transportOf(t, addr) {
+		case "ip4/tcp":
+			endpoint = "/ip4/127.0.0.1/tcp/" + port(addr, ma.P_TCP)
+		case "ip4/quic-v1":
+			endpoint = "/ip4/127.0.0.1/udp/" + port(addr, ma.P_UDP) + "/quic-v1"
+		case "ip6/tcp":
+			endpoint = "/ip6/::1/tcp/" + port(addr, ma.P_TCP)
+		case "ip6/quic-v1":
+			endpoint = "/ip6/::1/udp/" + port(addr, ma.P_UDP) + "/quic-v1"
+		default:
+			continue
+		}
+		t.Run(endpoint, func(t *testing.T) {
+			require.NoError(t, dialer.Network().ClosePeer(node.host.ID()))
+			dialer.Peerstore().ClearAddrs(node.host.ID())
+			require.NoError(t, dialer.Connect(ctx, peer.AddrInfo{ID: node.host.ID(), Addrs: []ma.Multiaddr{ma.StringCast(endpoint)}}))
+			conns := dialer.Network().ConnsToPeer(node.host.ID())
+			require.NotEmpty(t, conns)
+			require.Equal(t, endpoint, conns[0].RemoteMultiaddr().String())
+		})
+	}
+}
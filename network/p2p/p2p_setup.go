@@ -9,6 +9,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/p2p/nat"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/network"
@@ -47,6 +48,8 @@ const (
 	connectTimeout = time.Minute
 	// connectorQueueSize is the buffer size of the channel used by the connector
 	connectorQueueSize = 256
+	// defaultPeerStoreTTL is the default expiry of the persisted peers and discovery nodes
+	defaultPeerStoreTTL = 24 * time.Hour
 )
 
 // Setup is used to setup the network
@@ -103,6 +106,23 @@ func (n *p2pNetwork) initCfg() error {
 	if n.cfg.TopicMaxPeers <= 0 {
 		n.cfg.TopicMaxPeers = minPeersBuffer / 2
 	}
+	// QUIC can't share the port of discovery: quic-go takes the packets whose first byte has one of its two
+	// high bits set as QUIC packets, while discv5 packets start with a random masking IV, so most of them
+	// would never reach discovery.
+	if n.cfg.QUICPort != 0 && n.cfg.QUICPort == n.cfg.UDPPort {
+		return fmt.Errorf("quic port %d is already used by discovery, which can't share it", n.cfg.QUICPort)
+	}
+	natInterface, err := nat.Parse(n.cfg.NAT)
+	if err != nil {
+		return fmt.Errorf("parse nat: %w", err)
+	}
+	n.nat = natInterface
+	if n.cfg.PeerStoreTTL == 0 {
+		n.cfg.PeerStoreTTL = defaultPeerStoreTTL
+	}
+	if n.cfg.DB != nil {
+		n.peerStore = peers.NewStore(n.cfg.DB, n.cfg.PeerStoreTTL)
+	}
 
 	return nil
 }
@@ -168,6 +188,7 @@ func (n *p2pNetwork) SetupServices(logger *zap.Logger) error {
 func (n *p2pNetwork) setupStreamCtrl(logger *zap.Logger) error {
 	n.streamCtrl = streams.NewStreamController(n.ctx, n.host, n.cfg.RequestTimeout, n.cfg.RequestTimeout)
 	logger.Debug("stream controller is ready")
+	n.setupSyncHandlers(logger)
 	return nil
 }
 
@@ -200,6 +221,7 @@ func (n *p2pNetwork) setupPeerServices(logger *zap.Logger) error {
 		}
 		ids.Start()
 	}
+	n.idService = ids
 
 	subnetsProvider := func() records.Subnets {
 		return n.activeSubnets
@@ -249,9 +271,27 @@ func (n *p2pNetwork) setupDiscovery(logger *zap.Logger) error {
 			BindIP:        net.IPv4zero.String(),
 			Port:          n.cfg.UDPPort,
 			TCPPort:       n.cfg.TCPPort,
+			QUICPort:      n.cfg.QUICPort,
 			NetworkKey:    n.cfg.NetworkPrivateKey,
 			Bootnodes:     n.cfg.TransformBootnodes(),
 			EnableLogging: n.cfg.DiscoveryTrace,
+			ExternalIP:    n.externalIP,
+		}
+		if n.peerStore != nil {
+			discV5Opts.KnownNodes = n.loadKnownNodes(logger)
+		}
+		if n.cfg.EnableIPv6 {
+			ip6, err := p2pcommons.IPv6Addr()
+			if err != nil {
+				return errors.Wrap(err, "could not get ipv6 addr")
+			}
+			if ip6 != nil {
+				discV5Opts.IP6 = ip6.String()
+			} else {
+				logger.Warn("discovery: no global IPv6 address found, advertising IPv4 only unless HostAddress has one")
+			}
+			// Listens on both IPv4 and IPv6.
+			discV5Opts.BindIP = net.IPv6zero.String()
 		}
 		if len(n.fixedSubnets) > 0 {
 			discV5Opts.Subnets = n.fixedSubnets
@@ -259,7 +299,8 @@ func (n *p2pNetwork) setupDiscovery(logger *zap.Logger) error {
 		}
 		logger.Info("discovery: using discv5",
 			zap.Strings("bootnodes", discV5Opts.Bootnodes),
-			zap.String("ip", discV5Opts.IP))
+			zap.String("ip", discV5Opts.IP),
+			zap.String("ip6", discV5Opts.IP6))
 	} else {
 		logger.Info("discovery: using mdns (local)")
 	}
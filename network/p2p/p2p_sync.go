@@ -0,0 +1,209 @@
+package p2pv1
+
+import (
+	"math/rand"
+	"sync"
+
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/pkg/errors"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/network/commons"
+	"github.com/ssvlabs/ssv/network/syncing"
+	p2pprotocol "github.com/ssvlabs/ssv/protocol/v2/p2p"
+)
+
+// LastDecided fetches the highest decided message of the given identifier from peers
+func (n *p2pNetwork) LastDecided(logger *zap.Logger, mid spectypes.MessageID) (p2pprotocol.SyncResults, error) {
+	if !n.isReady() {
+		return nil, p2pprotocol.ErrNetworkIsNotReady
+	}
+
+	protocolID, peerCount := commons.ProtocolID(p2pprotocol.LastDecidedProtocol)
+	peers := n.syncPeers(protocolID, peerCount)
+	if len(peers) == 0 {
+		return nil, errors.New("no peers to sync with")
+	}
+
+	req := &p2pprotocol.SyncMessage{
+		Protocol: p2pprotocol.LastDecidedProtocol,
+		Params: &p2pprotocol.SyncParams{
+			Identifier: mid,
+		},
+	}
+
+	var (
+		results p2pprotocol.SyncResults
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+	)
+	for _, pid := range peers {
+		wg.Add(1)
+		go func(pid peer.ID) {
+			defer wg.Done()
+
+			res, err := n.syncRequest(logger, pid, protocolID, req)
+			if err != nil {
+				logger.Debug("could not fetch highest decided", fields.PeerID(pid), zap.Error(err))
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, msg := range res.Data {
+				results = append(results, p2pprotocol.SyncResult{Msg: msg, Sender: pid.String()})
+			}
+		}(pid)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// GetHistory fetches the decided messages of the given identifier in the given height range
+// from the first peer which serves it, the given targets are preferred over other peers
+func (n *p2pNetwork) GetHistory(logger *zap.Logger, mid spectypes.MessageID, from, to specqbft.Height, targets ...string) (p2pprotocol.SyncResults, error) {
+	if !n.isReady() {
+		return nil, p2pprotocol.ErrNetworkIsNotReady
+	}
+
+	protocolID, peerCount := commons.ProtocolID(p2pprotocol.DecidedHistoryProtocol)
+	var peers []peer.ID
+	for _, target := range targets {
+		pid, err := peer.Decode(target)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode target peer")
+		}
+		peers = append(peers, pid)
+	}
+	peers = append(peers, n.syncPeers(protocolID, peerCount)...)
+	if len(peers) == 0 {
+		return nil, errors.New("no peers to sync with")
+	}
+
+	req := &p2pprotocol.SyncMessage{
+		Protocol: p2pprotocol.DecidedHistoryProtocol,
+		Params: &p2pprotocol.SyncParams{
+			Identifier: mid,
+			Height:     []specqbft.Height{from, to},
+		},
+	}
+
+	for _, pid := range peers {
+		res, err := n.syncRequest(logger, pid, protocolID, req)
+		if err != nil {
+			logger.Debug("could not fetch decided history", fields.PeerID(pid), zap.Error(err))
+			continue
+		}
+
+		results := make(p2pprotocol.SyncResults, 0, len(res.Data))
+		for _, msg := range res.Data {
+			results = append(results, p2pprotocol.SyncResult{Msg: msg, Sender: pid.String()})
+		}
+		return results, nil
+	}
+
+	return nil, errors.New("no peer served the decided history")
+}
+
+// syncRequest sends the given sync request to the given peer,
+// responses with a status other than success or not found are returned as errors
+func (n *p2pNetwork) syncRequest(logger *zap.Logger, pid peer.ID, protocolID protocol.ID, req *p2pprotocol.SyncMessage) (*p2pprotocol.SyncMessage, error) {
+	data, err := req.Encode()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode sync request")
+	}
+
+	raw, err := n.streamCtrl.Request(logger, pid, protocolID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &p2pprotocol.SyncMessage{}
+	if err := res.Decode(raw); err != nil {
+		return nil, errors.Wrap(err, "could not decode sync response")
+	}
+
+	switch res.Status {
+	case p2pprotocol.StatusSuccess, p2pprotocol.StatusNotFound:
+		return res, nil
+	default:
+		return nil, errors.Errorf("sync request failed with status %d", res.Status)
+	}
+}
+
+// syncPeers returns up to the given amount of random connected peers which support the given protocol
+func (n *p2pNetwork) syncPeers(protocolID protocol.ID, count int) []peer.ID {
+	var peers []peer.ID
+	for _, pid := range n.host.Network().Peers() {
+		supported, err := n.host.Peerstore().SupportsProtocols(pid, protocolID)
+		if err != nil || len(supported) == 0 {
+			continue
+		}
+		peers = append(peers, pid)
+	}
+
+	rand.Shuffle(len(peers), func(i, j int) {
+		peers[i], peers[j] = peers[j], peers[i]
+	})
+	if len(peers) > count {
+		peers = peers[:count]
+	}
+	return peers
+}
+
+// setupSyncHandlers registers the stream handlers serving the decided messages stored by this node
+func (n *p2pNetwork) setupSyncHandlers(logger *zap.Logger) {
+	if n.cfg.DecidedStores == nil {
+		return
+	}
+
+	opts := []syncing.ServerOption{
+		syncing.WithMaxBatch(n.cfg.MaxBatchResponse),
+	}
+	if n.cfg.FullNode {
+		opts = append(opts, syncing.WithServerFullNode())
+	}
+	server := syncing.NewServer(logger, n.cfg.DecidedStores, opts...)
+
+	for _, prot := range server.Protocols() {
+		protocolID, _ := commons.ProtocolID(prot)
+		n.host.SetStreamHandler(protocolID, n.handleSyncStream(logger, server))
+	}
+	logger.Debug("sync handlers are ready", zap.Bool("full_node", n.cfg.FullNode))
+}
+
+// handleSyncStream reads a sync request from the stream and writes the response of the given server
+func (n *p2pNetwork) handleSyncStream(logger *zap.Logger, server *syncing.Server) libp2pnetwork.StreamHandler {
+	return func(stream libp2pnetwork.Stream) {
+		data, respond, done, err := n.streamCtrl.HandleStream(logger, stream)
+		defer done()
+		if err != nil {
+			logger.Debug("could not handle sync stream", zap.Error(err))
+			return
+		}
+
+		pid := stream.Conn().RemotePeer()
+		req := &p2pprotocol.SyncMessage{}
+		var res *p2pprotocol.SyncMessage
+		if err := req.Decode(data); err != nil {
+			res = &p2pprotocol.SyncMessage{Status: p2pprotocol.StatusBadRequest}
+		} else {
+			res = server.Handle(pid, req)
+		}
+
+		resData, err := res.Encode()
+		if err != nil {
+			logger.Debug("could not encode sync response", fields.PeerID(pid), zap.Error(err))
+			return
+		}
+		if err := respond(resData); err != nil {
+			logger.Debug("could not respond to sync request", fields.PeerID(pid), zap.Error(err))
+		}
+	}
+}
@@ -321,3 +321,11 @@ func createNetworkAndSubscribe(t *testing.T, ctx context.Context, options LocalN
 
 	return ln, routers, nil
 }
+
+func TestP2pNetwork_QUICPortSharedWithDiscovery(t *testing.T) {
+	n := &p2pNetwork{cfg: &Config{UDPPort: 12001, QUICPort: 12001}}
+	require.ErrorContains(t, n.initCfg(), "already used by discovery")
+
+	n = &p2pNetwork{cfg: &Config{UDPPort: 12001, QUICPort: 13001}}
+	require.NoError(t, n.initCfg())
+}
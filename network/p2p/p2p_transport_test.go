@@ -0,0 +1,83 @@
+package p2pv1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/security/noise"
+	libp2ptcp "github.com/libp2p/go-libp2p/p2p/transport/tcp"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+)
+
+func TestP2pNetwork_IPv6(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	logger := logging.TestLogger(t)
+	ln, err := CreateAndStartLocalNet(ctx, logger, LocalNetOptions{
+		Nodes:        2,
+		MinConnected: 1,
+		IPv6:         true,
+	})
+	require.NoError(t, err)
+	defer func() {
+		for _, node := range ln.Nodes {
+			require.NoError(t, node.(*p2pNetwork).Close())
+		}
+	}()
+
+	// The nodes listen on both IPv4 and IPv6.
+	node := ln.Nodes[0].(*p2pNetwork)
+	var listening []string
+	for _, addr := range node.host.Network().ListenAddresses() {
+		listening = append(listening, transportOf(t, addr))
+	}
+	require.Subset(t, listening, []string{"ip4/tcp", "ip6/tcp"})
+
+	// Other hosts can connect with each of them.
+	dialer, err := libp2p.New(
+		libp2p.NoListenAddrs,
+		libp2p.Transport(libp2ptcp.NewTCPTransport),
+		libp2p.Security(noise.ID, noise.New),
+	)
+	require.NoError(t, err)
+	defer dialer.Close()
+
+	port := func(addr ma.Multiaddr, code int) string {
+		value, err := addr.ValueForProtocol(code)
+		require.NoError(t, err)
+		return value
+	}
+	for _, addr := range node.host.Network().ListenAddresses() {
+		var endpoint string
+		switch transportOf(t, addr) {
+		case "ip4/tcp":
+			endpoint = "/ip4/127.0.0.1/tcp/" + port(addr, ma.P_TCP)
+		case "ip6/tcp":
+			endpoint = "/ip6/::1/tcp/" + port(addr, ma.P_TCP)
+		default:
+			continue
+		}
+		t.Run(endpoint, func(t *testing.T) {
+			require.NoError(t, dialer.Network().ClosePeer(node.host.ID()))
+			dialer.Peerstore().ClearAddrs(node.host.ID())
+			require.NoError(t, dialer.Connect(ctx, peer.AddrInfo{ID: node.host.ID(), Addrs: []ma.Multiaddr{ma.StringCast(endpoint)}}))
+			conns := dialer.Network().ConnsToPeer(node.host.ID())
+			require.NotEmpty(t, conns)
+			require.Equal(t, endpoint, conns[0].RemoteMultiaddr().String())
+		})
+	}
+}
+
+// transportOf returns the IP version and transport of a listen address, such as ip4/quic-v1.
+func transportOf(t *testing.T, addr ma.Multiaddr) string {
+	protocols := addr.Protocols()
+	require.NotEmpty(t, protocols)
+	return protocols[0].Name + "/" + protocols[len(protocols)-1].Name
+}
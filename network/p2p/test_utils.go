@@ -188,6 +188,10 @@ func (ln *LocalNet) NewTestP2pNetwork(ctx context.Context, nodeIndex uint64, key
 		signatureVerifier,
 	)
 	cfg.Network = networkconfig.TestNetwork
+	if options.QUIC {
+		cfg.QUICPort = ln.udpRand.Next(14001, 14999)
+	}
+	cfg.EnableIPv6 = options.IPv6
 	if options.TotalValidators > 0 {
 		cfg.GetValidatorStats = func() (uint64, uint64, uint64, error) {
 			return options.TotalValidators, options.ActiveValidators, options.MyValidators, nil
@@ -245,6 +249,10 @@ type LocalNetOptions struct {
 	PeerScoreInspector                              func(selfPeer peer.ID, peerMap map[peer.ID]*pubsub.PeerScoreSnapshot)
 	PeerScoreInspectorInterval                      time.Duration
 	Shares                                          []*ssvtypes.SSVShare
+	// QUIC enables the QUIC transport in addition to TCP.
+	QUIC bool
+	// IPv6 listens on IPv6 in addition to IPv4.
+	IPv6 bool
 }
 
 // NewLocalNet creates a new mdns network
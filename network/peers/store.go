@@ -0,0 +1,156 @@
+package peers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+var (
+	knownPeersPrefix = []byte("p2p-peers/")
+	knownNodesPrefix = []byte("p2p-nodes/")
+)
+
+// KnownPeer is a peer the node was connected to, persisted to reconnect to it after a restart.
+type KnownPeer struct {
+	ID    peer.ID
+	Addrs []ma.Multiaddr
+	// Subnets is the subnets of the peer from its records.NodeInfo.
+	Subnets string
+	// Score is the last gossip score of the peer.
+	Score    float64
+	LastSeen time.Time
+}
+
+type knownPeerJSON struct {
+	Addrs    []string  `json:"addrs"`
+	Subnets  string    `json:"subnets"`
+	Score    float64   `json:"score"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+type knownNodeJSON struct {
+	ENR      string    `json:"enr"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// Store persists the known peers and discovery nodes of the node,
+// which expire after the TTL since they were last seen.
+type Store struct {
+	db  basedb.Database
+	ttl time.Duration
+}
+
+func NewStore(db basedb.Database, ttl time.Duration) *Store {
+	return &Store{db: db, ttl: ttl}
+}
+
+// SavePeers adds or updates the given peers.
+func (s *Store) SavePeers(peers []KnownPeer) error {
+	return s.db.SetMany(knownPeersPrefix, len(peers), func(i int) (basedb.Obj, error) {
+		p := peers[i]
+		value := knownPeerJSON{
+			Addrs:    make([]string, 0, len(p.Addrs)),
+			Subnets:  p.Subnets,
+			Score:    p.Score,
+			LastSeen: p.LastSeen,
+		}
+		for _, addr := range p.Addrs {
+			value.Addrs = append(value.Addrs, addr.String())
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return basedb.Obj{}, fmt.Errorf("marshal peer %s: %w", p.ID, err)
+		}
+		return basedb.Obj{Key: []byte(p.ID), Value: b}, nil
+	})
+}
+
+// Peers returns the peers which haven't expired, and deletes the expired ones.
+func (s *Store) Peers() ([]KnownPeer, error) {
+	var peers []KnownPeer
+	err := s.loadAll(knownPeersPrefix, func(key []byte, value []byte) (time.Time, error) {
+		var p knownPeerJSON
+		if err := json.Unmarshal(value, &p); err != nil {
+			return time.Time{}, err
+		}
+		if s.expired(p.LastSeen) {
+			return p.LastSeen, nil
+		}
+		knownPeer := KnownPeer{
+			ID:       peer.ID(key),
+			Subnets:  p.Subnets,
+			Score:    p.Score,
+			LastSeen: p.LastSeen,
+		}
+		for _, addr := range p.Addrs {
+			maddr, err := ma.NewMultiaddr(addr)
+			if err != nil {
+				return time.Time{}, err
+			}
+			knownPeer.Addrs = append(knownPeer.Addrs, maddr)
+		}
+		peers = append(peers, knownPeer)
+		return p.LastSeen, nil
+	})
+	return peers, err
+}
+
+// SaveNodes adds or updates the given discovery nodes, as seen at the given time.
+func (s *Store) SaveNodes(nodes []*enode.Node, lastSeen time.Time) error {
+	return s.db.SetMany(knownNodesPrefix, len(nodes), func(i int) (basedb.Obj, error) {
+		b, err := json.Marshal(knownNodeJSON{ENR: nodes[i].String(), LastSeen: lastSeen})
+		if err != nil {
+			return basedb.Obj{}, fmt.Errorf("marshal node %s: %w", nodes[i].ID(), err)
+		}
+		return basedb.Obj{Key: nodes[i].ID().Bytes(), Value: b}, nil
+	})
+}
+
+// Nodes returns the ENRs of the discovery nodes which haven't expired, and deletes the expired ones.
+func (s *Store) Nodes() ([]string, error) {
+	var enrs []string
+	err := s.loadAll(knownNodesPrefix, func(key []byte, value []byte) (time.Time, error) {
+		var n knownNodeJSON
+		if err := json.Unmarshal(value, &n); err != nil {
+			return time.Time{}, err
+		}
+		if !s.expired(n.LastSeen) {
+			enrs = append(enrs, n.ENR)
+		}
+		return n.LastSeen, nil
+	})
+	return enrs, err
+}
+
+// loadAll calls load with every entry of the prefix, and deletes the entries
+// which expired or can't be loaded.
+func (s *Store) loadAll(prefix []byte, load func(key []byte, value []byte) (lastSeen time.Time, err error)) error {
+	var stale [][]byte
+	err := s.db.GetAll(prefix, func(_ int, obj basedb.Obj) error {
+		lastSeen, err := load(obj.Key, obj.Value)
+		if err != nil || s.expired(lastSeen) {
+			stale = append(stale, obj.Key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range stale {
+		if err := s.db.Delete(prefix, key); err != nil {
+			return fmt.Errorf("delete %x: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) expired(lastSeen time.Time) bool {
+	return time.Since(lastSeen) > s.ttl
+}
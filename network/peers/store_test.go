@@ -0,0 +1,106 @@
+package peers
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/network/commons"
+	nettesting "github.com/ssvlabs/ssv/network/testing"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestStore_Peers(t *testing.T) {
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	nks, err := nettesting.CreateKeys(2)
+	require.NoError(t, err)
+	var pids []peer.ID
+	for _, nk := range nks {
+		sk, err := commons.ECDSAPrivToInterface(nk.NetKey)
+		require.NoError(t, err)
+		pid, err := peer.IDFromPrivateKey(sk)
+		require.NoError(t, err)
+		pids = append(pids, pid)
+	}
+
+	store := NewStore(db, time.Hour)
+	known := []KnownPeer{
+		{
+			ID:       pids[0],
+			Addrs:    []ma.Multiaddr{ma.StringCast("/ip4/1.2.3.4/tcp/13001"), ma.StringCast("/ip4/1.2.3.4/udp/13001/quic-v1")},
+			Subnets:  "ffffffffffffffffffffffffffffffff",
+			Score:    12.5,
+			LastSeen: time.Now().Add(-time.Minute).Round(0),
+		},
+		{
+			ID:       pids[1],
+			Addrs:    []ma.Multiaddr{ma.StringCast("/ip4/5.6.7.8/tcp/13001")},
+			Subnets:  "00000000000000000000000000000000",
+			LastSeen: time.Now().Add(-2 * time.Hour).Round(0),
+		},
+	}
+	require.NoError(t, store.SavePeers(known))
+
+	// The expired peer is deleted.
+	loaded, err := store.Peers()
+	require.NoError(t, err)
+	require.Len(t, loaded, 1)
+	require.Equal(t, known[0].ID, loaded[0].ID)
+	require.Equal(t, known[0].Addrs, loaded[0].Addrs)
+	require.Equal(t, known[0].Subnets, loaded[0].Subnets)
+	require.Equal(t, known[0].Score, loaded[0].Score)
+	require.True(t, known[0].LastSeen.Equal(loaded[0].LastSeen))
+
+	count, err := db.CountPrefix(knownPeersPrefix)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, count)
+
+	// Saving a peer again updates it.
+	known[0].Score = -3
+	require.NoError(t, store.SavePeers(known[:1]))
+	loaded, err = store.Peers()
+	require.NoError(t, err)
+	require.Len(t, loaded, 1)
+	require.Equal(t, float64(-3), loaded[0].Score)
+}
+
+func TestStore_Nodes(t *testing.T) {
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	var nodes []*enode.Node
+	for i := 0; i < 3; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		nodeDB, err := enode.OpenDB("")
+		require.NoError(t, err)
+		defer nodeDB.Close()
+		localNode := enode.NewLocalNode(nodeDB, key)
+		localNode.SetStaticIP(net.IPv4(1, 2, 3, byte(i)))
+		nodes = append(nodes, localNode.Node())
+	}
+
+	store := NewStore(db, time.Hour)
+	require.NoError(t, store.SaveNodes(nodes[:2], time.Now()))
+	require.NoError(t, store.SaveNodes(nodes[2:], time.Now().Add(-2*time.Hour)))
+
+	enrs, err := store.Nodes()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{nodes[0].String(), nodes[1].String()}, enrs)
+
+	count, err := db.CountPrefix(knownNodesPrefix)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+}
@@ -0,0 +1,190 @@
+package syncing
+
+import (
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/libp2p/go-libp2p/core/peer"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging/fields"
+	protocolp2p "github.com/ssvlabs/ssv/protocol/v2/p2p"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+)
+
+const (
+	// DefaultRequestsPerSecond is the default rate of sync requests allowed per peer.
+	DefaultRequestsPerSecond = 5
+	// DefaultRequestsBurst is the default burst of sync requests allowed per peer.
+	DefaultRequestsBurst = 10
+	// DefaultMaxBatch is the default maximum number of heights served in a single history response.
+	DefaultMaxBatch = 25
+
+	// limiterTTL is how long the rate limiter of an idle peer is kept.
+	limiterTTL = 10 * time.Minute
+)
+
+// Server serves the decided messages stored locally to peers.
+// The highest decided message is served by every node, while
+// the decided history is served only by full nodes.
+type Server struct {
+	logger   *zap.Logger
+	stores   *storage.QBFTStores
+	fullNode bool
+	maxBatch uint64
+
+	limit    rate.Limit
+	burst    int
+	limiters *ttlcache.Cache[peer.ID, *rate.Limiter]
+}
+
+// ServerOption defines Server configuration option.
+type ServerOption func(*Server)
+
+// WithServerFullNode enables serving the decided history.
+func WithServerFullNode() ServerOption {
+	return func(s *Server) {
+		s.fullNode = true
+	}
+}
+
+// WithMaxBatch sets the maximum number of heights served in a single history response.
+func WithMaxBatch(maxBatch uint64) ServerOption {
+	return func(s *Server) {
+		if maxBatch > 0 {
+			s.maxBatch = maxBatch
+		}
+	}
+}
+
+// WithRateLimit sets the rate and burst of sync requests allowed per peer.
+func WithRateLimit(requestsPerSecond float64, burst int) ServerOption {
+	return func(s *Server) {
+		s.limit = rate.Limit(requestsPerSecond)
+		s.burst = burst
+	}
+}
+
+// NewServer creates a new Server serving decided messages from the given stores.
+func NewServer(logger *zap.Logger, stores *storage.QBFTStores, opts ...ServerOption) *Server {
+	s := &Server{
+		logger:   logger,
+		stores:   stores,
+		maxBatch: DefaultMaxBatch,
+		limit:    DefaultRequestsPerSecond,
+		burst:    DefaultRequestsBurst,
+		limiters: ttlcache.New(
+			ttlcache.WithTTL[peer.ID, *rate.Limiter](limiterTTL),
+		),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	go s.limiters.Start()
+	return s
+}
+
+// Protocols returns the sync protocols served by this node.
+func (s *Server) Protocols() []protocolp2p.SyncProtocol {
+	if s.fullNode {
+		return []protocolp2p.SyncProtocol{protocolp2p.LastDecidedProtocol, protocolp2p.DecidedHistoryProtocol}
+	}
+	return []protocolp2p.SyncProtocol{protocolp2p.LastDecidedProtocol}
+}
+
+// Handle serves the given request of the given peer.
+func (s *Server) Handle(peerID peer.ID, req *protocolp2p.SyncMessage) *protocolp2p.SyncMessage {
+	res := &protocolp2p.SyncMessage{
+		Protocol: req.Protocol,
+		Params:   req.Params,
+	}
+
+	if !s.allow(peerID) {
+		res.Status = protocolp2p.StatusRateLimited
+		return res
+	}
+
+	if req.Params == nil {
+		res.Status = protocolp2p.StatusBadRequest
+		return res
+	}
+
+	store := s.stores.Get(convert.RunnerRole(req.Params.Identifier.GetRoleType()))
+	if store == nil {
+		res.Status = protocolp2p.StatusBadRequest
+		return res
+	}
+
+	logger := s.logger.With(
+		fields.PeerID(peerID),
+		fields.MessageID(req.Params.Identifier),
+	)
+
+	var err error
+	switch req.Protocol {
+	case protocolp2p.LastDecidedProtocol:
+		res.Data, err = s.highestDecided(store, req.Params.Identifier)
+	case protocolp2p.DecidedHistoryProtocol:
+		if !s.fullNode || len(req.Params.Height) != 2 || req.Params.Height[0] > req.Params.Height[1] {
+			res.Status = protocolp2p.StatusBadRequest
+			return res
+		}
+		res.Data, err = s.decidedHistory(store, req.Params.Identifier, req.Params.Height[0], req.Params.Height[1])
+	default:
+		res.Status = protocolp2p.StatusBadRequest
+		return res
+	}
+	if err != nil {
+		logger.Debug("could not serve sync request", zap.Error(err))
+		res.Status = protocolp2p.StatusInternalError
+		return res
+	}
+
+	if len(res.Data) == 0 {
+		res.Status = protocolp2p.StatusNotFound
+	} else {
+		res.Status = protocolp2p.StatusSuccess
+	}
+	return res
+}
+
+func (s *Server) highestDecided(store qbftstorage.QBFTStore, mid spectypes.MessageID) ([]*spectypes.SignedSSVMessage, error) {
+	instance, err := store.GetHighestInstance(mid[:])
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil || instance.DecidedMessage == nil {
+		return nil, nil
+	}
+	return []*spectypes.SignedSSVMessage{instance.DecidedMessage}, nil
+}
+
+func (s *Server) decidedHistory(store qbftstorage.QBFTStore, mid spectypes.MessageID, from, to specqbft.Height) ([]*spectypes.SignedSSVMessage, error) {
+	if uint64(to-from) >= s.maxBatch {
+		to = from + specqbft.Height(s.maxBatch) - 1
+	}
+
+	instances, err := store.GetInstancesInRange(mid[:], from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	decided := make([]*spectypes.SignedSSVMessage, 0, len(instances))
+	for _, instance := range instances {
+		if instance.DecidedMessage != nil {
+			decided = append(decided, instance.DecidedMessage)
+		}
+	}
+	return decided, nil
+}
+
+// allow reports whether the given peer is within its rate limit.
+func (s *Server) allow(peerID peer.ID) bool {
+	item, _ := s.limiters.GetOrSet(peerID, rate.NewLimiter(s.limit, s.burst))
+	return item.Value().Allow()
+}
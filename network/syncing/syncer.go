@@ -0,0 +1,370 @@
+package syncing
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging/fields"
+	protocolp2p "github.com/ssvlabs/ssv/protocol/v2/p2p"
+	"github.com/ssvlabs/ssv/protocol/v2/qbft"
+	"github.com/ssvlabs/ssv/protocol/v2/qbft/controller"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// DefaultMaxBackfillRange is the default maximum number of heights backfilled per identifier,
+// counting back from the highest decided height known to peers.
+const DefaultMaxBackfillRange = 7200
+
+// OperatorStore provides the operators' public keys for verifying committee signatures.
+type OperatorStore interface {
+	GetOperatorData(r basedb.Reader, id spectypes.OperatorID) (*registrystorage.OperatorData, bool, error)
+}
+
+// Options contains options to create the Syncer.
+type Options struct {
+	// Network fetches decided messages from peers.
+	Network protocolp2p.Syncer
+	// Stores are the stores in which the validated decided messages are saved.
+	Stores *storage.QBFTStores
+	// ValidatorStore provides the committees of the synced identifiers.
+	ValidatorStore registrystorage.BaseValidatorStore
+	// Operators provides the public keys of the committee members.
+	Operators OperatorStore
+	// OperatorID returns the ID of this operator.
+	OperatorID func() spectypes.OperatorID
+	// FullNode determines whether the decided history is synced, otherwise only the highest decided is.
+	FullNode bool
+	// BatchSize is the number of heights requested in a single history request.
+	BatchSize int
+	// MaxBackfillRange is the maximum number of heights backfilled per identifier.
+	MaxBackfillRange uint64
+}
+
+// Syncer fetches decided messages from peers, validates them against
+// the signatures of their committee and saves them to the local storage.
+type Syncer struct {
+	logger *zap.Logger
+	opts   Options
+}
+
+// New creates a new Syncer.
+func New(logger *zap.Logger, opts Options) *Syncer {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultMaxBatch
+	}
+	if opts.MaxBackfillRange == 0 {
+		opts.MaxBackfillRange = DefaultMaxBackfillRange
+	}
+	return &Syncer{
+		logger: logger,
+		opts:   opts,
+	}
+}
+
+// SyncHighestDecided fetches the highest decided message of the given identifier from peers
+// and saves it if it's higher than the locally stored one.
+// It returns the highest valid decided height returned by peers, if any.
+func (s *Syncer) SyncHighestDecided(logger *zap.Logger, mid spectypes.MessageID) (specqbft.Height, bool, error) {
+	store, err := s.store(mid)
+	if err != nil {
+		return 0, false, err
+	}
+
+	results, err := s.opts.Network.LastDecided(logger, mid)
+	if err != nil {
+		return 0, false, fmt.Errorf("could not fetch highest decided: %w", err)
+	}
+
+	var highest *specqbft.ProcessingMessage
+	var highestMember *spectypes.CommitteeMember
+	for _, result := range results {
+		msg, member, err := s.validateDecided(mid, result.Msg)
+		if err != nil {
+			logger.Debug("received invalid highest decided",
+				zap.String("sender", result.Sender),
+				zap.Error(err))
+			continue
+		}
+		if highest == nil || msg.QBFTMessage.Height > highest.QBFTMessage.Height {
+			highest, highestMember = msg, member
+		}
+	}
+	if highest == nil {
+		return 0, false, nil
+	}
+
+	local, err := store.GetHighestInstance(mid[:])
+	if err != nil {
+		return 0, false, fmt.Errorf("could not get local highest instance: %w", err)
+	}
+	if local != nil && local.State.Height >= highest.QBFTMessage.Height {
+		return highest.QBFTMessage.Height, true, nil
+	}
+
+	if err := s.save(store, mid, highest, highestMember, true); err != nil {
+		return 0, false, err
+	}
+	logger.Debug("synced highest decided", fields.Height(highest.QBFTMessage.Height))
+
+	return highest.QBFTMessage.Height, true, nil
+}
+
+// SyncDecidedByRange fetches the decided messages of the given identifier in the given
+// height range from peers and saves them. It's a no-op unless running as a full node.
+func (s *Syncer) SyncDecidedByRange(ctx context.Context, logger *zap.Logger, mid spectypes.MessageID, from, to specqbft.Height) error {
+	if !s.opts.FullNode {
+		return nil
+	}
+
+	store, err := s.store(mid)
+	if err != nil {
+		return err
+	}
+
+	local, err := store.GetHighestInstance(mid[:])
+	if err != nil {
+		return fmt.Errorf("could not get local highest instance: %w", err)
+	}
+
+	synced := 0
+	for batchFrom := from; batchFrom <= to; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		batchTo := batchFrom + specqbft.Height(s.opts.BatchSize) - 1
+		if batchTo > to || batchTo < batchFrom {
+			batchTo = to
+		}
+
+		results, err := s.opts.Network.GetHistory(logger, mid, batchFrom, batchTo)
+		if err != nil {
+			return fmt.Errorf("could not fetch decided history (%d-%d): %w", batchFrom, batchTo, err)
+		}
+
+		for _, result := range results {
+			msg, member, err := s.validateDecided(mid, result.Msg)
+			if err != nil {
+				logger.Debug("received invalid decided",
+					zap.String("sender", result.Sender),
+					zap.Error(err))
+				continue
+			}
+			if msg.QBFTMessage.Height < batchFrom || msg.QBFTMessage.Height > batchTo {
+				logger.Debug("received decided out of the requested range",
+					zap.String("sender", result.Sender),
+					fields.Height(msg.QBFTMessage.Height))
+				continue
+			}
+
+			asHighest := local == nil || msg.QBFTMessage.Height > local.State.Height
+			if err := s.save(store, mid, msg, member, asHighest); err != nil {
+				return err
+			}
+			synced++
+		}
+
+		if batchTo == to {
+			break
+		}
+		batchFrom = batchTo + 1
+	}
+
+	logger.Debug("synced decided history",
+		zap.Uint64("from", uint64(from)),
+		zap.Uint64("to", uint64(to)),
+		fields.Count(synced))
+
+	return nil
+}
+
+// Backfill syncs the highest decided of the given identifiers and, when running as a full node,
+// the decided history since the locally stored highest decided.
+func (s *Syncer) Backfill(ctx context.Context, mids ...spectypes.MessageID) {
+	for _, mid := range mids {
+		if ctx.Err() != nil {
+			return
+		}
+		logger := s.logger.With(fields.MessageID(mid))
+
+		store, err := s.store(mid)
+		if err != nil {
+			logger.Debug("could not backfill decided", zap.Error(err))
+			continue
+		}
+
+		// The local highest must be read before syncing, as syncing replaces it.
+		local, err := store.GetHighestInstance(mid[:])
+		if err != nil {
+			logger.Debug("could not get local highest instance", zap.Error(err))
+			continue
+		}
+
+		highest, found, err := s.SyncHighestDecided(logger, mid)
+		if err != nil {
+			logger.Debug("could not sync highest decided", zap.Error(err))
+			continue
+		}
+		// Without a local instance there is no gap to backfill.
+		if !found || local == nil || highest <= local.State.Height+1 {
+			continue
+		}
+
+		from := local.State.Height + 1
+		if uint64(highest-from) > s.opts.MaxBackfillRange {
+			from = highest - specqbft.Height(s.opts.MaxBackfillRange)
+		}
+		if err := s.SyncDecidedByRange(ctx, logger, mid, from, highest-1); err != nil {
+			logger.Debug("could not sync decided history", zap.Error(err))
+		}
+	}
+}
+
+// validateDecided checks that the given message is a decided message of the given identifier
+// which is signed by a quorum of its committee.
+func (s *Syncer) validateDecided(mid spectypes.MessageID, msg *spectypes.SignedSSVMessage) (*specqbft.ProcessingMessage, *spectypes.CommitteeMember, error) {
+	if msg == nil || msg.SSVMessage == nil {
+		return nil, nil, fmt.Errorf("empty message")
+	}
+	if msg.SSVMessage.MsgType != spectypes.SSVConsensusMsgType {
+		return nil, nil, fmt.Errorf("not a consensus message")
+	}
+	if msg.SSVMessage.MsgID != mid {
+		return nil, nil, fmt.Errorf("message ID mismatch")
+	}
+
+	processingMsg, err := specqbft.NewProcessingMessage(msg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not decode consensus message: %w", err)
+	}
+	if !bytes.Equal(processingMsg.QBFTMessage.Identifier, mid[:]) {
+		return nil, nil, fmt.Errorf("identifier mismatch")
+	}
+
+	member, err := s.committeeMember(mid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := &qbft.Config{Domain: member.DomainType}
+	if err := controller.ValidateDecided(config, processingMsg, member); err != nil {
+		return nil, nil, err
+	}
+
+	return processingMsg, member, nil
+}
+
+// committeeMember returns the committee which decides the instances of the given identifier.
+func (s *Syncer) committeeMember(mid spectypes.MessageID) (*spectypes.CommitteeMember, error) {
+	var operatorIDs []spectypes.OperatorID
+	if mid.GetRoleType() == spectypes.RoleCommittee {
+		var committeeID spectypes.CommitteeID
+		copy(committeeID[:], mid.GetDutyExecutorID()[16:])
+		committee, found := s.opts.ValidatorStore.Committee(committeeID)
+		if !found {
+			return nil, fmt.Errorf("committee not found")
+		}
+		operatorIDs = slices.Clone(committee.Operators)
+	} else {
+		share, found := s.opts.ValidatorStore.Validator(mid.GetDutyExecutorID())
+		if !found {
+			return nil, fmt.Errorf("validator not found")
+		}
+		for _, member := range share.Committee {
+			operatorIDs = append(operatorIDs, member.Signer)
+		}
+	}
+
+	member := &spectypes.CommitteeMember{
+		OperatorID:  s.opts.OperatorID(),
+		CommitteeID: spectypes.GetCommitteeID(slices.Clone(operatorIDs)),
+		FaultyNodes: ssvtypes.ComputeF(uint64(len(operatorIDs))),
+		Committee:   make([]*spectypes.Operator, 0, len(operatorIDs)),
+	}
+	copy(member.DomainType[:], mid.GetDomain())
+
+	for _, operatorID := range operatorIDs {
+		operatorData, found, err := s.opts.Operators.GetOperatorData(nil, operatorID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get operator data: %w", err)
+		}
+		if !found {
+			return nil, fmt.Errorf("operator %d not found", operatorID)
+		}
+
+		operatorPEM, err := base64.StdEncoding.DecodeString(string(operatorData.PublicKey))
+		if err != nil {
+			return nil, fmt.Errorf("could not decode public key: %w", err)
+		}
+
+		member.Committee = append(member.Committee, &spectypes.Operator{
+			OperatorID:        operatorID,
+			SSVOperatorPubKey: operatorPEM,
+		})
+		if operatorID == member.OperatorID {
+			member.SSVOperatorPubKey = operatorPEM
+		}
+	}
+
+	return member, nil
+}
+
+// save saves the given decided message as a decided instance.
+// Light nodes only save the highest instance.
+func (s *Syncer) save(
+	store qbftstorage.QBFTStore,
+	mid spectypes.MessageID,
+	msg *specqbft.ProcessingMessage,
+	member *spectypes.CommitteeMember,
+	asHighest bool,
+) error {
+	storedInstance := &qbftstorage.StoredInstance{
+		State: &specqbft.State{
+			CommitteeMember:      member,
+			ID:                   mid[:],
+			Round:                msg.QBFTMessage.Round,
+			Height:               msg.QBFTMessage.Height,
+			Decided:              true,
+			DecidedValue:         msg.SignedMessage.FullData,
+			ProposeContainer:     specqbft.NewMsgContainer(),
+			PrepareContainer:     specqbft.NewMsgContainer(),
+			CommitContainer:      specqbft.NewMsgContainer(),
+			RoundChangeContainer: specqbft.NewMsgContainer(),
+		},
+		DecidedMessage: msg.SignedMessage,
+	}
+
+	var err error
+	switch {
+	case s.opts.FullNode && asHighest:
+		err = store.SaveHighestAndHistoricalInstance(storedInstance)
+	case s.opts.FullNode:
+		err = store.SaveInstance(storedInstance)
+	case asHighest:
+		err = store.SaveHighestInstance(storedInstance)
+	}
+	if err != nil {
+		return fmt.Errorf("could not save decided instance: %w", err)
+	}
+	return nil
+}
+
+func (s *Syncer) store(mid spectypes.MessageID) (qbftstorage.QBFTStore, error) {
+	store := s.opts.Stores.Get(convert.RunnerRole(mid.GetRoleType()))
+	if store == nil {
+		return nil, fmt.Errorf("no store for role %v", mid.GetRoleType())
+	}
+	return store, nil
+}
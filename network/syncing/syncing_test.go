@@ -0,0 +1,263 @@
+package syncing
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"testing"
+
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	protocolp2p "github.com/ssvlabs/ssv/protocol/v2/p2p"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/registry/storage/mocks"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestServerHandle(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	logger := logging.TestLogger(t)
+	stores := newTestStores(t, logger)
+	mid, _ := testCommitteeMsgID()
+
+	store := stores.Get(convert.RunnerRole(spectypes.RoleCommittee))
+	for h := specqbft.Height(0); h < 10; h++ {
+		require.NoError(t, store.SaveHighestAndHistoricalInstance(testInstance(ks, mid, h)))
+	}
+
+	highestReq := &protocolp2p.SyncMessage{
+		Protocol: protocolp2p.LastDecidedProtocol,
+		Params:   &protocolp2p.SyncParams{Identifier: mid},
+	}
+	historyReq := &protocolp2p.SyncMessage{
+		Protocol: protocolp2p.DecidedHistoryProtocol,
+		Params:   &protocolp2p.SyncParams{Identifier: mid, Height: []specqbft.Height{0, 9}},
+	}
+
+	t.Run("light node", func(t *testing.T) {
+		server := NewServer(logger, stores)
+		require.Equal(t, []protocolp2p.SyncProtocol{protocolp2p.LastDecidedProtocol}, server.Protocols())
+
+		res := server.Handle("peer", highestReq)
+		require.Equal(t, protocolp2p.StatusSuccess, res.Status)
+		require.Len(t, res.Data, 1)
+		require.Equal(t, specqbft.Height(9), decodeHeight(t, res.Data[0]))
+
+		res = server.Handle("peer", historyReq)
+		require.Equal(t, protocolp2p.StatusBadRequest, res.Status)
+	})
+
+	t.Run("full node", func(t *testing.T) {
+		server := NewServer(logger, stores, WithServerFullNode(), WithMaxBatch(3))
+
+		res := server.Handle("peer", historyReq)
+		require.Equal(t, protocolp2p.StatusSuccess, res.Status)
+		require.Len(t, res.Data, 3)
+		for i, msg := range res.Data {
+			require.Equal(t, specqbft.Height(i), decodeHeight(t, msg))
+		}
+
+		otherMsgID := spectypes.NewMsgID(networkconfig.TestNetwork.AlanDomainType, []byte("other"), spectypes.RoleCommittee)
+		res = server.Handle("peer", &protocolp2p.SyncMessage{
+			Protocol: protocolp2p.LastDecidedProtocol,
+			Params:   &protocolp2p.SyncParams{Identifier: otherMsgID},
+		})
+		require.Equal(t, protocolp2p.StatusNotFound, res.Status)
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		server := NewServer(logger, stores, WithRateLimit(0, 2))
+
+		for i := 0; i < 2; i++ {
+			require.Equal(t, protocolp2p.StatusSuccess, server.Handle("peer", highestReq).Status)
+		}
+		require.Equal(t, protocolp2p.StatusRateLimited, server.Handle("peer", highestReq).Status)
+		require.Equal(t, protocolp2p.StatusSuccess, server.Handle("other peer", highestReq).Status)
+	})
+}
+
+func TestSyncer(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	logger := logging.TestLogger(t)
+	mid, committeeID := testCommitteeMsgID()
+
+	ctrl := gomock.NewController(t)
+	validatorStore := mocks.NewMockValidatorStore(ctrl)
+	validatorStore.EXPECT().Committee(committeeID).Return(&registrystorage.Committee{
+		ID:        committeeID,
+		Operators: []spectypes.OperatorID{1, 2, 3, 4},
+	}, true).AnyTimes()
+
+	operators := testOperatorStore{}
+	for id, sk := range ks.OperatorKeys {
+		pem, err := spectypes.GetPublicKeyPem(sk)
+		require.NoError(t, err)
+		operators[id] = &registrystorage.OperatorData{
+			ID:        id,
+			PublicKey: []byte(base64.StdEncoding.EncodeToString(pem)),
+		}
+	}
+
+	newSyncer := func(network protocolp2p.Syncer, fullNode bool) (*Syncer, qbftstorage.QBFTStore) {
+		stores := newTestStores(t, logger)
+		return New(logger, Options{
+			Network:        network,
+			Stores:         stores,
+			ValidatorStore: validatorStore,
+			Operators:      operators,
+			OperatorID:     func() spectypes.OperatorID { return 1 },
+			FullNode:       fullNode,
+			BatchSize:      2,
+		}), stores.Get(convert.RunnerRole(spectypes.RoleCommittee))
+	}
+
+	t.Run("highest decided", func(t *testing.T) {
+		noQuorum := testingutils.TestingCommitMultiSignerMessageWithHeightAndIdentifier(
+			[]*rsa.PrivateKey{ks.OperatorKeys[1], ks.OperatorKeys[2]},
+			[]spectypes.OperatorID{1, 2},
+			20,
+			mid[:],
+		)
+		wrongSigner := testingutils.TestingCommitMultiSignerMessageWithHeightAndIdentifier(
+			[]*rsa.PrivateKey{ks.OperatorKeys[1], ks.OperatorKeys[2], ks.OperatorKeys[4]},
+			[]spectypes.OperatorID{1, 2, 3},
+			30,
+			mid[:],
+		)
+		network := &testNetwork{highest: protocolp2p.SyncResults{
+			{Msg: testInstance(ks, mid, 10).DecidedMessage, Sender: "a"},
+			{Msg: noQuorum, Sender: "b"},
+			{Msg: wrongSigner, Sender: "c"},
+		}}
+		syncer, store := newSyncer(network, false)
+
+		height, found, err := syncer.SyncHighestDecided(logger, mid)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, specqbft.Height(10), height)
+
+		highest, err := store.GetHighestInstance(mid[:])
+		require.NoError(t, err)
+		require.NotNil(t, highest)
+		require.Equal(t, specqbft.Height(10), highest.State.Height)
+		require.True(t, highest.State.Decided)
+		require.Equal(t, testingutils.TestingQBFTFullData, highest.State.DecidedValue)
+
+		// Light nodes don't save the history.
+		instance, err := store.GetInstance(mid[:], 10)
+		require.NoError(t, err)
+		require.Nil(t, instance)
+	})
+
+	t.Run("backfill", func(t *testing.T) {
+		network := &testNetwork{
+			highest: protocolp2p.SyncResults{{Msg: testInstance(ks, mid, 10).DecidedMessage, Sender: "a"}},
+		}
+		for h := specqbft.Height(0); h <= 10; h++ {
+			network.history = append(network.history, testInstance(ks, mid, h).DecidedMessage)
+		}
+		syncer, store := newSyncer(network, true)
+		require.NoError(t, store.SaveHighestAndHistoricalInstance(testInstance(ks, mid, 4)))
+
+		syncer.Backfill(context.Background(), mid)
+
+		for h := specqbft.Height(4); h <= 10; h++ {
+			instance, err := store.GetInstance(mid[:], h)
+			require.NoError(t, err)
+			require.NotNil(t, instance, "height %d", h)
+		}
+		instance, err := store.GetInstance(mid[:], 3)
+		require.NoError(t, err)
+		require.Nil(t, instance)
+
+		highest, err := store.GetHighestInstance(mid[:])
+		require.NoError(t, err)
+		require.Equal(t, specqbft.Height(10), highest.State.Height)
+
+		// Heights 5-9 were requested in batches of 2.
+		require.Equal(t, [][2]specqbft.Height{{5, 6}, {7, 8}, {9, 9}}, network.requests)
+	})
+}
+
+func newTestStores(t *testing.T, logger *zap.Logger) *storage.QBFTStores {
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return storage.NewStoresFromRoles(db, convert.RunnerRole(spectypes.RoleCommittee))
+}
+
+func testCommitteeMsgID() (spectypes.MessageID, spectypes.CommitteeID) {
+	committeeID := spectypes.GetCommitteeID([]spectypes.OperatorID{1, 2, 3, 4})
+	return spectypes.NewMsgID(networkconfig.TestNetwork.AlanDomainType, committeeID[:], spectypes.RoleCommittee), committeeID
+}
+
+func testInstance(ks *testingutils.TestKeySet, mid spectypes.MessageID, height specqbft.Height) *qbftstorage.StoredInstance {
+	return &qbftstorage.StoredInstance{
+		State: &specqbft.State{
+			ID:                   mid[:],
+			Height:               height,
+			Decided:              true,
+			ProposeContainer:     specqbft.NewMsgContainer(),
+			PrepareContainer:     specqbft.NewMsgContainer(),
+			CommitContainer:      specqbft.NewMsgContainer(),
+			RoundChangeContainer: specqbft.NewMsgContainer(),
+		},
+		DecidedMessage: testingutils.TestingCommitMultiSignerMessageWithHeightAndIdentifier(
+			[]*rsa.PrivateKey{ks.OperatorKeys[1], ks.OperatorKeys[2], ks.OperatorKeys[3]},
+			[]spectypes.OperatorID{1, 2, 3},
+			height,
+			mid[:],
+		),
+	}
+}
+
+func decodeHeight(t *testing.T, msg *spectypes.SignedSSVMessage) specqbft.Height {
+	qbftMsg, err := specqbft.DecodeMessage(msg.SSVMessage.Data)
+	require.NoError(t, err)
+	return qbftMsg.Height
+}
+
+type testOperatorStore map[spectypes.OperatorID]*registrystorage.OperatorData
+
+func (s testOperatorStore) GetOperatorData(_ basedb.Reader, id spectypes.OperatorID) (*registrystorage.OperatorData, bool, error) {
+	operator, found := s[id]
+	return operator, found, nil
+}
+
+type testNetwork struct {
+	highest  protocolp2p.SyncResults
+	history  []*spectypes.SignedSSVMessage
+	requests [][2]specqbft.Height
+}
+
+func (n *testNetwork) LastDecided(*zap.Logger, spectypes.MessageID) (protocolp2p.SyncResults, error) {
+	return n.highest, nil
+}
+
+func (n *testNetwork) GetHistory(_ *zap.Logger, _ spectypes.MessageID, from, to specqbft.Height, _ ...string) (protocolp2p.SyncResults, error) {
+	n.requests = append(n.requests, [2]specqbft.Height{from, to})
+
+	var results protocolp2p.SyncResults
+	for _, msg := range n.history {
+		qbftMsg, err := specqbft.DecodeMessage(msg.SSVMessage.Data)
+		if err != nil {
+			return nil, err
+		}
+		if qbftMsg.Height >= from && qbftMsg.Height <= to {
+			results = append(results, protocolp2p.SyncResult{Msg: msg, Sender: "a"})
+		}
+	}
+	return results, nil
+}
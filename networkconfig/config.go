@@ -49,6 +49,9 @@ type NetworkConfig struct {
 	DiscoveryProtocolID  [6]byte
 
 	AlanForkEpoch phase0.Epoch
+
+	// DefinitionHash is the hash of the definition the config was loaded from, empty for built-in networks.
+	DefinitionHash string `json:",omitempty"`
 }
 
 func (n NetworkConfig) String() string {
@@ -0,0 +1,194 @@
+package networkconfig
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+)
+
+// Definition is a network definition which can be loaded from a YAML or JSON file.
+// Byte values (fork version, domain types) are 0x-prefixed hex strings.
+type Definition struct {
+	Name                 string           `yaml:"Name" json:"Name"`
+	Beacon               BeaconDefinition `yaml:"Beacon" json:"Beacon"`
+	GenesisDomainType    string           `yaml:"GenesisDomainType" json:"GenesisDomainType"`
+	AlanDomainType       string           `yaml:"AlanDomainType" json:"AlanDomainType"`
+	GenesisEpoch         uint64           `yaml:"GenesisEpoch" json:"GenesisEpoch"`
+	AlanForkEpoch        uint64           `yaml:"AlanForkEpoch" json:"AlanForkEpoch"`
+	RegistrySyncOffset   uint64           `yaml:"RegistrySyncOffset" json:"RegistrySyncOffset"`
+	RegistryContractAddr string           `yaml:"RegistryContractAddr" json:"RegistryContractAddr"`
+	Bootnodes            []string         `yaml:"Bootnodes" json:"Bootnodes"`
+	DiscoveryProtocolID  string           `yaml:"DiscoveryProtocolID" json:"DiscoveryProtocolID"`
+}
+
+// BeaconDefinition holds the parameters of the beacon chain network.
+type BeaconDefinition struct {
+	// Name is the name of the beacon network, which also namespaces the key manager storage.
+	Name               string `yaml:"Name" json:"Name"`
+	GenesisForkVersion string `yaml:"GenesisForkVersion" json:"GenesisForkVersion"`
+	MinGenesisTime     int64  `yaml:"MinGenesisTime" json:"MinGenesisTime"`
+	SlotDurationSec    uint64 `yaml:"SlotDurationSec" json:"SlotDurationSec"`
+	SlotsPerEpoch      uint64 `yaml:"SlotsPerEpoch" json:"SlotsPerEpoch"`
+}
+
+// LoadFromFile reads a network definition from a YAML or JSON file (decided by the file extension)
+// and returns the validated network config.
+func LoadFromFile(path string) (NetworkConfig, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return NetworkConfig{}, fmt.Errorf("could not read network definition: %w", err)
+	}
+
+	var def Definition
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&def)
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&def)
+	default:
+		return NetworkConfig{}, fmt.Errorf("unsupported network definition file extension %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return NetworkConfig{}, fmt.Errorf("could not decode network definition: %w", err)
+	}
+
+	return def.NetworkConfig()
+}
+
+// NetworkConfig validates the definition and converts it to a network config.
+func (d Definition) NetworkConfig() (NetworkConfig, error) {
+	if d.Name == "" {
+		return NetworkConfig{}, errors.New("name is required")
+	}
+	if _, ok := SupportedConfigs[d.Name]; ok {
+		return NetworkConfig{}, fmt.Errorf("name %q is taken by a built-in network", d.Name)
+	}
+
+	beaconNetwork, err := d.Beacon.network()
+	if err != nil {
+		return NetworkConfig{}, fmt.Errorf("invalid beacon network: %w", err)
+	}
+
+	genesisDomainType, err := decodeHexBytes(d.GenesisDomainType, 4)
+	if err != nil {
+		return NetworkConfig{}, fmt.Errorf("invalid genesis domain type: %w", err)
+	}
+	alanDomainType, err := decodeHexBytes(d.AlanDomainType, 4)
+	if err != nil {
+		return NetworkConfig{}, fmt.Errorf("invalid alan domain type: %w", err)
+	}
+	if bytes.Equal(genesisDomainType, alanDomainType) {
+		return NetworkConfig{}, errors.New("genesis and alan domain types must differ")
+	}
+
+	if d.AlanForkEpoch < d.GenesisEpoch {
+		return NetworkConfig{}, fmt.Errorf("alan fork epoch %d is before genesis epoch %d", d.AlanForkEpoch, d.GenesisEpoch)
+	}
+
+	if !ethcommon.IsHexAddress(d.RegistryContractAddr) {
+		return NetworkConfig{}, fmt.Errorf("invalid registry contract address %q", d.RegistryContractAddr)
+	}
+
+	if len(d.Bootnodes) == 0 {
+		return NetworkConfig{}, errors.New("at least one bootnode is required")
+	}
+	for _, bootnode := range d.Bootnodes {
+		if _, err := enode.Parse(enode.ValidSchemes, bootnode); err != nil {
+			return NetworkConfig{}, fmt.Errorf("invalid bootnode %q: %w", bootnode, err)
+		}
+	}
+
+	if len(d.DiscoveryProtocolID) != 6 {
+		return NetworkConfig{}, fmt.Errorf("discovery protocol ID %q must be 6 characters", d.DiscoveryProtocolID)
+	}
+
+	hash, err := d.Hash()
+	if err != nil {
+		return NetworkConfig{}, err
+	}
+
+	return NetworkConfig{
+		Name:                 d.Name,
+		Beacon:               beaconNetwork,
+		GenesisDomainType:    spectypes.DomainType(genesisDomainType),
+		AlanDomainType:       spectypes.DomainType(alanDomainType),
+		GenesisEpoch:         phase0.Epoch(d.GenesisEpoch),
+		RegistrySyncOffset:   new(big.Int).SetUint64(d.RegistrySyncOffset),
+		RegistryContractAddr: ethcommon.HexToAddress(d.RegistryContractAddr).Hex(),
+		Bootnodes:            d.Bootnodes,
+		DiscoveryProtocolID:  [6]byte([]byte(d.DiscoveryProtocolID)),
+		AlanForkEpoch:        phase0.Epoch(d.AlanForkEpoch),
+		DefinitionHash:       hash,
+	}, nil
+}
+
+// Hash returns the hex encoded SHA-256 hash of the definition.
+// It doesn't depend on the file format or formatting, only on the values.
+func (d Definition) Hash() (string, error) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return "", fmt.Errorf("could not encode network definition: %w", err)
+	}
+	hash := sha256.Sum256(b)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func (d BeaconDefinition) network() (beacon.Network, error) {
+	if d.Name == "" {
+		return beacon.Network{}, errors.New("name is required")
+	}
+	forkVersion, err := decodeHexBytes(d.GenesisForkVersion, 4)
+	if err != nil {
+		return beacon.Network{}, fmt.Errorf("invalid genesis fork version: %w", err)
+	}
+	if d.MinGenesisTime <= 0 {
+		return beacon.Network{}, errors.New("min genesis time must be positive")
+	}
+	if d.SlotDurationSec == 0 {
+		return beacon.Network{}, errors.New("slot duration must be positive")
+	}
+	if d.SlotsPerEpoch == 0 {
+		return beacon.Network{}, errors.New("slots per epoch must be positive")
+	}
+
+	return beacon.NewCustomNetwork(spectypes.BeaconNetwork(d.Name), beacon.NetworkParams{
+		GenesisForkVersion: [4]byte(forkVersion),
+		MinGenesisTime:     d.MinGenesisTime,
+		SlotDuration:       time.Duration(d.SlotDurationSec) * time.Second,
+		SlotsPerEpoch:      d.SlotsPerEpoch,
+	}), nil
+}
+
+func decodeHexBytes(s string, length int) ([]byte, error) {
+	if !strings.HasPrefix(s, "0x") {
+		return nil, fmt.Errorf("%q must be a 0x-prefixed hex string", s)
+	}
+	b, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != length {
+		return nil, fmt.Errorf("%q must be %d bytes", s, length)
+	}
+	return b, nil
+}
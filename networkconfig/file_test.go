@@ -0,0 +1,103 @@
+package networkconfig
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFromFile(t *testing.T) {
+	config, err := LoadFromFile("../config/network.example.yaml")
+	require.NoError(t, err)
+
+	require.Equal(t, "my-devnet", config.Name)
+	require.Equal(t, spectypes.BeaconNetwork("my-devnet"), config.Beacon.GetBeaconNetwork())
+	require.Equal(t, [4]byte{0x10, 0x00, 0x00, 0x38}, config.ForkVersion())
+	require.Equal(t, time.Unix(1695902400, 0), config.GetGenesisTime())
+	require.Equal(t, 12*time.Second, config.SlotDurationSec())
+	require.Equal(t, uint64(32), config.SlotsPerEpoch())
+	require.Equal(t, spectypes.DomainType{0x0, 0x0, 0x6, 0x1}, config.GenesisDomainType)
+	require.Equal(t, spectypes.DomainType{0x0, 0x0, 0x6, 0x2}, config.AlanDomainType)
+	require.Equal(t, phase0.Epoch(1), config.AlanForkEpoch)
+	require.Equal(t, int64(181612), config.RegistrySyncOffset.Int64())
+	require.Equal(t, [6]byte{'s', 's', 'v', 'd', 'v', '5'}, config.DiscoveryProtocolID)
+	require.Len(t, config.Bootnodes, 1)
+	require.NotEmpty(t, config.DefinitionHash)
+
+	t.Run("json definition has the same hash", func(t *testing.T) {
+		def := validDefinition(t)
+		b, err := json.MarshalIndent(def, "", "  ")
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "network.json")
+		require.NoError(t, os.WriteFile(path, b, 0600))
+
+		jsonConfig, err := LoadFromFile(path)
+		require.NoError(t, err)
+		require.Equal(t, config.DefinitionHash, jsonConfig.DefinitionHash)
+	})
+
+	t.Run("unknown fields are rejected", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "network.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("Name: test\nUnknown: 1\n"), 0600))
+		_, err := LoadFromFile(path)
+		require.ErrorContains(t, err, "could not decode network definition")
+	})
+}
+
+func TestDefinitionValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Definition)
+		err    string
+	}{
+		{"missing name", func(d *Definition) { d.Name = "" }, "name is required"},
+		{"built-in name", func(d *Definition) { d.Name = Mainnet.Name }, "taken by a built-in network"},
+		{"zero slot duration", func(d *Definition) { d.Beacon.SlotDurationSec = 0 }, "slot duration must be positive"},
+		{"zero slots per epoch", func(d *Definition) { d.Beacon.SlotsPerEpoch = 0 }, "slots per epoch must be positive"},
+		{"invalid fork version", func(d *Definition) { d.Beacon.GenesisForkVersion = "0x01" }, "invalid genesis fork version"},
+		{"invalid domain type", func(d *Definition) { d.GenesisDomainType = "00000601" }, "invalid genesis domain type"},
+		{"same domain types", func(d *Definition) { d.AlanDomainType = d.GenesisDomainType }, "domain types must differ"},
+		{"alan fork before genesis", func(d *Definition) { d.GenesisEpoch = 2 }, "alan fork epoch 1 is before genesis epoch 2"},
+		{"invalid registry address", func(d *Definition) { d.RegistryContractAddr = "0x1234" }, "invalid registry contract address"},
+		{"invalid bootnode", func(d *Definition) { d.Bootnodes = []string{"enr:invalid"} }, "invalid bootnode"},
+		{"no bootnodes", func(d *Definition) { d.Bootnodes = nil }, "at least one bootnode is required"},
+		{"invalid discovery protocol ID", func(d *Definition) { d.DiscoveryProtocolID = "ssv" }, "must be 6 characters"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			def := validDefinition(t)
+			test.modify(&def)
+			_, err := def.NetworkConfig()
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func validDefinition(t *testing.T) Definition {
+	config, err := LoadFromFile("../config/network.example.yaml")
+	require.NoError(t, err)
+	return Definition{
+		Name: config.Name,
+		Beacon: BeaconDefinition{
+			Name:               string(config.Beacon.GetBeaconNetwork()),
+			GenesisForkVersion: "0x10000038",
+			MinGenesisTime:     config.Beacon.MinGenesisTime(),
+			SlotDurationSec:    12,
+			SlotsPerEpoch:      32,
+		},
+		GenesisDomainType:    "0x00000601",
+		AlanDomainType:       "0x00000602",
+		GenesisEpoch:         1,
+		AlanForkEpoch:        1,
+		RegistrySyncOffset:   181612,
+		RegistryContractAddr: "0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA",
+		Bootnodes:            config.Bootnodes,
+		DiscoveryProtocolID:  "ssvdv5",
+	}
+}
@@ -18,6 +18,12 @@ type Node interface {
 	Healthy(ctx context.Context) error
 }
 
+// EndpointsHealthChecker is implemented by nodes backed by multiple endpoints,
+// which can report the health of each endpoint separately.
+type EndpointsHealthChecker interface {
+	EndpointsHealth(ctx context.Context) map[string]error
+}
+
 type Prober struct {
 	logger           *zap.Logger
 	interval         time.Duration
@@ -147,6 +153,31 @@ func (p *Prober) CheckBeaconNodeHealth(ctx context.Context) error {
 	return p.nodes["consensus client"].Healthy(ctx)
 }
 
+// CheckBeaconNodeEndpointsHealth returns the health of each consensus client endpoint,
+// or nil if the consensus client doesn't report it.
+func (p *Prober) CheckBeaconNodeEndpointsHealth(ctx context.Context) map[string]error {
+	return p.checkEndpointsHealth(ctx, "consensus client")
+}
+
+// CheckExecutionNodeEndpointsHealth returns the health of each execution client endpoint,
+// or nil if the execution client doesn't report it.
+func (p *Prober) CheckExecutionNodeEndpointsHealth(ctx context.Context) map[string]error {
+	return p.checkEndpointsHealth(ctx, "execution client")
+}
+
+func (p *Prober) checkEndpointsHealth(ctx context.Context, name string) map[string]error {
+	p.nodesMu.Lock()
+	defer p.nodesMu.Unlock()
+	ctx, cancel := context.WithTimeout(ctx, p.interval)
+	defer cancel()
+
+	checker, ok := p.nodes[name].(EndpointsHealthChecker)
+	if !ok {
+		return nil
+	}
+	return checker.EndpointsHealth(ctx)
+}
+
 func (p *Prober) CheckExecutionNodeHealth(ctx context.Context) error {
 	p.nodesMu.Lock()
 	defer p.nodesMu.Unlock()
@@ -0,0 +1,249 @@
+package backup
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/migrations"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+// formatVersion is the version of the snapshot format, a JSON metadata line followed by the storage engine's backup stream.
+const formatVersion = 1
+
+var (
+	backupPrefix = []byte("backup/")
+	// restoredKey holds the metadata of the restored snapshot until the slashing protection of its shares is bumped.
+	restoredKey = []byte("restored")
+)
+
+// Metadata tags a snapshot of the node database.
+type Metadata struct {
+	FormatVersion int                        `json:"format_version"`
+	Config        operatorstorage.ConfigLock `json:"config"`
+	// Engine is the storage engine of the snapshot, which is badger if it's empty.
+	Engine string `json:"engine,omitempty"`
+	// LastProcessedBlock is the last processed registry block when the snapshot started,
+	// the snapshot itself may include later blocks.
+	LastProcessedBlock uint64 `json:"last_processed_block"`
+	// MigrationVersion is the number of migrations applied to the database.
+	MigrationVersion int       `json:"migration_version"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// Snapshotter writes consistent snapshots of the node database, while the node is running or not.
+type Snapshotter struct {
+	logger      *zap.Logger
+	db          kv.DB
+	nodeStorage operatorstorage.Storage
+}
+
+// NewSnapshotter returns a snapshotter of the given database.
+func NewSnapshotter(logger *zap.Logger, db kv.DB, nodeStorage operatorstorage.Storage) *Snapshotter {
+	return &Snapshotter{
+		logger:      logger,
+		db:          db,
+		nodeStorage: nodeStorage,
+	}
+}
+
+// Snapshot writes a snapshot of the database, tagged with its metadata, to the given writer.
+func (s *Snapshotter) Snapshot(w io.Writer) (*Metadata, error) {
+	metadata, err := s.metadata()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode snapshot metadata: %w", err)
+	}
+	if _, err := w.Write(append(b, '\n')); err != nil {
+		return nil, fmt.Errorf("could not write snapshot metadata: %w", err)
+	}
+
+	start := time.Now()
+	if err := s.db.Backup(w); err != nil {
+		return nil, err
+	}
+	s.logger.Info("wrote database snapshot",
+		zap.String("engine", metadata.Engine),
+		zap.String("network", metadata.Config.NetworkName),
+		zap.Uint64("last_processed_block", metadata.LastProcessedBlock),
+		zap.Int("migration_version", metadata.MigrationVersion),
+		zap.Duration("took", time.Since(start)),
+	)
+	return metadata, nil
+}
+
+func (s *Snapshotter) metadata() (*Metadata, error) {
+	config, found, err := s.nodeStorage.GetConfig(nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not get config lock: %w", err)
+	}
+	if !found {
+		return nil, errors.New("database has no config lock, it was never used by a node")
+	}
+
+	metadata := &Metadata{
+		FormatVersion: formatVersion,
+		Config:        *config,
+		Engine:        s.db.Engine(),
+		CreatedAt:     time.Now().UTC(),
+	}
+	lastProcessedBlock, found, err := s.nodeStorage.GetLastProcessedBlock(nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not get last processed block: %w", err)
+	}
+	if found {
+		metadata.LastProcessedBlock = lastProcessedBlock.Uint64()
+	}
+	metadata.MigrationVersion, err = migrations.Version(s.db)
+	if err != nil {
+		return nil, fmt.Errorf("could not get migration version: %w", err)
+	}
+	return metadata, nil
+}
+
+// ReadMetadata reads the metadata of a snapshot, leaving the reader at the snapshot data.
+func ReadMetadata(r *bufio.Reader) (*Metadata, error) {
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("could not read snapshot metadata: %w", err)
+	}
+	metadata := &Metadata{}
+	if err := json.Unmarshal(line, metadata); err != nil {
+		return nil, fmt.Errorf("could not decode snapshot metadata: %w", err)
+	}
+	if metadata.FormatVersion != formatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version %d", metadata.FormatVersion)
+	}
+	return metadata, nil
+}
+
+func (m *Metadata) engine() string {
+	if m.Engine == "" {
+		return kv.EngineBadger
+	}
+	return m.Engine
+}
+
+// Verify checks that the snapshot can be used with the given config, by this version of the node.
+func (m *Metadata) Verify(current *operatorstorage.ConfigLock) error {
+	if err := m.Config.ValidateCompatibility(current); err != nil {
+		return fmt.Errorf("incompatible snapshot: %w", err)
+	}
+	if latest := migrations.LatestVersion(); m.MigrationVersion > latest {
+		return fmt.Errorf("snapshot has migration version %d, newer than %d of this node version", m.MigrationVersion, latest)
+	}
+	return nil
+}
+
+// Restore verifies a snapshot against the given config and loads it into the given database,
+// which must be empty and of the snapshot's storage engine. Migrations missing from the snapshot
+// are applied when the node starts.
+func Restore(logger *zap.Logger, r io.Reader, db kv.DB, current *operatorstorage.ConfigLock) (*Metadata, error) {
+	br := bufio.NewReader(r)
+	metadata, err := ReadMetadata(br)
+	if err != nil {
+		return nil, err
+	}
+	if err := metadata.Verify(current); err != nil {
+		return nil, err
+	}
+	if engine := metadata.engine(); engine != db.Engine() {
+		return nil, fmt.Errorf("snapshot of a %s database can't be restored into a %s database", engine, db.Engine())
+	}
+
+	count, err := db.CountPrefix(nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not count database keys: %w", err)
+	}
+	if count != 0 {
+		return nil, errors.New("database is not empty")
+	}
+
+	start := time.Now()
+	if err := db.Load(br); err != nil {
+		return nil, err
+	}
+
+	// Make sure the loaded data is the tagged snapshot.
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	if err != nil {
+		return nil, fmt.Errorf("could not open restored node storage: %w", err)
+	}
+	config, found, err := nodeStorage.GetConfig(nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not get restored config lock: %w", err)
+	}
+	if !found || *config != metadata.Config {
+		return nil, errors.New("restored config lock doesn't match the snapshot metadata")
+	}
+
+	// The slashing protection of the snapshot is outdated, so it's bumped when the node starts.
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode snapshot metadata: %w", err)
+	}
+	if err := db.Set(backupPrefix, restoredKey, b); err != nil {
+		return nil, fmt.Errorf("could not mark database as restored: %w", err)
+	}
+
+	logger.Info("restored database snapshot",
+		zap.String("network", metadata.Config.NetworkName),
+		zap.Uint64("last_processed_block", metadata.LastProcessedBlock),
+		zap.Int("migration_version", metadata.MigrationVersion),
+		zap.Time("created_at", metadata.CreatedAt),
+		zap.Duration("took", time.Since(start)),
+	)
+	return metadata, nil
+}
+
+// SlashingProtectionBumper bumps the slashing protection of share keys to the current slot.
+type SlashingProtectionBumper interface {
+	BumpSlashingProtection(pubKey []byte) error
+}
+
+// BumpRestoredSlashingProtection bumps the slashing protection of the given shares
+// if the database was restored since it was last done, and returns whether it was.
+// The slashing protection of a snapshot predates the duties signed after it was taken,
+// which could otherwise be signed again at a lagging beacon node's request.
+func BumpRestoredSlashingProtection(logger *zap.Logger, db basedb.Database, bumper SlashingProtectionBumper, shares []*ssvtypes.SSVShare) (bool, error) {
+	obj, found, err := db.Get(backupPrefix, restoredKey)
+	if err != nil {
+		return false, fmt.Errorf("could not get restored snapshot: %w", err)
+	}
+	if !found {
+		return false, nil
+	}
+	metadata := &Metadata{}
+	if err := json.Unmarshal(obj.Value, metadata); err != nil {
+		return false, fmt.Errorf("could not decode restored snapshot metadata: %w", err)
+	}
+
+	for _, share := range shares {
+		if err := bumper.BumpSlashingProtection(share.SharePubKey); err != nil {
+			return false, fmt.Errorf("could not bump slashing protection of share %x: %w", share.SharePubKey, err)
+		}
+	}
+
+	// Only done once every share is bumped, so that it's retried if interrupted.
+	if err := db.Delete(backupPrefix, restoredKey); err != nil {
+		return false, fmt.Errorf("could not unmark database as restored: %w", err)
+	}
+	logger.Info("bumped slashing protection of restored snapshot",
+		zap.Time("created_at", metadata.CreatedAt),
+		zap.Int("shares", len(shares)),
+	)
+	return true, nil
+}
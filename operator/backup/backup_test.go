@@ -0,0 +1,169 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/migrations"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			testSnapshotRestore(t, engine)
+		})
+	}
+}
+
+func testSnapshotRestore(t *testing.T, engine string) {
+	logger := logging.TestLogger(t)
+
+	db, err := kv.OpenInMemory(logger, basedb.Options{Engine: engine})
+	require.NoError(t, err)
+	defer db.Close()
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	snapshotter := NewSnapshotter(logger, db, nodeStorage)
+	_, err = snapshotter.Snapshot(&bytes.Buffer{})
+	require.ErrorContains(t, err, "no config lock")
+
+	config := &operatorstorage.ConfigLock{NetworkName: "holesky_alan"}
+	require.NoError(t, nodeStorage.SaveConfig(nil, config))
+	require.NoError(t, nodeStorage.SaveLastProcessedBlock(nil, big.NewInt(1234)))
+	require.NoError(t, db.Set([]byte("test/"), []byte("key"), []byte("value")))
+
+	var snapshot bytes.Buffer
+	metadata, err := snapshotter.Snapshot(&snapshot)
+	require.NoError(t, err)
+	require.Equal(t, *config, metadata.Config)
+	require.Equal(t, engine, metadata.Engine)
+	require.EqualValues(t, 1234, metadata.LastProcessedBlock)
+
+	read, err := ReadMetadata(bufio.NewReader(bytes.NewReader(snapshot.Bytes())))
+	require.NoError(t, err)
+	require.Equal(t, metadata.Config, read.Config)
+	require.Equal(t, metadata.LastProcessedBlock, read.LastProcessedBlock)
+	require.Equal(t, metadata.MigrationVersion, read.MigrationVersion)
+
+	t.Run("restore", func(t *testing.T) {
+		restored, err := kv.OpenInMemory(logger, basedb.Options{Engine: engine})
+		require.NoError(t, err)
+		defer restored.Close()
+
+		_, err = Restore(logger, bytes.NewReader(snapshot.Bytes()), restored, config)
+		require.NoError(t, err)
+
+		obj, found, err := restored.Get([]byte("test/"), []byte("key"))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, []byte("value"), obj.Value)
+
+		// Only into an empty database.
+		_, err = Restore(logger, bytes.NewReader(snapshot.Bytes()), restored, config)
+		require.ErrorContains(t, err, "not empty")
+	})
+
+	t.Run("incompatible network", func(t *testing.T) {
+		restored, err := kv.OpenInMemory(logger, basedb.Options{Engine: engine})
+		require.NoError(t, err)
+		defer restored.Close()
+
+		_, err = Restore(logger, bytes.NewReader(snapshot.Bytes()), restored, &operatorstorage.ConfigLock{NetworkName: "mainnet_alan"})
+		require.ErrorContains(t, err, "network mismatch")
+	})
+
+	t.Run("other engine", func(t *testing.T) {
+		other := kv.EnginePebble
+		if engine == kv.EnginePebble {
+			other = kv.EngineBadger
+		}
+		restored, err := kv.OpenInMemory(logger, basedb.Options{Engine: other})
+		require.NoError(t, err)
+		defer restored.Close()
+
+		_, err = Restore(logger, bytes.NewReader(snapshot.Bytes()), restored, config)
+		require.ErrorContains(t, err, "can't be restored")
+	})
+
+	t.Run("newer migration version", func(t *testing.T) {
+		newer := *read
+		newer.MigrationVersion = migrations.LatestVersion() + 1
+		require.ErrorContains(t, newer.Verify(config), "newer")
+	})
+}
+
+type recordingBumper struct {
+	bumped [][]byte
+	err    error
+}
+
+func (b *recordingBumper) BumpSlashingProtection(pubKey []byte) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.bumped = append(b.bumped, pubKey)
+	return nil
+}
+
+func TestBumpRestoredSlashingProtection(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	db, err := kv.OpenInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+	config := &operatorstorage.ConfigLock{NetworkName: "holesky_alan"}
+	require.NoError(t, nodeStorage.SaveConfig(nil, config))
+
+	shares := []*ssvtypes.SSVShare{
+		{Share: spectypes.Share{SharePubKey: []byte("share1")}},
+		{Share: spectypes.Share{SharePubKey: []byte("share2")}},
+	}
+
+	// The database the snapshot is taken of isn't restored.
+	bumper := &recordingBumper{}
+	bumped, err := BumpRestoredSlashingProtection(logger, db, bumper, shares)
+	require.NoError(t, err)
+	require.False(t, bumped)
+	require.Empty(t, bumper.bumped)
+
+	var snapshot bytes.Buffer
+	_, err = NewSnapshotter(logger, db, nodeStorage).Snapshot(&snapshot)
+	require.NoError(t, err)
+
+	restored, err := kv.OpenInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer restored.Close()
+	_, err = Restore(logger, bytes.NewReader(snapshot.Bytes()), restored, config)
+	require.NoError(t, err)
+
+	// A failed bump is retried on the next start.
+	bumper.err = errors.New("storage error")
+	_, err = BumpRestoredSlashingProtection(logger, restored, bumper, shares)
+	require.ErrorContains(t, err, "storage error")
+
+	bumper.err = nil
+	bumped, err = BumpRestoredSlashingProtection(logger, restored, bumper, shares)
+	require.NoError(t, err)
+	require.True(t, bumped)
+	require.Equal(t, [][]byte{[]byte("share1"), []byte("share2")}, bumper.bumped)
+
+	// Only once per restore.
+	bumped, err = BumpRestoredSlashingProtection(logger, restored, bumper, shares)
+	require.NoError(t, err)
+	require.False(t, bumped)
+	require.Len(t, bumper.bumped, 2)
+}
@@ -0,0 +1,237 @@
+package doppelganger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/peer"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/beacon/goclient"
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+// ErrDoppelganger is returned when another instance of this operator, or of its validators, is detected.
+var ErrDoppelganger = errors.New("doppelganger detected")
+
+// livenessEpochs is the number of watched epochs in which a validator must be live without consensus messages
+// of its committee being observed to be reported, since the messages of a single epoch may be missed.
+const livenessEpochs = 2
+
+// ValidateEpochs returns an error if the given number of epochs to watch is enabled but too short
+// to ever report a validator which is live outside of SSV.
+func ValidateEpochs(epochs uint64) error {
+	if epochs > 0 && epochs < livenessEpochs {
+		return fmt.Errorf("at least %d epochs must be watched for doppelgangers, got %d", livenessEpochs, epochs)
+	}
+	return nil
+}
+
+// BeaconNode provides the liveness of validators as observed by the beacon chain.
+type BeaconNode interface {
+	ValidatorLiveness(ctx context.Context, epoch phase0.Epoch, indices []phase0.ValidatorIndex) ([]*goclient.ValidatorLiveness, error)
+}
+
+// Options holds the dependencies of the doppelganger service.
+type Options struct {
+	Network        networkconfig.NetworkConfig
+	BeaconNode     BeaconNode
+	ValidatorStore registrystorage.SelfValidatorStore
+	OperatorID     func() spectypes.OperatorID
+	// Epochs is the number of full epochs to watch before signing is enabled.
+	Epochs uint64
+}
+
+// Service watches the network and the beacon chain for other instances of this operator
+// before its validators are started. While watching, the operator doesn't sign anything, so:
+//   - a message signed with the operator's key can only come from another instance of the operator
+//   - a validator can only be live without its committee reaching consensus if it's run outside of SSV
+//
+// The latter is a heuristic: the consensus messages of a committee may be missed, for example while
+// subnets are still being joined, so a validator is only reported once it was live without them
+// in livenessEpochs of the watched epochs.
+type Service struct {
+	logger *zap.Logger
+	opts   Options
+
+	mu         sync.Mutex
+	watching   bool
+	startEpoch phase0.Epoch
+	detected   error
+	// activity holds the epochs in which consensus messages of each committee were observed.
+	activity map[spectypes.CommitteeID]map[phase0.Epoch]struct{}
+	// unexplained holds the number of epochs in which each validator was live without consensus of its committee.
+	unexplained map[phase0.ValidatorIndex]int
+}
+
+// New creates a new doppelganger service.
+func New(logger *zap.Logger, opts Options) *Service {
+	return &Service{
+		logger:      logger,
+		opts:        opts,
+		activity:    make(map[spectypes.CommitteeID]map[phase0.Epoch]struct{}),
+		unexplained: make(map[phase0.ValidatorIndex]int),
+	}
+}
+
+// Run watches for doppelgangers during the configured amount of full epochs,
+// it blocks until they pass and returns an error wrapping ErrDoppelganger if one was detected.
+// The caller is expected to be subscribed to the subnets of its committees.
+func (s *Service) Run(ctx context.Context) error {
+	beaconNetwork := s.opts.Network.Beacon
+	startEpoch := beaconNetwork.EstimatedCurrentEpoch() + 1
+	endEpoch := startEpoch + phase0.Epoch(s.opts.Epochs)
+	s.start(startEpoch)
+	defer s.stop()
+
+	s.logger.Info("watching for doppelgangers before starting validators",
+		zap.Uint64("start_epoch", uint64(startEpoch)),
+		zap.Uint64("end_epoch", uint64(endEpoch)))
+
+	for epoch := startEpoch; epoch < endEpoch; epoch++ {
+		// Liveness of an epoch is checked a slot into the next one, to let its last attestations propagate.
+		checkTime := beaconNetwork.EpochStartTime(epoch + 1).Add(beaconNetwork.SlotDurationSec())
+		if err := s.waitUntil(ctx, checkTime); err != nil {
+			return err
+		}
+		if err := s.checkLiveness(ctx, epoch); err != nil {
+			return err
+		}
+		s.logger.Debug("no doppelganger detected in epoch", fields.Epoch(epoch))
+	}
+
+	s.logger.Info("no doppelganger detected, enabling signing")
+	return nil
+}
+
+// waitUntil waits until the given time, returning early if a doppelganger is detected.
+func (s *Service) waitUntil(ctx context.Context, t time.Time) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for time.Now().Before(t) {
+		if err := s.err(); err != nil {
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return s.err()
+}
+
+// ObserveMessage inspects a message received from another peer.
+func (s *Service) ObserveMessage(from peer.ID, msg *queue.SSVMessage) {
+	if msg == nil || msg.SignedSSVMessage == nil || msg.SSVMessage == nil {
+		return
+	}
+	slot, err := msg.Slot()
+	if err != nil {
+		return
+	}
+	epoch := s.opts.Network.Beacon.EstimatedEpochAtSlot(slot)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Messages of the epoch in which the operator was started may have been signed by its previous run.
+	if !s.watching || epoch < s.startEpoch {
+		return
+	}
+
+	operatorID := s.opts.OperatorID()
+	for _, signer := range msg.SignedSSVMessage.OperatorIDs {
+		if signer == operatorID && s.detected == nil {
+			s.detected = fmt.Errorf("%w: message signed by operator %d was received from peer %s in slot %d",
+				ErrDoppelganger, operatorID, from, slot)
+		}
+	}
+
+	if msg.MsgID.GetRoleType() == spectypes.RoleCommittee {
+		var committeeID spectypes.CommitteeID
+		copy(committeeID[:], msg.MsgID.GetDutyExecutorID()[16:])
+		if _, ok := s.activity[committeeID]; !ok {
+			s.activity[committeeID] = make(map[phase0.Epoch]struct{})
+		}
+		s.activity[committeeID][epoch] = struct{}{}
+	}
+}
+
+// checkLiveness fails if any of the operator's validators was live in the given epoch
+// without consensus messages of its committee being observed, for the livenessEpochs time.
+func (s *Service) checkLiveness(ctx context.Context, epoch phase0.Epoch) error {
+	shares := s.opts.ValidatorStore.SelfParticipatingValidators(epoch)
+	if len(shares) == 0 {
+		return s.err()
+	}
+
+	committees := make(map[phase0.ValidatorIndex]spectypes.CommitteeID, len(shares))
+	indices := make([]phase0.ValidatorIndex, 0, len(shares))
+	for _, share := range shares {
+		committees[share.BeaconMetadata.Index] = share.CommitteeID()
+		indices = append(indices, share.BeaconMetadata.Index)
+	}
+
+	liveness, err := s.opts.BeaconNode.ValidatorLiveness(ctx, epoch, indices)
+	if err != nil {
+		return fmt.Errorf("could not check validator liveness: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, l := range liveness {
+		committeeID, ok := committees[l.Index]
+		if !l.IsLive || !ok {
+			continue
+		}
+		if _, active := s.activity[committeeID][epoch]; active {
+			continue
+		}
+		s.unexplained[l.Index]++
+		if s.unexplained[l.Index] < livenessEpochs {
+			s.logger.Warn("validator was live without consensus of its committee",
+				zap.Uint64("validator_index", uint64(l.Index)), fields.Epoch(epoch))
+			continue
+		}
+		if s.detected == nil {
+			s.detected = fmt.Errorf("%w: validator %d was live in %d epochs up to %d without consensus of its committee",
+				ErrDoppelganger, l.Index, s.unexplained[l.Index], epoch)
+		}
+	}
+	return s.detected
+}
+
+func (s *Service) start(epoch phase0.Epoch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.watching = true
+	s.startEpoch = epoch
+}
+
+func (s *Service) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.watching = false
+	s.activity = make(map[spectypes.CommitteeID]map[phase0.Epoch]struct{})
+	s.unexplained = make(map[phase0.ValidatorIndex]int)
+}
+
+func (s *Service) err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.detected
+}
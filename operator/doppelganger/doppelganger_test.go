@@ -0,0 +1,140 @@
+package doppelganger
+
+import (
+	"context"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ssvlabs/ssv/beacon/goclient"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	beaconprotocol "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/registry/storage/mocks"
+)
+
+func TestObserveMessage(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	committeeID := spectypes.GetCommitteeID([]spectypes.OperatorID{1, 2, 3, 4})
+	mid := spectypes.NewMsgID(networkconfig.TestNetwork.AlanDomainType, committeeID[:], spectypes.RoleCommittee)
+
+	commit := func(t *testing.T, height specqbft.Height, signers ...spectypes.OperatorID) *queue.SSVMessage {
+		sks := make([]*rsa.PrivateKey, 0, len(signers))
+		for _, signer := range signers {
+			sks = append(sks, ks.OperatorKeys[signer])
+		}
+		msg, err := queue.DecodeSignedSSVMessage(testingutils.TestingCommitMultiSignerMessageWithHeightAndIdentifier(sks, signers, height, mid[:]))
+		require.NoError(t, err)
+		return msg
+	}
+
+	newService := func() *Service {
+		s := New(logging.TestLogger(t), Options{
+			Network:    networkconfig.TestNetwork,
+			OperatorID: func() spectypes.OperatorID { return 1 },
+			Epochs:     2,
+		})
+		s.start(10)
+		return s
+	}
+
+	t.Run("other operators", func(t *testing.T) {
+		s := newService()
+		s.ObserveMessage("peer", commit(t, 10*32, 2, 3, 4))
+		require.NoError(t, s.err())
+		require.Contains(t, s.activity[committeeID], phase0.Epoch(10))
+	})
+
+	t.Run("own operator", func(t *testing.T) {
+		s := newService()
+		s.ObserveMessage("peer", commit(t, 10*32, 1, 2, 3))
+		require.ErrorIs(t, s.err(), ErrDoppelganger)
+	})
+
+	t.Run("before start epoch", func(t *testing.T) {
+		s := newService()
+		s.ObserveMessage("peer", commit(t, 10*32-1, 1, 2, 3))
+		require.NoError(t, s.err())
+		require.Empty(t, s.activity)
+	})
+
+	t.Run("after stop", func(t *testing.T) {
+		s := newService()
+		s.stop()
+		s.ObserveMessage("peer", commit(t, 10*32, 1, 2, 3))
+		require.NoError(t, s.err())
+	})
+}
+
+func TestCheckLiveness(t *testing.T) {
+	share := &ssvtypes.SSVShare{
+		Share: spectypes.Share{
+			Committee: []*spectypes.ShareMember{{Signer: 1}, {Signer: 2}, {Signer: 3}, {Signer: 4}},
+		},
+		Metadata: ssvtypes.Metadata{
+			BeaconMetadata: &beaconprotocol.ValidatorMetadata{Index: 5},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	validatorStore := mocks.NewMockSelfValidatorStore(ctrl)
+	validatorStore.EXPECT().SelfParticipatingValidators(gomock.Any()).Return([]*ssvtypes.SSVShare{share}).AnyTimes()
+
+	newService := func(live bool) *Service {
+		s := New(logging.TestLogger(t), Options{
+			Network:        networkconfig.TestNetwork,
+			BeaconNode:     testBeaconNode{live: live},
+			ValidatorStore: validatorStore,
+			OperatorID:     func() spectypes.OperatorID { return 1 },
+			Epochs:         2,
+		})
+		s.start(10)
+		return s
+	}
+
+	t.Run("not live", func(t *testing.T) {
+		require.NoError(t, newService(false).checkLiveness(context.Background(), 10))
+	})
+
+	t.Run("live with committee consensus", func(t *testing.T) {
+		s := newService(true)
+		s.activity[share.CommitteeID()] = map[phase0.Epoch]struct{}{10: {}}
+		require.NoError(t, s.checkLiveness(context.Background(), 10))
+	})
+
+	t.Run("live without committee consensus", func(t *testing.T) {
+		s := newService(true)
+		s.activity[share.CommitteeID()] = map[phase0.Epoch]struct{}{9: {}, 11: {}}
+		// The messages of a single epoch may have been missed.
+		require.NoError(t, s.checkLiveness(context.Background(), 10))
+		require.NoError(t, s.checkLiveness(context.Background(), 11))
+		require.ErrorIs(t, s.checkLiveness(context.Background(), 12), ErrDoppelganger)
+	})
+}
+
+type testBeaconNode struct {
+	live bool
+}
+
+func (b testBeaconNode) ValidatorLiveness(_ context.Context, _ phase0.Epoch, indices []phase0.ValidatorIndex) ([]*goclient.ValidatorLiveness, error) {
+	liveness := make([]*goclient.ValidatorLiveness, 0, len(indices))
+	for _, index := range indices {
+		liveness = append(liveness, &goclient.ValidatorLiveness{Index: index, IsLive: b.live})
+	}
+	return liveness, nil
+}
+
+func TestValidateEpochs(t *testing.T) {
+	require.NoError(t, ValidateEpochs(0))
+	require.ErrorContains(t, ValidateEpochs(livenessEpochs-1), "at least 2 epochs")
+	require.NoError(t, ValidateEpochs(livenessEpochs))
+	require.NoError(t, ValidateEpochs(livenessEpochs+1))
+}
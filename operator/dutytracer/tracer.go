@@ -0,0 +1,415 @@
+// Package dutytracer records the execution of duties for debugging,
+// keeping the traces of the most recent slots in memory.
+package dutytracer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+)
+
+// DutyTrace is the execution trace of a duty.
+type DutyTrace struct {
+	Role string      `json:"role"`
+	Slot phase0.Slot `json:"slot"`
+	// DutyExecutorID is the committee ID for committee duties, and the validator public key otherwise.
+	DutyExecutorID string                  `json:"duty_executor_id"`
+	Started        *time.Time              `json:"started,omitempty"`
+	PreConsensus   *PartialSignaturesTrace `json:"pre_consensus,omitempty"`
+	Consensus      *ConsensusTrace         `json:"consensus,omitempty"`
+	PostConsensus  *PartialSignaturesTrace `json:"post_consensus,omitempty"`
+	Submissions    []SubmissionTrace       `json:"submissions,omitempty"`
+
+	role           spectypes.RunnerRole
+	dutyExecutorID []byte
+}
+
+// PartialSignaturesTrace traces the partial signatures of a pre or post consensus phase.
+type PartialSignaturesTrace struct {
+	Signatures []MessageTrace `json:"signatures"`
+	Quorum     *time.Time     `json:"quorum,omitempty"`
+}
+
+// ConsensusTrace traces the QBFT instance of a duty.
+type ConsensusTrace struct {
+	Rounds  []RoundTrace  `json:"rounds"`
+	Decided *DecidedTrace `json:"decided,omitempty"`
+}
+
+// RoundTrace traces a single round of a QBFT instance.
+type RoundTrace struct {
+	Round        specqbft.Round `json:"round"`
+	Started      *time.Time     `json:"started,omitempty"`
+	ProposalRoot string         `json:"proposal_root,omitempty"`
+	Proposals    []MessageTrace `json:"proposals,omitempty"`
+	Prepares     []MessageTrace `json:"prepares,omitempty"`
+	Commits      []MessageTrace `json:"commits,omitempty"`
+	RoundChanges []MessageTrace `json:"round_changes,omitempty"`
+}
+
+// DecidedTrace traces the decision of a QBFT instance.
+type DecidedTrace struct {
+	Time    time.Time              `json:"time"`
+	Round   specqbft.Round         `json:"round"`
+	Root    string                 `json:"root"`
+	Signers []spectypes.OperatorID `json:"signers"`
+}
+
+// MessageTrace is a message seen by the node.
+type MessageTrace struct {
+	Time    time.Time              `json:"time"`
+	Signers []spectypes.OperatorID `json:"signers"`
+}
+
+// SubmissionTrace is a submission to the beacon node.
+type SubmissionTrace struct {
+	Time  time.Time `json:"time"`
+	Error string    `json:"error,omitempty"`
+}
+
+// Filter selects duty traces. Empty fields match everything.
+type Filter struct {
+	FromSlot        phase0.Slot
+	ToSlot          phase0.Slot
+	Roles           []spectypes.RunnerRole
+	DutyExecutorIDs [][]byte
+}
+
+type traceKey struct {
+	role           spectypes.RunnerRole
+	dutyExecutorID string
+	slot           phase0.Slot
+}
+
+// Tracer keeps the traces of the duties of the last retention slots.
+//
+// Events are traced from the consensus of every duty, so the traces are bucketed by slot
+// and each trace has its own lock: events of different duties only share the locks
+// of the buckets, which are held for map lookups.
+type Tracer struct {
+	retention phase0.Slot
+
+	// mu guards slots and highestSlot.
+	mu          sync.RWMutex
+	slots       map[phase0.Slot]*slotTraces
+	highestSlot phase0.Slot
+}
+
+// slotTraces holds the traces of the duties of a slot.
+type slotTraces struct {
+	mu     sync.RWMutex
+	traces map[traceKey]*lockedTrace
+}
+
+type lockedTrace struct {
+	mu    sync.Mutex
+	trace *DutyTrace
+}
+
+// New returns a tracer which keeps the traces of the duties of the last retentionSlots slots.
+func New(retentionSlots uint64) *Tracer {
+	return &Tracer{
+		retention: phase0.Slot(retentionSlots),
+		slots:     make(map[phase0.Slot]*slotTraces),
+	}
+}
+
+var _ ssvtypes.DutyTracer = (*Tracer)(nil)
+
+// TraceDuty records the event in the trace of its duty.
+func (t *Tracer) TraceDuty(event ssvtypes.DutyTraceEvent) {
+	dutyExecutorID := event.DutyExecutorID
+	if event.Role == spectypes.RoleCommittee && len(dutyExecutorID) > len(spectypes.CommitteeID{}) {
+		// Committee IDs are left-padded in message IDs.
+		dutyExecutorID = dutyExecutorID[len(dutyExecutorID)-len(spectypes.CommitteeID{}):]
+	}
+
+	slotTraces := t.slotTraces(event.Slot)
+	if slotTraces == nil {
+		return
+	}
+	key := traceKey{role: event.Role, dutyExecutorID: string(dutyExecutorID), slot: event.Slot}
+	trace := slotTraces.get(key)
+	if trace == nil {
+		trace = slotTraces.add(key, &DutyTrace{
+			Role:           RoleName(event.Role),
+			Slot:           event.Slot,
+			DutyExecutorID: hex.EncodeToString(dutyExecutorID),
+			role:           event.Role,
+			dutyExecutorID: bytes.Clone(dutyExecutorID),
+		})
+	}
+
+	trace.mu.Lock()
+	defer trace.mu.Unlock()
+	trace.trace.apply(event)
+}
+
+// slotTraces returns the traces of the slot, adding them if they don't exist yet,
+// or nil if the slot is older than the retention.
+func (t *Tracer) slotTraces(slot phase0.Slot) *slotTraces {
+	t.mu.RLock()
+	traces, ok := t.slots[slot]
+	t.mu.RUnlock()
+	if ok {
+		return traces
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if slot+t.retention < t.highestSlot {
+		return nil
+	}
+	if traces, ok := t.slots[slot]; ok {
+		return traces
+	}
+	traces = &slotTraces{traces: make(map[traceKey]*lockedTrace)}
+	t.slots[slot] = traces
+	if slot > t.highestSlot {
+		t.highestSlot = slot
+		t.prune()
+	}
+	return traces
+}
+
+func (s *slotTraces) get(key traceKey) *lockedTrace {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.traces[key]
+}
+
+// add adds the trace unless another one was added meanwhile, and returns the added one.
+func (s *slotTraces) add(key traceKey, trace *DutyTrace) *lockedTrace {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.traces[key]; ok {
+		return existing
+	}
+	locked := &lockedTrace{trace: trace}
+	s.traces[key] = locked
+	return locked
+}
+
+// Traces returns copies of the matching duty traces ordered by slot.
+func (t *Tracer) Traces(filter Filter) []*DutyTrace {
+	t.mu.RLock()
+	var slots []*slotTraces
+	for slot, traces := range t.slots {
+		if slot >= filter.FromSlot && (filter.ToSlot == 0 || slot <= filter.ToSlot) {
+			slots = append(slots, traces)
+		}
+	}
+	t.mu.RUnlock()
+
+	var traces []*DutyTrace
+	for _, slotTraces := range slots {
+		slotTraces.mu.RLock()
+		for _, locked := range slotTraces.traces {
+			locked.mu.Lock()
+			if filter.matches(locked.trace) {
+				traces = append(traces, locked.trace.clone())
+			}
+			locked.mu.Unlock()
+		}
+		slotTraces.mu.RUnlock()
+	}
+	sort.Slice(traces, func(i, j int) bool {
+		a, b := traces[i], traces[j]
+		if a.Slot != b.Slot {
+			return a.Slot < b.Slot
+		}
+		if a.Role != b.Role {
+			return a.Role < b.Role
+		}
+		return a.DutyExecutorID < b.DutyExecutorID
+	})
+	return traces
+}
+
+// RoleName returns the name of the role as used in duty traces, e.g. PROPOSER.
+func RoleName(role spectypes.RunnerRole) string {
+	return strings.TrimSuffix(role.String(), "_RUNNER")
+}
+
+// prune removes the traces of the slots older than the retention.
+func (t *Tracer) prune() {
+	for slot := range t.slots {
+		if slot+t.retention < t.highestSlot {
+			delete(t.slots, slot)
+		}
+	}
+}
+
+func (f Filter) matches(trace *DutyTrace) bool {
+	if trace.Slot < f.FromSlot || (f.ToSlot != 0 && trace.Slot > f.ToSlot) {
+		return false
+	}
+	if len(f.Roles) > 0 {
+		found := false
+		for _, role := range f.Roles {
+			if role == trace.role {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.DutyExecutorIDs) > 0 {
+		found := false
+		for _, id := range f.DutyExecutorIDs {
+			if bytes.Equal(id, trace.dutyExecutorID) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *DutyTrace) apply(event ssvtypes.DutyTraceEvent) {
+	eventTime := event.Time
+	switch event.Type {
+	case ssvtypes.DutyStarted:
+		d.Started = &eventTime
+	case ssvtypes.PreConsensusSignature, ssvtypes.PreConsensusQuorum:
+		if d.PreConsensus == nil {
+			d.PreConsensus = &PartialSignaturesTrace{}
+		}
+		d.PreConsensus.apply(event)
+	case ssvtypes.PostConsensusSignature, ssvtypes.PostConsensusQuorum:
+		if d.PostConsensus == nil {
+			d.PostConsensus = &PartialSignaturesTrace{}
+		}
+		d.PostConsensus.apply(event)
+	case ssvtypes.QBFTMessage, ssvtypes.QBFTRoundChange, ssvtypes.QBFTDecided:
+		if d.Consensus == nil {
+			d.Consensus = &ConsensusTrace{}
+		}
+		d.Consensus.apply(event)
+	case ssvtypes.BeaconSubmission:
+		submission := SubmissionTrace{Time: event.Time}
+		if event.Error != nil {
+			submission.Error = event.Error.Error()
+		}
+		d.Submissions = append(d.Submissions, submission)
+	}
+}
+
+func (p *PartialSignaturesTrace) apply(event ssvtypes.DutyTraceEvent) {
+	switch event.Type {
+	case ssvtypes.PreConsensusQuorum, ssvtypes.PostConsensusQuorum:
+		if p.Quorum == nil {
+			eventTime := event.Time
+			p.Quorum = &eventTime
+		}
+	default:
+		p.Signatures = append(p.Signatures, MessageTrace{Time: event.Time, Signers: event.Signers})
+	}
+}
+
+func (c *ConsensusTrace) apply(event ssvtypes.DutyTraceEvent) {
+	switch event.Type {
+	case ssvtypes.QBFTDecided:
+		if c.Decided == nil {
+			c.Decided = &DecidedTrace{
+				Time:    event.Time,
+				Round:   event.Round,
+				Root:    hex.EncodeToString(event.Root[:]),
+				Signers: event.Signers,
+			}
+		}
+	case ssvtypes.QBFTRoundChange:
+		round := c.round(event.Round)
+		if round.Started == nil {
+			eventTime := event.Time
+			round.Started = &eventTime
+		}
+	case ssvtypes.QBFTMessage:
+		round := c.round(event.Round)
+		msg := MessageTrace{Time: event.Time, Signers: event.Signers}
+		switch event.MsgType {
+		case specqbft.ProposalMsgType:
+			round.ProposalRoot = hex.EncodeToString(event.Root[:])
+			round.Proposals = append(round.Proposals, msg)
+		case specqbft.PrepareMsgType:
+			round.Prepares = append(round.Prepares, msg)
+		case specqbft.CommitMsgType:
+			round.Commits = append(round.Commits, msg)
+		case specqbft.RoundChangeMsgType:
+			round.RoundChanges = append(round.RoundChanges, msg)
+		}
+	}
+}
+
+// round returns the trace of the given round, adding it if it doesn't exist yet.
+func (c *ConsensusTrace) round(round specqbft.Round) *RoundTrace {
+	for i := range c.Rounds {
+		if c.Rounds[i].Round == round {
+			return &c.Rounds[i]
+		}
+	}
+	c.Rounds = append(c.Rounds, RoundTrace{Round: round})
+	sort.Slice(c.Rounds, func(i, j int) bool {
+		return c.Rounds[i].Round < c.Rounds[j].Round
+	})
+	for i := range c.Rounds {
+		if c.Rounds[i].Round == round {
+			return &c.Rounds[i]
+		}
+	}
+	return nil
+}
+
+// clone returns a copy of the trace which isn't modified by later events.
+func (d *DutyTrace) clone() *DutyTrace {
+	clone := *d
+	if d.PreConsensus != nil {
+		clone.PreConsensus = d.PreConsensus.clone()
+	}
+	if d.PostConsensus != nil {
+		clone.PostConsensus = d.PostConsensus.clone()
+	}
+	if d.Consensus != nil {
+		consensus := *d.Consensus
+		consensus.Rounds = make([]RoundTrace, len(d.Consensus.Rounds))
+		for i, round := range d.Consensus.Rounds {
+			round.Proposals = cloneSlice(round.Proposals)
+			round.Prepares = cloneSlice(round.Prepares)
+			round.Commits = cloneSlice(round.Commits)
+			round.RoundChanges = cloneSlice(round.RoundChanges)
+			consensus.Rounds[i] = round
+		}
+		clone.Consensus = &consensus
+	}
+	clone.Submissions = cloneSlice(d.Submissions)
+	return &clone
+}
+
+func (p *PartialSignaturesTrace) clone() *PartialSignaturesTrace {
+	clone := *p
+	clone.Signatures = cloneSlice(p.Signatures)
+	return &clone
+}
+
+func cloneSlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(s)), s...)
+}
@@ -0,0 +1,148 @@
+package dutytracer
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+)
+
+func TestTracer_TraceDuty(t *testing.T) {
+	tracer := New(32)
+	pubKey := []byte{0x1, 0x2}
+	start := time.Unix(1000, 0)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	event := func(typ ssvtypes.DutyTraceEventType, d time.Duration) ssvtypes.DutyTraceEvent {
+		return ssvtypes.DutyTraceEvent{
+			Type:           typ,
+			Role:           spectypes.RoleProposer,
+			DutyExecutorID: pubKey,
+			Slot:           100,
+			Time:           at(d),
+		}
+	}
+
+	tracer.TraceDuty(event(ssvtypes.DutyStarted, 0))
+	for i := spectypes.OperatorID(1); i <= 3; i++ {
+		e := event(ssvtypes.PreConsensusSignature, time.Duration(i)*time.Millisecond)
+		e.Signers = []spectypes.OperatorID{i}
+		tracer.TraceDuty(e)
+	}
+	tracer.TraceDuty(event(ssvtypes.PreConsensusQuorum, 3*time.Millisecond))
+
+	proposal := event(ssvtypes.QBFTMessage, 10*time.Millisecond)
+	proposal.MsgType, proposal.Round, proposal.Root, proposal.Signers = specqbft.ProposalMsgType, 1, [32]byte{0xa}, []spectypes.OperatorID{1}
+	tracer.TraceDuty(proposal)
+	roundChange := event(ssvtypes.QBFTRoundChange, 20*time.Millisecond)
+	roundChange.Round = 2
+	tracer.TraceDuty(roundChange)
+	commit := event(ssvtypes.QBFTMessage, 30*time.Millisecond)
+	commit.MsgType, commit.Round, commit.Signers = specqbft.CommitMsgType, 2, []spectypes.OperatorID{2}
+	tracer.TraceDuty(commit)
+	decided := event(ssvtypes.QBFTDecided, 40*time.Millisecond)
+	decided.Round, decided.Root, decided.Signers = 2, [32]byte{0xb}, []spectypes.OperatorID{1, 2, 3}
+	tracer.TraceDuty(decided)
+
+	tracer.TraceDuty(event(ssvtypes.PostConsensusQuorum, 50*time.Millisecond))
+	failed := event(ssvtypes.BeaconSubmission, 60*time.Millisecond)
+	failed.Error = errors.New("timeout")
+	tracer.TraceDuty(failed)
+	tracer.TraceDuty(event(ssvtypes.BeaconSubmission, 70*time.Millisecond))
+
+	traces := tracer.Traces(Filter{})
+	require.Len(t, traces, 1)
+	trace := traces[0]
+	require.Equal(t, "PROPOSER", trace.Role)
+	require.Equal(t, "0102", trace.DutyExecutorID)
+	require.Equal(t, at(0), *trace.Started)
+
+	require.Len(t, trace.PreConsensus.Signatures, 3)
+	require.Equal(t, []spectypes.OperatorID{3}, trace.PreConsensus.Signatures[2].Signers)
+	require.Equal(t, at(3*time.Millisecond), *trace.PreConsensus.Quorum)
+
+	require.Len(t, trace.Consensus.Rounds, 2)
+	require.Equal(t, specqbft.Round(1), trace.Consensus.Rounds[0].Round)
+	require.Len(t, trace.Consensus.Rounds[0].Proposals, 1)
+	require.Equal(t, "0a"+strings.Repeat("00", 31), trace.Consensus.Rounds[0].ProposalRoot)
+	require.Equal(t, at(20*time.Millisecond), *trace.Consensus.Rounds[1].Started)
+	require.Len(t, trace.Consensus.Rounds[1].Commits, 1)
+	require.Equal(t, specqbft.Round(2), trace.Consensus.Decided.Round)
+	require.Equal(t, "0b"+strings.Repeat("00", 31), trace.Consensus.Decided.Root)
+
+	require.Empty(t, trace.PostConsensus.Signatures)
+	require.Equal(t, at(50*time.Millisecond), *trace.PostConsensus.Quorum)
+	require.Equal(t, []SubmissionTrace{
+		{Time: at(60 * time.Millisecond), Error: "timeout"},
+		{Time: at(70 * time.Millisecond)},
+	}, trace.Submissions)
+
+	// Returned traces aren't modified by later events.
+	tracer.TraceDuty(event(ssvtypes.BeaconSubmission, 80*time.Millisecond))
+	require.Len(t, trace.Submissions, 2)
+}
+
+func TestTracer_Traces(t *testing.T) {
+	tracer := New(10)
+	committeeID := spectypes.CommitteeID{0x3}
+	// Committee IDs are left-padded in message IDs.
+	paddedCommitteeID := append(make([]byte, 16), committeeID[:]...)
+
+	for slot := phase0.Slot(1); slot <= 20; slot++ {
+		tracer.TraceDuty(ssvtypes.DutyTraceEvent{Type: ssvtypes.DutyStarted, Role: spectypes.RoleCommittee, DutyExecutorID: committeeID[:], Slot: slot})
+		tracer.TraceDuty(ssvtypes.DutyTraceEvent{Type: ssvtypes.QBFTDecided, Role: spectypes.RoleCommittee, DutyExecutorID: paddedCommitteeID, Slot: slot})
+		tracer.TraceDuty(ssvtypes.DutyTraceEvent{Type: ssvtypes.DutyStarted, Role: spectypes.RoleAggregator, DutyExecutorID: []byte{0x1}, Slot: slot})
+	}
+
+	// Traces older than the retention are pruned, and late events for them are dropped.
+	tracer.TraceDuty(ssvtypes.DutyTraceEvent{Type: ssvtypes.DutyStarted, Role: spectypes.RoleProposer, DutyExecutorID: []byte{0x1}, Slot: 5})
+	traces := tracer.Traces(Filter{})
+	require.Len(t, traces, 22)
+	require.Equal(t, phase0.Slot(10), traces[0].Slot)
+	require.Equal(t, phase0.Slot(20), traces[len(traces)-1].Slot)
+
+	traces = tracer.Traces(Filter{FromSlot: 12, ToSlot: 13, Roles: []spectypes.RunnerRole{spectypes.RoleCommittee}})
+	require.Len(t, traces, 2)
+	for _, trace := range traces {
+		require.Equal(t, "COMMITTEE", trace.Role)
+		require.NotNil(t, trace.Started)
+		require.NotNil(t, trace.Consensus.Decided)
+	}
+
+	traces = tracer.Traces(Filter{DutyExecutorIDs: [][]byte{{0x1}}})
+	require.Len(t, traces, 11)
+	require.Equal(t, "AGGREGATOR", traces[0].Role)
+}
+
+func TestTracer_Concurrency(t *testing.T) {
+	tracer := New(4)
+	var wg sync.WaitGroup
+	for operator := spectypes.OperatorID(1); operator <= 4; operator++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for slot := phase0.Slot(1); slot <= 100; slot++ {
+				for _, validator := range []byte{0x1, 0x2} {
+					tracer.TraceDuty(ssvtypes.DutyTraceEvent{Type: ssvtypes.PostConsensusSignature, Role: spectypes.RoleProposer, DutyExecutorID: []byte{validator}, Slot: slot, Signers: []spectypes.OperatorID{operator}})
+				}
+				tracer.Traces(Filter{FromSlot: slot - 1})
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Every signature of the retained slots is traced once.
+	traces := tracer.Traces(Filter{})
+	require.Len(t, traces, 5*2)
+	for _, trace := range traces {
+		require.GreaterOrEqual(t, trace.Slot, phase0.Slot(96))
+		require.Len(t, trace.PostConsensus.Signatures, 4)
+	}
+}
@@ -33,6 +33,8 @@ type ControllerOptions struct {
 	RecipientStorage   storage.Recipients
 	SlotTickerProvider slotticker.Provider
 	OperatorDataStore  operatordatastore.OperatorDataStore
+	// ProposerSettings overrides the fee recipients of validators, if set.
+	ProposerSettings types.ProposerSettingsProvider
 }
 
 // recipientController implementation of RecipientController
@@ -44,6 +46,7 @@ type recipientController struct {
 	recipientStorage   storage.Recipients
 	slotTickerProvider slotticker.Provider
 	operatorDataStore  operatordatastore.OperatorDataStore
+	proposerSettings   types.ProposerSettingsProvider
 }
 
 func NewController(opts *ControllerOptions) *recipientController {
@@ -55,6 +58,7 @@ func NewController(opts *ControllerOptions) *recipientController {
 		recipientStorage:   opts.RecipientStorage,
 		slotTickerProvider: opts.SlotTickerProvider,
 		operatorDataStore:  opts.OperatorDataStore,
+		proposerSettings:   opts.ProposerSettings,
 	}
 }
 
@@ -156,6 +160,11 @@ func (rc *recipientController) toProposalPreparation(shares []*types.SSVShare) (
 		if !found {
 			copy(feeRecipient[:], share.OwnerAddress.Bytes())
 		}
+		if rc.proposerSettings != nil {
+			if settings := rc.proposerSettings.ProposerSettings(share.ValidatorPubKey); settings.FeeRecipient != nil {
+				feeRecipient = *settings.FeeRecipient
+			}
+		}
 		m[share.BeaconMetadata.Index] = feeRecipient
 	}
 
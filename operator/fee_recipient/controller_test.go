@@ -124,6 +124,47 @@ func TestSubmitProposal(t *testing.T) {
 	})
 }
 
+type testProposerSettings map[spectypes.ValidatorPK]types.ProposerSettings
+
+func (s testProposerSettings) ProposerSettings(pubKey spectypes.ValidatorPK) types.ProposerSettings {
+	return s[pubKey]
+}
+
+func TestToProposalPreparation_ProposerSettings(t *testing.T) {
+	logger := logging.TestLogger(t)
+	operatorData := &registrystorage.OperatorData{
+		ID: 123456789,
+	}
+
+	db, shareStorage, recipientStorage := createStorage(t)
+	defer db.Close()
+	populateStorage(t, logger, shareStorage, operatorData)
+	shares := shareStorage.List(nil, registrystorage.ByOperatorID(operatorData.ID))
+
+	override := bellatrix.ExecutionAddress{0x42}
+	frCtrl := NewController(&ControllerOptions{
+		Ctx:               context.TODO(),
+		Network:           networkconfig.TestNetwork,
+		ShareStorage:      shareStorage,
+		RecipientStorage:  recipientStorage,
+		OperatorDataStore: operatordatastore.New(operatorData),
+		ProposerSettings: testProposerSettings{
+			shares[0].ValidatorPubKey: {FeeRecipient: &override},
+		},
+	})
+
+	m, err := frCtrl.toProposalPreparation(shares)
+	require.NoError(t, err)
+	require.Len(t, m, len(shares))
+	for _, share := range shares {
+		expected := bellatrix.ExecutionAddress(share.OwnerAddress)
+		if share.ValidatorPubKey == shares[0].ValidatorPubKey {
+			expected = override
+		}
+		require.Equal(t, expected, m[share.BeaconMetadata.Index])
+	}
+}
+
 func createStorage(t *testing.T) (basedb.Database, registrystorage.Shares, registrystorage.Recipients) {
 	logger := logging.TestLogger(t)
 	db, err := kv.NewInMemory(logger, basedb.Options{})
@@ -3,7 +3,9 @@ package operator
 import (
 	"context"
 	"fmt"
+	"time"
 
+	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/eth/executionclient"
@@ -12,7 +14,9 @@ import (
 	"github.com/ssvlabs/ssv/logging"
 	"github.com/ssvlabs/ssv/logging/fields"
 	"github.com/ssvlabs/ssv/network"
+	"github.com/ssvlabs/ssv/network/syncing"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/doppelganger"
 	"github.com/ssvlabs/ssv/operator/duties"
 	"github.com/ssvlabs/ssv/operator/duties/dutystore"
 	"github.com/ssvlabs/ssv/operator/fee_recipient"
@@ -24,6 +28,9 @@ import (
 	"github.com/ssvlabs/ssv/storage/basedb"
 )
 
+// decidedBackfillDelay is the time given to the network to connect to peers before backfilling decided instances.
+const decidedBackfillDelay = 30 * time.Second
+
 // Node represents the behavior of SSV node
 type Node interface {
 	Start(logger *zap.Logger) error
@@ -33,6 +40,7 @@ type Node interface {
 type Options struct {
 	// NetworkName is the network name of this node
 	NetworkName         string `yaml:"Network" env:"NETWORK" env-default:"mainnet" env-description:"Network is the network of this node"`
+	NetworkConfigFile   string `yaml:"NetworkConfigFile" env:"NETWORK_CONFIG_FILE" env-description:"Path to a YAML or JSON network definition file, overrides Network"`
 	CustomDomainType    string `yaml:"CustomDomainType" env:"CUSTOM_DOMAIN_TYPE" env-default:"" env-description:"Override the SSV domain type. This is used to isolate the node from the rest of the network. Do not set unless you know what you are doing. This would be incremented by 1 for Alan, for example: 0x01020304 becomes 0x01020305 post-fork."`
 	Network             networkconfig.NetworkConfig
 	BeaconNode          beaconprotocol.BeaconNode // TODO: consider renaming to ConsensusClient
@@ -47,6 +55,11 @@ type Options struct {
 	WS                  api.WebSocketServer
 	WsAPIPort           int
 	Metrics             nodeMetrics
+	DoppelgangerEpochs  uint64 `yaml:"DoppelgangerEpochs" env:"DOPPELGANGER_EPOCHS" env-default:"0" env-description:"Number of epochs to watch for another instance of this operator before starting validators (0 to disable, otherwise at least 2)"`
+	Doppelganger        *doppelganger.Service
+	AnalyticsEpochs     uint64                       `yaml:"AnalyticsEpochs" env:"ANALYTICS_EPOCHS" env-default:"0" env-description:"Number of recent epochs to compute the participation of operators over, in exporter mode (0 to disable)"`
+	DecidedRetention    qbftstorage.RetentionOptions `yaml:"DecidedRetention"`
+	DutyTraceSlots      uint64                       `yaml:"DutyTraceSlots" env:"DUTY_TRACE_SLOTS" env-default:"0" env-description:"Number of recent slots to keep duty execution traces for, served by the SSV API at /v1/duties (0 to disable)"`
 }
 
 // operatorNode implements Node interface
@@ -60,8 +73,11 @@ type operatorNode struct {
 	net              network.P2PNetwork
 	storage          storage.Storage
 	qbftStorage      *qbftstorage.QBFTStores
+	validatorStore   storage2.SelfValidatorStore
+	decidedSyncer    *syncing.Syncer
 	dutyScheduler    *duties.Scheduler
 	feeRecipientCtrl fee_recipient.RecipientController
+	doppelganger     *doppelganger.Service
 
 	ws        api.WebSocketServer
 	wsAPIPort int
@@ -71,6 +87,8 @@ type operatorNode struct {
 
 // New is the constructor of operatorNode
 func New(logger *zap.Logger, opts Options, slotTickerProvider slotticker.Provider, qbftStorage *qbftstorage.QBFTStores) Node {
+	validatorStore := opts.ValidatorStore.WithOperatorID(opts.ValidatorOptions.OperatorDataStore.GetOperatorID)
+
 	node := &operatorNode{
 		context:          opts.Context,
 		validatorsCtrl:   opts.ValidatorController,
@@ -81,12 +99,22 @@ func New(logger *zap.Logger, opts Options, slotTickerProvider slotticker.Provide
 		net:              opts.P2PNetwork,
 		storage:          opts.ValidatorOptions.RegistryStorage,
 		qbftStorage:      qbftStorage,
+		validatorStore:   validatorStore,
+		decidedSyncer: syncing.New(logger.Named(logging.NameDecidedSyncer), syncing.Options{
+			Network:        opts.P2PNetwork,
+			Stores:         qbftStorage,
+			ValidatorStore: opts.ValidatorStore,
+			Operators:      opts.ValidatorOptions.RegistryStorage,
+			OperatorID:     opts.ValidatorOptions.OperatorDataStore.GetOperatorID,
+			FullNode:       opts.ValidatorOptions.FullNode,
+			BatchSize:      opts.ValidatorOptions.HistorySyncBatchSize,
+		}),
 		dutyScheduler: duties.NewScheduler(&duties.SchedulerOptions{
 			Ctx:                 opts.Context,
 			BeaconNode:          opts.BeaconNode,
 			ExecutionClient:     opts.ExecutionClient,
 			Network:             opts.Network,
-			ValidatorProvider:   opts.ValidatorStore.WithOperatorID(opts.ValidatorOptions.OperatorDataStore.GetOperatorID),
+			ValidatorProvider:   validatorStore,
 			ValidatorController: opts.ValidatorController,
 			DutyExecutor:        opts.ValidatorController,
 			IndicesChg:          opts.ValidatorController.IndicesChangeChan(),
@@ -103,8 +131,11 @@ func New(logger *zap.Logger, opts Options, slotTickerProvider slotticker.Provide
 			RecipientStorage:   opts.ValidatorOptions.RegistryStorage,
 			OperatorDataStore:  opts.ValidatorOptions.OperatorDataStore,
 			SlotTickerProvider: slotTickerProvider,
+			ProposerSettings:   opts.ValidatorOptions.ProposerSettings,
 		}),
 
+		doppelganger: opts.Doppelganger,
+
 		ws:        opts.WS,
 		wsAPIPort: opts.WsAPIPort,
 
@@ -150,11 +181,15 @@ func (n *operatorNode) Start(logger *zap.Logger) error {
 	go n.net.UpdateSubnets(logger)
 	go n.net.UpdateScoreParams(logger)
 	n.validatorsCtrl.ForkListener(logger)
+	if err := n.watchDoppelganger(logger); err != nil {
+		return err
+	}
 	n.validatorsCtrl.StartValidators()
 	go n.reportOperators(logger)
 
 	go n.feeRecipientCtrl.Start(logger)
 	go n.validatorsCtrl.UpdateValidatorMetaDataLoop()
+	go n.backfillDecided(logger)
 
 	if err := n.dutyScheduler.Wait(); err != nil {
 		logger.Fatal("duty scheduler exited with error", zap.Error(err))
@@ -163,6 +198,58 @@ func (n *operatorNode) Start(logger *zap.Logger) error {
 	return nil
 }
 
+// watchDoppelganger blocks until the doppelganger phase passes, if enabled,
+// and fails if another instance of this operator or of its validators was detected.
+func (n *operatorNode) watchDoppelganger(logger *zap.Logger) error {
+	if n.doppelganger == nil {
+		return nil
+	}
+
+	// Subscribe to the committees in advance to observe their messages.
+	for _, share := range n.validatorStore.SelfValidators() {
+		if err := n.net.Subscribe(share.ValidatorPubKey); err != nil {
+			logger.Warn("could not subscribe to validator", fields.PubKey(share.ValidatorPubKey[:]), zap.Error(err))
+		}
+	}
+
+	if err := n.doppelganger.Run(n.context); err != nil {
+		return fmt.Errorf("refusing to start validators: %w", err)
+	}
+	return nil
+}
+
+// backfillDecided syncs the decided instances of the operator's committees and validators
+// which were missed while the node was offline.
+func (n *operatorNode) backfillDecided(logger *zap.Logger) {
+	if !n.network.PastAlanFork() {
+		return
+	}
+
+	select {
+	case <-time.After(decidedBackfillDelay):
+	case <-n.context.Done():
+		return
+	}
+
+	domain := n.network.AlanDomainType
+	var mids []spectypes.MessageID
+	for _, committee := range n.validatorStore.SelfCommittees() {
+		mids = append(mids, spectypes.NewMsgID(domain, committee.ID[:], spectypes.RoleCommittee))
+	}
+	for _, share := range n.validatorStore.SelfValidators() {
+		for _, role := range []spectypes.RunnerRole{
+			spectypes.RoleProposer,
+			spectypes.RoleAggregator,
+			spectypes.RoleSyncCommitteeContribution,
+		} {
+			mids = append(mids, spectypes.NewMsgID(domain, share.ValidatorPubKey[:], role))
+		}
+	}
+
+	logger.Debug("backfilling decided instances", fields.Count(len(mids)))
+	n.decidedSyncer.Backfill(n.context, mids...)
+}
+
 // HealthCheck returns a list of issues regards the state of the operator node
 func (n *operatorNode) HealthCheck() error {
 	// TODO: previously this checked availability of consensus & execution clients.
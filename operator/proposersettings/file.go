@@ -0,0 +1,153 @@
+package proposersettings
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/ethereum/go-ethereum/common"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+)
+
+// maxGraffitiLength is the size of the graffiti field of beacon blocks.
+const maxGraffitiLength = 32
+
+// File is the proposer settings file. The settings of a validator take precedence
+// over the settings of its owner, which take precedence over the defaults.
+// Validators are keyed by their hex public key and owners by their address.
+type File struct {
+	Default    Settings            `yaml:"default"`
+	Owners     map[string]Settings `yaml:"owners"`
+	Validators map[string]Settings `yaml:"validators"`
+}
+
+// Settings are proposer settings in the file, where unset fields fall back to the next level.
+//
+// The gas limit and fee recipient are signed in builder registrations by all operators
+// of a validator, so they must be set to the same values on all of them.
+type Settings struct {
+	Graffiti           *string `yaml:"graffiti"`
+	GasLimit           *uint64 `yaml:"gas_limit"`
+	FeeRecipient       *string `yaml:"fee_recipient"`
+	BuilderBoostFactor *uint64 `yaml:"builder_boost_factor"`
+	// LocalOnly is a shorthand for a builder boost factor of 0.
+	LocalOnly bool `yaml:"local_only"`
+}
+
+// settings are the parsed proposer settings of a file.
+type settings struct {
+	defaults   types.ProposerSettings
+	owners     map[common.Address]types.ProposerSettings
+	validators map[spectypes.ValidatorPK]types.ProposerSettings
+}
+
+// parseFile decodes and validates a proposer settings file.
+func parseFile(data []byte) (*settings, error) {
+	var file File
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("could not decode proposer settings: %w", err)
+	}
+
+	defaults, err := file.Default.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid default settings: %w", err)
+	}
+	s := &settings{
+		defaults:   defaults,
+		owners:     make(map[common.Address]types.ProposerSettings, len(file.Owners)),
+		validators: make(map[spectypes.ValidatorPK]types.ProposerSettings, len(file.Validators)),
+	}
+	for key, owner := range file.Owners {
+		if !common.IsHexAddress(key) {
+			return nil, fmt.Errorf("invalid owner address %q", key)
+		}
+		parsed, err := owner.parse()
+		if err != nil {
+			return nil, fmt.Errorf("invalid settings of owner %s: %w", key, err)
+		}
+		s.owners[common.HexToAddress(key)] = parsed
+	}
+	for key, validator := range file.Validators {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+		if err != nil || len(pubKey) != len(spectypes.ValidatorPK{}) {
+			return nil, fmt.Errorf("invalid validator public key %q", key)
+		}
+		parsed, err := validator.parse()
+		if err != nil {
+			return nil, fmt.Errorf("invalid settings of validator %s: %w", key, err)
+		}
+		s.validators[spectypes.ValidatorPK(pubKey)] = parsed
+	}
+	return s, nil
+}
+
+func (s Settings) parse() (types.ProposerSettings, error) {
+	var parsed types.ProposerSettings
+	if s.Graffiti != nil {
+		if len(*s.Graffiti) > maxGraffitiLength {
+			return parsed, fmt.Errorf("graffiti is longer than %d bytes", maxGraffitiLength)
+		}
+		parsed.Graffiti = []byte(*s.Graffiti)
+	}
+	if s.GasLimit != nil {
+		if *s.GasLimit == 0 {
+			return parsed, errors.New("gas limit must be positive")
+		}
+		parsed.GasLimit = *s.GasLimit
+	}
+	if s.FeeRecipient != nil {
+		if !common.IsHexAddress(*s.FeeRecipient) {
+			return parsed, fmt.Errorf("invalid fee recipient %q", *s.FeeRecipient)
+		}
+		feeRecipient := bellatrix.ExecutionAddress(common.HexToAddress(*s.FeeRecipient))
+		parsed.FeeRecipient = &feeRecipient
+	}
+	if s.LocalOnly {
+		if s.BuilderBoostFactor != nil && *s.BuilderBoostFactor != 0 {
+			return parsed, errors.New("local only conflicts with a non-zero builder boost factor")
+		}
+		var localOnly uint64
+		parsed.BuilderBoostFactor = &localOnly
+	} else if s.BuilderBoostFactor != nil {
+		builderBoostFactor := *s.BuilderBoostFactor
+		parsed.BuilderBoostFactor = &builderBoostFactor
+	}
+	return parsed, nil
+}
+
+// resolve returns the settings of the validator, merged with the settings of its owner and the defaults.
+func (s *settings) resolve(pubKey spectypes.ValidatorPK, owner func() (common.Address, bool)) types.ProposerSettings {
+	resolved := s.defaults
+	if len(s.owners) > 0 {
+		if address, ok := owner(); ok {
+			resolved = merge(resolved, s.owners[address])
+		}
+	}
+	return merge(resolved, s.validators[pubKey])
+}
+
+// merge returns the base settings with the fields set in the override.
+func merge(base, override types.ProposerSettings) types.ProposerSettings {
+	if override.Graffiti != nil {
+		base.Graffiti = override.Graffiti
+	}
+	if override.GasLimit != 0 {
+		base.GasLimit = override.GasLimit
+	}
+	if override.FeeRecipient != nil {
+		base.FeeRecipient = override.FeeRecipient
+	}
+	if override.BuilderBoostFactor != nil {
+		base.BuilderBoostFactor = override.BuilderBoostFactor
+	}
+	return base
+}
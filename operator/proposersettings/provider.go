@@ -0,0 +1,98 @@
+package proposersettings
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+// reloadInterval is the interval at which the file is checked for changes.
+var reloadInterval = 10 * time.Second
+
+// Provider provides the proposer settings of a file, which is reloaded when it changes.
+type Provider struct {
+	logger     *zap.Logger
+	path       string
+	validators registrystorage.BaseValidatorStore
+
+	settings atomic.Pointer[settings]
+	contents []byte
+}
+
+var _ types.ProposerSettingsProvider = (*Provider)(nil)
+
+// New loads the proposer settings file at the given path.
+// The owners of validators are looked up in the given validator store.
+func New(logger *zap.Logger, path string, validators registrystorage.BaseValidatorStore) (*Provider, error) {
+	p := &Provider{
+		logger:     logger,
+		path:       filepath.Clean(path),
+		validators: validators,
+	}
+	if _, err := p.reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Start reloads the file whenever it changes, until the context is done.
+// An invalid file is logged and the previous settings are kept.
+func (p *Provider) Start(ctx context.Context) {
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := p.reload()
+			if err != nil {
+				p.logger.Error("could not reload proposer settings, keeping previous settings", zap.Error(err))
+				continue
+			}
+			if changed {
+				p.logger.Info("reloaded proposer settings", zap.String("path", p.path))
+			}
+		}
+	}
+}
+
+// ProposerSettings returns the settings of the validator, merged with the settings of its owner and the defaults.
+func (p *Provider) ProposerSettings(pubKey spectypes.ValidatorPK) types.ProposerSettings {
+	return p.settings.Load().resolve(pubKey, func() (common.Address, bool) {
+		share, ok := p.validators.Validator(pubKey[:])
+		if !ok {
+			return common.Address{}, false
+		}
+		return share.OwnerAddress, true
+	})
+}
+
+// reload parses the file if its contents changed, and reports whether they did.
+func (p *Provider) reload() (bool, error) {
+	contents, err := os.ReadFile(p.path)
+	if err != nil {
+		return false, fmt.Errorf("could not read proposer settings: %w", err)
+	}
+	if p.settings.Load() != nil && bytes.Equal(contents, p.contents) {
+		return false, nil
+	}
+	s, err := parseFile(contents)
+	if err != nil {
+		return false, err
+	}
+	p.settings.Store(s)
+	p.contents = contents
+	return true, nil
+}
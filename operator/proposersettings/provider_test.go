@@ -0,0 +1,121 @@
+package proposersettings
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/ethereum/go-ethereum/common"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/registry/storage/mocks"
+)
+
+const testSettings = `
+default:
+  graffiti: SSV.Network
+  builder_boost_factor: 90
+owners:
+  "0x1111111111111111111111111111111111111111":
+    gas_limit: 36000000
+    fee_recipient: "0x2222222222222222222222222222222222222222"
+validators:
+  "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa":
+    graffiti: my validator
+    local_only: true
+`
+
+func TestProvider(t *testing.T) {
+	defer func(interval time.Duration) { reloadInterval = interval }(reloadInterval)
+	reloadInterval = 10 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "proposer-settings.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testSettings), 0600))
+
+	var ownedPK, otherPK, ownerlessPK spectypes.ValidatorPK
+	for i := range ownedPK {
+		ownedPK[i] = 0xaa
+		otherPK[i] = 0xbb
+		ownerlessPK[i] = 0xcc
+	}
+	owner := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	ctrl := gomock.NewController(t)
+	validators := mocks.NewMockBaseValidatorStore(ctrl)
+	validators.EXPECT().Validator(gomock.Any()).DoAndReturn(func(pubKey []byte) (*types.SSVShare, bool) {
+		if spectypes.ValidatorPK(pubKey) == ownerlessPK {
+			return nil, false
+		}
+		return &types.SSVShare{Metadata: types.Metadata{OwnerAddress: owner}}, true
+	}).AnyTimes()
+
+	p, err := New(logging.TestLogger(t), path, validators)
+	require.NoError(t, err)
+
+	localOnly, boost := uint64(0), uint64(90)
+	feeRecipient := bellatrix.ExecutionAddress(common.HexToAddress("0x2222222222222222222222222222222222222222"))
+
+	// The validator's settings take precedence over its owner's, which take precedence over the defaults.
+	require.Equal(t, types.ProposerSettings{
+		Graffiti:           []byte("my validator"),
+		GasLimit:           36000000,
+		FeeRecipient:       &feeRecipient,
+		BuilderBoostFactor: &localOnly,
+	}, p.ProposerSettings(ownedPK))
+	require.Equal(t, types.ProposerSettings{
+		Graffiti:           []byte("SSV.Network"),
+		GasLimit:           36000000,
+		FeeRecipient:       &feeRecipient,
+		BuilderBoostFactor: &boost,
+	}, p.ProposerSettings(otherPK))
+	require.Equal(t, types.ProposerSettings{
+		Graffiti:           []byte("SSV.Network"),
+		BuilderBoostFactor: &boost,
+	}, p.ProposerSettings(ownerlessPK))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.Start(ctx)
+
+	// An invalid file keeps the previous settings.
+	require.NoError(t, os.WriteFile(path, []byte("default:\n  gas_limit: 0\n"), 0600))
+	time.Sleep(5 * reloadInterval)
+	require.Equal(t, []byte("SSV.Network"), p.ProposerSettings(otherPK).Graffiti)
+
+	// Changes are reloaded.
+	require.NoError(t, os.WriteFile(path, []byte("default:\n  graffiti: reloaded\n"), 0600))
+	require.Eventually(t, func() bool {
+		return string(p.ProposerSettings(otherPK).Graffiti) == "reloaded"
+	}, time.Second, reloadInterval)
+	require.Equal(t, types.ProposerSettings{Graffiti: []byte("reloaded")}, p.ProposerSettings(ownedPK))
+}
+
+func TestParseFile(t *testing.T) {
+	s, err := parseFile(nil)
+	require.NoError(t, err)
+	require.Equal(t, types.ProposerSettings{}, s.defaults)
+
+	invalid := map[string]string{
+		"unknown field":        "default:\n  grafiti: typo\n",
+		"long graffiti":        "default:\n  graffiti: 0123456789012345678901234567890123\n",
+		"zero gas limit":       "default:\n  gas_limit: 0\n",
+		"invalid fee":          "default:\n  fee_recipient: 0x1234\n",
+		"conflicting boost":    "default:\n  local_only: true\n  builder_boost_factor: 100\n",
+		"invalid owner":        "owners:\n  nope:\n    gas_limit: 1\n",
+		"invalid validator":    "validators:\n  \"0x1234\":\n    gas_limit: 1\n",
+		"invalid owner values": "owners:\n  \"0x1111111111111111111111111111111111111111\":\n    gas_limit: 0\n",
+	}
+	for name, data := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseFile([]byte(data))
+			require.Error(t, err)
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 type ConfigLock struct {
 	NetworkName      string `json:"network_name"`
 	UsingLocalEvents bool   `json:"using_local_events"`
+	// NetworkDefinitionHash is the hash of the network definition file, empty for built-in networks.
+	NetworkDefinitionHash string `json:"network_definition_hash,omitempty"`
 }
 
 func (stored *ConfigLock) ValidateCompatibility(current *ConfigLock) error {
@@ -14,6 +16,10 @@ func (stored *ConfigLock) ValidateCompatibility(current *ConfigLock) error {
 		return fmt.Errorf("network mismatch. Stored network %s does not match current network %s. The database must be removed or reinitialized", stored.NetworkName, current.NetworkName)
 	}
 
+	if stored.NetworkDefinitionHash != current.NetworkDefinitionHash {
+		return fmt.Errorf("network definition mismatch. Stored definition hash %q does not match current definition hash %q. The database must be removed or reinitialized", stored.NetworkDefinitionHash, current.NetworkDefinitionHash)
+	}
+
 	if stored.UsingLocalEvents && !current.UsingLocalEvents {
 		return fmt.Errorf("disabling local events is not allowed. The database must be removed or reinitialized")
 	}
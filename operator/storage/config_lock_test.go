@@ -62,4 +62,20 @@ func TestConfigLock(t *testing.T) {
 
 		require.Error(t, c1.ValidateCompatibility(c2))
 	})
+
+	t.Run("only network definition hash is different", func(t *testing.T) {
+		c1 := &ConfigLock{
+			NetworkName:           "test",
+			UsingLocalEvents:      true,
+			NetworkDefinitionHash: "a1",
+		}
+
+		c2 := &ConfigLock{
+			NetworkName:           "test",
+			UsingLocalEvents:      true,
+			NetworkDefinitionHash: "a2",
+		}
+
+		require.ErrorContains(t, c1.ValidateCompatibility(c2), "network definition mismatch")
+	})
 }
@@ -26,336 +26,372 @@ var (
 )
 
 func TestSaveAndGetPrivateKeyHash(t *testing.T) {
-	logger := logging.TestLogger(t)
-	db, err := kv.NewInMemory(logger, basedb.Options{})
-	require.NoError(t, err)
-	defer func() {
-		_ = db.Close()
-	}()
-
-	operatorStorage := storage{
-		db: db,
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+			db, err := kv.OpenInMemory(logger, basedb.Options{Engine: engine})
+			require.NoError(t, err)
+			defer func() {
+				_ = db.Close()
+			}()
+
+			operatorStorage := storage{
+				db: db,
+			}
+
+			parsedPrivKey, err := keys.PrivateKeyFromString(skPem)
+			require.NoError(t, err)
+
+			parsedPrivKeyHash, err := parsedPrivKey.StorageHash()
+			require.NoError(t, err)
+
+			encodedPubKey, err := parsedPrivKey.Public().Base64()
+			require.NoError(t, err)
+			require.Equal(t, pkPem, string(encodedPubKey))
+
+			require.NoError(t, operatorStorage.SavePrivateKeyHash(parsedPrivKeyHash))
+			extractedHash, found, err := operatorStorage.GetPrivateKeyHash()
+			require.True(t, true, found)
+			require.NoError(t, err)
+			require.Equal(t, parsedPrivKeyHash, extractedHash)
+		})
 	}
-
-	parsedPrivKey, err := keys.PrivateKeyFromString(skPem)
-	require.NoError(t, err)
-
-	parsedPrivKeyHash, err := parsedPrivKey.StorageHash()
-	require.NoError(t, err)
-
-	encodedPubKey, err := parsedPrivKey.Public().Base64()
-	require.NoError(t, err)
-	require.Equal(t, pkPem, string(encodedPubKey))
-
-	require.NoError(t, operatorStorage.SavePrivateKeyHash(parsedPrivKeyHash))
-	extractedHash, found, err := operatorStorage.GetPrivateKeyHash()
-	require.True(t, true, found)
-	require.NoError(t, err)
-	require.Equal(t, parsedPrivKeyHash, extractedHash)
 }
 
 func TestDropRegistryData(t *testing.T) {
-	logger := logging.TestLogger(t)
-	db, err := kv.NewInMemory(logger, basedb.Options{})
-	require.NoError(t, err)
-	defer func() {
-		_ = db.Close()
-	}()
-
-	storage, err := NewNodeStorage(logger, db)
-	require.NoError(t, err)
-
-	// Save operators, shares and recipients.
-	var (
-		operatorIDs     = []uint64{1, 2, 3}
-		sharePubKeys    = [][]byte{{1}, {2}, {3}}
-		recipientOwners = []common.Address{{1}, {2}, {3}}
-	)
-	for _, id := range operatorIDs {
-		found, err := storage.SaveOperatorData(nil, &registrystorage.OperatorData{
-			ID:           id,
-			PublicKey:    []byte("publicKey"),
-			OwnerAddress: common.Address{byte(id)},
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+			db, err := kv.OpenInMemory(logger, basedb.Options{Engine: engine})
+			require.NoError(t, err)
+			defer func() {
+				_ = db.Close()
+			}()
+
+			storage, err := NewNodeStorage(logger, db)
+			require.NoError(t, err)
+
+			// Save operators, shares and recipients.
+			var (
+				operatorIDs     = []uint64{1, 2, 3}
+				sharePubKeys    = [][]byte{{1}, {2}, {3}}
+				recipientOwners = []common.Address{{1}, {2}, {3}}
+			)
+			for _, id := range operatorIDs {
+				found, err := storage.SaveOperatorData(nil, &registrystorage.OperatorData{
+					ID:           id,
+					PublicKey:    []byte("publicKey"),
+					OwnerAddress: common.Address{byte(id)},
+				})
+				require.NoError(t, err)
+				require.False(t, found)
+
+				found, err = storage.OperatorsExist(nil, []spectypes.OperatorID{id})
+				require.NoError(t, err)
+				require.True(t, found)
+			}
+			for _, pk := range sharePubKeys {
+				err := storage.Shares().Save(nil, &types.SSVShare{
+					Share: spectypes.Share{
+						SharePubKey:     pk,
+						ValidatorPubKey: spectypes.ValidatorPK(append(make([]byte, 47), pk...)),
+					},
+				})
+				require.NoError(t, err)
+			}
+			for _, owner := range recipientOwners {
+				var fr bellatrix.ExecutionAddress
+				copy(fr[:], append([]byte{1}, owner[:]...))
+				_, err := storage.SaveRecipientData(nil, &registrystorage.RecipientData{
+					Owner:        owner,
+					FeeRecipient: fr,
+				})
+				require.NoError(t, err)
+
+			}
+
+			// Check that everything was saved.
+			requireSaved := func(t *testing.T, operators, shares, recipients int) {
+				allOperators, err := storage.ListOperators(nil, 0, 0)
+				require.NoError(t, err)
+				require.Len(t, allOperators, operators)
+
+				allShares := storage.Shares().List(nil)
+				require.NoError(t, err)
+				require.Len(t, allShares, shares)
+
+				allRecipients, err := storage.GetRecipientDataMany(nil, recipientOwners)
+				require.NoError(t, err)
+				require.Len(t, allRecipients, recipients)
+			}
+			requireSaved(t, len(operatorIDs), len(sharePubKeys), len(recipientOwners))
+
+			// Re-open storage and check again that everything is still saved.
+			// Re-opening helps ensure that the changes were persisted and not just cached.
+			storage, err = NewNodeStorage(logger, db)
+			require.NoError(t, err)
+			requireSaved(t, len(operatorIDs), len(sharePubKeys), len(recipientOwners))
+
+			// Drop registry data.
+			err = storage.DropRegistryData()
+			require.NoError(t, err)
+
+			// Check that everything was dropped.
+			requireSaved(t, 0, 0, 0)
+
+			// Re-open storage and check again that everything is still dropped.
+			storage, err = NewNodeStorage(logger, db)
+			require.NoError(t, err)
 		})
-		require.NoError(t, err)
-		require.False(t, found)
-
-		found, err = storage.OperatorsExist(nil, []spectypes.OperatorID{id})
-		require.NoError(t, err)
-		require.True(t, found)
-	}
-	for _, pk := range sharePubKeys {
-		err := storage.Shares().Save(nil, &types.SSVShare{
-			Share: spectypes.Share{
-				SharePubKey:     pk,
-				ValidatorPubKey: spectypes.ValidatorPK(append(make([]byte, 47), pk...)),
-			},
-		})
-		require.NoError(t, err)
-	}
-	for _, owner := range recipientOwners {
-		var fr bellatrix.ExecutionAddress
-		copy(fr[:], append([]byte{1}, owner[:]...))
-		_, err := storage.SaveRecipientData(nil, &registrystorage.RecipientData{
-			Owner:        owner,
-			FeeRecipient: fr,
-		})
-		require.NoError(t, err)
-
-	}
-
-	// Check that everything was saved.
-	requireSaved := func(t *testing.T, operators, shares, recipients int) {
-		allOperators, err := storage.ListOperators(nil, 0, 0)
-		require.NoError(t, err)
-		require.Len(t, allOperators, operators)
-
-		allShares := storage.Shares().List(nil)
-		require.NoError(t, err)
-		require.Len(t, allShares, shares)
-
-		allRecipients, err := storage.GetRecipientDataMany(nil, recipientOwners)
-		require.NoError(t, err)
-		require.Len(t, allRecipients, recipients)
 	}
-	requireSaved(t, len(operatorIDs), len(sharePubKeys), len(recipientOwners))
-
-	// Re-open storage and check again that everything is still saved.
-	// Re-opening helps ensure that the changes were persisted and not just cached.
-	storage, err = NewNodeStorage(logger, db)
-	require.NoError(t, err)
-	requireSaved(t, len(operatorIDs), len(sharePubKeys), len(recipientOwners))
-
-	// Drop registry data.
-	err = storage.DropRegistryData()
-	require.NoError(t, err)
-
-	// Check that everything was dropped.
-	requireSaved(t, 0, 0, 0)
-
-	// Re-open storage and check again that everything is still dropped.
-	storage, err = NewNodeStorage(logger, db)
-	require.NoError(t, err)
 }
 
 func TestNetworkAndLocalEventsConfig(t *testing.T) {
-	logger := logging.TestLogger(t)
-	db, err := kv.NewInMemory(logger, basedb.Options{})
-	require.NoError(t, err)
-	defer func() {
-		_ = db.Close()
-	}()
-
-	storage, err := NewNodeStorage(logger, db)
-	require.NoError(t, err)
-
-	storedCfg, found, err := storage.GetConfig(nil)
-	require.NoError(t, err)
-	require.False(t, found)
-	require.Nil(t, storedCfg)
-
-	c1 := &ConfigLock{
-		NetworkName:      networkconfig.TestNetwork.Name,
-		UsingLocalEvents: false,
-	}
-	require.NoError(t, storage.SaveConfig(nil, c1))
-
-	storedCfg, found, err = storage.GetConfig(nil)
-	require.NoError(t, err)
-	require.True(t, found)
-	require.Equal(t, c1, storedCfg)
-
-	c2 := &ConfigLock{
-		NetworkName:      networkconfig.TestNetwork.Name + "1",
-		UsingLocalEvents: false,
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+			db, err := kv.OpenInMemory(logger, basedb.Options{Engine: engine})
+			require.NoError(t, err)
+			defer func() {
+				_ = db.Close()
+			}()
+
+			storage, err := NewNodeStorage(logger, db)
+			require.NoError(t, err)
+
+			storedCfg, found, err := storage.GetConfig(nil)
+			require.NoError(t, err)
+			require.False(t, found)
+			require.Nil(t, storedCfg)
+
+			c1 := &ConfigLock{
+				NetworkName:      networkconfig.TestNetwork.Name,
+				UsingLocalEvents: false,
+			}
+			require.NoError(t, storage.SaveConfig(nil, c1))
+
+			storedCfg, found, err = storage.GetConfig(nil)
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, c1, storedCfg)
+
+			c2 := &ConfigLock{
+				NetworkName:      networkconfig.TestNetwork.Name + "1",
+				UsingLocalEvents: false,
+			}
+			require.NoError(t, storage.SaveConfig(nil, c2))
+
+			storedCfg, found, err = storage.GetConfig(nil)
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, c2, storedCfg)
+		})
 	}
-	require.NoError(t, storage.SaveConfig(nil, c2))
-
-	storedCfg, found, err = storage.GetConfig(nil)
-	require.NoError(t, err)
-	require.True(t, found)
-	require.Equal(t, c2, storedCfg)
 }
 
 func TestGetOperatorsPrefix(t *testing.T) {
-	logger := logging.TestLogger(t)
-	db, err := kv.NewInMemory(logger, basedb.Options{})
-	defer func() {
-		_ = db.Close()
-	}()
-
-	require.NoError(t, err)
-
-	operatorStorage, err := NewNodeStorage(logger, db)
-	require.NoError(t, err)
-	require.Equal(t, []byte("operators"), operatorStorage.GetOperatorsPrefix())
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+			db, err := kv.OpenInMemory(logger, basedb.Options{Engine: engine})
+			defer func() {
+				_ = db.Close()
+			}()
+
+			require.NoError(t, err)
+
+			operatorStorage, err := NewNodeStorage(logger, db)
+			require.NoError(t, err)
+			require.Equal(t, []byte("operators"), operatorStorage.GetOperatorsPrefix())
+		})
+	}
 }
 
 func TestGetRecipientsPrefix(t *testing.T) {
-	logger := logging.TestLogger(t)
-	db, err := kv.NewInMemory(logger, basedb.Options{})
-	defer func() {
-		_ = db.Close()
-	}()
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+			db, err := kv.OpenInMemory(logger, basedb.Options{Engine: engine})
+			defer func() {
+				_ = db.Close()
+			}()
 
-	require.NoError(t, err)
+			require.NoError(t, err)
 
-	operatorStorage, err := NewNodeStorage(logger, db)
-	require.NoError(t, err)
+			operatorStorage, err := NewNodeStorage(logger, db)
+			require.NoError(t, err)
 
-	require.Equal(t, []byte("recipients"), operatorStorage.GetRecipientsPrefix())
+			require.Equal(t, []byte("recipients"), operatorStorage.GetRecipientsPrefix())
+		})
+	}
 }
 
 func Test_Config(t *testing.T) {
-	logger := logging.TestLogger(t)
-	db, err := kv.NewInMemory(logger, basedb.Options{})
-	defer func() {
-		_ = db.Close()
-	}()
-
-	require.NoError(t, err)
-
-	operatorStorage, err := NewNodeStorage(logger, db)
-	require.NoError(t, err)
-
-	cfgData := &ConfigLock{
-		NetworkName:      "test",
-		UsingLocalEvents: false,
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+			db, err := kv.OpenInMemory(logger, basedb.Options{Engine: engine})
+			defer func() {
+				_ = db.Close()
+			}()
+
+			require.NoError(t, err)
+
+			operatorStorage, err := NewNodeStorage(logger, db)
+			require.NoError(t, err)
+
+			cfgData := &ConfigLock{
+				NetworkName:      "test",
+				UsingLocalEvents: false,
+			}
+
+			err = operatorStorage.SaveConfig(nil, cfgData)
+			require.NoError(t, err)
+
+			cfg, validAndFound, err := operatorStorage.GetConfig(nil)
+			require.NoError(t, err)
+			require.True(t, validAndFound)
+			require.NotNil(t, cfg)
+			require.Equal(t, cfgData.NetworkName, cfg.NetworkName)
+			require.Equal(t, cfgData.UsingLocalEvents, cfg.UsingLocalEvents)
+
+			require.NoError(t, operatorStorage.DeleteConfig(nil))
+
+			cfg, validAndFound, err = operatorStorage.GetConfig(nil)
+			require.NoError(t, err)
+			require.False(t, validAndFound)
+			require.Nil(t, cfg)
+		})
 	}
-
-	err = operatorStorage.SaveConfig(nil, cfgData)
-	require.NoError(t, err)
-
-	cfg, validAndFound, err := operatorStorage.GetConfig(nil)
-	require.NoError(t, err)
-	require.True(t, validAndFound)
-	require.NotNil(t, cfg)
-	require.Equal(t, cfgData.NetworkName, cfg.NetworkName)
-	require.Equal(t, cfgData.UsingLocalEvents, cfg.UsingLocalEvents)
-
-	require.NoError(t, operatorStorage.DeleteConfig(nil))
-
-	cfg, validAndFound, err = operatorStorage.GetConfig(nil)
-	require.NoError(t, err)
-	require.False(t, validAndFound)
-	require.Nil(t, cfg)
 }
 
 func Test_LastProcessedBlock(t *testing.T) {
-	logger := logging.TestLogger(t)
-	db, err := kv.NewInMemory(logger, basedb.Options{})
-	defer func() {
-		_ = db.Close()
-	}()
-
-	require.NoError(t, err)
-
-	operatorStorage, err := NewNodeStorage(logger, db)
-	require.NoError(t, err)
-
-	_, found, err := operatorStorage.GetLastProcessedBlock(nil)
-	require.NoError(t, err)
-	require.False(t, found)
-
-	err = operatorStorage.SaveLastProcessedBlock(nil, big.NewInt(123))
-	require.NoError(t, err)
-
-	blockNum, found, err := operatorStorage.GetLastProcessedBlock(nil)
-	require.NoError(t, err)
-	require.True(t, found)
-	require.Equal(t, *big.NewInt(123), *blockNum)
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+			db, err := kv.OpenInMemory(logger, basedb.Options{Engine: engine})
+			defer func() {
+				_ = db.Close()
+			}()
+
+			require.NoError(t, err)
+
+			operatorStorage, err := NewNodeStorage(logger, db)
+			require.NoError(t, err)
+
+			_, found, err := operatorStorage.GetLastProcessedBlock(nil)
+			require.NoError(t, err)
+			require.False(t, found)
+
+			err = operatorStorage.SaveLastProcessedBlock(nil, big.NewInt(123))
+			require.NoError(t, err)
+
+			blockNum, found, err := operatorStorage.GetLastProcessedBlock(nil)
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, *big.NewInt(123), *blockNum)
+		})
+	}
 }
 
 func Test_OperatorData(t *testing.T) {
-	logger := logging.TestLogger(t)
-	db, err := kv.NewInMemory(logger, basedb.Options{})
-	defer func() {
-		_ = db.Close()
-	}()
-
-	require.NoError(t, err)
-
-	operatorStorage, err := NewNodeStorage(logger, db)
-	require.NoError(t, err)
-
-	operatorIDs := []uint64{1, 2, 3}
-
-	for _, id := range operatorIDs {
-		pubkey := []byte(fmt.Sprintf("publicKey%d", id))
-		operatorData := &registrystorage.OperatorData{
-			ID:           id,
-			PublicKey:    pubkey,
-			OwnerAddress: common.Address{byte(id)},
-		}
-
-		found, err := operatorStorage.SaveOperatorData(nil, operatorData)
-		require.NoError(t, err)
-		require.False(t, found)
-
-		opData, found, err := operatorStorage.GetOperatorData(nil, id)
-		require.NoError(t, err)
-		require.True(t, found)
-		require.Equal(t, *operatorData, *opData)
-
-		opData, found, err = operatorStorage.GetOperatorDataByPubKey(nil, pubkey)
-		require.NoError(t, err)
-		require.True(t, found)
-		require.Equal(t, *operatorData, *opData)
-
-		err = operatorStorage.DeleteOperatorData(nil, id)
-		require.NoError(t, err)
-
-		opData, found, err = operatorStorage.GetOperatorData(nil, id)
-		require.NoError(t, err)
-		require.False(t, found)
-		require.Nil(t, opData)
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+			db, err := kv.OpenInMemory(logger, basedb.Options{Engine: engine})
+			defer func() {
+				_ = db.Close()
+			}()
+
+			require.NoError(t, err)
+
+			operatorStorage, err := NewNodeStorage(logger, db)
+			require.NoError(t, err)
+
+			operatorIDs := []uint64{1, 2, 3}
+
+			for _, id := range operatorIDs {
+				pubkey := []byte(fmt.Sprintf("publicKey%d", id))
+				operatorData := &registrystorage.OperatorData{
+					ID:           id,
+					PublicKey:    pubkey,
+					OwnerAddress: common.Address{byte(id)},
+				}
+
+				found, err := operatorStorage.SaveOperatorData(nil, operatorData)
+				require.NoError(t, err)
+				require.False(t, found)
+
+				opData, found, err := operatorStorage.GetOperatorData(nil, id)
+				require.NoError(t, err)
+				require.True(t, found)
+				require.Equal(t, *operatorData, *opData)
+
+				opData, found, err = operatorStorage.GetOperatorDataByPubKey(nil, pubkey)
+				require.NoError(t, err)
+				require.True(t, found)
+				require.Equal(t, *operatorData, *opData)
+
+				err = operatorStorage.DeleteOperatorData(nil, id)
+				require.NoError(t, err)
+
+				opData, found, err = operatorStorage.GetOperatorData(nil, id)
+				require.NoError(t, err)
+				require.False(t, found)
+				require.Nil(t, opData)
+			}
+		})
 	}
 }
 
 func Test_NonceBumping(t *testing.T) {
-	logger := logging.TestLogger(t)
-	db, err := kv.NewInMemory(logger, basedb.Options{})
-	defer func() {
-		_ = db.Close()
-	}()
-
-	require.NoError(t, err)
-
-	operatorStorage, err := NewNodeStorage(logger, db)
-	require.NoError(t, err)
-
-	owner := common.Address{1}
-
-	var fr bellatrix.ExecutionAddress
-	copy(fr[:], append([]byte{1}, owner[:]...))
-
-	recipientData := &registrystorage.RecipientData{
-		Owner:        owner,
-		FeeRecipient: fr,
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+			db, err := kv.OpenInMemory(logger, basedb.Options{Engine: engine})
+			defer func() {
+				_ = db.Close()
+			}()
+
+			require.NoError(t, err)
+
+			operatorStorage, err := NewNodeStorage(logger, db)
+			require.NoError(t, err)
+
+			owner := common.Address{1}
+
+			var fr bellatrix.ExecutionAddress
+			copy(fr[:], append([]byte{1}, owner[:]...))
+
+			recipientData := &registrystorage.RecipientData{
+				Owner:        owner,
+				FeeRecipient: fr,
+			}
+			_, err = operatorStorage.SaveRecipientData(nil, recipientData)
+			require.NoError(t, err)
+
+			data, found, err := operatorStorage.GetRecipientData(nil, owner)
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, *recipientData, *data)
+
+			require.NoError(t, operatorStorage.BumpNonce(nil, owner))
+			require.NoError(t, operatorStorage.BumpNonce(nil, owner))
+			nonce, err := operatorStorage.GetNextNonce(nil, owner)
+			require.NoError(t, err)
+			require.Equal(t, registrystorage.Nonce(2), nonce)
+
+			err = operatorStorage.DeleteRecipientData(nil, owner)
+			require.NoError(t, err)
+
+			data, found, err = operatorStorage.GetRecipientData(nil, owner)
+			require.NoError(t, err)
+			require.False(t, found)
+			require.Nil(t, data)
+
+			nonce, err = operatorStorage.GetNextNonce(nil, owner)
+			require.NoError(t, err)
+			require.Equal(t, registrystorage.Nonce(0), nonce)
+		})
 	}
-	_, err = operatorStorage.SaveRecipientData(nil, recipientData)
-	require.NoError(t, err)
-
-	data, found, err := operatorStorage.GetRecipientData(nil, owner)
-	require.NoError(t, err)
-	require.True(t, found)
-	require.Equal(t, *recipientData, *data)
-
-	require.NoError(t, operatorStorage.BumpNonce(nil, owner))
-	require.NoError(t, operatorStorage.BumpNonce(nil, owner))
-	nonce, err := operatorStorage.GetNextNonce(nil, owner)
-	require.NoError(t, err)
-	require.Equal(t, registrystorage.Nonce(2), nonce)
-
-	err = operatorStorage.DeleteRecipientData(nil, owner)
-	require.NoError(t, err)
-
-	data, found, err = operatorStorage.GetRecipientData(nil, owner)
-	require.NoError(t, err)
-	require.False(t, found)
-	require.Nil(t, data)
-
-	nonce, err = operatorStorage.GetNextNonce(nil, owner)
-	require.NoError(t, err)
-	require.Equal(t, registrystorage.Nonce(0), nonce)
 }
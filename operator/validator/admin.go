@@ -0,0 +1,107 @@
+package validator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+// ErrValidatorNotFound is returned when a validator isn't one of the operator's active validators.
+var ErrValidatorNotFound = errors.New("validator not found")
+
+// PauseValidator stops the given validator until it's resumed or the node restarts,
+// keeping its share so that it isn't started again by registry events or metadata updates.
+func (c *controller) PauseValidator(pubKey spectypes.ValidatorPK) error {
+	if _, err := c.ownShare(pubKey); err != nil {
+		return err
+	}
+
+	c.pausedValidatorsMu.Lock()
+	c.pausedValidators[pubKey] = struct{}{}
+	c.pausedValidatorsMu.Unlock()
+
+	c.onShareStop(pubKey)
+	c.logger.Info("paused validator", fields.PubKey(pubKey[:]))
+	return nil
+}
+
+// ResumeValidator starts the given validator if it was paused.
+func (c *controller) ResumeValidator(pubKey spectypes.ValidatorPK) error {
+	share, err := c.ownShare(pubKey)
+	if err != nil {
+		return err
+	}
+
+	c.pausedValidatorsMu.Lock()
+	_, paused := c.pausedValidators[pubKey]
+	delete(c.pausedValidators, pubKey)
+	c.pausedValidatorsMu.Unlock()
+	if !paused {
+		return fmt.Errorf("validator %x is not paused", pubKey[:])
+	}
+
+	started, err := c.onShareStart(share)
+	if err != nil {
+		return fmt.Errorf("could not start validator: %w", err)
+	}
+	c.logger.Info("resumed validator", fields.PubKey(pubKey[:]), zap.Bool("started", started))
+	return nil
+}
+
+// PausedValidators returns the validators which are currently paused.
+func (c *controller) PausedValidators() []spectypes.ValidatorPK {
+	c.pausedValidatorsMu.Lock()
+	defer c.pausedValidatorsMu.Unlock()
+
+	pubKeys := make([]spectypes.ValidatorPK, 0, len(c.pausedValidators))
+	for pubKey := range c.pausedValidators {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	return pubKeys
+}
+
+// RefreshValidatorsMetadata marks the metadata of the given validators, or of all validators if none are given,
+// as outdated, so that UpdateValidatorMetaDataLoop fetches it on its next iterations.
+// It returns the number of validators which were marked.
+func (c *controller) RefreshValidatorsMetadata(pubKeys []spectypes.ValidatorPK) int {
+	filters := []registrystorage.SharesFilter{registrystorage.ByNotLiquidated()}
+	if len(pubKeys) > 0 {
+		set := make(map[spectypes.ValidatorPK]struct{}, len(pubKeys))
+		for _, pubKey := range pubKeys {
+			set[pubKey] = struct{}{}
+		}
+		filters = append(filters, func(share *ssvtypes.SSVShare) bool {
+			_, ok := set[share.ValidatorPubKey]
+			return ok
+		})
+	}
+
+	shares := c.sharesStorage.List(nil, filters...)
+	for _, share := range shares {
+		share.SetMetadataLastUpdated(time.Time{})
+	}
+	return len(shares)
+}
+
+func (c *controller) isPaused(pubKey spectypes.ValidatorPK) bool {
+	c.pausedValidatorsMu.Lock()
+	defer c.pausedValidatorsMu.Unlock()
+
+	_, ok := c.pausedValidators[pubKey]
+	return ok
+}
+
+func (c *controller) ownShare(pubKey spectypes.ValidatorPK) (*ssvtypes.SSVShare, error) {
+	share, found := c.sharesStorage.Get(nil, pubKey[:])
+	if !found || share.Liquidated || !share.BelongsToOperator(c.operatorDataStore.GetOperatorID()) {
+		return nil, fmt.Errorf("%w: %x", ErrValidatorNotFound, pubKey[:])
+	}
+	return share, nil
+}
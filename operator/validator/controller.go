@@ -100,6 +100,8 @@ type ControllerOptions struct {
 	ValidatorsMap              *validators.ValidatorsMap
 	NetworkConfig              networkconfig.NetworkConfig
 	Graffiti                   []byte
+	DutyTracer                 ssvtypes.DutyTracer
+	ProposerSettings           ssvtypes.ProposerSettingsProvider
 
 	// worker flags
 	WorkersCount    int `yaml:"MsgWorkersCount" env:"MSG_WORKERS_COUNT" env-default:"256" env-description:"Number of goroutines to use for message workers"`
@@ -142,6 +144,11 @@ type Controller interface {
 	UpdateFeeRecipient(owner, recipient common.Address) error
 	ExitValidator(pubKey phase0.BLSPubKey, blockNumber uint64, validatorIndex phase0.ValidatorIndex, ownValidator bool) error
 
+	PauseValidator(pubKey spectypes.ValidatorPK) error
+	ResumeValidator(pubKey spectypes.ValidatorPK) error
+	PausedValidators() []spectypes.ValidatorPK
+	RefreshValidatorsMetadata(pubKeys []spectypes.ValidatorPK) int
+
 	duties.DutyExecutor
 }
 
@@ -216,6 +223,10 @@ type controller struct {
 	recentlyStartedValidators uint64
 	indicesChange             chan struct{}
 	validatorExitCh           chan duties.ExitDescriptor
+
+	// pausedValidators are stopped by the operator and aren't started until resumed.
+	pausedValidators   map[spectypes.ValidatorPK]struct{}
+	pausedValidatorsMu sync.Mutex
 }
 
 // NewController creates a new validator controller instance
@@ -248,6 +259,8 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		MessageValidator:  options.MessageValidator,
 		Metrics:           options.Metrics,
 		Graffiti:          options.Graffiti,
+		DutyTracer:        options.DutyTracer,
+		ProposerSettings:  options.ProposerSettings,
 		GenesisOptions: validator.GenesisOptions{
 			Network:           options.GenesisControllerOptions.Network,
 			Signer:            options.GenesisControllerOptions.KeyManager,
@@ -323,6 +336,7 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		indicesChange:           make(chan struct{}),
 		validatorExitCh:         make(chan duties.ExitDescriptor),
 		committeeValidatorSetup: make(chan struct{}, 1),
+		pausedValidators:        make(map[spectypes.ValidatorPK]struct{}),
 
 		messageValidator: options.MessageValidator,
 	}
@@ -889,6 +903,11 @@ func (c *controller) onShareInit(share *ssvtypes.SSVShare) (*validators.Validato
 		return nil, nil, nil
 	}
 
+	if c.isPaused(share.ValidatorPubKey) {
+		c.logger.Debug("skipping paused validator", fields.PubKey(share.ValidatorPubKey[:]))
+		return nil, nil, nil
+	}
+
 	if err := c.setShareFeeRecipient(share, c.recipientsStorage.GetRecipientData); err != nil {
 		return nil, nil, fmt.Errorf("could not set share fee recipient: %w", err)
 	}
@@ -964,7 +983,7 @@ func (c *controller) onShareInit(share *ssvtypes.SSVShare) (*validators.Validato
 
 		committeeRunnerFunc := SetupCommitteeRunners(ctx, opts)
 
-		vc = validator.NewCommittee(ctx, cancel, logger, c.beacon.GetBeaconNetwork(), operator, committeeRunnerFunc, nil)
+		vc = validator.NewCommittee(ctx, cancel, logger, c.networkConfig.Beacon.GetNetwork(), operator, committeeRunnerFunc, nil)
 		vc.AddShare(&share.Share)
 		c.validatorsMap.PutCommittee(operator.CommitteeID, vc)
 
@@ -1243,6 +1262,7 @@ func SetupCommitteeRunners(
 			Network:     options.Network,
 			Timer:       roundtimer.New(ctx, options.NetworkConfig.Beacon, role, nil),
 			CutOffRound: roundtimer.CutOffRound,
+			DutyTracer:  options.DutyTracer,
 		}
 
 		identifier := spectypes.NewMsgID(options.NetworkConfig.AlanDomainType, options.Operator.CommitteeID[:], role)
@@ -1252,7 +1272,7 @@ func SetupCommitteeRunners(
 
 	return func(slot phase0.Slot, shares map[phase0.ValidatorIndex]*spectypes.Share, attestingValidators []spectypes.ShareValidatorPK, dutyGuard runner.CommitteeDutyGuard) (*runner.CommitteeRunner, error) {
 		// Create a committee runner.
-		epoch := options.NetworkConfig.Beacon.GetBeaconNetwork().EstimatedEpochAtSlot(slot)
+		epoch := options.NetworkConfig.Beacon.EstimatedEpochAtSlot(slot)
 		valCheck := ssv.BeaconVoteValueCheckF(options.Signer, slot, attestingValidators, epoch)
 		crunner, err := runner.NewCommitteeRunner(
 			options.NetworkConfig,
@@ -1268,6 +1288,7 @@ func SetupCommitteeRunners(
 		if err != nil {
 			return nil, err
 		}
+		crunner.GetBaseRunner().DutyTracer = options.DutyTracer
 		return crunner.(*runner.CommitteeRunner), nil
 	}
 }
@@ -1307,6 +1328,7 @@ func SetupRunners(
 			Network:     options.Network,
 			Timer:       roundtimer.New(ctx, options.NetworkConfig.Beacon, role, nil),
 			CutOffRound: roundtimer.CutOffRound,
+			DutyTracer:  options.DutyTracer,
 		}
 		config.ValueCheckF = valueCheckF
 
@@ -1330,29 +1352,36 @@ func SetupRunners(
 		//	qbftCtrl := buildController(spectypes.BNRoleAttester, valCheck)
 		//	runners[role] = runner.NewAttesterRunner(options.NetworkConfig.Beacon.GetBeaconNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, options.OperatorSigner, valCheck, 0)
 		case spectypes.RoleProposer:
-			proposedValueCheck := ssv.ProposerValueCheckF(options.Signer, options.NetworkConfig.Beacon.GetBeaconNetwork(), options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index, options.SSVShare.SharePubKey)
+			proposedValueCheck := ssv.ProposerValueCheckF(options.Signer, options.NetworkConfig.Beacon.GetNetwork(), options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index, options.SSVShare.SharePubKey)
 			qbftCtrl := buildController(spectypes.RoleProposer, proposedValueCheck)
-			runners[role], err = runner.NewProposerRunner(alanDomainType, options.NetworkConfig.Beacon.GetBeaconNetwork(), shareMap, qbftCtrl, options.Beacon, options.Network, options.Signer, options.OperatorSigner, proposedValueCheck, 0, options.Graffiti)
+			runners[role], err = runner.NewProposerRunner(alanDomainType, options.NetworkConfig.Beacon.GetNetwork(), shareMap, qbftCtrl, options.Beacon, options.Network, options.Signer, options.OperatorSigner, proposedValueCheck, 0, options.Graffiti)
 		case spectypes.RoleAggregator:
-			aggregatorValueCheckF := ssv.AggregatorValueCheckF(options.Signer, options.NetworkConfig.Beacon.GetBeaconNetwork(), options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
+			aggregatorValueCheckF := ssv.AggregatorValueCheckF(options.Signer, options.NetworkConfig.Beacon.GetNetwork(), options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
 			qbftCtrl := buildController(spectypes.RoleAggregator, aggregatorValueCheckF)
-			runners[role], err = runner.NewAggregatorRunner(alanDomainType, options.NetworkConfig.Beacon.GetBeaconNetwork(), shareMap, qbftCtrl, options.Beacon, options.Network, options.Signer, options.OperatorSigner, aggregatorValueCheckF, 0)
+			runners[role], err = runner.NewAggregatorRunner(alanDomainType, options.NetworkConfig.Beacon.GetNetwork(), shareMap, qbftCtrl, options.Beacon, options.Network, options.Signer, options.OperatorSigner, aggregatorValueCheckF, 0)
 		//case spectypes.BNRoleSyncCommittee:
 		//syncCommitteeValueCheckF := specssv.SyncCommitteeValueCheckF(options.Signer, options.NetworkConfig.Beacon.GetBeaconNetwork(), options.SSVShare.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
 		//qbftCtrl := buildController(spectypes.BNRoleSyncCommittee, syncCommitteeValueCheckF)
 		//runners[role] = runner.NewSyncCommitteeRunner(options.NetworkConfig, options.NetworkConfig.Beacon.GetBeaconNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, options.OperatorSigner, syncCommitteeValueCheckF, 0)
 		case spectypes.RoleSyncCommitteeContribution:
-			syncCommitteeContributionValueCheckF := ssv.SyncCommitteeContributionValueCheckF(options.Signer, options.NetworkConfig.Beacon.GetBeaconNetwork(), options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
+			syncCommitteeContributionValueCheckF := ssv.SyncCommitteeContributionValueCheckF(options.Signer, options.NetworkConfig.Beacon.GetNetwork(), options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
 			qbftCtrl := buildController(spectypes.RoleSyncCommitteeContribution, syncCommitteeContributionValueCheckF)
-			runners[role], err = runner.NewSyncCommitteeAggregatorRunner(alanDomainType, options.NetworkConfig.Beacon.GetBeaconNetwork(), shareMap, qbftCtrl, options.Beacon, options.Network, options.Signer, options.OperatorSigner, syncCommitteeContributionValueCheckF, 0)
+			runners[role], err = runner.NewSyncCommitteeAggregatorRunner(alanDomainType, options.NetworkConfig.Beacon.GetNetwork(), shareMap, qbftCtrl, options.Beacon, options.Network, options.Signer, options.OperatorSigner, syncCommitteeContributionValueCheckF, 0)
 		case spectypes.RoleValidatorRegistration:
-			runners[role], err = runner.NewValidatorRegistrationRunner(alanDomainType, options.NetworkConfig.Beacon.GetBeaconNetwork(), shareMap, options.Beacon, options.Network, options.Signer, options.OperatorSigner)
+			runners[role], err = runner.NewValidatorRegistrationRunner(alanDomainType, options.NetworkConfig.Beacon.GetNetwork(), shareMap, options.Beacon, options.Network, options.Signer, options.OperatorSigner)
 		case spectypes.RoleVoluntaryExit:
-			runners[role], err = runner.NewVoluntaryExitRunner(alanDomainType, options.NetworkConfig.Beacon.GetBeaconNetwork(), shareMap, options.Beacon, options.Network, options.Signer, options.OperatorSigner)
+			runners[role], err = runner.NewVoluntaryExitRunner(alanDomainType, options.NetworkConfig.Beacon.GetNetwork(), shareMap, options.Beacon, options.Network, options.Signer, options.OperatorSigner)
 		}
 		if err != nil {
 			return nil, errors.Wrap(err, "could not create duty runner")
 		}
+		if r, ok := runners[role]; ok {
+			r.GetBaseRunner().DutyTracer = options.DutyTracer
+			r.GetBaseRunner().ProposerSettings = options.ProposerSettings
+		}
+		if r, ok := runners[role].(*runner.ValidatorRegistrationRunner); ok {
+			r.GasLimit = options.GasLimit
+		}
 	}
 	return runners, nil
 }
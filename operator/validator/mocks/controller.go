@@ -201,6 +201,34 @@ func (mr *MockControllerMockRecorder) LiquidateCluster(owner, operatorIDs, toLiq
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LiquidateCluster", reflect.TypeOf((*MockController)(nil).LiquidateCluster), owner, operatorIDs, toLiquidate)
 }
 
+// PauseValidator mocks base method.
+func (m *MockController) PauseValidator(pubKey types0.ValidatorPK) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseValidator", pubKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseValidator indicates an expected call of PauseValidator.
+func (mr *MockControllerMockRecorder) PauseValidator(pubKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseValidator", reflect.TypeOf((*MockController)(nil).PauseValidator), pubKey)
+}
+
+// PausedValidators mocks base method.
+func (m *MockController) PausedValidators() []types0.ValidatorPK {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PausedValidators")
+	ret0, _ := ret[0].([]types0.ValidatorPK)
+	return ret0
+}
+
+// PausedValidators indicates an expected call of PausedValidators.
+func (mr *MockControllerMockRecorder) PausedValidators() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PausedValidators", reflect.TypeOf((*MockController)(nil).PausedValidators))
+}
+
 // ReactivateCluster mocks base method.
 func (m *MockController) ReactivateCluster(owner common.Address, operatorIDs []uint64, toReactivate []*types1.SSVShare) error {
 	m.ctrl.T.Helper()
@@ -215,6 +243,34 @@ func (mr *MockControllerMockRecorder) ReactivateCluster(owner, operatorIDs, toRe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateCluster", reflect.TypeOf((*MockController)(nil).ReactivateCluster), owner, operatorIDs, toReactivate)
 }
 
+// RefreshValidatorsMetadata mocks base method.
+func (m *MockController) RefreshValidatorsMetadata(pubKeys []types0.ValidatorPK) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshValidatorsMetadata", pubKeys)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// RefreshValidatorsMetadata indicates an expected call of RefreshValidatorsMetadata.
+func (mr *MockControllerMockRecorder) RefreshValidatorsMetadata(pubKeys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshValidatorsMetadata", reflect.TypeOf((*MockController)(nil).RefreshValidatorsMetadata), pubKeys)
+}
+
+// ResumeValidator mocks base method.
+func (m *MockController) ResumeValidator(pubKey types0.ValidatorPK) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeValidator", pubKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeValidator indicates an expected call of ResumeValidator.
+func (mr *MockControllerMockRecorder) ResumeValidator(pubKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeValidator", reflect.TypeOf((*MockController)(nil).ResumeValidator), pubKey)
+}
+
 // StartNetworkHandlers mocks base method.
 func (m *MockController) StartNetworkHandlers() {
 	m.ctrl.T.Helper()
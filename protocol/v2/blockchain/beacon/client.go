@@ -6,8 +6,10 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
 	specssv "github.com/ssvlabs/ssv-spec/ssv"
 )
 
@@ -39,6 +41,12 @@ type beaconValidator interface {
 type proposer interface {
 	// SubmitProposalPreparation with fee recipients
 	SubmitProposalPreparation(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error
+	// GetBeaconBlockWithBuilderBoost returns a block proposal like GetBeaconBlock, with builder payloads
+	// weighted against local ones by the given boost factor (0 for local payloads only, 100 for no preference).
+	GetBeaconBlockWithBuilderBoost(slot phase0.Slot, graffiti, randao []byte, builderBoostFactor uint64) (ssz.Marshaler, spec.DataVersion, error)
+	// SubmitSignedValidatorRegistration submits the given registration as signed,
+	// unlike SubmitValidatorRegistration which builds it with the node's gas limit.
+	SubmitSignedValidatorRegistration(registration *eth2apiv1.SignedValidatorRegistration) error
 }
 
 // TODO need to handle differently (by spec)
@@ -62,7 +70,7 @@ type BeaconNode interface {
 type Options struct {
 	Context        context.Context
 	Network        Network
-	BeaconNodeAddr string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true"`
+	BeaconNodeAddr string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true" env-description:"Beacon node URL(s), comma-separated for multiple nodes"`
 	GasLimit       uint64
 	CommonTimeout  time.Duration // Optional.
 	LongTimeout    time.Duration // Optional.
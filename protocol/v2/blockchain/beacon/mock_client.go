@@ -219,6 +219,22 @@ func (m *Mockproposer) EXPECT() *MockproposerMockRecorder {
 	return m.recorder
 }
 
+// GetBeaconBlockWithBuilderBoost mocks base method.
+func (m *Mockproposer) GetBeaconBlockWithBuilderBoost(slot phase0.Slot, graffiti, randao []byte, builderBoostFactor uint64) (ssz.Marshaler, spec.DataVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeaconBlockWithBuilderBoost", slot, graffiti, randao, builderBoostFactor)
+	ret0, _ := ret[0].(ssz.Marshaler)
+	ret1, _ := ret[1].(spec.DataVersion)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBeaconBlockWithBuilderBoost indicates an expected call of GetBeaconBlockWithBuilderBoost.
+func (mr *MockproposerMockRecorder) GetBeaconBlockWithBuilderBoost(slot, graffiti, randao, builderBoostFactor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeaconBlockWithBuilderBoost", reflect.TypeOf((*Mockproposer)(nil).GetBeaconBlockWithBuilderBoost), slot, graffiti, randao, builderBoostFactor)
+}
+
 // SubmitProposalPreparation mocks base method.
 func (m *Mockproposer) SubmitProposalPreparation(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error {
 	m.ctrl.T.Helper()
@@ -233,6 +249,20 @@ func (mr *MockproposerMockRecorder) SubmitProposalPreparation(feeRecipients any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitProposalPreparation", reflect.TypeOf((*Mockproposer)(nil).SubmitProposalPreparation), feeRecipients)
 }
 
+// SubmitSignedValidatorRegistration mocks base method.
+func (m *Mockproposer) SubmitSignedValidatorRegistration(registration *v1.SignedValidatorRegistration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitSignedValidatorRegistration", registration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitSignedValidatorRegistration indicates an expected call of SubmitSignedValidatorRegistration.
+func (mr *MockproposerMockRecorder) SubmitSignedValidatorRegistration(registration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitSignedValidatorRegistration", reflect.TypeOf((*Mockproposer)(nil).SubmitSignedValidatorRegistration), registration)
+}
+
 // Mocksigner is a mock of signer interface.
 type Mocksigner struct {
 	ctrl     *gomock.Controller
@@ -385,6 +415,22 @@ func (mr *MockBeaconNodeMockRecorder) GetBeaconBlock(slot, graffiti, randao any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeaconBlock", reflect.TypeOf((*MockBeaconNode)(nil).GetBeaconBlock), slot, graffiti, randao)
 }
 
+// GetBeaconBlockWithBuilderBoost mocks base method.
+func (m *MockBeaconNode) GetBeaconBlockWithBuilderBoost(slot phase0.Slot, graffiti, randao []byte, builderBoostFactor uint64) (ssz.Marshaler, spec.DataVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeaconBlockWithBuilderBoost", slot, graffiti, randao, builderBoostFactor)
+	ret0, _ := ret[0].(ssz.Marshaler)
+	ret1, _ := ret[1].(spec.DataVersion)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBeaconBlockWithBuilderBoost indicates an expected call of GetBeaconBlockWithBuilderBoost.
+func (mr *MockBeaconNodeMockRecorder) GetBeaconBlockWithBuilderBoost(slot, graffiti, randao, builderBoostFactor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeaconBlockWithBuilderBoost", reflect.TypeOf((*MockBeaconNode)(nil).GetBeaconBlockWithBuilderBoost), slot, graffiti, randao, builderBoostFactor)
+}
+
 // GetBeaconNetwork mocks base method.
 func (m *MockBeaconNode) GetBeaconNetwork() types.BeaconNetwork {
 	m.ctrl.T.Helper()
@@ -590,6 +636,20 @@ func (mr *MockBeaconNodeMockRecorder) SubmitSignedContributionAndProof(contribut
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitSignedContributionAndProof", reflect.TypeOf((*MockBeaconNode)(nil).SubmitSignedContributionAndProof), contribution)
 }
 
+// SubmitSignedValidatorRegistration mocks base method.
+func (m *MockBeaconNode) SubmitSignedValidatorRegistration(registration *v1.SignedValidatorRegistration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitSignedValidatorRegistration", registration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitSignedValidatorRegistration indicates an expected call of SubmitSignedValidatorRegistration.
+func (mr *MockBeaconNodeMockRecorder) SubmitSignedValidatorRegistration(registration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitSignedValidatorRegistration", reflect.TypeOf((*MockBeaconNode)(nil).SubmitSignedValidatorRegistration), registration)
+}
+
 // SubmitSyncCommitteeSubscriptions mocks base method.
 func (m *MockBeaconNode) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscription []*v1.SyncCommitteeSubscription) error {
 	m.ctrl.T.Helper()
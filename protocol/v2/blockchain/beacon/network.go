@@ -13,6 +13,16 @@ import (
 type Network struct {
 	spectypes.BeaconNetwork
 	LocalTestNet bool
+	// Params overrides the chain parameters of BeaconNetwork for networks unknown to the spec.
+	Params *NetworkParams
+}
+
+// NetworkParams are the parameters of a beacon chain network.
+type NetworkParams struct {
+	GenesisForkVersion [4]byte
+	MinGenesisTime     int64
+	SlotDuration       time.Duration
+	SlotsPerEpoch      uint64
 }
 
 type BeaconNetwork interface {
@@ -58,14 +68,49 @@ func NewLocalTestNetwork(network spectypes.BeaconNetwork) Network {
 	}
 }
 
+// NewCustomNetwork creates a new beacon chain network with the given parameters.
+func NewCustomNetwork(network spectypes.BeaconNetwork, params NetworkParams) Network {
+	return Network{
+		BeaconNetwork: network,
+		Params:        &params,
+	}
+}
+
+// ForkVersion returns the genesis fork version of the network
+func (n Network) ForkVersion() [4]byte {
+	if n.Params != nil {
+		return n.Params.GenesisForkVersion
+	}
+	return n.BeaconNetwork.ForkVersion()
+}
+
 // MinGenesisTime returns min genesis time value
 func (n Network) MinGenesisTime() int64 {
+	if n.Params != nil {
+		return n.Params.MinGenesisTime
+	}
 	if n.LocalTestNet {
 		return 1689072978
 	}
 	return int64(n.BeaconNetwork.MinGenesisTime()) // #nosec G115
 }
 
+// SlotDurationSec returns slot duration
+func (n Network) SlotDurationSec() time.Duration {
+	if n.Params != nil {
+		return n.Params.SlotDuration
+	}
+	return n.BeaconNetwork.SlotDurationSec()
+}
+
+// SlotsPerEpoch returns number of slots per one epoch
+func (n Network) SlotsPerEpoch() uint64 {
+	if n.Params != nil {
+		return n.Params.SlotsPerEpoch
+	}
+	return n.BeaconNetwork.SlotsPerEpoch()
+}
+
 // GetNetwork returns the network
 func (n Network) GetNetwork() Network {
 	return n
@@ -102,6 +147,21 @@ func (n Network) EstimatedSlotAtTime(time int64) phase0.Slot {
 	return phase0.Slot(uint64(time-genesis) / uint64(n.SlotDurationSec().Seconds())) //#nosec G115
 }
 
+// EstimatedTimeAtSlot returns the estimated unix time at the start of the given slot
+func (n Network) EstimatedTimeAtSlot(slot phase0.Slot) int64 {
+	return n.GetSlotStartTime(slot).Unix()
+}
+
+// FirstSlotAtEpoch returns the first slot of the given epoch
+func (n Network) FirstSlotAtEpoch(epoch phase0.Epoch) phase0.Slot {
+	return n.GetEpochFirstSlot(epoch)
+}
+
+// EpochStartTime returns the start time of the given epoch
+func (n Network) EpochStartTime(epoch phase0.Epoch) time.Time {
+	return n.GetSlotStartTime(n.GetEpochFirstSlot(epoch))
+}
+
 // EstimatedCurrentEpoch estimates the current epoch
 // https://github.com/ethereum/eth2.0-specs/blob/dev/specs/phase0/beacon-chain.md#compute_start_slot_at_epoch
 func (n Network) EstimatedCurrentEpoch() phase0.Epoch {
@@ -1,6 +1,7 @@
 package protocolp2p
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -31,7 +32,7 @@ type Broadcaster interface {
 
 // SyncResult holds the result of a sync request, including the actual message and the sender
 type SyncResult struct {
-	Msg    *spectypes.SSVMessage
+	Msg    *spectypes.SignedSSVMessage
 	Sender string
 }
 
@@ -41,26 +42,30 @@ type SyncResults []SyncResult
 func (s SyncResults) String() string {
 	var v []string
 	for _, m := range s {
-		var sm *spectypes.SignedSSVMessage
-		if m.Msg.MsgType == spectypes.SSVConsensusMsgType {
-
-			decMsg, err := specqbft.DecodeMessage(sm.SSVMessage.Data)
-			if err != nil {
-				v = append(v, fmt.Sprintf("(%v)", err))
-				continue
-			}
-
-			v = append(
-				v,
-				fmt.Sprintf(
-					"(type=%d height=%d round=%d)",
-					m.Msg.MsgType,
-					decMsg.Height,
-					decMsg.Round,
-				),
-			)
+		if m.Msg == nil || m.Msg.SSVMessage == nil {
+			v = append(v, "(nil)")
+			continue
 		}
-		v = append(v, fmt.Sprintf("(type=%d)", m.Msg.MsgType))
+		if m.Msg.SSVMessage.MsgType != spectypes.SSVConsensusMsgType {
+			v = append(v, fmt.Sprintf("(type=%d)", m.Msg.SSVMessage.MsgType))
+			continue
+		}
+
+		decMsg, err := specqbft.DecodeMessage(m.Msg.SSVMessage.Data)
+		if err != nil {
+			v = append(v, fmt.Sprintf("(%v)", err))
+			continue
+		}
+
+		v = append(
+			v,
+			fmt.Sprintf(
+				"(type=%d height=%d round=%d)",
+				m.Msg.SSVMessage.MsgType,
+				decMsg.Height,
+				decMsg.Round,
+			),
+		)
 	}
 
 	return strings.Join(v, ", ")
@@ -76,6 +81,58 @@ const (
 	DecidedHistoryProtocol
 )
 
+// SyncStatus is the status of a sync response
+type SyncStatus int32
+
+const (
+	// StatusUnknown is the status of a response which wasn't set by the responder
+	StatusUnknown SyncStatus = iota
+	// StatusSuccess is returned when the request was served
+	StatusSuccess
+	// StatusNotFound is returned when the responder has no decided messages for the request
+	StatusNotFound
+	// StatusBadRequest is returned when the request is malformed or not supported by the responder
+	StatusBadRequest
+	// StatusRateLimited is returned when the requester exceeded its rate limit
+	StatusRateLimited
+	// StatusInternalError is returned when the responder failed to serve the request
+	StatusInternalError
+)
+
+// SyncParams holds the parameters of a sync request
+type SyncParams struct {
+	// Identifier is the MessageID of the requested instances
+	Identifier spectypes.MessageID
+	// Height is the requested height range (from and to), it is empty when requesting the highest decided
+	Height []specqbft.Height
+}
+
+// SyncMessage is the request and response of the sync protocols
+type SyncMessage struct {
+	Protocol SyncProtocol
+	Params   *SyncParams
+	Data     []*spectypes.SignedSSVMessage
+	Status   SyncStatus
+}
+
+// Encode encodes the message
+func (msg *SyncMessage) Encode() ([]byte, error) {
+	return json.Marshal(msg)
+}
+
+// Decode decodes the message
+func (msg *SyncMessage) Decode(data []byte) error {
+	return json.Unmarshal(data, msg)
+}
+
+// Syncer fetches decided messages from peers
+type Syncer interface {
+	// LastDecided fetches the highest decided message of the given identifier from peers
+	LastDecided(logger *zap.Logger, mid spectypes.MessageID) (SyncResults, error)
+	// GetHistory fetches the decided messages of the given identifier in the given height range from a peer
+	GetHistory(logger *zap.Logger, mid spectypes.MessageID, from, to specqbft.Height, targets ...string) (SyncResults, error)
+}
+
 // MsgValidationResult helps other components to report message validation with a generic results scheme
 type MsgValidationResult int32
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/roundtimer"
 	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
 )
 
 var CutOffRound specqbft.Round = specqbft.Round(specqbft.CutoffRound)
@@ -31,6 +32,8 @@ type IConfig interface {
 	GetTimer() roundtimer.Timer
 	// GetRoundCutOff returns the round cut off
 	GetCutOffRound() specqbft.Round
+	// GetDutyTracer returns the duty tracer, nil if duties aren't traced
+	GetDutyTracer() ssvtypes.DutyTracer
 }
 
 type Config struct {
@@ -42,6 +45,7 @@ type Config struct {
 	Network      specqbft.Network
 	Timer        roundtimer.Timer
 	CutOffRound  specqbft.Round
+	DutyTracer   ssvtypes.DutyTracer
 }
 
 // GetShareSigner returns a BeaconSigner instance
@@ -82,3 +86,8 @@ func (c *Config) GetTimer() roundtimer.Timer {
 func (c *Config) GetCutOffRound() specqbft.Round {
 	return c.CutOffRound
 }
+
+// GetDutyTracer returns the duty tracer, nil if duties aren't traced
+func (c *Config) GetDutyTracer() ssvtypes.DutyTracer {
+	return c.DutyTracer
+}
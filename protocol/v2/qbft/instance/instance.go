@@ -4,7 +4,9 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"sync"
+	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
@@ -135,6 +137,7 @@ func (i *Instance) ProcessMsg(logger *zap.Logger, msg *specqbft.ProcessingMessag
 		return false, nil, nil, errors.Wrap(err, "invalid signed message")
 	}
 
+	prevDecided := i.State.Decided
 	res := i.processMsgF.Run(func() interface{} {
 
 		switch msg.QBFTMessage.MsgType {
@@ -158,6 +161,22 @@ func (i *Instance) ProcessMsg(logger *zap.Logger, msg *specqbft.ProcessingMessag
 	if res != nil {
 		return false, nil, nil, res.(error)
 	}
+
+	i.trace(ssvtypes.DutyTraceEvent{
+		Type:    ssvtypes.QBFTMessage,
+		MsgType: msg.QBFTMessage.MsgType,
+		Round:   msg.QBFTMessage.Round,
+		Signers: msg.SignedMessage.OperatorIDs,
+		Root:    msg.QBFTMessage.Root,
+	})
+	if i.State.Decided && !prevDecided && aggregatedCommit != nil {
+		i.trace(ssvtypes.DutyTraceEvent{
+			Type:    ssvtypes.QBFTDecided,
+			Round:   i.State.Round,
+			Signers: aggregatedCommit.OperatorIDs,
+			Root:    msg.QBFTMessage.Root,
+		})
+	}
 	return i.State.Decided, i.State.DecidedValue, aggregatedCommit, nil
 }
 
@@ -252,6 +271,27 @@ func (i *Instance) Decode(data []byte) error {
 func (i *Instance) bumpToRound(round specqbft.Round) {
 	i.State.Round = round
 	i.metrics.SetRound(round)
+	i.trace(ssvtypes.DutyTraceEvent{
+		Type:  ssvtypes.QBFTRoundChange,
+		Round: round,
+	})
+}
+
+// trace records the event of this instance's duty, if duties are traced.
+func (i *Instance) trace(event ssvtypes.DutyTraceEvent) {
+	if i.config == nil {
+		return
+	}
+	tracer := i.config.GetDutyTracer()
+	if tracer == nil || len(i.State.ID) != len(spectypes.MessageID{}) {
+		return
+	}
+	msgID := spectypes.MessageID(i.State.ID)
+	event.Role = msgID.GetRoleType()
+	event.DutyExecutorID = msgID.GetDutyExecutorID()
+	event.Slot = phase0.Slot(i.State.Height)
+	event.Time = time.Now()
+	tracer.TraceDuty(event)
 }
 
 // CanProcessMessages will return true if instance can process messages
@@ -40,6 +40,13 @@ type InstanceStore interface {
 	// GetInstancesInRange returns historical instances in the given range.
 	GetInstancesInRange(identifier []byte, from specqbft.Height, to specqbft.Height) ([]*StoredInstance, error)
 
+	// GetInstancesPage returns the historical instances in the given range, skipping the first offset ones,
+	// and at most limit of them unless it's 0.
+	GetInstancesPage(identifier []byte, from specqbft.Height, to specqbft.Height, offset, limit int) ([]*StoredInstance, error)
+
+	// CountInstancesInRange returns the number of historical instances in the given range.
+	CountInstancesInRange(identifier []byte, from specqbft.Height, to specqbft.Height) (int, error)
+
 	// SaveInstance updates/inserts the given instance to it's identifier's history.
 	SaveInstance(instance *StoredInstance) error
 
@@ -61,6 +68,13 @@ type InstanceStore interface {
 	// GetParticipantsInRange returns participants in quorum for the given slot range.
 	GetParticipantsInRange(identifier convert.MessageID, from, to phase0.Slot) ([]ParticipantsRangeEntry, error)
 
+	// GetParticipantsPage returns participants in quorum for the given slot range, skipping the first offset slots,
+	// and at most limit of them unless it's 0.
+	GetParticipantsPage(identifier convert.MessageID, from, to phase0.Slot, offset, limit int) ([]ParticipantsRangeEntry, error)
+
+	// CountParticipantsInRange returns the number of slots with participants in quorum in the given slot range.
+	CountParticipantsInRange(identifier convert.MessageID, from, to phase0.Slot) (int, error)
+
 	// GetParticipants returns participants in quorum for the given slot.
 	GetParticipants(identifier convert.MessageID, slot phase0.Slot) ([]spectypes.OperatorID, error)
 }
@@ -35,7 +35,7 @@ var _ Runner = &AggregatorRunner{}
 
 func NewAggregatorRunner(
 	domainType spectypes.DomainType,
-	beaconNetwork spectypes.BeaconNetwork,
+	beaconNetwork beacon.Network,
 	share map[phase0.ValidatorIndex]*spectypes.Share,
 	qbftController *controller.Controller,
 	beacon beacon.BeaconNode,
@@ -234,7 +234,7 @@ func (r *AggregatorRunner) ProcessPostConsensus(logger *zap.Logger, signedMsg *s
 
 		start := time.Now()
 
-		if err := r.GetBeaconNode().SubmitSignedAggregateSelectionProof(msg); err != nil {
+		if err := r.BaseRunner.traceSubmission(r.GetBeaconNode().SubmitSignedAggregateSelectionProof(msg)); err != nil {
 			r.metrics.RoleSubmissionFailed()
 			logger.Error("❌ could not submit to Beacon chain reconstructed contribution and proof",
 				fields.SubmissionTime(time.Since(start)),
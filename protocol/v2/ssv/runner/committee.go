@@ -66,7 +66,7 @@ func NewCommitteeRunner(
 		BaseRunner: &BaseRunner{
 			RunnerRoleType: spectypes.RoleCommittee,
 			DomainType:     networkConfig.AlanDomainType,
-			BeaconNetwork:  networkConfig.Beacon.GetBeaconNetwork(),
+			BeaconNetwork:  networkConfig.Beacon.GetNetwork(),
 			Share:          share,
 			QBFTController: qbftController,
 		},
@@ -442,7 +442,7 @@ func (cr *CommitteeRunner) ProcessPostConsensus(logger *zap.Logger, signedMsg *s
 
 	if len(attestations) > 0 {
 		submissionStart := time.Now()
-		if err := cr.beacon.SubmitAttestations(attestations); err != nil {
+		if err := cr.BaseRunner.traceSubmission(cr.beacon.SubmitAttestations(attestations)); err != nil {
 			logger.Error("❌ failed to submit attestation", zap.Error(err))
 			return errors.Wrap(err, "could not submit to Beacon chain reconstructed attestation")
 		}
@@ -468,7 +468,7 @@ func (cr *CommitteeRunner) ProcessPostConsensus(logger *zap.Logger, signedMsg *s
 
 	if len(syncCommitteeMessages) > 0 {
 		submissionStart := time.Now()
-		if err := cr.beacon.SubmitSyncMessages(syncCommitteeMessages); err != nil {
+		if err := cr.BaseRunner.traceSubmission(cr.beacon.SubmitSyncMessages(syncCommitteeMessages)); err != nil {
 			logger.Error("❌ failed to submit sync committee", zap.Error(err))
 			return errors.Wrap(err, "could not submit to Beacon chain reconstructed signed sync committee")
 		}
@@ -65,6 +65,10 @@ var (
 		Name: "ssv_instances_decided",
 		Help: "Number of decided QBFT instances",
 	}, []string{"role"})
+	metricsWrongSigningRoots = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_validator_partial_signatures_wrong_root_total",
+		Help: "Number of partial signatures of other operators over an unexpected root",
+	}, []string{"role"})
 )
 
 func init() {
@@ -98,6 +102,7 @@ type ConsensusMetrics struct {
 	rolesSubmissionFailures        prometheus.Counter
 	metricsInstancesStarted        prometheus.Counter
 	metricsInstancesDecided        prometheus.Counter
+	wrongSigningRoots              prometheus.Counter
 	preConsensusStart              time.Time
 	consensusStart                 time.Time
 	postConsensusStart             time.Time
@@ -123,6 +128,7 @@ func NewConsensusMetrics(role spectypes.RunnerRole) ConsensusMetrics {
 		rolesSubmissionFailures: metricsRolesSubmissionFailures.WithLabelValues(values...),
 		metricsInstancesStarted: metricsInstancesStarted.WithLabelValues(values...),
 		metricsInstancesDecided: metricsInstancesDecided.WithLabelValues(values...),
+		wrongSigningRoots:       metricsWrongSigningRoots.WithLabelValues(values...),
 	}
 }
 
@@ -139,6 +145,13 @@ func (cm *ConsensusMetrics) GetBeaconDataTime() time.Duration {
 	return cm.beaconDataDuration
 }
 
+// WrongSigningRoot counts a partial signature over an unexpected root.
+func (cm *ConsensusMetrics) WrongSigningRoot() {
+	if cm != nil && cm.wrongSigningRoots != nil {
+		cm.wrongSigningRoots.Inc()
+	}
+}
+
 // StartPreConsensus stores pre-consensus start time.
 func (cm *ConsensusMetrics) StartPreConsensus() {
 	if cm != nil {
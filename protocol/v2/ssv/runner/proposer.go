@@ -40,7 +40,7 @@ type ProposerRunner struct {
 
 func NewProposerRunner(
 	domainType spectypes.DomainType,
-	beaconNetwork spectypes.BeaconNetwork,
+	beaconNetwork beacon.Network,
 	share map[phase0.ValidatorIndex]*spectypes.Share,
 	qbftController *controller.Controller,
 	beacon beacon.BeaconNode,
@@ -116,7 +116,7 @@ func (r *ProposerRunner) ProcessPreConsensus(logger *zap.Logger, signedMsg *spec
 
 	start := time.Now()
 	duty = r.GetState().StartingDuty.(*spectypes.ValidatorDuty)
-	obj, ver, err := r.GetBeaconNode().GetBeaconBlock(duty.Slot, r.graffiti, fullSig)
+	obj, ver, err := r.getBeaconBlock(duty.Slot, fullSig)
 	if err != nil {
 		logger.Error("❌ failed to get blinded beacon block",
 			fields.PreConsensusTime(r.metrics.GetPreConsensusTime()),
@@ -288,7 +288,7 @@ func (r *ProposerRunner) ProcessPostConsensus(logger *zap.Logger, signedMsg *spe
 				zap.NamedError("summarize_err", summarizeErr),
 			)
 
-			if err := r.GetBeaconNode().SubmitBlindedBeaconBlock(vBlindedBlk, specSig); err != nil {
+			if err := r.BaseRunner.traceSubmission(r.GetBeaconNode().SubmitBlindedBeaconBlock(vBlindedBlk, specSig)); err != nil {
 				r.metrics.RoleSubmissionFailed()
 				logger.Error("❌ could not submit blinded Beacon block",
 					fields.SubmissionTime(time.Since(start)),
@@ -306,7 +306,7 @@ func (r *ProposerRunner) ProcessPostConsensus(logger *zap.Logger, signedMsg *spe
 				zap.NamedError("summarize_err", summarizeErr),
 			)
 
-			if err := r.GetBeaconNode().SubmitBeaconBlock(vBlk, specSig); err != nil {
+			if err := r.BaseRunner.traceSubmission(r.GetBeaconNode().SubmitBeaconBlock(vBlk, specSig)); err != nil {
 				r.metrics.RoleSubmissionFailed()
 				logger.Error("❌ could not submit Beacon block",
 					fields.SubmissionTime(time.Since(start)),
@@ -383,7 +383,7 @@ func (r *ProposerRunner) executeDuty(logger *zap.Logger, duty spectypes.Duty) er
 	r.metrics.StartPreConsensus()
 
 	// sign partial randao
-	epoch := r.GetBeaconNode().GetBeaconNetwork().EstimatedEpochAtSlot(duty.DutySlot())
+	epoch := r.BaseRunner.BeaconNetwork.EstimatedEpochAtSlot(duty.DutySlot())
 	msg, err := r.BaseRunner.signBeaconObject(r, duty.(*spectypes.ValidatorDuty), spectypes.SSZUint64(epoch), duty.DutySlot(), spectypes.DomainRandao)
 	if err != nil {
 		return errors.Wrap(err, "could not sign randao")
@@ -434,6 +434,24 @@ func (r *ProposerRunner) GetNetwork() specqbft.Network {
 	return r.network
 }
 
+// getBeaconBlock returns a block proposal with the graffiti and builder boost factor
+// of the validator's proposer settings, if any.
+func (r *ProposerRunner) getBeaconBlock(slot phase0.Slot, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	if r.BaseRunner.ProposerSettings == nil {
+		return r.GetBeaconNode().GetBeaconBlock(slot, r.graffiti, randao)
+	}
+
+	settings := r.BaseRunner.ProposerSettings.ProposerSettings(r.GetShare().ValidatorPubKey)
+	graffiti := r.graffiti
+	if settings.Graffiti != nil {
+		graffiti = settings.Graffiti
+	}
+	if settings.BuilderBoostFactor != nil {
+		return r.GetBeaconNode().GetBeaconBlockWithBuilderBoost(slot, graffiti, randao, *settings.BuilderBoostFactor)
+	}
+	return r.GetBeaconNode().GetBeaconBlock(slot, graffiti, randao)
+}
+
 func (r *ProposerRunner) GetBeaconNode() beacon.BeaconNode {
 	return r.beacon
 }
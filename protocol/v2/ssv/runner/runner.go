@@ -3,6 +3,7 @@ package runner
 import (
 	"encoding/json"
 	"sync"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
@@ -57,12 +58,17 @@ type BaseRunner struct {
 	Share          map[phase0.ValidatorIndex]*spectypes.Share
 	QBFTController *controller.Controller
 	DomainType     spectypes.DomainType
-	BeaconNetwork  spectypes.BeaconNetwork
+	BeaconNetwork  beacon.Network
 	RunnerRoleType spectypes.RunnerRole
 	ssvtypes.OperatorSigner
 
 	// implementation vars
 	TimeoutF TimeoutF `json:"-"`
+	// DutyTracer records the execution of duties, nil if duties aren't traced.
+	DutyTracer ssvtypes.DutyTracer `json:"-"`
+	// ProposerSettings provides the proposal and builder registration preferences of validators,
+	// nil if the node's defaults apply.
+	ProposerSettings ssvtypes.ProposerSettingsProvider `json:"-"`
 
 	// highestDecidedSlot holds the highest decided duty slot and gets updated after each decided is reached
 	highestDecidedSlot phase0.Slot
@@ -91,7 +97,7 @@ func (b *BaseRunner) MarshalJSON() ([]byte, error) {
 		State:              b.State,
 		Share:              b.Share,
 		QBFTController:     b.QBFTController,
-		BeaconNetwork:      b.BeaconNetwork,
+		BeaconNetwork:      b.BeaconNetwork.GetBeaconNetwork(),
 		RunnerRoleType:     b.RunnerRoleType,
 		highestDecidedSlot: b.highestDecidedSlot,
 	}
@@ -118,6 +124,8 @@ func (b *BaseRunner) baseSetupForNewDuty(duty spectypes.Duty, quorum uint64) {
 	b.mtx.Lock() // writes to b.State
 	b.State = state
 	b.mtx.Unlock()
+
+	b.trace(ssvtypes.DutyTraceEvent{Type: ssvtypes.DutyStarted})
 }
 
 func NewBaseRunner(
@@ -125,7 +133,7 @@ func NewBaseRunner(
 	share map[phase0.ValidatorIndex]*spectypes.Share,
 	controller *controller.Controller,
 	domainType spectypes.DomainType,
-	beaconNetwork spectypes.BeaconNetwork,
+	beaconNetwork beacon.Network,
 	runnerRoleType spectypes.RunnerRole,
 	highestDecidedSlot phase0.Slot,
 ) *BaseRunner {
@@ -167,6 +175,7 @@ func (b *BaseRunner) basePreConsensusMsgProcessing(runner Runner, signedMsg *spe
 	}
 
 	hasQuorum, roots := b.basePartialSigMsgProcessing(signedMsg, b.State.PreConsensusContainer)
+	b.tracePartialSignatures(signedMsg, ssvtypes.PreConsensusSignature, ssvtypes.PreConsensusQuorum, hasQuorum)
 	return hasQuorum, roots, nil
 }
 
@@ -240,6 +249,7 @@ func (b *BaseRunner) basePostConsensusMsgProcessing(logger *zap.Logger, runner R
 	}
 
 	hasQuorum, roots := b.basePartialSigMsgProcessing(signedMsg, b.State.PostConsensusContainer)
+	b.tracePartialSignatures(signedMsg, ssvtypes.PostConsensusSignature, ssvtypes.PostConsensusQuorum, hasQuorum)
 	return hasQuorum, roots, nil
 }
 
@@ -363,3 +373,52 @@ func (b *BaseRunner) ShouldProcessNonBeaconDuty(duty spectypes.Duty) error {
 	}
 	return nil
 }
+
+// trace records the event of the running duty, if duties are traced.
+func (b *BaseRunner) trace(event ssvtypes.DutyTraceEvent) {
+	if b.DutyTracer == nil || b.State == nil || b.State.StartingDuty == nil {
+		return
+	}
+	event.Role = b.RunnerRoleType
+	event.DutyExecutorID = b.dutyExecutorID()
+	event.Slot = b.State.StartingDuty.DutySlot()
+	event.Time = time.Now()
+	b.DutyTracer.TraceDuty(event)
+}
+
+func (b *BaseRunner) tracePartialSignatures(
+	signedMsg *spectypes.PartialSignatureMessages,
+	signatureType, quorumType ssvtypes.DutyTraceEventType,
+	hasQuorum bool,
+) {
+	if b.DutyTracer == nil || len(signedMsg.Messages) == 0 {
+		return
+	}
+	b.trace(ssvtypes.DutyTraceEvent{
+		Type:    signatureType,
+		Signers: []spectypes.OperatorID{signedMsg.Messages[0].Signer},
+	})
+	if hasQuorum {
+		b.trace(ssvtypes.DutyTraceEvent{Type: quorumType})
+	}
+}
+
+// traceSubmission records the result of a submission to the beacon node and returns its error.
+func (b *BaseRunner) traceSubmission(err error) error {
+	b.trace(ssvtypes.DutyTraceEvent{
+		Type:  ssvtypes.BeaconSubmission,
+		Error: err,
+	})
+	return err
+}
+
+// dutyExecutorID returns the committee ID for committee runners, and the validator public key otherwise.
+func (b *BaseRunner) dutyExecutorID() []byte {
+	if b.QBFTController != nil && len(b.QBFTController.Identifier) == len(spectypes.MessageID{}) {
+		return spectypes.MessageID(b.QBFTController.Identifier).GetDutyExecutorID()
+	}
+	for _, share := range b.Share {
+		return share.ValidatorPubKey[:]
+	}
+	return nil
+}
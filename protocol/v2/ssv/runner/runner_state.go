@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
@@ -22,6 +23,10 @@ type State struct {
 	StartingDuty spectypes.Duty `json:"StartingDuty,omitempty"`
 	// flags
 	Finished bool // Finished marked true when there is a full successful cycle (pre, consensus and post) with quorum
+
+	// validatorRegistration is the registration of a validator registration duty, computed when it started
+	// so that the proposer settings changing while it runs don't change what is signed.
+	validatorRegistration *v1.ValidatorRegistration
 }
 
 func NewRunnerState(quorum uint64, duty spectypes.Duty) *State {
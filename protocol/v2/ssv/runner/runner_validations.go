@@ -102,6 +102,9 @@ func (b *BaseRunner) validateDecidedConsensusData(runner Runner, val spectypes.E
 	return nil
 }
 
+// ErrWrongSigningRoot is returned when a partial signature isn't over the expected root.
+var ErrWrongSigningRoot = errors.New("wrong signing root")
+
 func (b *BaseRunner) verifyExpectedRoot(runner Runner, signedMsg *spectypes.PartialSignatureMessages, expectedRootObjs []ssz.HashRoot, domain spec.DomainType) error {
 	if len(expectedRootObjs) != len(signedMsg.Messages) {
 		return errors.New("wrong expected roots count")
@@ -148,7 +151,7 @@ func (b *BaseRunner) verifyExpectedRoot(runner Runner, signedMsg *spectypes.Part
 	// verify roots
 	for i, r := range sortedRoots {
 		if !bytes.Equal(sortedExpectedRoots[i][:], r[:]) {
-			return errors.New("wrong signing root")
+			return ErrWrongSigningRoot
 		}
 	}
 	return nil
@@ -21,6 +21,9 @@ import (
 
 type ValidatorRegistrationRunner struct {
 	BaseRunner *BaseRunner
+	// GasLimit of the registrations of validators without one in their proposer settings,
+	// spectypes.DefaultGasLimit if 0.
+	GasLimit uint64
 
 	beacon         beacon.BeaconNode
 	network        specqbft.Network
@@ -33,7 +36,7 @@ type ValidatorRegistrationRunner struct {
 
 func NewValidatorRegistrationRunner(
 	domainType spectypes.DomainType,
-	beaconNetwork spectypes.BeaconNetwork,
+	beaconNetwork beacon.Network,
 	share map[phase0.ValidatorIndex]*spectypes.Share,
 	beacon beacon.BeaconNode,
 	network specqbft.Network,
@@ -73,6 +76,13 @@ func (r *ValidatorRegistrationRunner) HasRunningDuty() bool {
 func (r *ValidatorRegistrationRunner) ProcessPreConsensus(logger *zap.Logger, signedMsg *spectypes.PartialSignatureMessages) error {
 	quorum, roots, err := r.BaseRunner.basePreConsensusMsgProcessing(r, signedMsg)
 	if err != nil {
+		if errors.Is(err, ErrWrongSigningRoot) && len(signedMsg.Messages) > 0 {
+			// Each operator signs the registration with its own proposer settings and gas limit,
+			// so the quorum can't be reached unless they're the same for the validator.
+			r.metrics.WrongSigningRoot()
+			logger.Warn("partial signature of a different validator registration, the fee recipient or gas limit of the validator may differ between operators",
+				zap.Uint64("signer", signedMsg.Messages[0].Signer))
+		}
 		return errors.Wrap(err, "failed processing validator registration message")
 	}
 
@@ -97,18 +107,22 @@ func (r *ValidatorRegistrationRunner) ProcessPreConsensus(logger *zap.Logger, si
 	specSig := phase0.BLSSignature{}
 	copy(specSig[:], fullSig)
 
-	share := r.GetShare()
-	if share == nil {
-		return errors.New("no share to get validator public key")
+	vr, err := r.runningValidatorRegistration()
+	if err != nil {
+		return err
 	}
 
-	if err := r.beacon.SubmitValidatorRegistration(share.ValidatorPubKey[:],
-		share.FeeRecipientAddress, specSig); err != nil {
+	signedRegistration := &v1.SignedValidatorRegistration{
+		Message:   vr,
+		Signature: specSig,
+	}
+	if err := r.BaseRunner.traceSubmission(r.beacon.SubmitSignedValidatorRegistration(signedRegistration)); err != nil {
 		return errors.Wrap(err, "could not submit validator registration")
 	}
 
 	logger.Debug("validator registration submitted successfully",
-		fields.FeeRecipient(share.FeeRecipientAddress[:]),
+		fields.FeeRecipient(vr.FeeRecipient[:]),
+		zap.Uint64("gas_limit", vr.GasLimit),
 		zap.String("signature", hex.EncodeToString(specSig[:])))
 
 	r.GetState().Finished = true
@@ -124,12 +138,9 @@ func (r *ValidatorRegistrationRunner) ProcessPostConsensus(logger *zap.Logger, s
 }
 
 func (r *ValidatorRegistrationRunner) expectedPreConsensusRootsAndDomain() ([]ssz.HashRoot, phase0.DomainType, error) {
-	if r.BaseRunner.State == nil || r.BaseRunner.State.StartingDuty == nil {
-		return nil, spectypes.DomainError, errors.New("no running duty to compute preconsensus roots and domain")
-	}
-	vr, err := r.calculateValidatorRegistration(r.BaseRunner.State.StartingDuty)
+	vr, err := r.runningValidatorRegistration()
 	if err != nil {
-		return nil, spectypes.DomainError, errors.Wrap(err, "could not calculate validator registration")
+		return nil, spectypes.DomainError, err
 	}
 	return []ssz.HashRoot{vr}, spectypes.DomainApplicationBuilder, nil
 }
@@ -144,6 +155,7 @@ func (r *ValidatorRegistrationRunner) executeDuty(logger *zap.Logger, duty spect
 	if err != nil {
 		return errors.Wrap(err, "could not calculate validator registration")
 	}
+	r.BaseRunner.State.validatorRegistration = vr
 
 	// sign partial randao
 	msg, err := r.BaseRunner.signBeaconObject(r, duty.(*spectypes.ValidatorDuty), vr, duty.DutySlot(),
@@ -191,6 +203,23 @@ func (r *ValidatorRegistrationRunner) executeDuty(logger *zap.Logger, duty spect
 	return nil
 }
 
+// runningValidatorRegistration returns the registration of the running duty, as computed when it started.
+func (r *ValidatorRegistrationRunner) runningValidatorRegistration() (*v1.ValidatorRegistration, error) {
+	state := r.BaseRunner.State
+	if state == nil || state.StartingDuty == nil {
+		return nil, errors.New("no running duty to compute preconsensus roots and domain")
+	}
+	if state.validatorRegistration == nil {
+		// The state wasn't started by this runner, such as when it's decoded.
+		vr, err := r.calculateValidatorRegistration(state.StartingDuty)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not calculate validator registration")
+		}
+		state.validatorRegistration = vr
+	}
+	return state.validatorRegistration, nil
+}
+
 func (r *ValidatorRegistrationRunner) calculateValidatorRegistration(duty spectypes.Duty) (*v1.ValidatorRegistration, error) {
 	share := r.GetShare()
 	if share == nil {
@@ -202,12 +231,25 @@ func (r *ValidatorRegistrationRunner) calculateValidatorRegistration(duty specty
 
 	epoch := r.BaseRunner.BeaconNetwork.EstimatedEpochAtSlot(duty.DutySlot())
 
-	return &v1.ValidatorRegistration{
+	vr := &v1.ValidatorRegistration{
 		FeeRecipient: share.FeeRecipientAddress,
-		GasLimit:     spectypes.DefaultGasLimit,
+		GasLimit:     r.GasLimit,
 		Timestamp:    r.BaseRunner.BeaconNetwork.EpochStartTime(epoch),
 		Pubkey:       pk,
-	}, nil
+	}
+	if vr.GasLimit == 0 {
+		vr.GasLimit = spectypes.DefaultGasLimit
+	}
+	if r.BaseRunner.ProposerSettings != nil {
+		settings := r.BaseRunner.ProposerSettings.ProposerSettings(share.ValidatorPubKey)
+		if settings.FeeRecipient != nil {
+			vr.FeeRecipient = *settings.FeeRecipient
+		}
+		if settings.GasLimit != 0 {
+			vr.GasLimit = settings.GasLimit
+		}
+	}
+	return vr, nil
 }
 
 func (r *ValidatorRegistrationRunner) GetBaseRunner() *BaseRunner {
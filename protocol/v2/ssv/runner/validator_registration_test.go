@@ -0,0 +1,98 @@
+package runner
+
+import (
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+)
+
+type staticProposerSettings map[spectypes.ValidatorPK]ssvtypes.ProposerSettings
+
+func (s staticProposerSettings) ProposerSettings(pubKey spectypes.ValidatorPK) ssvtypes.ProposerSettings {
+	return s[pubKey]
+}
+
+func TestCalculateValidatorRegistration(t *testing.T) {
+	share1 := &spectypes.Share{ValidatorPubKey: spectypes.ValidatorPK{0x1}, FeeRecipientAddress: bellatrix.ExecutionAddress{0x1}}
+	share2 := &spectypes.Share{ValidatorPubKey: spectypes.ValidatorPK{0x2}, FeeRecipientAddress: bellatrix.ExecutionAddress{0x2}}
+	feeRecipient := bellatrix.ExecutionAddress{0x3}
+	settings := staticProposerSettings{
+		share1.ValidatorPubKey: {GasLimit: 40_000_000, FeeRecipient: &feeRecipient},
+	}
+	duty := &spectypes.ValidatorDuty{Type: spectypes.BNRoleValidatorRegistration, Slot: 64}
+
+	tests := []struct {
+		name         string
+		share        *spectypes.Share
+		settings     ssvtypes.ProposerSettingsProvider
+		gasLimit     uint64
+		wantGasLimit uint64
+		wantFee      bellatrix.ExecutionAddress
+	}{
+		{name: "defaults", share: share1, wantGasLimit: spectypes.DefaultGasLimit, wantFee: share1.FeeRecipientAddress},
+		{name: "node gas limit", share: share1, gasLimit: 36_000_000, wantGasLimit: 36_000_000, wantFee: share1.FeeRecipientAddress},
+		{name: "proposer settings", share: share1, settings: settings, gasLimit: 36_000_000, wantGasLimit: 40_000_000, wantFee: feeRecipient},
+		{name: "validator without settings", share: share2, settings: settings, gasLimit: 36_000_000, wantGasLimit: 36_000_000, wantFee: share2.FeeRecipientAddress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewValidatorRegistrationRunner(spectypes.GenesisMainnet, beacon.NewNetwork(spectypes.BeaconTestNetwork),
+				map[phase0.ValidatorIndex]*spectypes.Share{1: tt.share}, nil, nil, nil, nil)
+			require.NoError(t, err)
+			registrationRunner := r.(*ValidatorRegistrationRunner)
+			registrationRunner.GasLimit = tt.gasLimit
+			registrationRunner.BaseRunner.ProposerSettings = tt.settings
+
+			vr, err := registrationRunner.calculateValidatorRegistration(duty)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantGasLimit, vr.GasLimit)
+			require.Equal(t, tt.wantFee, vr.FeeRecipient)
+			require.Equal(t, phase0.BLSPubKey(tt.share.ValidatorPubKey), vr.Pubkey)
+		})
+	}
+}
+
+func TestValidatorRegistrationComputedOnceAtDutyStart(t *testing.T) {
+	ks := spectestingutils.Testing4SharesSet()
+	share := spectestingutils.TestingShare(ks, spectestingutils.TestingValidatorIndex)
+	beaconNode := beacon.NewMockBeaconNode(gomock.NewController(t))
+	beaconNode.EXPECT().DomainData(gomock.Any(), spectypes.DomainApplicationBuilder).Return(phase0.Domain{}, nil)
+	r, err := NewValidatorRegistrationRunner(spectypes.JatoTestnet, beacon.NewNetwork(spectypes.BeaconTestNetwork),
+		map[phase0.ValidatorIndex]*spectypes.Share{share.ValidatorIndex: share},
+		beaconNode,
+		spectestingutils.NewTestingNetwork(1, ks.OperatorKeys[1]),
+		spectestingutils.NewTestingKeyManager(),
+		spectestingutils.NewOperatorSigner(ks, 1),
+	)
+	require.NoError(t, err)
+	registrationRunner := r.(*ValidatorRegistrationRunner)
+
+	feeRecipient := bellatrix.ExecutionAddress{0x1}
+	settings := staticProposerSettings{
+		share.ValidatorPubKey: {GasLimit: 40_000_000, FeeRecipient: &feeRecipient},
+	}
+	registrationRunner.BaseRunner.ProposerSettings = settings
+	require.NoError(t, r.StartNewDuty(logging.TestLogger(t), &spectestingutils.TestingValidatorRegistrationDuty, 3))
+
+	// The proposer settings change while the duty runs.
+	changedFeeRecipient := bellatrix.ExecutionAddress{0x2}
+	settings[share.ValidatorPubKey] = ssvtypes.ProposerSettings{GasLimit: 50_000_000, FeeRecipient: &changedFeeRecipient}
+
+	roots, domain, err := registrationRunner.expectedPreConsensusRootsAndDomain()
+	require.NoError(t, err)
+	require.EqualValues(t, spectypes.DomainApplicationBuilder, domain)
+	require.Len(t, roots, 1)
+	vr := roots[0].(*v1.ValidatorRegistration)
+	require.Equal(t, feeRecipient, vr.FeeRecipient)
+	require.EqualValues(t, 40_000_000, vr.GasLimit)
+}
@@ -35,7 +35,7 @@ type VoluntaryExitRunner struct {
 
 func NewVoluntaryExitRunner(
 	domainType spectypes.DomainType,
-	beaconNetwork spectypes.BeaconNetwork,
+	beaconNetwork beacon.Network,
 	share map[phase0.ValidatorIndex]*spectypes.Share,
 	beacon beacon.BeaconNode,
 	network specqbft.Network,
@@ -102,7 +102,7 @@ func (r *VoluntaryExitRunner) ProcessPreConsensus(logger *zap.Logger, signedMsg
 		Message:   r.voluntaryExit,
 		Signature: specSig,
 	}
-	if err := r.beacon.SubmitVoluntaryExit(signedVoluntaryExit); err != nil {
+	if err := r.BaseRunner.traceSubmission(r.beacon.SubmitVoluntaryExit(signedVoluntaryExit)); err != nil {
 		return errors.Wrap(err, "could not submit voluntary exit")
 	}
 
@@ -16,6 +16,7 @@ import (
 	"github.com/ssvlabs/ssv/exporter/convert"
 	"github.com/ssvlabs/ssv/integration/qbft/tests"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/testing"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/runner"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/validator"
@@ -23,6 +24,9 @@ import (
 
 var TestingHighestDecidedSlot = phase0.Slot(0)
 
+// TestingBeaconNetwork is the beacon network of the spec tests.
+var TestingBeaconNetwork = beacon.NewNetwork(spectypes.BeaconTestNetwork)
+
 var CommitteeRunner = func(logger *zap.Logger, keySet *spectestingutils.TestKeySet) runner.Runner {
 	return baseRunner(logger, spectypes.RoleCommittee, keySet)
 }
@@ -86,13 +90,13 @@ var ConstructBaseRunner = func(
 		valCheck = ssv.BeaconVoteValueCheckF(km, spectestingutils.TestingDutySlot,
 			[]spectypes.ShareValidatorPK{share.SharePubKey}, spectestingutils.TestingDutyEpoch)
 	case spectypes.RoleProposer:
-		valCheck = ssv.ProposerValueCheckF(km, spectypes.BeaconTestNetwork,
+		valCheck = ssv.ProposerValueCheckF(km, TestingBeaconNetwork,
 			(spectypes.ValidatorPK)(spectestingutils.TestingValidatorPubKey), spectestingutils.TestingValidatorIndex, share.SharePubKey)
 	case spectypes.RoleAggregator:
-		valCheck = ssv.AggregatorValueCheckF(km, spectypes.BeaconTestNetwork,
+		valCheck = ssv.AggregatorValueCheckF(km, TestingBeaconNetwork,
 			(spectypes.ValidatorPK)(spectestingutils.TestingValidatorPubKey), spectestingutils.TestingValidatorIndex)
 	case spectypes.RoleSyncCommitteeContribution:
-		valCheck = ssv.SyncCommitteeContributionValueCheckF(km, spectypes.BeaconTestNetwork,
+		valCheck = ssv.SyncCommitteeContributionValueCheckF(km, TestingBeaconNetwork,
 			(spectypes.ValidatorPK)(spectestingutils.TestingValidatorPubKey), spectestingutils.TestingValidatorIndex)
 	default:
 		valCheck = nil
@@ -137,7 +141,7 @@ var ConstructBaseRunner = func(
 	case spectypes.RoleAggregator:
 		r, err = runner.NewAggregatorRunner(
 			networkconfig.TestNetwork.AlanDomainType,
-			spectypes.BeaconTestNetwork,
+			TestingBeaconNetwork,
 			shareMap,
 			contr,
 			tests.NewTestingBeaconNodeWrapped(),
@@ -150,7 +154,7 @@ var ConstructBaseRunner = func(
 	case spectypes.RoleProposer:
 		r, err = runner.NewProposerRunner(
 			networkconfig.TestNetwork.AlanDomainType,
-			spectypes.BeaconTestNetwork,
+			TestingBeaconNetwork,
 			shareMap,
 			contr,
 			tests.NewTestingBeaconNodeWrapped(),
@@ -164,7 +168,7 @@ var ConstructBaseRunner = func(
 	case spectypes.RoleSyncCommitteeContribution:
 		r, err = runner.NewSyncCommitteeAggregatorRunner(
 			networkconfig.TestNetwork.AlanDomainType,
-			spectypes.BeaconTestNetwork,
+			TestingBeaconNetwork,
 			shareMap,
 			contr,
 			tests.NewTestingBeaconNodeWrapped(),
@@ -177,7 +181,7 @@ var ConstructBaseRunner = func(
 	case spectypes.RoleValidatorRegistration:
 		r, err = runner.NewValidatorRegistrationRunner(
 			networkconfig.TestNetwork.AlanDomainType,
-			spectypes.BeaconTestNetwork,
+			TestingBeaconNetwork,
 			shareMap,
 			tests.NewTestingBeaconNodeWrapped(),
 			net,
@@ -187,7 +191,7 @@ var ConstructBaseRunner = func(
 	case spectypes.RoleVoluntaryExit:
 		r, err = runner.NewVoluntaryExitRunner(
 			networkconfig.TestNetwork.AlanDomainType,
-			spectypes.BeaconTestNetwork,
+			TestingBeaconNetwork,
 			shareMap,
 			tests.NewTestingBeaconNodeWrapped(),
 			net,
@@ -340,13 +344,13 @@ var ConstructBaseRunnerWithShareMap = func(
 			valCheck = ssv.BeaconVoteValueCheckF(km, spectestingutils.TestingDutySlot,
 				sharePubKeys, spectestingutils.TestingDutyEpoch)
 		case spectypes.RoleProposer:
-			valCheck = ssv.ProposerValueCheckF(km, spectypes.BeaconTestNetwork,
+			valCheck = ssv.ProposerValueCheckF(km, TestingBeaconNetwork,
 				shareInstance.ValidatorPubKey, shareInstance.ValidatorIndex, shareInstance.SharePubKey)
 		case spectypes.RoleAggregator:
-			valCheck = ssv.AggregatorValueCheckF(km, spectypes.BeaconTestNetwork,
+			valCheck = ssv.AggregatorValueCheckF(km, TestingBeaconNetwork,
 				shareInstance.ValidatorPubKey, shareInstance.ValidatorIndex)
 		case spectypes.RoleSyncCommitteeContribution:
-			valCheck = ssv.SyncCommitteeContributionValueCheckF(km, spectypes.BeaconTestNetwork,
+			valCheck = ssv.SyncCommitteeContributionValueCheckF(km, TestingBeaconNetwork,
 				shareInstance.ValidatorPubKey, shareInstance.ValidatorIndex)
 		default:
 			valCheck = nil
@@ -387,7 +391,7 @@ var ConstructBaseRunnerWithShareMap = func(
 	case spectypes.RoleAggregator:
 		r, err = runner.NewAggregatorRunner(
 			networkconfig.TestNetwork.AlanDomainType,
-			spectypes.BeaconTestNetwork,
+			TestingBeaconNetwork,
 			shareMap,
 			contr,
 			tests.NewTestingBeaconNodeWrapped(),
@@ -400,7 +404,7 @@ var ConstructBaseRunnerWithShareMap = func(
 	case spectypes.RoleProposer:
 		r, err = runner.NewProposerRunner(
 			networkconfig.TestNetwork.AlanDomainType,
-			spectypes.BeaconTestNetwork,
+			TestingBeaconNetwork,
 			shareMap,
 			contr,
 			tests.NewTestingBeaconNodeWrapped(),
@@ -414,7 +418,7 @@ var ConstructBaseRunnerWithShareMap = func(
 	case spectypes.RoleSyncCommitteeContribution:
 		r, err = runner.NewSyncCommitteeAggregatorRunner(
 			networkconfig.TestNetwork.AlanDomainType,
-			spectypes.BeaconTestNetwork,
+			TestingBeaconNetwork,
 			shareMap,
 			contr,
 			tests.NewTestingBeaconNodeWrapped(),
@@ -427,7 +431,7 @@ var ConstructBaseRunnerWithShareMap = func(
 	case spectypes.RoleValidatorRegistration:
 		r, err = runner.NewValidatorRegistrationRunner(
 			networkconfig.TestNetwork.AlanDomainType,
-			spectypes.BeaconTestNetwork,
+			TestingBeaconNetwork,
 			shareMap,
 			tests.NewTestingBeaconNodeWrapped(),
 			net,
@@ -437,7 +441,7 @@ var ConstructBaseRunnerWithShareMap = func(
 	case spectypes.RoleVoluntaryExit:
 		r, err = runner.NewVoluntaryExitRunner(
 			networkconfig.TestNetwork.AlanDomainType,
-			spectypes.BeaconTestNetwork,
+			TestingBeaconNetwork,
 			shareMap,
 			tests.NewTestingBeaconNodeWrapped(),
 			net,
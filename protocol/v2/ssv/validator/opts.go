@@ -43,6 +43,8 @@ type Options struct {
 	MessageValidator  validation.MessageValidator
 	Metrics           Metrics
 	Graffiti          []byte
+	DutyTracer        ssvtypes.DutyTracer
+	ProposerSettings  ssvtypes.ProposerSettingsProvider
 	GenesisOptions
 }
 
@@ -9,11 +9,13 @@ import (
 
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 )
 
 func dutyValueCheck(
 	duty *spectypes.ValidatorDuty,
-	network spectypes.BeaconNetwork,
+	network beacon.Network,
 	expectedType spectypes.BeaconRole,
 	validatorPK spectypes.ValidatorPK,
 	validatorIndex phase0.ValidatorIndex,
@@ -79,7 +81,7 @@ func BeaconVoteValueCheckF(
 
 func ProposerValueCheckF(
 	signer spectypes.BeaconSigner,
-	network spectypes.BeaconNetwork,
+	network beacon.Network,
 	validatorPK spectypes.ValidatorPK,
 	validatorIndex phase0.ValidatorIndex,
 	sharePublicKey []byte,
@@ -118,7 +120,7 @@ func ProposerValueCheckF(
 
 func AggregatorValueCheckF(
 	signer spectypes.BeaconSigner,
-	network spectypes.BeaconNetwork,
+	network beacon.Network,
 	validatorPK spectypes.ValidatorPK,
 	validatorIndex phase0.ValidatorIndex,
 ) specqbft.ProposedValueCheckF {
@@ -140,7 +142,7 @@ func AggregatorValueCheckF(
 
 func SyncCommitteeContributionValueCheckF(
 	signer spectypes.BeaconSigner,
-	network spectypes.BeaconNetwork,
+	network beacon.Network,
 	validatorPK spectypes.ValidatorPK,
 	validatorIndex phase0.ValidatorIndex,
 ) specqbft.ProposedValueCheckF {
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+)
+
+// DutyTraceEventType is the type of a step in the execution of a duty.
+type DutyTraceEventType int
+
+const (
+	// DutyStarted is traced when the runner starts the duty.
+	DutyStarted DutyTraceEventType = iota
+	// PreConsensusSignature is traced for every pre-consensus partial signature message.
+	PreConsensusSignature
+	// PreConsensusQuorum is traced when a quorum of pre-consensus partial signatures is reached.
+	PreConsensusQuorum
+	// QBFTMessage is traced for every processed consensus message.
+	QBFTMessage
+	// QBFTRoundChange is traced when the instance moves to a new round.
+	QBFTRoundChange
+	// QBFTDecided is traced when the instance decides.
+	QBFTDecided
+	// PostConsensusSignature is traced for every post-consensus partial signature message.
+	PostConsensusSignature
+	// PostConsensusQuorum is traced when a quorum of post-consensus partial signatures is reached.
+	PostConsensusQuorum
+	// BeaconSubmission is traced for every submission to the beacon node.
+	BeaconSubmission
+)
+
+// DutyTraceEvent is a step in the execution of a duty,
+// identified by the runner role, the duty executor (validator public key or committee ID) and the slot.
+type DutyTraceEvent struct {
+	Type           DutyTraceEventType
+	Role           spectypes.RunnerRole
+	DutyExecutorID []byte
+	Slot           phase0.Slot
+	Time           time.Time
+
+	// Signers are the signers of a message.
+	Signers []spectypes.OperatorID
+	// MsgType and Round are set for QBFT events.
+	MsgType specqbft.MessageType
+	Round   specqbft.Round
+	// Root is the data root of proposals and decided values.
+	Root [32]byte
+	// Error is the error of a beacon submission, if it failed.
+	Error error
+}
+
+// DutyTracer records the execution of duties.
+// Implementations must be safe for concurrent use and must not block.
+type DutyTracer interface {
+	TraceDuty(event DutyTraceEvent)
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+)
+
+// ProposerSettings are the preferences of a validator for its block proposals and builder registrations.
+// Unset fields fall back to the node's defaults.
+type ProposerSettings struct {
+	// Graffiti of the validator's block proposals, nil if unset.
+	Graffiti []byte
+	// GasLimit of the validator's builder registrations, 0 if unset.
+	GasLimit uint64
+	// FeeRecipient overrides the fee recipient of the validator's owner, nil if unset.
+	FeeRecipient *bellatrix.ExecutionAddress
+	// BuilderBoostFactor weights builder payloads against local ones in the validator's proposals,
+	// where 0 means local payloads only and 100 means no preference. Nil if unset.
+	BuilderBoostFactor *uint64
+}
+
+// ProposerSettingsProvider returns the proposer settings of validators.
+// Implementations must be safe for concurrent use.
+type ProposerSettingsProvider interface {
+	ProposerSettings(pubKey spectypes.ValidatorPK) ProposerSettings
+}
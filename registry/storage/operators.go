@@ -162,21 +162,27 @@ func (s *operatorsStorage) operatorsExist(
 	return seen == len(ids), nil
 }
 
+// listOperators scans all operators, since their keys aren't ordered by ID.
 func (s *operatorsStorage) listOperators(r basedb.Reader, from, to uint64) ([]OperatorData, error) {
 	var operators []OperatorData
-	err := s.db.UsingReader(r).
-		GetAll(append(s.prefix, operatorsPrefix...), func(i int, obj basedb.Obj) error {
-			var od OperatorData
-			if err := json.Unmarshal(obj.Value, &od); err != nil {
-				return err
-			}
-			if (od.ID >= from && od.ID <= to) || (to == 0) {
-				operators = append(operators, od)
-			}
-			return nil
-		})
-
-	return operators, err
+	it := s.db.UsingReader(r).Iterator(append(s.prefix, operatorsPrefix...), basedb.IteratorOptions{})
+	for ; it.Valid(); it.Next() {
+		value, err := it.Value()
+		if err != nil {
+			_ = it.Close()
+			return nil, err
+		}
+		var od OperatorData
+		if err := json.Unmarshal(value, &od); err != nil {
+			_ = it.Close()
+			return nil, err
+		}
+		if (od.ID >= from && od.ID <= to) || (to == 0) {
+			operators = append(operators, od)
+		}
+	}
+
+	return operators, it.Close()
 }
 
 // SaveOperatorData saves operator data
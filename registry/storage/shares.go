@@ -47,6 +47,9 @@ type Shares interface {
 	// Drop deletes all shares.
 	Drop() error
 
+	// Reload discards the in-memory shares and reads them again from the db.
+	Reload() error
+
 	// UpdateValidatorsMetadata updates the metadata of the given validators
 	UpdateValidatorsMetadata(map[spectypes.ValidatorPK]*beaconprotocol.ValidatorMetadata) error
 }
@@ -117,9 +120,11 @@ func NewSharesStorage(logger *zap.Logger, db basedb.Database, prefix []byte) (Sh
 		prefix: prefix,
 	}
 
-	if err := storage.load(); err != nil {
+	shares, err := storage.load()
+	if err != nil {
 		return nil, nil, err
 	}
+	storage.shares = shares
 	storage.validatorStore = newValidatorStore(
 		func() []*types.SSVShare { return storage.List(nil) },
 		func(pk []byte) (*types.SSVShare, bool) { return storage.Get(nil, pk) },
@@ -130,25 +135,34 @@ func NewSharesStorage(logger *zap.Logger, db basedb.Database, prefix []byte) (Sh
 	return storage, storage.validatorStore, nil
 }
 
-// load reads all shares from db.
-func (s *sharesStorage) load() error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	return s.db.GetAll(append(s.prefix, sharesPrefix...), func(i int, obj basedb.Obj) error {
+// load reads all shares from db into a new map, leaving the in-memory shares as they are.
+func (s *sharesStorage) load() (map[string]*types.SSVShare, error) {
+	shares := make(map[string]*types.SSVShare)
+	it := s.db.Iterator(append(s.prefix, sharesPrefix...), basedb.IteratorOptions{})
+	for ; it.Valid(); it.Next() {
+		value, err := it.Value()
+		if err != nil {
+			_ = it.Close()
+			return nil, fmt.Errorf("failed to read share: %w", err)
+		}
 		val := &storageShare{}
-		if err := val.Decode(obj.Value); err != nil {
-			return fmt.Errorf("failed to deserialize share: %w", err)
+		if err := val.Decode(value); err != nil {
+			_ = it.Close()
+			return nil, fmt.Errorf("failed to deserialize share: %w", err)
 		}
 		val.DomainType = spectypes.DomainType(genesistypes.GetDefaultDomain())
 		share, err := s.storageShareToSpecShare(val)
 		if err != nil {
-			return fmt.Errorf("failed to convert storage share to spec share: %w", err)
+			_ = it.Close()
+			return nil, fmt.Errorf("failed to convert storage share to spec share: %w", err)
 		}
 
-		s.shares[hex.EncodeToString(val.ValidatorPubKey[:])] = share
-		return nil
-	})
+		shares[hex.EncodeToString(val.ValidatorPubKey[:])] = share
+	}
+	if err := it.Close(); err != nil {
+		return nil, err
+	}
+	return shares, nil
 }
 
 func (s *sharesStorage) Get(_ basedb.Reader, pubKey []byte) (*types.SSVShare, bool) {
@@ -406,6 +420,23 @@ func (s *sharesStorage) Drop() error {
 	return nil
 }
 
+// Reload reads the shares again from the db and replaces the in-memory shares with them.
+// Readers see the previous shares until the reloaded ones are swapped in,
+// and if reading fails, the previous shares are kept.
+func (s *sharesStorage) Reload() error {
+	shares, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.shares = shares
+	s.mu.Unlock()
+
+	s.validatorStore.handleDrop()
+	return s.validatorStore.handleSharesAdded(maps.Values(shares)...)
+}
+
 // storageKey builds share key using sharesPrefix & validator public key, e.g. "shares/0x00..01"
 func (s *sharesStorage) storageKey(pk []byte) []byte {
 	return bytes.Join([][]byte{sharesPrefix, pk}, []byte("/"))
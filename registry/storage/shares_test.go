@@ -70,121 +70,125 @@ func TestMaxPossibleShareSize(t *testing.T) {
 }
 
 func TestSharesStorage(t *testing.T) {
-	logger := logging.TestLogger(t)
-	storage, err := newTestStorage(logger)
-	require.NoError(t, err)
-	defer storage.Close()
-
-	threshold.Init()
-	const keysCount = 4
-
-	sk := &bls.SecretKey{}
-	sk.SetByCSPRNG()
-
-	splitKeys, err := threshold.Create(sk.Serialize(), keysCount-1, keysCount)
-	require.NoError(t, err)
-
-	for operatorID := range splitKeys {
-		_, err = storage.Operators.SaveOperatorData(nil, &OperatorData{ID: operatorID, PublicKey: []byte(strconv.FormatUint(operatorID, 10))})
-		require.NoError(t, err)
-	}
-
-	validatorShare, _ := generateRandomValidatorSpecShare(splitKeys)
-	validatorShare.Metadata = ssvtypes.Metadata{
-		BeaconMetadata: &beaconprotocol.ValidatorMetadata{
-			Balance:         1,
-			Status:          eth2apiv1.ValidatorStateActiveOngoing,
-			Index:           3,
-			ActivationEpoch: 4,
-		},
-		OwnerAddress: common.HexToAddress("0xFeedB14D8b2C76FdF808C29818b06b830E8C2c0e"),
-		Liquidated:   false,
-	}
-	require.NoError(t, storage.Shares.Save(nil, validatorShare))
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+			storage, err := newTestStorage(logger, engine)
+			require.NoError(t, err)
+			defer storage.Close()
 
-	validatorShare2, _ := generateRandomValidatorSpecShare(splitKeys)
-	require.NoError(t, storage.Shares.Save(nil, validatorShare2))
+			threshold.Init()
+			const keysCount = 4
 
-	validatorShareByKey, exists := storage.Shares.Get(nil, validatorShare.ValidatorPubKey[:])
-	require.True(t, exists)
-	require.NotNil(t, validatorShareByKey)
-	require.NoError(t, err)
-	require.EqualValues(t, hex.EncodeToString(validatorShareByKey.ValidatorPubKey[:]), hex.EncodeToString(validatorShare.ValidatorPubKey[:]))
-	require.EqualValues(t, validatorShare.Committee, validatorShareByKey.Committee)
-
-	validators := storage.Shares.List(nil)
-	require.NoError(t, err)
-	require.EqualValues(t, 2, len(validators))
-
-	t.Run("UpdateValidatorMetadata_shareExists", func(t *testing.T) {
-		require.NoError(t, storage.Shares.UpdateValidatorsMetadata(map[spectypes.ValidatorPK]*beaconprotocol.ValidatorMetadata{
-			validatorShare.ValidatorPubKey: {
-				Balance:         10000,
-				Index:           3,
-				Status:          eth2apiv1.ValidatorStateActiveOngoing,
-				ActivationEpoch: 4,
-			},
-		}))
-	})
-
-	t.Run("List_Filter_ByClusterId", func(t *testing.T) {
-		clusterID := ssvtypes.ComputeClusterIDHash(validatorShare.Metadata.OwnerAddress, []uint64{1, 2, 3, 4})
-
-		validators := storage.Shares.List(nil, ByClusterIDHash(clusterID))
-		require.Equal(t, 2, len(validators))
-	})
+			sk := &bls.SecretKey{}
+			sk.SetByCSPRNG()
 
-	t.Run("List_Filter_ByOperatorID", func(t *testing.T) {
-		validators := storage.Shares.List(nil, ByOperatorID(1))
-		require.Equal(t, 2, len(validators))
-	})
-
-	t.Run("List_Filter_ByActiveValidator", func(t *testing.T) {
-		validators := storage.Shares.List(nil, ByActiveValidator())
-		require.Equal(t, 2, len(validators))
-	})
+			splitKeys, err := threshold.Create(sk.Serialize(), keysCount-1, keysCount)
+			require.NoError(t, err)
 
-	t.Run("List_Filter_ByNotLiquidated", func(t *testing.T) {
-		validators := storage.Shares.List(nil, ByNotLiquidated())
-		require.Equal(t, 1, len(validators))
-	})
-
-	t.Run("List_Filter_ByAttesting", func(t *testing.T) {
-		validators := storage.Shares.List(nil, ByAttesting(phase0.Epoch(1)))
-		require.Equal(t, 1, len(validators))
-	})
-
-	t.Run("KV_reuse_works", func(t *testing.T) {
-		storageDuplicate, _, err := NewSharesStorage(logger, storage.db, []byte("test"))
-		require.NoError(t, err)
-		existingValidators := storageDuplicate.List(nil)
+			for operatorID := range splitKeys {
+				_, err = storage.Operators.SaveOperatorData(nil, &OperatorData{ID: operatorID, PublicKey: []byte(strconv.FormatUint(operatorID, 10))})
+				require.NoError(t, err)
+			}
 
-		require.Equal(t, 2, len(existingValidators))
-	})
+			validatorShare, _ := generateRandomValidatorSpecShare(splitKeys)
+			validatorShare.Metadata = ssvtypes.Metadata{
+				BeaconMetadata: &beaconprotocol.ValidatorMetadata{
+					Balance:         1,
+					Status:          eth2apiv1.ValidatorStateActiveOngoing,
+					Index:           3,
+					ActivationEpoch: 4,
+				},
+				OwnerAddress: common.HexToAddress("0xFeedB14D8b2C76FdF808C29818b06b830E8C2c0e"),
+				Liquidated:   false,
+			}
+			require.NoError(t, storage.Shares.Save(nil, validatorShare))
 
-	require.NoError(t, storage.Shares.Delete(nil, validatorShare.ValidatorPubKey[:]))
-	share, exists := storage.Shares.Get(nil, validatorShare.ValidatorPubKey[:])
-	require.False(t, exists)
-	require.Nil(t, share)
+			validatorShare2, _ := generateRandomValidatorSpecShare(splitKeys)
+			require.NoError(t, storage.Shares.Save(nil, validatorShare2))
 
-	t.Run("UpdateValidatorMetadata_shareIsDeleted", func(t *testing.T) {
-		require.NoError(t, storage.Shares.UpdateValidatorsMetadata(map[spectypes.ValidatorPK]*beaconprotocol.ValidatorMetadata{
-			validatorShare.ValidatorPubKey: {
-				Balance:         10000,
-				Index:           3,
-				Status:          2,
-				ActivationEpoch: 4,
-			},
-		}))
-	})
+			validatorShareByKey, exists := storage.Shares.Get(nil, validatorShare.ValidatorPubKey[:])
+			require.True(t, exists)
+			require.NotNil(t, validatorShareByKey)
+			require.NoError(t, err)
+			require.EqualValues(t, hex.EncodeToString(validatorShareByKey.ValidatorPubKey[:]), hex.EncodeToString(validatorShare.ValidatorPubKey[:]))
+			require.EqualValues(t, validatorShare.Committee, validatorShareByKey.Committee)
 
-	t.Run("Drop", func(t *testing.T) {
-		require.NoError(t, storage.Shares.Drop())
+			validators := storage.Shares.List(nil)
+			require.NoError(t, err)
+			require.EqualValues(t, 2, len(validators))
+
+			t.Run("UpdateValidatorMetadata_shareExists", func(t *testing.T) {
+				require.NoError(t, storage.Shares.UpdateValidatorsMetadata(map[spectypes.ValidatorPK]*beaconprotocol.ValidatorMetadata{
+					validatorShare.ValidatorPubKey: {
+						Balance:         10000,
+						Index:           3,
+						Status:          eth2apiv1.ValidatorStateActiveOngoing,
+						ActivationEpoch: 4,
+					},
+				}))
+			})
+
+			t.Run("List_Filter_ByClusterId", func(t *testing.T) {
+				clusterID := ssvtypes.ComputeClusterIDHash(validatorShare.Metadata.OwnerAddress, []uint64{1, 2, 3, 4})
+
+				validators := storage.Shares.List(nil, ByClusterIDHash(clusterID))
+				require.Equal(t, 2, len(validators))
+			})
+
+			t.Run("List_Filter_ByOperatorID", func(t *testing.T) {
+				validators := storage.Shares.List(nil, ByOperatorID(1))
+				require.Equal(t, 2, len(validators))
+			})
+
+			t.Run("List_Filter_ByActiveValidator", func(t *testing.T) {
+				validators := storage.Shares.List(nil, ByActiveValidator())
+				require.Equal(t, 2, len(validators))
+			})
+
+			t.Run("List_Filter_ByNotLiquidated", func(t *testing.T) {
+				validators := storage.Shares.List(nil, ByNotLiquidated())
+				require.Equal(t, 1, len(validators))
+			})
+
+			t.Run("List_Filter_ByAttesting", func(t *testing.T) {
+				validators := storage.Shares.List(nil, ByAttesting(phase0.Epoch(1)))
+				require.Equal(t, 1, len(validators))
+			})
+
+			t.Run("KV_reuse_works", func(t *testing.T) {
+				storageDuplicate, _, err := NewSharesStorage(logger, storage.db, []byte("test"))
+				require.NoError(t, err)
+				existingValidators := storageDuplicate.List(nil)
+
+				require.Equal(t, 2, len(existingValidators))
+			})
 
-		validators := storage.Shares.List(nil, ByOperatorID(1))
-		require.NoError(t, err)
-		require.EqualValues(t, 0, len(validators))
-	})
+			require.NoError(t, storage.Shares.Delete(nil, validatorShare.ValidatorPubKey[:]))
+			share, exists := storage.Shares.Get(nil, validatorShare.ValidatorPubKey[:])
+			require.False(t, exists)
+			require.Nil(t, share)
+
+			t.Run("UpdateValidatorMetadata_shareIsDeleted", func(t *testing.T) {
+				require.NoError(t, storage.Shares.UpdateValidatorsMetadata(map[spectypes.ValidatorPK]*beaconprotocol.ValidatorMetadata{
+					validatorShare.ValidatorPubKey: {
+						Balance:         10000,
+						Index:           3,
+						Status:          2,
+						ActivationEpoch: 4,
+					},
+				}))
+			})
+
+			t.Run("Drop", func(t *testing.T) {
+				require.NoError(t, storage.Shares.Drop())
+
+				validators := storage.Shares.List(nil, ByOperatorID(1))
+				require.NoError(t, err)
+				require.EqualValues(t, 0, len(validators))
+			})
+		})
+	}
 }
 
 func generateRandomValidatorStorageShare(splitKeys map[uint64]*bls.SecretKey) (*storageShare, *bls.SecretKey) {
@@ -294,14 +298,14 @@ func generateMaxPossibleShare() (*storageShare, error) {
 }
 
 type testStorage struct {
-	db             *kv.BadgerDB
+	db             kv.DB
 	Operators      Operators
 	Shares         Shares
 	ValidatorStore ValidatorStore
 }
 
-func newTestStorage(logger *zap.Logger) (*testStorage, error) {
-	db, err := kv.NewInMemory(logger, basedb.Options{})
+func newTestStorage(logger *zap.Logger, engine string) (*testStorage, error) {
+	db, err := kv.OpenInMemory(logger, basedb.Options{Engine: engine})
 	if err != nil {
 		return nil, err
 	}
@@ -331,171 +335,238 @@ func (t *testStorage) Close() error {
 }
 
 func TestShareDeletionHandlesValidatorStoreCorrectly(t *testing.T) {
-	logger := logging.TestLogger(t)
-	storage, err := newTestStorage(logger)
-	require.NoError(t, err)
-	defer storage.Close()
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+			storage, err := newTestStorage(logger, engine)
+			require.NoError(t, err)
+			defer storage.Close()
+
+			// Initialize threshold and generate keys for test setup
+			threshold.Init()
+			const keysCount = 4
+
+			sk := &bls.SecretKey{}
+			sk.SetByCSPRNG()
+
+			splitKeys, err := threshold.Create(sk.Serialize(), keysCount-1, keysCount)
+			require.NoError(t, err)
+
+			// Save operators to the storage
+			for operatorID := range splitKeys {
+				_, err = storage.Operators.SaveOperatorData(nil, &OperatorData{ID: operatorID, PublicKey: []byte(strconv.FormatUint(operatorID, 10))})
+				require.NoError(t, err)
+			}
 
-	// Initialize threshold and generate keys for test setup
-	threshold.Init()
-	const keysCount = 4
+			// Test share deletion with and without reopening the database.
+			for _, withReopen := range []bool{true, false} {
+				t.Run(fmt.Sprintf("withReopen=%t", withReopen), func(t *testing.T) {
+					// Generate and save a random validator share
+					validatorShare, _ := generateRandomValidatorSpecShare(splitKeys)
+					require.NoError(t, storage.Shares.Save(nil, validatorShare))
+					if withReopen {
+						require.NoError(t, storage.Reopen(logger))
+					}
+
+					// Ensure the share is saved correctly
+					savedShare, exists := storage.Shares.Get(nil, validatorShare.ValidatorPubKey[:])
+					require.True(t, exists)
+					require.NotNil(t, savedShare)
+
+					// Ensure the share is saved correctly in the validatorStore
+					validatorShareFromStore, exists := storage.ValidatorStore.Validator(validatorShare.ValidatorPubKey[:])
+					require.True(t, exists)
+					require.NotNil(t, validatorShareFromStore)
+
+					// Delete the share from storage
+					require.NoError(t, storage.Shares.Delete(nil, validatorShare.ValidatorPubKey[:]))
+					if withReopen {
+						require.NoError(t, storage.Reopen(logger))
+					}
+
+					// Verify that the share is deleted from shareStorage
+					deletedShare, exists := storage.Shares.Get(nil, validatorShare.ValidatorPubKey[:])
+					require.False(t, exists)
+					require.Nil(t, deletedShare, "Share should be deleted from shareStorage")
+
+					// Verify that the validatorStore reflects the removal correctly
+					removedShare, exists := storage.ValidatorStore.Validator(validatorShare.ValidatorPubKey[:])
+					require.False(t, exists)
+					require.Nil(t, removedShare, "Share should be removed from validator store after deletion")
+
+					// Further checks on internal data structures
+					committeeID := validatorShare.CommitteeID()
+					committee, exists := storage.ValidatorStore.Committee(committeeID)
+					require.False(t, exists)
+					require.Nil(t, committee, "Committee should be nil after share deletion")
+
+					// Verify that other internal mappings are updated accordingly
+					for _, operator := range validatorShare.Committee {
+						shares := storage.ValidatorStore.OperatorValidators(operator.Signer)
+						require.Empty(t, shares, "Data for operator should be nil after share deletion")
+					}
+
+					// Cleanup the share storage for the next test
+					require.NoError(t, storage.Shares.Drop())
+					if withReopen {
+						require.NoError(t, storage.Reopen(logger))
+					}
+					validators := storage.Shares.List(nil)
+					require.EqualValues(t, 0, len(validators), "No validators should be left in storage after drop")
+				})
+			}
+		})
+	}
+}
 
-	sk := &bls.SecretKey{}
-	sk.SetByCSPRNG()
+func TestSharesStorageReload(t *testing.T) {
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+			storage, err := newTestStorage(logger, engine)
+			require.NoError(t, err)
+			defer storage.Close()
 
-	splitKeys, err := threshold.Create(sk.Serialize(), keysCount-1, keysCount)
-	require.NoError(t, err)
+			threshold.Init()
+			const keysCount = 4
 
-	// Save operators to the storage
-	for operatorID := range splitKeys {
-		_, err = storage.Operators.SaveOperatorData(nil, &OperatorData{ID: operatorID, PublicKey: []byte(strconv.FormatUint(operatorID, 10))})
-		require.NoError(t, err)
-	}
+			sk := &bls.SecretKey{}
+			sk.SetByCSPRNG()
 
-	// Test share deletion with and without reopening the database.
-	for _, withReopen := range []bool{true, false} {
-		t.Run(fmt.Sprintf("withReopen=%t", withReopen), func(t *testing.T) {
-			// Generate and save a random validator share
-			validatorShare, _ := generateRandomValidatorSpecShare(splitKeys)
-			require.NoError(t, storage.Shares.Save(nil, validatorShare))
-			if withReopen {
-				require.NoError(t, storage.Reopen(logger))
+			splitKeys, err := threshold.Create(sk.Serialize(), keysCount-1, keysCount)
+			require.NoError(t, err)
+
+			for operatorID := range splitKeys {
+				_, err = storage.Operators.SaveOperatorData(nil, &OperatorData{ID: operatorID, PublicKey: []byte(strconv.FormatUint(operatorID, 10))})
+				require.NoError(t, err)
 			}
 
-			// Ensure the share is saved correctly
-			savedShare, exists := storage.Shares.Get(nil, validatorShare.ValidatorPubKey[:])
-			require.True(t, exists)
-			require.NotNil(t, savedShare)
+			kept, _ := generateRandomValidatorSpecShare(splitKeys)
+			removed, _ := generateRandomValidatorSpecShare(splitKeys)
+			require.NoError(t, storage.Shares.Save(nil, kept, removed))
+
+			// Delete a share behind the cache's back, e.g. by rolling back a transaction.
+			s := storage.Shares.(*sharesStorage)
+			require.NoError(t, storage.db.Delete(s.prefix, s.storageKey(removed.ValidatorPubKey[:])))
 
-			// Ensure the share is saved correctly in the validatorStore
-			validatorShareFromStore, exists := storage.ValidatorStore.Validator(validatorShare.ValidatorPubKey[:])
+			_, exists := storage.Shares.Get(nil, removed.ValidatorPubKey[:])
 			require.True(t, exists)
-			require.NotNil(t, validatorShareFromStore)
 
-			// Delete the share from storage
-			require.NoError(t, storage.Shares.Delete(nil, validatorShare.ValidatorPubKey[:]))
-			if withReopen {
-				require.NoError(t, storage.Reopen(logger))
-			}
+			require.NoError(t, storage.Shares.Reload())
 
-			// Verify that the share is deleted from shareStorage
-			deletedShare, exists := storage.Shares.Get(nil, validatorShare.ValidatorPubKey[:])
+			_, exists = storage.Shares.Get(nil, removed.ValidatorPubKey[:])
 			require.False(t, exists)
-			require.Nil(t, deletedShare, "Share should be deleted from shareStorage")
-
-			// Verify that the validatorStore reflects the removal correctly
-			removedShare, exists := storage.ValidatorStore.Validator(validatorShare.ValidatorPubKey[:])
+			_, exists = storage.ValidatorStore.Validator(removed.ValidatorPubKey[:])
 			require.False(t, exists)
-			require.Nil(t, removedShare, "Share should be removed from validator store after deletion")
 
-			// Further checks on internal data structures
-			committeeID := validatorShare.CommitteeID()
-			committee, exists := storage.ValidatorStore.Committee(committeeID)
-			require.False(t, exists)
-			require.Nil(t, committee, "Committee should be nil after share deletion")
+			_, exists = storage.Shares.Get(nil, kept.ValidatorPubKey[:])
+			require.True(t, exists)
+			_, exists = storage.ValidatorStore.Validator(kept.ValidatorPubKey[:])
+			require.True(t, exists)
+			require.Len(t, storage.Shares.List(nil), 1)
 
-			// Verify that other internal mappings are updated accordingly
-			for _, operator := range validatorShare.Committee {
-				shares := storage.ValidatorStore.OperatorValidators(operator.Signer)
-				require.Empty(t, shares, "Data for operator should be nil after share deletion")
-			}
+			// A failed reload keeps the previous shares.
+			require.NoError(t, storage.db.Set(s.prefix, s.storageKey(removed.ValidatorPubKey[:]), []byte("corrupted")))
+			require.Error(t, storage.Shares.Reload())
 
-			// Cleanup the share storage for the next test
-			require.NoError(t, storage.Shares.Drop())
-			if withReopen {
-				require.NoError(t, storage.Reopen(logger))
-			}
-			validators := storage.Shares.List(nil)
-			require.EqualValues(t, 0, len(validators), "No validators should be left in storage after drop")
+			_, exists = storage.Shares.Get(nil, kept.ValidatorPubKey[:])
+			require.True(t, exists)
+			_, exists = storage.ValidatorStore.Validator(kept.ValidatorPubKey[:])
+			require.True(t, exists)
+			require.Len(t, storage.Shares.List(nil), 1)
 		})
 	}
 }
 
 func TestValidatorStoreThroughSharesStorage(t *testing.T) {
-	logger := logging.TestLogger(t)
-	storage, err := newTestStorage(logger)
-	require.NoError(t, err)
-	defer storage.Close()
-
-	// Initialize threshold and generate keys for test setup
-	threshold.Init()
-	const keysCount = 4
-
-	sk := &bls.SecretKey{}
-	sk.SetByCSPRNG()
-
-	splitKeys, err := threshold.Create(sk.Serialize(), keysCount-1, keysCount)
-	require.NoError(t, err)
-
-	// Save operators to the storage
-	for operatorID := range splitKeys {
-		_, err = storage.Operators.SaveOperatorData(nil, &OperatorData{ID: operatorID, PublicKey: []byte(strconv.FormatUint(operatorID, 10))})
-		require.NoError(t, err)
-	}
-
-	for _, withReopen := range []bool{true, false} {
-		t.Run(fmt.Sprintf("withReopen=%t", withReopen), func(t *testing.T) {
-			// Generate and save a random validator share
-			validatorShare, _ := generateRandomValidatorSpecShare(splitKeys)
-			require.NoError(t, storage.Shares.Save(nil, validatorShare))
-			if withReopen {
-				require.NoError(t, storage.Reopen(logger))
+	for _, engine := range kv.Engines {
+		t.Run(engine, func(t *testing.T) {
+			logger := logging.TestLogger(t)
+			storage, err := newTestStorage(logger, engine)
+			require.NoError(t, err)
+			defer storage.Close()
+
+			// Initialize threshold and generate keys for test setup
+			threshold.Init()
+			const keysCount = 4
+
+			sk := &bls.SecretKey{}
+			sk.SetByCSPRNG()
+
+			splitKeys, err := threshold.Create(sk.Serialize(), keysCount-1, keysCount)
+			require.NoError(t, err)
+
+			// Save operators to the storage
+			for operatorID := range splitKeys {
+				_, err = storage.Operators.SaveOperatorData(nil, &OperatorData{ID: operatorID, PublicKey: []byte(strconv.FormatUint(operatorID, 10))})
+				require.NoError(t, err)
 			}
 
-			// Try saving nil share/shares
-			require.Error(t, storage.Shares.Save(nil, nil))
-			require.Error(t, storage.Shares.Save(nil, nil, validatorShare))
-			require.Error(t, storage.Shares.Save(nil, validatorShare, nil))
-			if withReopen {
-				require.NoError(t, storage.Reopen(logger))
+			for _, withReopen := range []bool{true, false} {
+				t.Run(fmt.Sprintf("withReopen=%t", withReopen), func(t *testing.T) {
+					// Generate and save a random validator share
+					validatorShare, _ := generateRandomValidatorSpecShare(splitKeys)
+					require.NoError(t, storage.Shares.Save(nil, validatorShare))
+					if withReopen {
+						require.NoError(t, storage.Reopen(logger))
+					}
+
+					// Try saving nil share/shares
+					require.Error(t, storage.Shares.Save(nil, nil))
+					require.Error(t, storage.Shares.Save(nil, nil, validatorShare))
+					require.Error(t, storage.Shares.Save(nil, validatorShare, nil))
+					if withReopen {
+						require.NoError(t, storage.Reopen(logger))
+					}
+
+					// Ensure the share is saved correctly
+					savedShare, exists := storage.Shares.Get(nil, validatorShare.ValidatorPubKey[:])
+					require.True(t, exists)
+					require.NotNil(t, savedShare)
+
+					// Verify that the validatorStore has the share via SharesStorage
+					storedShare, exists := storage.ValidatorStore.Validator(validatorShare.ValidatorPubKey[:])
+					require.True(t, exists)
+					require.NotNil(t, storedShare, "Share should be present in validator store after adding to sharesStorage")
+
+					// Now update the share
+					updatedMetadata := &beaconprotocol.ValidatorMetadata{
+						Balance:         5000,
+						Status:          eth2apiv1.ValidatorStateActiveOngoing,
+						Index:           3,
+						ActivationEpoch: 5,
+					}
+
+					// Update the share with new metadata
+					require.NoError(t, storage.Shares.UpdateValidatorsMetadata(map[spectypes.ValidatorPK]*beaconprotocol.ValidatorMetadata{
+						validatorShare.ValidatorPubKey: updatedMetadata,
+					}))
+					if withReopen {
+						require.NoError(t, storage.Reopen(logger))
+					}
+
+					// Ensure the updated share is reflected in validatorStore
+					updatedShare, exists := storage.ValidatorStore.Validator(validatorShare.ValidatorPubKey[:])
+					require.True(t, exists)
+					require.NotNil(t, updatedShare, "Updated share should be present in validator store")
+					require.Equal(t, updatedMetadata, updatedShare.BeaconMetadata, "Validator metadata should be updated in validator store")
+
+					// Remove the share via SharesStorage
+					require.NoError(t, storage.Shares.Delete(nil, validatorShare.ValidatorPubKey[:]))
+					if withReopen {
+						require.NoError(t, storage.Reopen(logger))
+					}
+
+					// Verify that the share is removed from both sharesStorage and validatorStore
+					deletedShare, exists := storage.Shares.Get(nil, validatorShare.ValidatorPubKey[:])
+					require.False(t, exists)
+					require.Nil(t, deletedShare, "Share should be deleted from sharesStorage")
+
+					removedShare, exists := storage.ValidatorStore.Validator(validatorShare.ValidatorPubKey[:])
+					require.False(t, exists)
+					require.Nil(t, removedShare, "Share should be removed from validator store after deletion in sharesStorage")
+				})
 			}
-
-			// Ensure the share is saved correctly
-			savedShare, exists := storage.Shares.Get(nil, validatorShare.ValidatorPubKey[:])
-			require.True(t, exists)
-			require.NotNil(t, savedShare)
-
-			// Verify that the validatorStore has the share via SharesStorage
-			storedShare, exists := storage.ValidatorStore.Validator(validatorShare.ValidatorPubKey[:])
-			require.True(t, exists)
-			require.NotNil(t, storedShare, "Share should be present in validator store after adding to sharesStorage")
-
-			// Now update the share
-			updatedMetadata := &beaconprotocol.ValidatorMetadata{
-				Balance:         5000,
-				Status:          eth2apiv1.ValidatorStateActiveOngoing,
-				Index:           3,
-				ActivationEpoch: 5,
-			}
-
-			// Update the share with new metadata
-			require.NoError(t, storage.Shares.UpdateValidatorsMetadata(map[spectypes.ValidatorPK]*beaconprotocol.ValidatorMetadata{
-				validatorShare.ValidatorPubKey: updatedMetadata,
-			}))
-			if withReopen {
-				require.NoError(t, storage.Reopen(logger))
-			}
-
-			// Ensure the updated share is reflected in validatorStore
-			updatedShare, exists := storage.ValidatorStore.Validator(validatorShare.ValidatorPubKey[:])
-			require.True(t, exists)
-			require.NotNil(t, updatedShare, "Updated share should be present in validator store")
-			require.Equal(t, updatedMetadata, updatedShare.BeaconMetadata, "Validator metadata should be updated in validator store")
-
-			// Remove the share via SharesStorage
-			require.NoError(t, storage.Shares.Delete(nil, validatorShare.ValidatorPubKey[:]))
-			if withReopen {
-				require.NoError(t, storage.Reopen(logger))
-			}
-
-			// Verify that the share is removed from both sharesStorage and validatorStore
-			deletedShare, exists := storage.Shares.Get(nil, validatorShare.ValidatorPubKey[:])
-			require.False(t, exists)
-			require.Nil(t, deletedShare, "Share should be deleted from sharesStorage")
-
-			removedShare, exists := storage.ValidatorStore.Validator(validatorShare.ValidatorPubKey[:])
-			require.False(t, exists)
-			require.Nil(t, removedShare, "Share should be removed from validator store after deletion in sharesStorage")
 		})
 	}
 }
@@ -2,12 +2,18 @@ package basedb
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrConflict is returned when committing a read-write transaction which read keys
+// that were written by others since it began.
+var ErrConflict = errors.New("transaction conflict")
+
 // Options for creating all db type
 type Options struct {
 	Ctx        context.Context
+	Engine     string        `yaml:"Engine" env:"DB_ENGINE" env-default:"badger" env-description:"Storage engine, either badger or pebble"`
 	Path       string        `yaml:"Path" env:"DB_PATH" env-default:"./data/db" env-description:"Path for storage"`
 	Reporting  bool          `yaml:"Reporting" env:"DB_REPORTING" env-default:"false" env-description:"Flag to run on-off db size reporting"`
 	GCInterval time.Duration `yaml:"GCInterval" env:"DB_GC_INTERVAL" env-default:"6m" env-description:"Interval between garbage collection cycles. Set to 0 to disable."`
@@ -18,6 +24,41 @@ type Reader interface {
 	Get(prefix []byte, key []byte) (Obj, bool, error)
 	GetMany(prefix []byte, keys [][]byte, iterator func(Obj) error) error
 	GetAll(prefix []byte, handler func(int, Obj) error) error
+
+	// Iterator returns an iterator over a range of the keys under the prefix, in key order.
+	// A read-write transaction can only have one iterator open at a time.
+	Iterator(prefix []byte, opts IteratorOptions) Iterator
+}
+
+// IteratorOptions selects the range of keys an Iterator iterates over.
+// Keys are relative to the prefix of the iterator.
+type IteratorOptions struct {
+	// Start is the first key of the range (inclusive). If empty, the range starts at the first key.
+	Start []byte
+	// End is the end of the range (exclusive). If empty, the range ends after the last key.
+	End []byte
+	// Reverse iterates from the last key of the range to the first.
+	Reverse bool
+	// Limit is the maximum number of items to iterate over, or zero for no limit.
+	Limit int
+}
+
+// Iterator iterates over the items in a range of keys.
+// It starts at the first item of the range, in its iteration order.
+type Iterator interface {
+	// Seek moves to the given key, or to the next one in the iteration order if there is no such key,
+	// staying within the range. Items iterated over before seeking count towards the limit.
+	Seek(key []byte)
+	// Valid returns whether the iterator is at an item.
+	Valid() bool
+	// Next moves to the next item.
+	Next()
+	// Key returns a copy of the key of the current item, relative to the prefix.
+	Key() []byte
+	// Value returns a copy of the value of the current item.
+	Value() ([]byte, error)
+	// Close releases the iterator, and returns the error which ended the iteration, if any.
+	Close() error
 }
 
 // ReadWrite is a read-write accessor to the database.
@@ -31,7 +72,6 @@ type ReadWriter interface {
 // Txn is a read-write transaction.
 type Txn interface {
 	ReadWriter
-	// TODO: add iterator
 	Commit() error
 	Discard()
 }
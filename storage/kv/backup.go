@@ -0,0 +1,152 @@
+package kv
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pkg/errors"
+)
+
+// maxPendingLoadWrites is the number of pending writes while loading a backup.
+const maxPendingLoadWrites = 256
+
+// Backup writes a consistent snapshot of the database to the given writer,
+// using badger's streaming backup. The database may be used while it runs.
+func (b *BadgerDB) Backup(w io.Writer) error {
+	if _, err := b.db.Backup(w, 0); err != nil {
+		return errors.Wrap(err, "failed to backup badger")
+	}
+	return nil
+}
+
+// Load loads a backup written by Backup into the database.
+// The database is expected to be empty and unused while it runs.
+func (b *BadgerDB) Load(r io.Reader) error {
+	if err := b.db.Load(r, maxPendingLoadWrites); err != nil {
+		return errors.Wrap(err, "failed to load badger backup")
+	}
+	return nil
+}
+
+// Markers of pebble backup streams, in which each entry is preceded by pebbleBackupEntry
+// and the stream ends with pebbleBackupEnd followed by the number of entries.
+const (
+	pebbleBackupEnd byte = iota
+	pebbleBackupEntry
+)
+
+// maxLoadBatchSize is the size of the batches committed while loading a pebble backup.
+const maxLoadBatchSize = 4 << 20
+
+// Backup writes a consistent snapshot of the database to the given writer,
+// as a stream of length-prefixed keys and values. The database may be used while it runs.
+func (p *PebbleDB) Backup(w io.Writer) error {
+	snapshot := p.db.NewSnapshot()
+	defer snapshot.Close()
+	it, err := snapshot.NewIter(nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create pebble iterator")
+	}
+	defer it.Close()
+
+	var entries uint64
+	var buf []byte
+	for valid := it.First(); valid; valid = it.Next() {
+		value, err := it.ValueAndErr()
+		if err != nil {
+			return errors.Wrap(err, "failed to read pebble value")
+		}
+		buf = append(buf[:0], pebbleBackupEntry)
+		buf = binary.AppendUvarint(buf, uint64(len(it.Key())))
+		buf = append(buf, it.Key()...)
+		buf = binary.AppendUvarint(buf, uint64(len(value)))
+		buf = append(buf, value...)
+		if _, err := w.Write(buf); err != nil {
+			return errors.Wrap(err, "failed to write pebble backup")
+		}
+		entries++
+	}
+	if err := it.Error(); err != nil {
+		return errors.Wrap(err, "failed to iterate pebble")
+	}
+
+	buf = binary.AppendUvarint(append(buf[:0], pebbleBackupEnd), entries)
+	if _, err := w.Write(buf); err != nil {
+		return errors.Wrap(err, "failed to write pebble backup")
+	}
+	return nil
+}
+
+// Load loads a backup written by Backup into the database.
+// The database is expected to be empty and unused while it runs.
+func (p *PebbleDB) Load(r io.Reader) error {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		buffered := bufio.NewReader(r)
+		r, br = buffered, buffered
+	}
+	readBytes := func() ([]byte, error) {
+		n, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, n)
+		_, err = io.ReadFull(r, b)
+		return b, err
+	}
+
+	batch := p.db.NewBatch()
+	defer func() { _ = batch.Close() }()
+	var entries uint64
+	for {
+		marker, err := br.ReadByte()
+		if err != nil {
+			return errors.Wrap(noEOF(err), "failed to read pebble backup")
+		}
+		if marker == pebbleBackupEnd {
+			expected, err := binary.ReadUvarint(br)
+			if err != nil {
+				return errors.Wrap(noEOF(err), "failed to read pebble backup")
+			}
+			if expected != entries {
+				return errors.Errorf("pebble backup has %d entries, expected %d", entries, expected)
+			}
+			break
+		}
+		if marker != pebbleBackupEntry {
+			return errors.Errorf("invalid pebble backup marker %d", marker)
+		}
+
+		key, err := readBytes()
+		if err != nil {
+			return errors.Wrap(noEOF(err), "failed to read pebble backup key")
+		}
+		value, err := readBytes()
+		if err != nil {
+			return errors.Wrap(noEOF(err), "failed to read pebble backup value")
+		}
+		if err := batch.Set(key, value, nil); err != nil {
+			return err
+		}
+		entries++
+
+		if batch.Len() >= maxLoadBatchSize {
+			if err := batch.Commit(pebble.NoSync); err != nil {
+				return errors.Wrap(err, "failed to load pebble backup")
+			}
+			_ = batch.Close()
+			batch = p.db.NewBatch()
+		}
+	}
+	return errors.Wrap(batch.Commit(pebbleWriteOptions), "failed to load pebble backup")
+}
+
+// noEOF reports a truncated stream as such.
+func noEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
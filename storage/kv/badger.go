@@ -94,6 +94,11 @@ func createDB(logger *zap.Logger, options basedb.Options, inMemory bool) (*Badge
 	return &badgerDB, nil
 }
 
+// Engine returns the name of the storage engine.
+func (b *BadgerDB) Engine() string {
+	return EngineBadger
+}
+
 // Badger returns the underlying badger.DB
 func (b *BadgerDB) Badger() *badger.DB {
 	return b.db
@@ -167,6 +172,12 @@ func (b *BadgerDB) GetAll(prefix []byte, handler func(int, basedb.Obj) error) er
 	return err
 }
 
+// Iterator returns an iterator over a range of the keys under the prefix,
+// which reads from a consistent snapshot.
+func (b *BadgerDB) Iterator(prefix []byte, opts basedb.IteratorOptions) basedb.Iterator {
+	return newBadgerIterator(b.db.NewTransaction(false), true, prefix, opts)
+}
+
 // CountPrefix return the object count for all keys under specified prefix(bucket)
 func (b *BadgerDB) CountPrefix(prefix []byte) (int64, error) {
 	var res int64
@@ -248,9 +259,9 @@ func (b *BadgerDB) listRawKeys(prefix []byte, txn *badger.Txn) [][]byte {
 // Update is a gateway to badger db Update function
 // creating and managing a read-write transaction
 func (b *BadgerDB) Update(fn func(basedb.Txn) error) error {
-	return b.db.Update(func(txn *badger.Txn) error {
+	return conflictError(b.db.Update(func(txn *badger.Txn) error {
 		return fn(newTxn(txn, b))
-	})
+	}))
 }
 
 func (b *BadgerDB) allGetter(prefix []byte, handler func(int, basedb.Obj) error) func(txn *badger.Txn) error {
@@ -1,7 +1,6 @@
 package kv
 
 import (
-	"bytes"
 	"context"
 	"encoding/binary"
 	"fmt"
@@ -12,7 +11,6 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 
-	"github.com/ssvlabs/ssv/logging"
 	"github.com/ssvlabs/ssv/storage/basedb"
 )
 
@@ -89,81 +87,6 @@ func TestBadgerEndToEnd(t *testing.T) {
 	require.NoError(t, db.DropPrefix([]byte("prefix2")))
 }
 
-func TestBadgerDb_GetAll(t *testing.T) {
-	logger := logging.TestLogger(t)
-
-	t.Run("100_items", func(t *testing.T) {
-		db, err := NewInMemory(logger, basedb.Options{})
-		require.NoError(t, err)
-		defer db.Close()
-
-		getAllTest(t, 100, db)
-	})
-
-	t.Run("10K_items", func(t *testing.T) {
-		db, err := NewInMemory(logger, basedb.Options{})
-		require.NoError(t, err)
-		defer db.Close()
-
-		getAllTest(t, 10000, db)
-	})
-
-	t.Run("100K_items", func(t *testing.T) {
-		db, err := NewInMemory(logger, basedb.Options{})
-		require.NoError(t, err)
-		defer db.Close()
-
-		getAllTest(t, 100000, db)
-	})
-}
-
-func TestBadgerDb_GetMany(t *testing.T) {
-	logger := logging.TestLogger(t)
-	db, err := NewInMemory(logger, basedb.Options{})
-	require.NoError(t, err)
-	defer db.Close()
-
-	prefix := []byte("prefix")
-	var i uint64
-	for i = 0; i < 100; i++ {
-		require.NoError(t, db.Set(prefix, uInt64ToByteSlice(i+1), uInt64ToByteSlice(i+1)))
-	}
-
-	results := make([]basedb.Obj, 0)
-	err = db.GetMany(prefix, [][]byte{uInt64ToByteSlice(1), uInt64ToByteSlice(2),
-		uInt64ToByteSlice(5), uInt64ToByteSlice(10)}, func(obj basedb.Obj) error {
-		require.True(t, bytes.Equal(obj.Key, obj.Value))
-		results = append(results, obj)
-		return nil
-	})
-	require.NoError(t, err)
-	require.Equal(t, 4, len(results))
-}
-
-func TestBadgerDb_SetMany(t *testing.T) {
-	logger := logging.TestLogger(t)
-	db, err := NewInMemory(logger, basedb.Options{})
-	require.NoError(t, err)
-	defer db.Close()
-
-	prefix := []byte("prefix")
-	var values [][]byte
-	err = db.SetMany(prefix, 10, func(i int) (basedb.Obj, error) {
-		seq := uint64(i + 1)
-		values = append(values, uInt64ToByteSlice(seq))
-		return basedb.Obj{Key: uInt64ToByteSlice(seq), Value: uInt64ToByteSlice(seq)}, nil
-	})
-	require.NoError(t, err)
-
-	for i := 0; i < 10; i++ {
-		seq := uint64(i + 1)
-		obj, found, err := db.Get(prefix, uInt64ToByteSlice(seq))
-		require.NoError(t, err, "should find item %d", i)
-		require.True(t, found, "should find item %d", i)
-		require.True(t, bytes.Equal(obj.Value, values[i]), "item %d wrong value", i)
-	}
-}
-
 func uInt64ToByteSlice(n uint64) []byte {
 	b := make([]byte, 8)
 	binary.LittleEndian.PutUint64(b, n)
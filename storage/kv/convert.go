@@ -0,0 +1,59 @@
+package kv
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// maxCopyBatchSize is the approximate size of the batches written while copying a database.
+const maxCopyBatchSize = 4 << 20
+
+// Copy copies all the keys of src into dst, which may be of different storage engines,
+// and verifies that dst ends up with as many keys as src. Both databases are expected to be unused,
+// and dst to be empty. It returns the number of copied keys.
+func Copy(dst, src basedb.Database) (int64, error) {
+	var (
+		batch     []basedb.Obj
+		batchSize int
+		copied    int64
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := dst.SetMany(nil, len(batch), func(i int) (basedb.Obj, error) {
+			return batch[i], nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to write batch")
+		}
+		copied += int64(len(batch))
+		batch, batchSize = batch[:0], 0
+		return nil
+	}
+
+	err := src.GetAll(nil, func(_ int, obj basedb.Obj) error {
+		batch = append(batch, obj)
+		batchSize += len(obj.Key) + len(obj.Value)
+		if batchSize >= maxCopyBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return copied, errors.Wrap(err, "failed to copy")
+	}
+	if err := flush(); err != nil {
+		return copied, err
+	}
+
+	count, err := dst.CountPrefix(nil)
+	if err != nil {
+		return copied, errors.Wrap(err, "failed to count copied keys")
+	}
+	if count != copied {
+		return copied, errors.Errorf("copied %d keys but destination has %d", copied, count)
+	}
+	return copied, nil
+}
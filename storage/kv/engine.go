@@ -0,0 +1,93 @@
+package kv
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/dgraph-io/badger/v4"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// Supported storage engines.
+const (
+	EngineBadger = "badger"
+	EnginePebble = "pebble"
+)
+
+// Engines lists the supported storage engines.
+var Engines = []string{EngineBadger, EnginePebble}
+
+// DB is a database backed by one of the supported storage engines.
+type DB interface {
+	basedb.Database
+
+	// Engine returns the name of the storage engine.
+	Engine() string
+
+	// Backup writes a consistent snapshot of the database to the given writer,
+	// in a format specific to the storage engine.
+	Backup(w io.Writer) error
+
+	// Load loads a backup written by Backup of the same storage engine into the database.
+	Load(r io.Reader) error
+}
+
+var (
+	_ DB = (*BadgerDB)(nil)
+	_ DB = (*PebbleDB)(nil)
+)
+
+// Open creates a persistent DB instance of the storage engine in the options,
+// which defaults to badger.
+func Open(logger *zap.Logger, options basedb.Options) (DB, error) {
+	// Opening a database of another storage engine would silently create an empty one next to it.
+	if existing, ok := DetectEngine(options.Path); ok && existing != engine(options) {
+		return nil, fmt.Errorf("database at %s is a %s database, convert it with 'ssvnode db convert' to use the %s storage engine",
+			options.Path, existing, engine(options))
+	}
+
+	switch engine(options) {
+	case EngineBadger:
+		return New(logger, options)
+	case EnginePebble:
+		return NewPebble(logger, options)
+	default:
+		return nil, fmt.Errorf("unknown storage engine %q", options.Engine)
+	}
+}
+
+// OpenInMemory creates an in-memory DB instance of the storage engine in the options,
+// which defaults to badger.
+func OpenInMemory(logger *zap.Logger, options basedb.Options) (DB, error) {
+	switch engine(options) {
+	case EngineBadger:
+		return NewInMemory(logger, options)
+	case EnginePebble:
+		return NewPebbleInMemory(logger, options)
+	default:
+		return nil, fmt.Errorf("unknown storage engine %q", options.Engine)
+	}
+}
+
+// DetectEngine returns the storage engine of the database at the given path, if there is one,
+// by the files which only that storage engine creates.
+func DetectEngine(path string) (string, bool) {
+	if _, err := os.Stat(filepath.Join(path, badger.KeyRegistryFileName)); err == nil {
+		return EngineBadger, true
+	}
+	if _, err := os.Stat(filepath.Join(path, "CURRENT")); err == nil {
+		return EnginePebble, true
+	}
+	return "", false
+}
+
+func engine(options basedb.Options) string {
+	if options.Engine == "" {
+		return EngineBadger
+	}
+	return options.Engine
+}
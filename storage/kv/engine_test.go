@@ -0,0 +1,475 @@
+package kv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// forEachEngine runs the test against an in-memory database of each storage engine.
+func forEachEngine(t *testing.T, test func(t *testing.T, db DB)) {
+	for _, engine := range Engines {
+		t.Run(engine, func(t *testing.T) {
+			db, err := OpenInMemory(logging.TestLogger(t), basedb.Options{Engine: engine})
+			require.NoError(t, err)
+			defer db.Close()
+
+			test(t, db)
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	db, err := Open(logger, basedb.Options{Path: t.TempDir()})
+	require.NoError(t, err)
+	require.Equal(t, EngineBadger, db.Engine())
+	require.NoError(t, db.Close())
+
+	path := t.TempDir()
+	db, err = Open(logger, basedb.Options{Engine: EnginePebble, Path: path})
+	require.NoError(t, err)
+	require.Equal(t, EnginePebble, db.Engine())
+	require.NoError(t, db.Set([]byte("prefix"), []byte("key"), []byte("value")))
+	require.NoError(t, db.Close())
+
+	// Persisted across reopening.
+	db, err = Open(logger, basedb.Options{Engine: EnginePebble, Path: path})
+	require.NoError(t, err)
+	obj, found, err := db.Get([]byte("prefix"), []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), obj.Value)
+	require.NoError(t, db.Close())
+
+	// Not opened with another storage engine.
+	_, err = Open(logger, basedb.Options{Engine: EngineBadger, Path: path})
+	require.ErrorContains(t, err, "is a pebble database")
+
+	_, err = Open(logger, basedb.Options{Engine: "leveldb", Path: t.TempDir()})
+	require.ErrorContains(t, err, "unknown storage engine")
+}
+
+func TestDb_EndToEnd(t *testing.T) {
+	forEachEngine(t, func(t *testing.T, db DB) {
+		require.NoError(t, db.Set([]byte("prefix1"), []byte("key1"), []byte("value1")))
+		require.NoError(t, db.Set([]byte("prefix1"), []byte("key2"), []byte("value2")))
+		require.NoError(t, db.Set([]byte("prefix2"), []byte("key1"), []byte("value3")))
+
+		obj, found, err := db.Get([]byte("prefix1"), []byte("key1"))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, []byte("key1"), obj.Key)
+		require.Equal(t, []byte("value1"), obj.Value)
+
+		_, found, err = db.Get([]byte("prefix1"), []byte("key3"))
+		require.NoError(t, err)
+		require.False(t, found)
+
+		var all []basedb.Obj
+		require.NoError(t, db.GetAll([]byte("prefix1"), func(i int, obj basedb.Obj) error {
+			require.Equal(t, len(all), i)
+			all = append(all, obj)
+			return nil
+		}))
+		require.Equal(t, []basedb.Obj{
+			{Key: []byte("key1"), Value: []byte("value1")},
+			{Key: []byte("key2"), Value: []byte("value2")},
+		}, all)
+
+		require.NoError(t, db.Delete([]byte("prefix1"), []byte("key1")))
+		_, found, err = db.Get([]byte("prefix1"), []byte("key1"))
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+}
+
+func TestDb_CountAndDropPrefix(t *testing.T) {
+	forEachEngine(t, func(t *testing.T, db DB) {
+		for _, prefix := range [][]byte{[]byte("a"), []byte("ab"), []byte("b"), {0xff}, {0xff, 0xff}} {
+			for i := 0; i < 10; i++ {
+				require.NoError(t, db.Set(prefix, []byte{byte(i)}, []byte("value")))
+			}
+		}
+
+		count, err := db.CountPrefix([]byte("a"))
+		require.NoError(t, err)
+		require.EqualValues(t, 20, count)
+		count, err = db.CountPrefix([]byte{0xff})
+		require.NoError(t, err)
+		require.EqualValues(t, 20, count)
+		count, err = db.CountPrefix(nil)
+		require.NoError(t, err)
+		require.EqualValues(t, 50, count)
+
+		require.NoError(t, db.DropPrefix([]byte("ab")))
+		count, err = db.CountPrefix([]byte("a"))
+		require.NoError(t, err)
+		require.EqualValues(t, 10, count)
+
+		// A prefix without an upper bound.
+		require.NoError(t, db.DropPrefix([]byte{0xff, 0xff}))
+		count, err = db.CountPrefix([]byte{0xff})
+		require.NoError(t, err)
+		require.EqualValues(t, 10, count)
+
+		require.NoError(t, db.DropPrefix(nil))
+		count, err = db.CountPrefix(nil)
+		require.NoError(t, err)
+		require.EqualValues(t, 0, count)
+	})
+}
+
+func TestDb_Txn(t *testing.T) {
+	forEachEngine(t, func(t *testing.T, db DB) {
+		prefix := []byte("prefix")
+		require.NoError(t, db.Set(prefix, []byte("key1"), []byte("value1")))
+
+		// Reads see the transaction's own writes, which are invisible until committed.
+		txn := db.Begin()
+		require.NoError(t, txn.Set(prefix, []byte("key2"), []byte("value2")))
+		require.NoError(t, txn.Delete(prefix, []byte("key1")))
+		_, found, err := txn.Get(prefix, []byte("key1"))
+		require.NoError(t, err)
+		require.False(t, found)
+		obj, found, err := txn.Get(prefix, []byte("key2"))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, []byte("value2"), obj.Value)
+		var keys []string
+		require.NoError(t, txn.GetAll(prefix, func(_ int, obj basedb.Obj) error {
+			keys = append(keys, string(obj.Key))
+			return nil
+		}))
+		require.Equal(t, []string{"key2"}, keys)
+
+		_, found, err = db.Get(prefix, []byte("key2"))
+		require.NoError(t, err)
+		require.False(t, found)
+
+		require.NoError(t, txn.Commit())
+		txn.Discard()
+		_, found, err = db.Get(prefix, []byte("key2"))
+		require.NoError(t, err)
+		require.True(t, found)
+		_, found, err = db.Get(prefix, []byte("key1"))
+		require.NoError(t, err)
+		require.False(t, found)
+
+		// Discarded transactions are not applied.
+		txn = db.Begin()
+		require.NoError(t, txn.Set(prefix, []byte("key3"), []byte("value3")))
+		txn.Discard()
+		_, found, err = db.Get(prefix, []byte("key3"))
+		require.NoError(t, err)
+		require.False(t, found)
+
+		// Update commits unless it fails.
+		require.NoError(t, db.Update(func(txn basedb.Txn) error {
+			return txn.Set(prefix, []byte("key4"), []byte("value4"))
+		}))
+		require.Error(t, db.Update(func(txn basedb.Txn) error {
+			require.NoError(t, txn.Set(prefix, []byte("key5"), []byte("value5")))
+			return fmt.Errorf("failed")
+		}))
+		count, err := db.CountPrefix(prefix)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, count)
+
+		// Read transactions read from a snapshot.
+		readTxn := db.BeginRead()
+		defer readTxn.Discard()
+		require.NoError(t, db.Set(prefix, []byte("key6"), []byte("value6")))
+		_, found, err = readTxn.Get(prefix, []byte("key6"))
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+}
+
+func TestDb_TxnConflict(t *testing.T) {
+	forEachEngine(t, func(t *testing.T, db DB) {
+		prefix := []byte("prefix")
+		require.NoError(t, db.Set(prefix, []byte("key"), []byte("value0")))
+
+		// Both transactions read the key, and only the first to commit its write succeeds.
+		txn1 := db.Begin()
+		defer txn1.Discard()
+		txn2 := db.Begin()
+		defer txn2.Discard()
+		for _, txn := range []basedb.Txn{txn1, txn2} {
+			obj, found, err := txn.Get(prefix, []byte("key"))
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, []byte("value0"), obj.Value)
+		}
+		require.NoError(t, txn1.Set(prefix, []byte("key"), []byte("value1")))
+		require.NoError(t, txn2.Set(prefix, []byte("key"), []byte("value2")))
+		require.NoError(t, txn1.Commit())
+
+		// The second transaction still reads from its snapshot.
+		obj, found, err := txn2.Get(prefix, []byte("other"))
+		require.NoError(t, err)
+		require.False(t, found)
+		require.Equal(t, basedb.Obj{}, obj)
+		require.ErrorIs(t, txn2.Commit(), basedb.ErrConflict)
+
+		obj, found, err = db.Get(prefix, []byte("key"))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, []byte("value1"), obj.Value)
+
+		// Writes outside of transactions conflict too, including those to iterated keys.
+		txn := db.Begin()
+		it := txn.Iterator(prefix, basedb.IteratorOptions{})
+		require.True(t, it.Valid())
+		require.Equal(t, []byte("key"), it.Key())
+		require.NoError(t, it.Close())
+		require.NoError(t, txn.Set(prefix, []byte("key2"), []byte("value")))
+		require.NoError(t, db.Delete(prefix, []byte("key")))
+		require.ErrorIs(t, txn.Commit(), basedb.ErrConflict)
+		txn.Discard()
+
+		// Transactions which read none of the written keys don't conflict.
+		txn1, txn2 = db.Begin(), db.Begin()
+		_, _, err = txn1.Get(prefix, []byte("key1"))
+		require.NoError(t, err)
+		require.NoError(t, txn1.Set(prefix, []byte("key1"), []byte("value")))
+		_, _, err = txn2.Get(prefix, []byte("key2"))
+		require.NoError(t, err)
+		require.NoError(t, txn2.Set(prefix, []byte("key2"), []byte("value")))
+		require.NoError(t, txn1.Commit())
+		require.NoError(t, txn2.Commit())
+		txn1.Discard()
+		txn2.Discard()
+	})
+}
+
+func TestDb_TxnIterator(t *testing.T) {
+	forEachEngine(t, func(t *testing.T, db DB) {
+		prefix := []byte("prefix")
+		for _, key := range []string{"a", "b", "c", "d"} {
+			require.NoError(t, db.Set(prefix, []byte(key), []byte("old")))
+		}
+
+		// The transaction's writes are overlaid on the committed items.
+		txn := db.Begin()
+		defer txn.Discard()
+		require.NoError(t, txn.Delete(prefix, []byte("b")))
+		require.NoError(t, txn.Set(prefix, []byte("c"), []byte("new")))
+		require.NoError(t, txn.Set(prefix, []byte("e"), []byte("new")))
+
+		collect := func(opts basedb.IteratorOptions, seek []byte) []string {
+			it := txn.Iterator(prefix, opts)
+			if seek != nil {
+				it.Seek(seek)
+			}
+			var items []string
+			for ; it.Valid(); it.Next() {
+				value, err := it.Value()
+				require.NoError(t, err)
+				items = append(items, string(it.Key())+"="+string(value))
+			}
+			require.NoError(t, it.Close())
+			return items
+		}
+		require.Equal(t, []string{"a=old", "c=new", "d=old", "e=new"}, collect(basedb.IteratorOptions{}, nil))
+		require.Equal(t, []string{"e=new", "d=old", "c=new"}, collect(basedb.IteratorOptions{Reverse: true, Limit: 3}, nil))
+		require.Equal(t, []string{"c=new", "d=old"}, collect(basedb.IteratorOptions{Start: []byte("b"), End: []byte("e")}, nil))
+		require.Equal(t, []string{"c=new", "d=old", "e=new"}, collect(basedb.IteratorOptions{}, []byte("b")))
+		require.Equal(t, []string{"a=old"}, collect(basedb.IteratorOptions{Reverse: true}, []byte("b")))
+	})
+}
+
+func TestDb_BackupLoad(t *testing.T) {
+	forEachEngine(t, func(t *testing.T, db DB) {
+		prefix := []byte("prefix")
+		for i := 0; i < 1000; i++ {
+			require.NoError(t, db.Set(prefix, uInt64ToByteSlice(uint64(i)), bytes.Repeat([]byte{byte(i)}, i+1)))
+		}
+
+		var backup bytes.Buffer
+		require.NoError(t, db.Backup(&backup))
+
+		restored, err := OpenInMemory(logging.TestLogger(t), basedb.Options{Engine: db.Engine()})
+		require.NoError(t, err)
+		defer restored.Close()
+		require.NoError(t, restored.Load(bytes.NewReader(backup.Bytes())))
+		for i := 0; i < 1000; i++ {
+			obj, found, err := restored.Get(prefix, uInt64ToByteSlice(uint64(i)))
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, bytes.Repeat([]byte{byte(i)}, i+1), obj.Value)
+		}
+
+		if db.Engine() == EnginePebble {
+			truncated, err := OpenInMemory(logging.TestLogger(t), basedb.Options{Engine: db.Engine()})
+			require.NoError(t, err)
+			defer truncated.Close()
+			require.Error(t, truncated.Load(bytes.NewReader(backup.Bytes()[:backup.Len()-1])))
+		}
+	})
+}
+
+func TestCopy(t *testing.T) {
+	logger := logging.TestLogger(t)
+	for _, from := range Engines {
+		for _, to := range Engines {
+			t.Run(from+"_to_"+to, func(t *testing.T) {
+				src, err := OpenInMemory(logger, basedb.Options{Engine: from})
+				require.NoError(t, err)
+				defer src.Close()
+				dst, err := OpenInMemory(logger, basedb.Options{Engine: to})
+				require.NoError(t, err)
+				defer dst.Close()
+
+				value := bytes.Repeat([]byte{1}, 1024)
+				require.NoError(t, src.SetMany([]byte("prefix"), 10000, func(i int) (basedb.Obj, error) {
+					return basedb.Obj{Key: uInt64ToByteSlice(uint64(i)), Value: value}, nil
+				}))
+
+				copied, err := Copy(dst, src)
+				require.NoError(t, err)
+				require.EqualValues(t, 10000, copied)
+				obj, found, err := dst.Get([]byte("prefix"), uInt64ToByteSlice(9999))
+				require.NoError(t, err)
+				require.True(t, found)
+				require.Equal(t, value, obj.Value)
+			})
+		}
+	}
+}
+
+func TestDb_GetAll(t *testing.T) {
+	for _, n := range []int{100, 10000, 100000} {
+		t.Run(fmt.Sprintf("%d_items", n), func(t *testing.T) {
+			forEachEngine(t, func(t *testing.T, db DB) {
+				getAllTest(t, n, db)
+			})
+		})
+	}
+}
+
+func TestDb_GetMany(t *testing.T) {
+	forEachEngine(t, func(t *testing.T, db DB) {
+		prefix := []byte("prefix")
+		var i uint64
+		for i = 0; i < 100; i++ {
+			require.NoError(t, db.Set(prefix, uInt64ToByteSlice(i+1), uInt64ToByteSlice(i+1)))
+		}
+
+		results := make([]basedb.Obj, 0)
+		err := db.GetMany(prefix, [][]byte{uInt64ToByteSlice(1), uInt64ToByteSlice(2),
+			uInt64ToByteSlice(5), uInt64ToByteSlice(10)}, func(obj basedb.Obj) error {
+			require.True(t, bytes.Equal(obj.Key, obj.Value))
+			results = append(results, obj)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 4, len(results))
+	})
+}
+
+func TestDb_SetMany(t *testing.T) {
+	forEachEngine(t, func(t *testing.T, db DB) {
+		prefix := []byte("prefix")
+		var values [][]byte
+		err := db.SetMany(prefix, 10, func(i int) (basedb.Obj, error) {
+			seq := uint64(i + 1)
+			values = append(values, uInt64ToByteSlice(seq))
+			return basedb.Obj{Key: uInt64ToByteSlice(seq), Value: uInt64ToByteSlice(seq)}, nil
+		})
+		require.NoError(t, err)
+
+		for i := 0; i < 10; i++ {
+			seq := uint64(i + 1)
+			obj, found, err := db.Get(prefix, uInt64ToByteSlice(seq))
+			require.NoError(t, err, "should find item %d", i)
+			require.True(t, found, "should find item %d", i)
+			require.True(t, bytes.Equal(obj.Value, values[i]), "item %d wrong value", i)
+		}
+	})
+}
+
+func TestDb_Iterator(t *testing.T) {
+	forEachEngine(t, func(t *testing.T, db DB) {
+		prefix := []byte("prefix")
+		for i := uint64(1); i <= 10; i++ {
+			require.NoError(t, db.Set(prefix, bigEndian(i), bigEndian(i*10)))
+		}
+		// Neighbouring prefixes are not iterated over.
+		require.NoError(t, db.Set([]byte("prefiw"), bigEndian(1), nil))
+		require.NoError(t, db.Set([]byte("prefiy"), bigEndian(1), nil))
+
+		keys := func(r basedb.Reader, opts basedb.IteratorOptions, seek []byte) []uint64 {
+			it := r.Iterator(prefix, opts)
+			if seek != nil {
+				it.Seek(seek)
+			}
+			var keys []uint64
+			for ; it.Valid(); it.Next() {
+				value, err := it.Value()
+				require.NoError(t, err)
+				require.Equal(t, binary.BigEndian.Uint64(it.Key())*10, binary.BigEndian.Uint64(value))
+				keys = append(keys, binary.BigEndian.Uint64(it.Key()))
+			}
+			require.NoError(t, it.Close())
+			return keys
+		}
+
+		tests := []struct {
+			name     string
+			opts     basedb.IteratorOptions
+			seek     []byte
+			expected []uint64
+		}{
+			{"all", basedb.IteratorOptions{}, nil, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+			{"reverse", basedb.IteratorOptions{Reverse: true}, nil, []uint64{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}},
+			{"range", basedb.IteratorOptions{Start: bigEndian(3), End: bigEndian(6)}, nil, []uint64{3, 4, 5}},
+			{"reverse range", basedb.IteratorOptions{Start: bigEndian(3), End: bigEndian(6), Reverse: true}, nil, []uint64{5, 4, 3}},
+			{"limit", basedb.IteratorOptions{Start: bigEndian(3), Limit: 2}, nil, []uint64{3, 4}},
+			{"reverse limit", basedb.IteratorOptions{Reverse: true, Limit: 2}, nil, []uint64{10, 9}},
+			{"seek", basedb.IteratorOptions{}, bigEndian(7), []uint64{7, 8, 9, 10}},
+			{"seek missing", basedb.IteratorOptions{}, append(bigEndian(7), 0), []uint64{8, 9, 10}},
+			{"seek before range", basedb.IteratorOptions{Start: bigEndian(8)}, bigEndian(2), []uint64{8, 9, 10}},
+			{"reverse seek", basedb.IteratorOptions{Reverse: true}, bigEndian(3), []uint64{3, 2, 1}},
+			{"reverse seek missing", basedb.IteratorOptions{Reverse: true}, append(bigEndian(3), 0), []uint64{3, 2, 1}},
+			{"reverse seek after range", basedb.IteratorOptions{End: bigEndian(3), Reverse: true}, bigEndian(9), []uint64{2, 1}},
+			{"empty range", basedb.IteratorOptions{Start: bigEndian(11)}, nil, nil},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				require.Equal(t, test.expected, keys(db, test.opts, test.seek))
+
+				readTxn := db.BeginRead()
+				defer readTxn.Discard()
+				require.Equal(t, test.expected, keys(readTxn, test.opts, test.seek))
+			})
+		}
+
+		// A prefix without an upper bound.
+		require.NoError(t, db.Set([]byte{0xff}, []byte{1}, nil))
+		require.NoError(t, db.Set([]byte{0xff}, []byte{2}, nil))
+		it := db.Iterator([]byte{0xff}, basedb.IteratorOptions{Reverse: true})
+		require.True(t, it.Valid())
+		require.Equal(t, []byte{2}, it.Key())
+		require.NoError(t, it.Close())
+
+		// Transactions iterate over their own writes.
+		txn := db.Begin()
+		defer txn.Discard()
+		require.NoError(t, txn.Delete(prefix, bigEndian(2)))
+		require.NoError(t, txn.Set(prefix, bigEndian(11), bigEndian(110)))
+		require.Equal(t, []uint64{1, 3, 4, 5, 6, 7, 8, 9, 10, 11}, keys(txn, basedb.IteratorOptions{}, nil))
+	})
+}
+
+func bigEndian(n uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, n)
+}
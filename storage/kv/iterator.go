@@ -0,0 +1,123 @@
+package kv
+
+import (
+	"bytes"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// badgerIterator is a basedb.Iterator over a badger transaction,
+// which it discards when closed if it owns it.
+type badgerIterator struct {
+	it     *badger.Iterator
+	txn    *badger.Txn
+	ownTxn bool
+
+	prefix  []byte
+	lower   []byte // inclusive
+	upper   []byte // exclusive, nil if unbounded
+	reverse bool
+	limit   int
+	count   int
+}
+
+func newBadgerIterator(txn *badger.Txn, ownTxn bool, prefix []byte, opts basedb.IteratorOptions) *badgerIterator {
+	lower, upper := rangeBounds(prefix, opts)
+
+	iterOpts := badger.DefaultIteratorOptions
+	iterOpts.Reverse = opts.Reverse
+	if !opts.Reverse || upper != nil {
+		// Badger rewinds to the prefix, rather than to the end of the keyspace in reverse.
+		iterOpts.Prefix = prefix
+	}
+	iterOpts.PrefetchValues = false
+
+	it := &badgerIterator{
+		it:      txn.NewIterator(iterOpts),
+		txn:     txn,
+		ownTxn:  ownTxn,
+		prefix:  prefix,
+		lower:   lower,
+		upper:   upper,
+		reverse: opts.Reverse,
+		limit:   opts.Limit,
+	}
+	it.rewind()
+	return it
+}
+
+// rewind moves to the first item of the range in the iteration order.
+func (b *badgerIterator) rewind() {
+	if !b.reverse {
+		b.it.Seek(b.lower)
+		return
+	}
+	if b.upper == nil {
+		// Without an upper bound, the prefix is at the end of the keyspace.
+		b.it.Rewind()
+		return
+	}
+	// Badger seeks in reverse to the last key before or at the given key,
+	// and the upper bound is exclusive.
+	b.it.Seek(b.upper)
+	if b.it.Valid() && bytes.Equal(b.it.Item().Key(), b.upper) {
+		b.it.Next()
+	}
+}
+
+func (b *badgerIterator) Seek(key []byte) {
+	key = append(bytes.Clone(b.prefix), key...)
+	switch {
+	case !b.reverse && bytes.Compare(key, b.lower) < 0,
+		b.reverse && b.upper != nil && bytes.Compare(key, b.upper) >= 0:
+		b.rewind()
+	default:
+		b.it.Seek(key)
+	}
+}
+
+func (b *badgerIterator) Valid() bool {
+	if b.limit > 0 && b.count >= b.limit {
+		return false
+	}
+	if !b.it.ValidForPrefix(b.prefix) {
+		return false
+	}
+	key := b.it.Item().Key()
+	return bytes.Compare(key, b.lower) >= 0 && (b.upper == nil || bytes.Compare(key, b.upper) < 0)
+}
+
+func (b *badgerIterator) Next() {
+	b.it.Next()
+	b.count++
+}
+
+func (b *badgerIterator) Key() []byte {
+	return b.it.Item().KeyCopy(nil)[len(b.prefix):]
+}
+
+func (b *badgerIterator) Value() ([]byte, error) {
+	return b.it.Item().ValueCopy(nil)
+}
+
+func (b *badgerIterator) Close() error {
+	b.it.Close()
+	if b.ownTxn {
+		b.txn.Discard()
+	}
+	return nil
+}
+
+// rangeBounds returns the inclusive lower bound and the exclusive upper bound of the range
+// selected by the options, as full keys. The upper bound is nil if the range is unbounded.
+func rangeBounds(prefix []byte, opts basedb.IteratorOptions) (lower, upper []byte) {
+	lower = append(bytes.Clone(prefix), opts.Start...)
+	if len(opts.End) > 0 {
+		upper = append(bytes.Clone(prefix), opts.End...)
+	} else {
+		upper = prefixUpperBound(prefix)
+	}
+	return lower, upper
+}
@@ -0,0 +1,477 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// pebbleWriteOptions are the options of all writes, which are synced to disk
+// since the database holds slashing protection data.
+var pebbleWriteOptions = pebble.Sync
+
+// PebbleDB is a basedb.Database backed by pebble.
+// Unlike badger, pebble compacts in the background and needs no garbage collection.
+// Pebble has no transactions, so read-write transactions are implemented on top of snapshots,
+// with the same conflict detection as badger's.
+type PebbleDB struct {
+	logger *zap.Logger
+
+	db *pebble.DB
+
+	// writesMu orders writes and the snapshots of read-write transactions.
+	writesMu sync.Mutex
+	// writeSeq is the number of writes so far.
+	writeSeq uint64
+	// writes are the writes since the oldest open read-write transaction began.
+	writes []pebbleWrite
+	// openTxns counts the open read-write transactions by the writeSeq at which they began.
+	openTxns map[uint64]int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// pebbleWrite is a write to a set of keys and key ranges.
+type pebbleWrite struct {
+	seq    uint64
+	keys   map[string]struct{}
+	ranges [][2][]byte
+}
+
+// overlaps returns whether the write is to the given key.
+func (w pebbleWrite) overlaps(key string) bool {
+	if _, ok := w.keys[key]; ok {
+		return true
+	}
+	for _, r := range w.ranges {
+		if key >= string(r[0]) && (r[1] == nil || key < string(r[1])) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewPebble creates a persistent pebble DB instance.
+func NewPebble(logger *zap.Logger, options basedb.Options) (*PebbleDB, error) {
+	return createPebbleDB(logger, options, false)
+}
+
+// NewPebbleInMemory creates an in-memory pebble DB instance.
+func NewPebbleInMemory(logger *zap.Logger, options basedb.Options) (*PebbleDB, error) {
+	return createPebbleDB(logger, options, true)
+}
+
+func createPebbleDB(logger *zap.Logger, options basedb.Options, inMemory bool) (*PebbleDB, error) {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	opt := &pebble.Options{}
+	path := options.Path
+	if inMemory {
+		opt.FS = vfs.NewMem()
+		path = ""
+	}
+	if options.Reporting {
+		opt.Logger = newPebbleLogger(logger)
+	} else {
+		opt.Logger = newPebbleLogger(zap.NewNop())
+	}
+
+	db, err := pebble.Open(path, opt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open pebble")
+	}
+
+	// Set up context/cancel to control background goroutines.
+	parentCtx := options.Ctx
+	if parentCtx == nil {
+		parentCtx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parentCtx)
+
+	pebbleDB := PebbleDB{
+		logger:   logger,
+		db:       db,
+		openTxns: make(map[uint64]int),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	// Start periodic reporting.
+	if options.Reporting && options.Ctx != nil {
+		pebbleDB.wg.Add(1)
+		go pebbleDB.periodicallyReport(1 * time.Minute)
+	}
+
+	return &pebbleDB, nil
+}
+
+// Engine returns the name of the storage engine.
+func (p *PebbleDB) Engine() string {
+	return EnginePebble
+}
+
+// Pebble returns the underlying pebble.DB
+func (p *PebbleDB) Pebble() *pebble.DB {
+	return p.db
+}
+
+// Begin creates a read-write transaction.
+// Its reads see its own writes on top of a snapshot of the database taken when it began,
+// and its writes are applied atomically on commit. Committing fails with basedb.ErrConflict
+// if any of the keys it read were written since it began.
+func (p *PebbleDB) Begin() basedb.Txn {
+	p.writesMu.Lock()
+	defer p.writesMu.Unlock()
+
+	p.openTxns[p.writeSeq]++
+	return &pebbleTxn{
+		reader:  p.db.NewSnapshot(),
+		writes:  make(map[string]pendingWrite),
+		reads:   make(map[string]struct{}),
+		beganAt: p.writeSeq,
+		db:      p,
+	}
+}
+
+// BeginRead creates a read-only transaction, which reads from a consistent snapshot.
+func (p *PebbleDB) BeginRead() basedb.ReadTxn {
+	return &pebbleTxn{reader: p.db.NewSnapshot(), db: p}
+}
+
+// Set save value with key to storage
+func (p *PebbleDB) Set(prefix []byte, key []byte, value []byte) error {
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	k := append(prefix, key...)
+	if err := batch.Set(k, value, nil); err != nil {
+		return err
+	}
+	return p.commit(batch, pebbleWrite{keys: map[string]struct{}{string(k): {}}})
+}
+
+// SetMany save many values with the given keys in a single batch
+func (p *PebbleDB) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	write := pebbleWrite{keys: make(map[string]struct{}, n)}
+	for i := 0; i < n; i++ {
+		item, err := next(i)
+		if err != nil {
+			return err
+		}
+		k := append(bytes.Clone(prefix), item.Key...)
+		if err := batch.Set(k, item.Value, nil); err != nil {
+			return err
+		}
+		write.keys[string(k)] = struct{}{}
+	}
+	return p.commit(batch, write)
+}
+
+// Get return value for specified key
+func (p *PebbleDB) Get(prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	return p.getter(p.db, prefix, key)
+}
+
+// GetMany return values for the given keys
+func (p *PebbleDB) GetMany(prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	snapshot := p.db.NewSnapshot()
+	defer snapshot.Close()
+	return p.manyGetter(snapshot, prefix, keys, iterator)
+}
+
+// Delete key in specific prefix
+func (p *PebbleDB) Delete(prefix []byte, key []byte) error {
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	k := append(prefix, key...)
+	if err := batch.Delete(k, nil); err != nil {
+		return err
+	}
+	return p.commit(batch, pebbleWrite{keys: map[string]struct{}{string(k): {}}})
+}
+
+// GetAll returns all the items of a given collection
+func (p *PebbleDB) GetAll(prefix []byte, handler func(int, basedb.Obj) error) error {
+	snapshot := p.db.NewSnapshot()
+	defer snapshot.Close()
+	return p.allGetter(snapshot, prefix, handler)
+}
+
+// Iterator returns an iterator over a range of the keys under the prefix,
+// which reads from a consistent view of the database.
+func (p *PebbleDB) Iterator(prefix []byte, opts basedb.IteratorOptions) basedb.Iterator {
+	return newPebbleIterator(p.db, prefix, opts)
+}
+
+// CountPrefix return the object count for all keys under specified prefix(bucket)
+func (p *PebbleDB) CountPrefix(prefix []byte) (int64, error) {
+	it, err := p.db.NewIter(prefixIterOptions(prefix))
+	if err != nil {
+		return 0, err
+	}
+	var res int64
+	for valid := it.First(); valid; valid = it.Next() {
+		res++
+	}
+	if err := it.Error(); err != nil {
+		_ = it.Close()
+		return 0, err
+	}
+	return res, it.Close()
+}
+
+// DropPrefix cleans all items in a collection
+func (p *PebbleDB) DropPrefix(prefix []byte) error {
+	end := prefixUpperBound(prefix)
+	if end == nil {
+		// The prefix has no upper bound, so the range ends right after its last key.
+		it, err := p.db.NewIter(prefixIterOptions(prefix))
+		if err != nil {
+			return err
+		}
+		if it.Last() {
+			end = append(bytes.Clone(it.Key()), 0)
+		}
+		if err := it.Close(); err != nil {
+			return err
+		}
+		if end == nil {
+			return nil
+		}
+	}
+
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	if err := batch.DeleteRange(prefix, end, nil); err != nil {
+		return err
+	}
+	return p.commit(batch, pebbleWrite{ranges: [][2][]byte{{bytes.Clone(prefix), end}}})
+}
+
+// commit commits the batch, and records its write for the conflict detection of open transactions.
+func (p *PebbleDB) commit(batch *pebble.Batch, write pebbleWrite) error {
+	p.writesMu.Lock()
+	defer p.writesMu.Unlock()
+	return p.commitLocked(batch, write)
+}
+
+// commitTxn commits the batch of a transaction which began at the given write,
+// unless any of the keys it read were written since.
+func (p *PebbleDB) commitTxn(batch *pebble.Batch, beganAt uint64, reads map[string]struct{}, write pebbleWrite) error {
+	p.writesMu.Lock()
+	defer p.writesMu.Unlock()
+
+	for _, w := range p.writes {
+		if w.seq <= beganAt {
+			continue
+		}
+		for key := range reads {
+			if w.overlaps(key) {
+				return basedb.ErrConflict
+			}
+		}
+	}
+	return p.commitLocked(batch, write)
+}
+
+// commitLocked is commit, with writesMu held.
+func (p *PebbleDB) commitLocked(batch *pebble.Batch, write pebbleWrite) error {
+	if err := batch.Commit(pebbleWriteOptions); err != nil {
+		return err
+	}
+	p.writeSeq++
+	if len(p.openTxns) > 0 {
+		write.seq = p.writeSeq
+		p.writes = append(p.writes, write)
+	}
+	return nil
+}
+
+// endTxn stops recording writes for the transaction which began at the given write,
+// dropping those which are no longer needed by the open ones.
+func (p *PebbleDB) endTxn(beganAt uint64) {
+	p.writesMu.Lock()
+	defer p.writesMu.Unlock()
+
+	p.openTxns[beganAt]--
+	if p.openTxns[beganAt] == 0 {
+		delete(p.openTxns, beganAt)
+	}
+	if len(p.openTxns) == 0 {
+		p.writes = nil
+		return
+	}
+	oldest := uint64(math.MaxUint64)
+	for seq := range p.openTxns {
+		oldest = min(oldest, seq)
+	}
+	i := 0
+	for i < len(p.writes) && p.writes[i].seq <= oldest {
+		i++
+	}
+	p.writes = p.writes[i:]
+}
+
+// Update creates a read-write transaction, which is committed if fn returns no error.
+func (p *PebbleDB) Update(fn func(basedb.Txn) error) error {
+	txn := p.Begin()
+	defer txn.Discard()
+	if err := fn(txn); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// Close closes the database.
+func (p *PebbleDB) Close() error {
+	// Stop & wait for background goroutines.
+	p.cancel()
+	p.wg.Wait()
+
+	err := p.db.Close()
+	if err != nil {
+		p.logger.Error("failed to close db", zap.Error(err))
+	}
+	return err
+}
+
+// Using returns the given ReadWriter, falling back to the database if it's nil.
+func (p *PebbleDB) Using(rw basedb.ReadWriter) basedb.ReadWriter {
+	if rw == nil {
+		return p
+	}
+	return rw
+}
+
+// UsingReader returns the given Reader, falling back to the database if it's nil.
+func (p *PebbleDB) UsingReader(r basedb.Reader) basedb.Reader {
+	if r == nil {
+		return p
+	}
+	return r
+}
+
+// report the db size and metrics
+func (p *PebbleDB) report() {
+	logger := p.logger.Named(logging.NamePebbleDBReporting)
+	metrics := p.db.Metrics()
+
+	logger.Debug("PebbleDBReport",
+		zap.Uint64("disk_usage", metrics.DiskSpaceUsage()),
+		zap.Uint64("memtable_size", metrics.MemTable.Size),
+		zap.Int64("block_cache_size", metrics.BlockCache.Size),
+		zap.Int64("block_cache_hits", metrics.BlockCache.Hits),
+		zap.Int64("block_cache_misses", metrics.BlockCache.Misses),
+		zap.Int64("compactions", metrics.Compact.Count),
+	)
+}
+
+func (p *PebbleDB) periodicallyReport(interval time.Duration) {
+	defer p.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.report()
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *PebbleDB) getter(r pebble.Reader, prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	value, closer, err := r.Get(append(prefix, key...))
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) { // in order to couple the not found errors together
+			return basedb.Obj{}, false, nil
+		}
+		return basedb.Obj{}, true, err
+	}
+	defer closer.Close()
+	return basedb.Obj{
+		Key:   key,
+		Value: bytes.Clone(value),
+	}, true, nil
+}
+
+func (p *PebbleDB) manyGetter(r pebble.Reader, prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	for _, k := range keys {
+		obj, found, err := p.getter(r, prefix, k)
+		if err != nil {
+			p.logger.Warn("failed to get item", zap.String("key", string(k)))
+			return err
+		}
+		if !found {
+			p.logger.Debug("item not found", zap.String("key", string(k)))
+			continue
+		}
+		if err := iterator(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PebbleDB) allGetter(r pebble.Reader, prefix []byte, handler func(int, basedb.Obj) error) error {
+	it, err := r.NewIter(prefixIterOptions(prefix))
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	i := 0
+	for valid := it.First(); valid; valid = it.Next() {
+		value, err := it.ValueAndErr()
+		if err != nil {
+			return err
+		}
+		if err := handler(i, basedb.Obj{
+			Key:   bytes.Clone(it.Key()[len(prefix):]),
+			Value: bytes.Clone(value),
+		}); err != nil {
+			return err
+		}
+		i++
+	}
+	return it.Error()
+}
+
+// prefixIterOptions returns the options of an iterator over the keys with the given prefix.
+func prefixIterOptions(prefix []byte) *pebble.IterOptions {
+	return &pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixUpperBound(prefix),
+	}
+}
+
+// prefixUpperBound returns the smallest key greater than all the keys with the given prefix,
+// or nil if there is none.
+func prefixUpperBound(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
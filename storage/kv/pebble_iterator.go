@@ -0,0 +1,95 @@
+package kv
+
+import (
+	"bytes"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// pebbleIterator is a basedb.Iterator over a pebble reader.
+type pebbleIterator struct {
+	it  *pebble.Iterator
+	err error
+
+	prefix  []byte
+	reverse bool
+	limit   int
+	count   int
+}
+
+func newPebbleIterator(r pebble.Reader, prefix []byte, opts basedb.IteratorOptions) *pebbleIterator {
+	lower, upper := rangeBounds(prefix, opts)
+	it, err := r.NewIter(&pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: upper,
+	})
+	p := &pebbleIterator{
+		it:      it,
+		err:     err,
+		prefix:  prefix,
+		reverse: opts.Reverse,
+		limit:   opts.Limit,
+	}
+	if err == nil {
+		if p.reverse {
+			p.it.Last()
+		} else {
+			p.it.First()
+		}
+	}
+	return p
+}
+
+func (p *pebbleIterator) Seek(key []byte) {
+	if p.err != nil {
+		return
+	}
+	key = append(bytes.Clone(p.prefix), key...)
+	if p.reverse {
+		// The last key before or at the given key is the last one before its successor.
+		p.it.SeekLT(append(key, 0))
+	} else {
+		p.it.SeekGE(key)
+	}
+}
+
+func (p *pebbleIterator) Valid() bool {
+	if p.err != nil || (p.limit > 0 && p.count >= p.limit) {
+		return false
+	}
+	return p.it.Valid()
+}
+
+func (p *pebbleIterator) Next() {
+	if p.reverse {
+		p.it.Prev()
+	} else {
+		p.it.Next()
+	}
+	p.count++
+}
+
+func (p *pebbleIterator) Key() []byte {
+	return bytes.Clone(p.it.Key()[len(p.prefix):])
+}
+
+func (p *pebbleIterator) Value() ([]byte, error) {
+	value, err := p.it.ValueAndErr()
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Clone(value), nil
+}
+
+func (p *pebbleIterator) Close() error {
+	if p.err != nil {
+		return p.err
+	}
+	err := p.it.Error()
+	if closeErr := p.it.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,280 @@
+package kv
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// pebbleTxn is a transaction over a snapshot. In a read-write transaction, writes are kept
+// in memory and overlaid on the snapshot until committed, and the keys read from the snapshot
+// are recorded to detect conflicts. In a read-only transaction, writes and reads are nil.
+type pebbleTxn struct {
+	reader  pebble.Reader
+	writes  map[string]pendingWrite
+	reads   map[string]struct{}
+	beganAt uint64
+	db      *PebbleDB
+	closed  bool
+}
+
+// pendingWrite is an uncommitted write of a transaction.
+type pendingWrite struct {
+	value   []byte
+	deleted bool
+}
+
+func (t *pebbleTxn) Commit() error {
+	if t.closed {
+		return pebble.ErrClosed
+	}
+	defer t.Discard()
+
+	batch := t.db.db.NewBatch()
+	defer batch.Close()
+	write := pebbleWrite{keys: make(map[string]struct{}, len(t.writes))}
+	for key, w := range t.writes {
+		var err error
+		if w.deleted {
+			err = batch.Delete([]byte(key), nil)
+		} else {
+			err = batch.Set([]byte(key), w.value, nil)
+		}
+		if err != nil {
+			return err
+		}
+		write.keys[key] = struct{}{}
+	}
+	return t.db.commitTxn(batch, t.beganAt, t.reads, write)
+}
+
+func (t *pebbleTxn) Discard() {
+	if t.closed {
+		return
+	}
+	t.closed = true
+	_ = t.reader.Close()
+	if t.writes != nil {
+		t.db.endTxn(t.beganAt)
+	}
+}
+
+func (t *pebbleTxn) Set(prefix []byte, key []byte, value []byte) error {
+	t.writes[string(prefix)+string(key)] = pendingWrite{value: bytes.Clone(value)}
+	return nil
+}
+
+func (t *pebbleTxn) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	for i := 0; i < n; i++ {
+		item, err := next(i)
+		if err != nil {
+			return err
+		}
+		if err := t.Set(prefix, item.Key, item.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *pebbleTxn) Get(prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	k := string(prefix) + string(key)
+	if w, ok := t.writes[k]; ok {
+		if w.deleted {
+			return basedb.Obj{}, false, nil
+		}
+		return basedb.Obj{Key: key, Value: bytes.Clone(w.value)}, true, nil
+	}
+	t.read(k)
+	return t.db.getter(t.reader, prefix, key)
+}
+
+func (t *pebbleTxn) GetMany(prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	for _, k := range keys {
+		obj, found, err := t.Get(prefix, k)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+		if err := iterator(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *pebbleTxn) GetAll(prefix []byte, handler func(int, basedb.Obj) error) error {
+	if t.writes == nil {
+		return t.db.allGetter(t.reader, prefix, handler)
+	}
+
+	it := t.Iterator(prefix, basedb.IteratorOptions{})
+	for i := 0; it.Valid(); i++ {
+		value, err := it.Value()
+		if err != nil {
+			_ = it.Close()
+			return err
+		}
+		if err := handler(i, basedb.Obj{Key: it.Key(), Value: value}); err != nil {
+			_ = it.Close()
+			return err
+		}
+		it.Next()
+	}
+	return it.Close()
+}
+
+func (t *pebbleTxn) Iterator(prefix []byte, opts basedb.IteratorOptions) basedb.Iterator {
+	if t.writes == nil {
+		return newPebbleIterator(t.reader, prefix, opts)
+	}
+	return newPebbleTxnIterator(t, prefix, opts)
+}
+
+func (t *pebbleTxn) Delete(prefix []byte, key []byte) error {
+	t.writes[string(prefix)+string(key)] = pendingWrite{deleted: true}
+	return nil
+}
+
+// read records that the key was read from the snapshot.
+func (t *pebbleTxn) read(key string) {
+	if t.reads != nil {
+		t.reads[key] = struct{}{}
+	}
+}
+
+// pebbleTxnIterator is a basedb.Iterator over the writes of a read-write transaction
+// overlaid on its snapshot.
+type pebbleTxnIterator struct {
+	txn *pebbleTxn
+	// base iterates over the snapshot, without a limit.
+	base *pebbleIterator
+	// pending are the keys written by the transaction within the range, in the iteration order.
+	pending []string
+	pos     int
+
+	prefix  []byte
+	reverse bool
+	limit   int
+	count   int
+}
+
+func newPebbleTxnIterator(txn *pebbleTxn, prefix []byte, opts basedb.IteratorOptions) *pebbleTxnIterator {
+	lower, upper := rangeBounds(prefix, opts)
+	var pending []string
+	for key := range txn.writes {
+		if key >= string(lower) && (upper == nil || key < string(upper)) {
+			pending = append(pending, key)
+		}
+	}
+	sort.Strings(pending)
+	if opts.Reverse {
+		for i, j := 0, len(pending)-1; i < j; i, j = i+1, j-1 {
+			pending[i], pending[j] = pending[j], pending[i]
+		}
+	}
+
+	baseOpts := opts
+	baseOpts.Limit = 0
+	it := &pebbleTxnIterator{
+		txn:     txn,
+		base:    newPebbleIterator(txn.reader, prefix, baseOpts),
+		pending: pending,
+		prefix:  prefix,
+		reverse: opts.Reverse,
+		limit:   opts.Limit,
+	}
+	it.settle()
+	return it
+}
+
+// before returns whether key a comes before key b in the iteration order.
+func (it *pebbleTxnIterator) before(a, b []byte) bool {
+	if it.reverse {
+		return bytes.Compare(a, b) > 0
+	}
+	return bytes.Compare(a, b) < 0
+}
+
+// baseValid returns whether the snapshot iterator is at an item.
+func (it *pebbleTxnIterator) baseValid() bool {
+	return it.base.err == nil && it.base.it.Valid()
+}
+
+// atPending returns whether the current item is a write of the transaction.
+func (it *pebbleTxnIterator) atPending() bool {
+	if it.pos >= len(it.pending) {
+		return false
+	}
+	return !it.baseValid() || !it.before(it.base.it.Key(), []byte(it.pending[it.pos]))
+}
+
+// settle skips the snapshot items overwritten by the transaction and the items it deleted,
+// and records the snapshot item it stops at as read.
+func (it *pebbleTxnIterator) settle() {
+	for {
+		if it.pos < len(it.pending) && it.baseValid() && string(it.base.it.Key()) == it.pending[it.pos] {
+			it.base.Next()
+			continue
+		}
+		if it.atPending() && it.txn.writes[it.pending[it.pos]].deleted {
+			it.pos++
+			continue
+		}
+		break
+	}
+	if !it.atPending() && it.baseValid() {
+		it.txn.read(string(it.base.it.Key()))
+	}
+}
+
+func (it *pebbleTxnIterator) Seek(key []byte) {
+	it.base.Seek(key)
+	k := string(it.prefix) + string(key)
+	if it.reverse {
+		it.pos = sort.Search(len(it.pending), func(i int) bool { return it.pending[i] <= k })
+	} else {
+		it.pos = sort.SearchStrings(it.pending, k)
+	}
+	it.settle()
+}
+
+func (it *pebbleTxnIterator) Valid() bool {
+	if it.base.err != nil || (it.limit > 0 && it.count >= it.limit) {
+		return false
+	}
+	return it.atPending() || it.baseValid()
+}
+
+func (it *pebbleTxnIterator) Next() {
+	if it.atPending() {
+		it.pos++
+	} else {
+		it.base.Next()
+	}
+	it.count++
+	it.settle()
+}
+
+func (it *pebbleTxnIterator) Key() []byte {
+	if it.atPending() {
+		return []byte(it.pending[it.pos][len(it.prefix):])
+	}
+	return it.base.Key()
+}
+
+func (it *pebbleTxnIterator) Value() ([]byte, error) {
+	if it.atPending() {
+		return bytes.Clone(it.txn.writes[it.pending[it.pos]].value), nil
+	}
+	return it.base.Value()
+}
+
+func (it *pebbleTxnIterator) Close() error {
+	return it.base.Close()
+}
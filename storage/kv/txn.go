@@ -21,7 +21,7 @@ func newTxn(txn *badger.Txn, db *BadgerDB) basedb.Txn {
 }
 
 func (t badgerTxn) Commit() error {
-	return t.txn.Commit()
+	return conflictError(t.txn.Commit())
 }
 
 func (t badgerTxn) Discard() {
@@ -78,6 +78,18 @@ func (t badgerTxn) GetAll(prefix []byte, handler func(int, basedb.Obj) error) er
 	return t.db.allGetter(prefix, handler)(t.txn)
 }
 
+func (t badgerTxn) Iterator(prefix []byte, opts basedb.IteratorOptions) basedb.Iterator {
+	return newBadgerIterator(t.txn, false, prefix, opts)
+}
+
 func (t badgerTxn) Delete(prefix []byte, key []byte) error {
 	return t.txn.Delete(append(prefix, key...))
 }
+
+// conflictError returns basedb.ErrConflict for badger's transaction conflicts, and the given error otherwise.
+func conflictError(err error) error {
+	if errors.Is(err, badger.ErrConflict) {
+		return basedb.ErrConflict
+	}
+	return err
+}
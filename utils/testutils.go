@@ -8,6 +8,7 @@ import (
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	mocknetwork "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon/mocks"
 )
 
@@ -41,6 +42,7 @@ func SetupMockBeaconNetwork(t *testing.T, currentSlot *SlotValue) *mocknetwork.M
 
 	mockBeaconNetwork := mocknetwork.NewMockBeaconNetwork(ctrl)
 	mockBeaconNetwork.EXPECT().GetBeaconNetwork().Return(beaconNetwork).AnyTimes()
+	mockBeaconNetwork.EXPECT().GetNetwork().Return(beacon.NewNetwork(beaconNetwork)).AnyTimes()
 
 	mockBeaconNetwork.EXPECT().EstimatedCurrentEpoch().DoAndReturn(
 		func() phase0.Epoch {